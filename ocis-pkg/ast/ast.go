@@ -74,6 +74,13 @@ type GroupNode struct {
 	Nodes []Node
 }
 
+// SimilarNode represents the similarity operator, it matches the resources with a similar meaning as the value.
+// The value is a text or the id of a resource.
+type SimilarNode struct {
+	*Base
+	Value string
+}
+
 // NodeKey tries to return the node key
 func NodeKey(n Node) string {
 	switch node := n.(type) {
@@ -85,6 +92,8 @@ func NodeKey(n Node) string {
 		return node.Key
 	case *GroupNode:
 		return node.Key
+	case *SimilarNode:
+		return "similar"
 	default:
 		return ""
 	}
@@ -101,6 +110,8 @@ func NodeValue(n Node) interface{} {
 		return node.Value
 	case *GroupNode:
 		return node.Nodes
+	case *SimilarNode:
+		return node.Value
 	default:
 		return ""
 	}
@@ -0,0 +1,185 @@
+// Package editingsessions tracks who is currently editing a file in an office app. The sessions are written by the
+// collaboration service when a user opens a file for editing, refreshed together with the WOPI lock of the file and
+// kept in a store which is shared with the services reading them.
+package editingsessions
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	microstore "go-micro.dev/v4/store"
+)
+
+const (
+	// Database is the database of the editing sessions in the store
+	Database = "collaboration"
+	// Table is the table of the editing sessions in the store
+	Table = "editing-sessions"
+
+	// TTL is the time after which a session which was not refreshed is considered to be over. It matches the
+	// duration of the WOPI locks, which are refreshed by the office apps while the document is open.
+	TTL = 30 * time.Minute
+)
+
+// Session is a user editing a file
+type Session struct {
+	// ResourceID is the formatted id of the edited file
+	ResourceID string `json:"resourceId"`
+	// UserID is the opaque id of the editing user
+	UserID      string `json:"userId"`
+	DisplayName string `json:"displayName"`
+	// App is the name of the office app the file is edited with
+	App       string    `json:"app"`
+	StartedAt time.Time `json:"startedAt"`
+	// LastSeen is the last time the session was refreshed
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// Expired returns true if the session was not refreshed within the TTL.
+func (s Session) Expired(now time.Time) bool {
+	return now.Sub(s.LastSeen) > TTL
+}
+
+func (s Session) key() string {
+	return s.ResourceID + "/" + s.UserID
+}
+
+// Manager reads and writes the editing sessions.
+type Manager struct {
+	store microstore.Store
+	now   func() time.Time
+}
+
+// NewManager returns a Manager for the sessions in the store.
+func NewManager(store microstore.Store) *Manager {
+	return &Manager{store: store, now: time.Now}
+}
+
+// Touch starts the session of the user on the file or refreshes it if it is already running. It returns true if a
+// new session was started.
+func (m *Manager) Touch(s Session) (bool, error) {
+	now := m.now()
+	s.LastSeen = now
+	s.StartedAt = now
+
+	existing, err := m.read(s.key())
+	switch {
+	case errors.Is(err, microstore.ErrNotFound):
+	case err != nil:
+		return false, err
+	case !existing.Expired(now):
+		s.StartedAt = existing.StartedAt
+	}
+
+	v, err := json.Marshal(s)
+	if err != nil {
+		return false, err
+	}
+	if err := m.store.Write(&microstore.Record{Key: s.key(), Value: v, Expiry: TTL}); err != nil {
+		return false, err
+	}
+	return s.StartedAt.Equal(now), nil
+}
+
+// List returns the running sessions on the file, the oldest first. Expired sessions are removed.
+func (m *Manager) List(resourceID string) ([]Session, error) {
+	keys, err := m.store.List(microstore.ListPrefix(resourceID + "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	now := m.now()
+	sessions := make([]Session, 0, len(keys))
+	for _, k := range keys {
+		s, err := m.read(k)
+		switch {
+		case errors.Is(err, microstore.ErrNotFound):
+			// ended in the meantime
+			continue
+		case err != nil:
+			return nil, err
+		case s.Expired(now):
+			if err := m.store.Delete(k); err != nil && !errors.Is(err, microstore.ErrNotFound) {
+				return nil, err
+			}
+			continue
+		}
+		sessions = append(sessions, s)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		if sessions[i].StartedAt.Equal(sessions[j].StartedAt) {
+			return sessions[i].UserID < sessions[j].UserID
+		}
+		return sessions[i].StartedAt.Before(sessions[j].StartedAt)
+	})
+	return sessions, nil
+}
+
+// Refresh extends all running sessions on the file. The office apps share one lock per file between all editing
+// users, the sessions are refreshed whenever it is.
+func (m *Manager) Refresh(resourceID string) error {
+	keys, err := m.store.List(microstore.ListPrefix(resourceID + "/"))
+	if err != nil {
+		return err
+	}
+
+	now := m.now()
+	for _, k := range keys {
+		s, err := m.read(k)
+		switch {
+		case errors.Is(err, microstore.ErrNotFound):
+			continue
+		case err != nil:
+			return err
+		case s.Expired(now):
+			if err := m.store.Delete(k); err != nil && !errors.Is(err, microstore.ErrNotFound) {
+				return err
+			}
+			continue
+		}
+
+		s.LastSeen = now
+		v, err := json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		if err := m.store.Write(&microstore.Record{Key: k, Value: v, Expiry: TTL}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// End removes all sessions on the file. The office apps share one lock per file between all editing users, the
+// sessions end when it is released.
+func (m *Manager) End(resourceID string) error {
+	keys, err := m.store.List(microstore.ListPrefix(resourceID + "/"))
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if err := m.store.Delete(k); err != nil && !errors.Is(err, microstore.ErrNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Manager) read(key string) (Session, error) {
+	recs, err := m.store.Read(key)
+	switch {
+	case err != nil:
+		return Session{}, err
+	case len(recs) == 0:
+		return Session{}, microstore.ErrNotFound
+	}
+
+	var s Session
+	if err := json.Unmarshal(recs[0].Value, &s); err != nil {
+		return Session{}, err
+	}
+	return s, nil
+}
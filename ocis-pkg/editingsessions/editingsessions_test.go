@@ -0,0 +1,69 @@
+package editingsessions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	microstore "go-micro.dev/v4/store"
+)
+
+func TestManager(t *testing.T) {
+	m := NewManager(microstore.NewMemoryStore())
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	started, err := m.Touch(Session{ResourceID: "storage$space!file", UserID: "alice", DisplayName: "Alice", App: "Collabora"})
+	require.NoError(t, err)
+	require.True(t, started)
+
+	now = now.Add(time.Minute)
+	started, err = m.Touch(Session{ResourceID: "storage$space!file", UserID: "bob", DisplayName: "Bob", App: "Collabora"})
+	require.NoError(t, err)
+	require.True(t, started)
+
+	now = now.Add(20 * time.Minute)
+	started, err = m.Touch(Session{ResourceID: "storage$space!file", UserID: "alice", DisplayName: "Alice", App: "Collabora"})
+	require.NoError(t, err)
+	require.False(t, started)
+
+	sessions, err := m.List("storage$space!file")
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	require.Equal(t, "alice", sessions[0].UserID)
+	require.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), sessions[0].StartedAt)
+	require.Equal(t, now, sessions[0].LastSeen)
+	require.Equal(t, "bob", sessions[1].UserID)
+
+	// bob did not refresh the session within the TTL
+	now = now.Add(15 * time.Minute)
+	sessions, err = m.List("storage$space!file")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, "alice", sessions[0].UserID)
+
+	// refreshing the lock of the file keeps all sessions on it running
+	require.NoError(t, m.Refresh("storage$space!file"))
+	now = now.Add(25 * time.Minute)
+	sessions, err = m.List("storage$space!file")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, "alice", sessions[0].UserID)
+
+	// an expired session is started again
+	started, err = m.Touch(Session{ResourceID: "storage$space!file", UserID: "bob", DisplayName: "Bob", App: "Collabora"})
+	require.NoError(t, err)
+	require.True(t, started)
+
+	_, err = m.Touch(Session{ResourceID: "storage$space!other", UserID: "alice", App: "OnlyOffice"})
+	require.NoError(t, err)
+
+	require.NoError(t, m.End("storage$space!file"))
+	sessions, err = m.List("storage$space!file")
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+
+	sessions, err = m.List("storage$space!other")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+}
@@ -43,12 +43,12 @@ var g = &grammar{
 					pos: position{line: 19, col: 6, offset: 351},
 					exprs: []any{
 						&actionExpr{
-							pos: position{line: 233, col: 5, offset: 4804},
+							pos: position{line: 243, col: 5, offset: 5101},
 							run: (*parser).callonNodes3,
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 233, col: 5, offset: 4804},
+								pos: position{line: 243, col: 5, offset: 5101},
 								expr: &charClassMatcher{
-									pos:        position{line: 233, col: 5, offset: 4804},
+									pos:        position{line: 243, col: 5, offset: 5101},
 									val:        "[ \\t]",
 									chars:      []rune{' ', '\t'},
 									ignoreCase: false,
@@ -75,21 +75,115 @@ var g = &grammar{
 						name: "GroupNode",
 					},
 					&actionExpr{
-						pos: position{line: 46, col: 5, offset: 1042},
+						pos: position{line: 42, col: 5, offset: 906},
 						run: (*parser).callonNode3,
 						expr: &seqExpr{
-							pos: position{line: 46, col: 5, offset: 1042},
+							pos: position{line: 42, col: 5, offset: 906},
+							exprs: []any{
+								&litMatcher{
+									pos:        position{line: 42, col: 5, offset: 906},
+									val:        "similar",
+									ignoreCase: true,
+									want:       "\"similar\"i",
+								},
+								&choiceExpr{
+									pos: position{line: 42, col: 17, offset: 918},
+									alternatives: []any{
+										&actionExpr{
+											pos: position{line: 130, col: 5, offset: 3208},
+											run: (*parser).callonNode7,
+											expr: &litMatcher{
+												pos:        position{line: 130, col: 5, offset: 3208},
+												val:        ":",
+												ignoreCase: false,
+												want:       "\":\"",
+											},
+										},
+										&actionExpr{
+											pos: position{line: 135, col: 5, offset: 3294},
+											run: (*parser).callonNode9,
+											expr: &litMatcher{
+												pos:        position{line: 135, col: 5, offset: 3294},
+												val:        "=",
+												ignoreCase: false,
+												want:       "\"=\"",
+											},
+										},
+									},
+								},
+								&labeledExpr{
+									pos:   position{line: 42, col: 56, offset: 957},
+									label: "v",
+									expr: &choiceExpr{
+										pos: position{line: 42, col: 59, offset: 960},
+										alternatives: []any{
+											&actionExpr{
+												pos: position{line: 233, col: 5, offset: 4990},
+												run: (*parser).callonNode13,
+												expr: &seqExpr{
+													pos: position{line: 233, col: 5, offset: 4990},
+													exprs: []any{
+														&litMatcher{
+															pos:        position{line: 233, col: 5, offset: 4990},
+															val:        "\"",
+															ignoreCase: false,
+															want:       "\"\\\"\"",
+														},
+														&labeledExpr{
+															pos:   position{line: 233, col: 9, offset: 4994},
+															label: "v",
+															expr: &zeroOrMoreExpr{
+																pos: position{line: 233, col: 11, offset: 4996},
+																expr: &charClassMatcher{
+																	pos:        position{line: 233, col: 11, offset: 4996},
+																	val:        "[^\"]",
+																	chars:      []rune{'"'},
+																	ignoreCase: false,
+																	inverted:   true,
+																},
+															},
+														},
+														&litMatcher{
+															pos:        position{line: 233, col: 17, offset: 5002},
+															val:        "\"",
+															ignoreCase: false,
+															want:       "\"\\\"\"",
+														},
+													},
+												},
+											},
+											&oneOrMoreExpr{
+												pos: position{line: 42, col: 68, offset: 969},
+												expr: &charClassMatcher{
+													pos:        position{line: 42, col: 68, offset: 969},
+													val:        "[^ ()]",
+													chars:      []rune{' ', '(', ')'},
+													ignoreCase: false,
+													inverted:   true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 56, col: 5, offset: 1339},
+						run: (*parser).callonNode22,
+						expr: &seqExpr{
+							pos: position{line: 56, col: 5, offset: 1339},
 							exprs: []any{
 								&labeledExpr{
-									pos:   position{line: 46, col: 5, offset: 1042},
+									pos:   position{line: 56, col: 5, offset: 1339},
 									label: "k",
 									expr: &oneOrMoreExpr{
-										pos: position{line: 46, col: 7, offset: 1044},
+										pos: position{line: 56, col: 7, offset: 1341},
 										expr: &actionExpr{
-											pos: position{line: 218, col: 5, offset: 4634},
-											run: (*parser).callonNode7,
+											pos: position{line: 228, col: 5, offset: 4931},
+											run: (*parser).callonNode26,
 											expr: &charClassMatcher{
-												pos:        position{line: 218, col: 5, offset: 4634},
+												pos:        position{line: 228, col: 5, offset: 4931},
 												val:        "[A-Za-z]",
 												ranges:     []rune{'A', 'Z', 'a', 'z'},
 												ignoreCase: false,
@@ -99,23 +193,23 @@ var g = &grammar{
 									},
 								},
 								&choiceExpr{
-									pos: position{line: 46, col: 14, offset: 1051},
+									pos: position{line: 56, col: 14, offset: 1348},
 									alternatives: []any{
 										&actionExpr{
-											pos: position{line: 120, col: 5, offset: 2911},
-											run: (*parser).callonNode10,
+											pos: position{line: 130, col: 5, offset: 3208},
+											run: (*parser).callonNode29,
 											expr: &litMatcher{
-												pos:        position{line: 120, col: 5, offset: 2911},
+												pos:        position{line: 130, col: 5, offset: 3208},
 												val:        ":",
 												ignoreCase: false,
 												want:       "\":\"",
 											},
 										},
 										&actionExpr{
-											pos: position{line: 125, col: 5, offset: 2997},
-											run: (*parser).callonNode12,
+											pos: position{line: 135, col: 5, offset: 3294},
+											run: (*parser).callonNode31,
 											expr: &litMatcher{
-												pos:        position{line: 125, col: 5, offset: 2997},
+												pos:        position{line: 135, col: 5, offset: 3294},
 												val:        "=",
 												ignoreCase: false,
 												want:       "\"=\"",
@@ -124,19 +218,19 @@ var g = &grammar{
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 46, col: 53, offset: 1090},
+									pos:   position{line: 56, col: 53, offset: 1387},
 									label: "v",
 									expr: &choiceExpr{
-										pos: position{line: 46, col: 56, offset: 1093},
+										pos: position{line: 56, col: 56, offset: 1390},
 										alternatives: []any{
 											&litMatcher{
-												pos:        position{line: 46, col: 56, offset: 1093},
+												pos:        position{line: 56, col: 56, offset: 1390},
 												val:        "true",
 												ignoreCase: false,
 												want:       "\"true\"",
 											},
 											&litMatcher{
-												pos:        position{line: 46, col: 65, offset: 1102},
+												pos:        position{line: 56, col: 65, offset: 1399},
 												val:        "false",
 												ignoreCase: false,
 												want:       "\"false\"",
@@ -148,21 +242,21 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 51, col: 5, offset: 1203},
-						run: (*parser).callonNode18,
+						pos: position{line: 61, col: 5, offset: 1500},
+						run: (*parser).callonNode37,
 						expr: &seqExpr{
-							pos: position{line: 51, col: 5, offset: 1203},
+							pos: position{line: 61, col: 5, offset: 1500},
 							exprs: []any{
 								&labeledExpr{
-									pos:   position{line: 51, col: 5, offset: 1203},
+									pos:   position{line: 61, col: 5, offset: 1500},
 									label: "k",
 									expr: &oneOrMoreExpr{
-										pos: position{line: 51, col: 7, offset: 1205},
+										pos: position{line: 61, col: 7, offset: 1502},
 										expr: &actionExpr{
-											pos: position{line: 218, col: 5, offset: 4634},
-											run: (*parser).callonNode22,
+											pos: position{line: 228, col: 5, offset: 4931},
+											run: (*parser).callonNode41,
 											expr: &charClassMatcher{
-												pos:        position{line: 218, col: 5, offset: 4634},
+												pos:        position{line: 228, col: 5, offset: 4931},
 												val:        "[A-Za-z]",
 												ranges:     []rune{'A', 'Z', 'a', 'z'},
 												ignoreCase: false,
@@ -172,66 +266,66 @@ var g = &grammar{
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 51, col: 13, offset: 1211},
+									pos:   position{line: 61, col: 13, offset: 1508},
 									label: "o",
 									expr: &choiceExpr{
-										pos: position{line: 52, col: 9, offset: 1223},
+										pos: position{line: 62, col: 9, offset: 1520},
 										alternatives: []any{
 											&actionExpr{
-												pos: position{line: 145, col: 5, offset: 3358},
-												run: (*parser).callonNode26,
+												pos: position{line: 155, col: 5, offset: 3655},
+												run: (*parser).callonNode45,
 												expr: &litMatcher{
-													pos:        position{line: 145, col: 5, offset: 3358},
+													pos:        position{line: 155, col: 5, offset: 3655},
 													val:        ">=",
 													ignoreCase: false,
 													want:       "\">=\"",
 												},
 											},
 											&actionExpr{
-												pos: position{line: 135, col: 5, offset: 3174},
-												run: (*parser).callonNode28,
+												pos: position{line: 145, col: 5, offset: 3471},
+												run: (*parser).callonNode47,
 												expr: &litMatcher{
-													pos:        position{line: 135, col: 5, offset: 3174},
+													pos:        position{line: 145, col: 5, offset: 3471},
 													val:        "<=",
 													ignoreCase: false,
 													want:       "\"<=\"",
 												},
 											},
 											&actionExpr{
-												pos: position{line: 140, col: 5, offset: 3263},
-												run: (*parser).callonNode30,
+												pos: position{line: 150, col: 5, offset: 3560},
+												run: (*parser).callonNode49,
 												expr: &litMatcher{
-													pos:        position{line: 140, col: 5, offset: 3263},
+													pos:        position{line: 150, col: 5, offset: 3560},
 													val:        ">",
 													ignoreCase: false,
 													want:       "\">\"",
 												},
 											},
 											&actionExpr{
-												pos: position{line: 130, col: 5, offset: 3082},
-												run: (*parser).callonNode32,
+												pos: position{line: 140, col: 5, offset: 3379},
+												run: (*parser).callonNode51,
 												expr: &litMatcher{
-													pos:        position{line: 130, col: 5, offset: 3082},
+													pos:        position{line: 140, col: 5, offset: 3379},
 													val:        "<",
 													ignoreCase: false,
 													want:       "\"<\"",
 												},
 											},
 											&actionExpr{
-												pos: position{line: 125, col: 5, offset: 2997},
-												run: (*parser).callonNode34,
+												pos: position{line: 135, col: 5, offset: 3294},
+												run: (*parser).callonNode53,
 												expr: &litMatcher{
-													pos:        position{line: 125, col: 5, offset: 2997},
+													pos:        position{line: 135, col: 5, offset: 3294},
 													val:        "=",
 													ignoreCase: false,
 													want:       "\"=\"",
 												},
 											},
 											&actionExpr{
-												pos: position{line: 120, col: 5, offset: 2911},
-												run: (*parser).callonNode36,
+												pos: position{line: 130, col: 5, offset: 3208},
+												run: (*parser).callonNode55,
 												expr: &litMatcher{
-													pos:        position{line: 120, col: 5, offset: 2911},
+													pos:        position{line: 130, col: 5, offset: 3208},
 													val:        ":",
 													ignoreCase: false,
 													want:       "\":\"",
@@ -241,43 +335,43 @@ var g = &grammar{
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 58, col: 7, offset: 1403},
+									pos: position{line: 68, col: 7, offset: 1700},
 									expr: &litMatcher{
-										pos:        position{line: 58, col: 7, offset: 1403},
+										pos:        position{line: 68, col: 7, offset: 1700},
 										val:        "\"",
 										ignoreCase: false,
 										want:       "\"\\\"\"",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 58, col: 12, offset: 1408},
+									pos:   position{line: 68, col: 12, offset: 1705},
 									label: "v",
 									expr: &choiceExpr{
-										pos: position{line: 59, col: 9, offset: 1420},
+										pos: position{line: 69, col: 9, offset: 1717},
 										alternatives: []any{
 											&actionExpr{
-												pos: position{line: 195, col: 5, offset: 4197},
-												run: (*parser).callonNode42,
+												pos: position{line: 205, col: 5, offset: 4494},
+												run: (*parser).callonNode61,
 												expr: &seqExpr{
-													pos: position{line: 195, col: 5, offset: 4197},
+													pos: position{line: 205, col: 5, offset: 4494},
 													exprs: []any{
 														&actionExpr{
-															pos: position{line: 185, col: 5, offset: 3960},
-															run: (*parser).callonNode44,
+															pos: position{line: 195, col: 5, offset: 4257},
+															run: (*parser).callonNode63,
 															expr: &seqExpr{
-																pos: position{line: 185, col: 5, offset: 3960},
+																pos: position{line: 195, col: 5, offset: 4257},
 																exprs: []any{
 																	&actionExpr{
-																		pos: position{line: 155, col: 5, offset: 3560},
-																		run: (*parser).callonNode46,
+																		pos: position{line: 165, col: 5, offset: 3857},
+																		run: (*parser).callonNode65,
 																		expr: &seqExpr{
-																			pos: position{line: 155, col: 5, offset: 3560},
+																			pos: position{line: 165, col: 5, offset: 3857},
 																			exprs: []any{
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode48,
+																					pos: position{line: 238, col: 5, offset: 5050},
+																					run: (*parser).callonNode67,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5050},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -285,10 +379,10 @@ var g = &grammar{
 																					},
 																				},
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode50,
+																					pos: position{line: 238, col: 5, offset: 5050},
+																					run: (*parser).callonNode69,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5050},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -296,10 +390,10 @@ var g = &grammar{
 																					},
 																				},
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode52,
+																					pos: position{line: 238, col: 5, offset: 5050},
+																					run: (*parser).callonNode71,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5050},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -307,10 +401,10 @@ var g = &grammar{
 																					},
 																				},
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode54,
+																					pos: position{line: 238, col: 5, offset: 5050},
+																					run: (*parser).callonNode73,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5050},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -321,22 +415,22 @@ var g = &grammar{
 																		},
 																	},
 																	&litMatcher{
-																		pos:        position{line: 185, col: 14, offset: 3969},
+																		pos:        position{line: 195, col: 14, offset: 4266},
 																		val:        "-",
 																		ignoreCase: false,
 																		want:       "\"-\"",
 																	},
 																	&actionExpr{
-																		pos: position{line: 160, col: 5, offset: 3637},
-																		run: (*parser).callonNode57,
+																		pos: position{line: 170, col: 5, offset: 3934},
+																		run: (*parser).callonNode76,
 																		expr: &seqExpr{
-																			pos: position{line: 160, col: 5, offset: 3637},
+																			pos: position{line: 170, col: 5, offset: 3934},
 																			exprs: []any{
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode59,
+																					pos: position{line: 238, col: 5, offset: 5050},
+																					run: (*parser).callonNode78,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5050},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -344,10 +438,10 @@ var g = &grammar{
 																					},
 																				},
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode61,
+																					pos: position{line: 238, col: 5, offset: 5050},
+																					run: (*parser).callonNode80,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5050},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -358,22 +452,22 @@ var g = &grammar{
 																		},
 																	},
 																	&litMatcher{
-																		pos:        position{line: 185, col: 28, offset: 3983},
+																		pos:        position{line: 195, col: 28, offset: 4280},
 																		val:        "-",
 																		ignoreCase: false,
 																		want:       "\"-\"",
 																	},
 																	&actionExpr{
-																		pos: position{line: 165, col: 5, offset: 3700},
-																		run: (*parser).callonNode64,
+																		pos: position{line: 175, col: 5, offset: 3997},
+																		run: (*parser).callonNode83,
 																		expr: &seqExpr{
-																			pos: position{line: 165, col: 5, offset: 3700},
+																			pos: position{line: 175, col: 5, offset: 3997},
 																			exprs: []any{
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode66,
+																					pos: position{line: 238, col: 5, offset: 5050},
+																					run: (*parser).callonNode85,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5050},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -381,10 +475,10 @@ var g = &grammar{
 																					},
 																				},
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode68,
+																					pos: position{line: 238, col: 5, offset: 5050},
+																					run: (*parser).callonNode87,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5050},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -398,28 +492,28 @@ var g = &grammar{
 															},
 														},
 														&litMatcher{
-															pos:        position{line: 195, col: 14, offset: 4206},
+															pos:        position{line: 205, col: 14, offset: 4503},
 															val:        "T",
 															ignoreCase: false,
 															want:       "\"T\"",
 														},
 														&actionExpr{
-															pos: position{line: 190, col: 5, offset: 4047},
-															run: (*parser).callonNode71,
+															pos: position{line: 200, col: 5, offset: 4344},
+															run: (*parser).callonNode90,
 															expr: &seqExpr{
-																pos: position{line: 190, col: 5, offset: 4047},
+																pos: position{line: 200, col: 5, offset: 4344},
 																exprs: []any{
 																	&actionExpr{
-																		pos: position{line: 170, col: 5, offset: 3764},
-																		run: (*parser).callonNode73,
+																		pos: position{line: 180, col: 5, offset: 4061},
+																		run: (*parser).callonNode92,
 																		expr: &seqExpr{
-																			pos: position{line: 170, col: 5, offset: 3764},
+																			pos: position{line: 180, col: 5, offset: 4061},
 																			exprs: []any{
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode75,
+																					pos: position{line: 238, col: 5, offset: 5050},
+																					run: (*parser).callonNode94,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5050},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -427,10 +521,10 @@ var g = &grammar{
 																					},
 																				},
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode77,
+																					pos: position{line: 238, col: 5, offset: 5050},
+																					run: (*parser).callonNode96,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5050},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -441,22 +535,22 @@ var g = &grammar{
 																		},
 																	},
 																	&litMatcher{
-																		pos:        position{line: 190, col: 14, offset: 4056},
+																		pos:        position{line: 200, col: 14, offset: 4353},
 																		val:        ":",
 																		ignoreCase: false,
 																		want:       "\":\"",
 																	},
 																	&actionExpr{
-																		pos: position{line: 175, col: 5, offset: 3830},
-																		run: (*parser).callonNode80,
+																		pos: position{line: 185, col: 5, offset: 4127},
+																		run: (*parser).callonNode99,
 																		expr: &seqExpr{
-																			pos: position{line: 175, col: 5, offset: 3830},
+																			pos: position{line: 185, col: 5, offset: 4127},
 																			exprs: []any{
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode82,
+																					pos: position{line: 238, col: 5, offset: 5050},
+																					run: (*parser).callonNode101,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5050},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -464,10 +558,10 @@ var g = &grammar{
 																					},
 																				},
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode84,
+																					pos: position{line: 238, col: 5, offset: 5050},
+																					run: (*parser).callonNode103,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5050},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -478,22 +572,22 @@ var g = &grammar{
 																		},
 																	},
 																	&litMatcher{
-																		pos:        position{line: 190, col: 29, offset: 4071},
+																		pos:        position{line: 200, col: 29, offset: 4368},
 																		val:        ":",
 																		ignoreCase: false,
 																		want:       "\":\"",
 																	},
 																	&actionExpr{
-																		pos: position{line: 180, col: 5, offset: 3896},
-																		run: (*parser).callonNode87,
+																		pos: position{line: 190, col: 5, offset: 4193},
+																		run: (*parser).callonNode106,
 																		expr: &seqExpr{
-																			pos: position{line: 180, col: 5, offset: 3896},
+																			pos: position{line: 190, col: 5, offset: 4193},
 																			exprs: []any{
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode89,
+																					pos: position{line: 238, col: 5, offset: 5050},
+																					run: (*parser).callonNode108,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5050},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -501,10 +595,10 @@ var g = &grammar{
 																					},
 																				},
 																				&actionExpr{
-																					pos: position{line: 228, col: 5, offset: 4753},
-																					run: (*parser).callonNode91,
+																					pos: position{line: 238, col: 5, offset: 5050},
+																					run: (*parser).callonNode110,
 																					expr: &charClassMatcher{
-																						pos:        position{line: 228, col: 5, offset: 4753},
+																						pos:        position{line: 238, col: 5, offset: 5050},
 																						val:        "[0-9]",
 																						ranges:     []rune{'0', '9'},
 																						ignoreCase: false,
@@ -515,23 +609,23 @@ var g = &grammar{
 																		},
 																	},
 																	&zeroOrOneExpr{
-																		pos: position{line: 190, col: 44, offset: 4086},
+																		pos: position{line: 200, col: 44, offset: 4383},
 																		expr: &seqExpr{
-																			pos: position{line: 190, col: 45, offset: 4087},
+																			pos: position{line: 200, col: 45, offset: 4384},
 																			exprs: []any{
 																				&litMatcher{
-																					pos:        position{line: 190, col: 45, offset: 4087},
+																					pos:        position{line: 200, col: 45, offset: 4384},
 																					val:        ".",
 																					ignoreCase: false,
 																					want:       "\".\"",
 																				},
 																				&oneOrMoreExpr{
-																					pos: position{line: 190, col: 49, offset: 4091},
+																					pos: position{line: 200, col: 49, offset: 4388},
 																					expr: &actionExpr{
-																						pos: position{line: 228, col: 5, offset: 4753},
-																						run: (*parser).callonNode97,
+																						pos: position{line: 238, col: 5, offset: 5050},
+																						run: (*parser).callonNode116,
 																						expr: &charClassMatcher{
-																							pos:        position{line: 228, col: 5, offset: 4753},
+																							pos:        position{line: 238, col: 5, offset: 5050},
 																							val:        "[0-9]",
 																							ranges:     []rune{'0', '9'},
 																							ignoreCase: false,
@@ -543,35 +637,35 @@ var g = &grammar{
 																		},
 																	},
 																	&choiceExpr{
-																		pos: position{line: 190, col: 59, offset: 4101},
+																		pos: position{line: 200, col: 59, offset: 4398},
 																		alternatives: []any{
 																			&litMatcher{
-																				pos:        position{line: 190, col: 59, offset: 4101},
+																				pos:        position{line: 200, col: 59, offset: 4398},
 																				val:        "Z",
 																				ignoreCase: false,
 																				want:       "\"Z\"",
 																			},
 																			&seqExpr{
-																				pos: position{line: 190, col: 65, offset: 4107},
+																				pos: position{line: 200, col: 65, offset: 4404},
 																				exprs: []any{
 																					&charClassMatcher{
-																						pos:        position{line: 190, col: 66, offset: 4108},
+																						pos:        position{line: 200, col: 66, offset: 4405},
 																						val:        "[+-]",
 																						chars:      []rune{'+', '-'},
 																						ignoreCase: false,
 																						inverted:   false,
 																					},
 																					&actionExpr{
-																						pos: position{line: 170, col: 5, offset: 3764},
-																						run: (*parser).callonNode103,
+																						pos: position{line: 180, col: 5, offset: 4061},
+																						run: (*parser).callonNode122,
 																						expr: &seqExpr{
-																							pos: position{line: 170, col: 5, offset: 3764},
+																							pos: position{line: 180, col: 5, offset: 4061},
 																							exprs: []any{
 																								&actionExpr{
-																									pos: position{line: 228, col: 5, offset: 4753},
-																									run: (*parser).callonNode105,
+																									pos: position{line: 238, col: 5, offset: 5050},
+																									run: (*parser).callonNode124,
 																									expr: &charClassMatcher{
-																										pos:        position{line: 228, col: 5, offset: 4753},
+																										pos:        position{line: 238, col: 5, offset: 5050},
 																										val:        "[0-9]",
 																										ranges:     []rune{'0', '9'},
 																										ignoreCase: false,
@@ -579,10 +673,10 @@ var g = &grammar{
 																									},
 																								},
 																								&actionExpr{
-																									pos: position{line: 228, col: 5, offset: 4753},
-																									run: (*parser).callonNode107,
+																									pos: position{line: 238, col: 5, offset: 5050},
+																									run: (*parser).callonNode126,
 																									expr: &charClassMatcher{
-																										pos:        position{line: 228, col: 5, offset: 4753},
+																										pos:        position{line: 238, col: 5, offset: 5050},
 																										val:        "[0-9]",
 																										ranges:     []rune{'0', '9'},
 																										ignoreCase: false,
@@ -593,22 +687,22 @@ var g = &grammar{
 																						},
 																					},
 																					&litMatcher{
-																						pos:        position{line: 190, col: 86, offset: 4128},
+																						pos:        position{line: 200, col: 86, offset: 4425},
 																						val:        ":",
 																						ignoreCase: false,
 																						want:       "\":\"",
 																					},
 																					&actionExpr{
-																						pos: position{line: 175, col: 5, offset: 3830},
-																						run: (*parser).callonNode110,
+																						pos: position{line: 185, col: 5, offset: 4127},
+																						run: (*parser).callonNode129,
 																						expr: &seqExpr{
-																							pos: position{line: 175, col: 5, offset: 3830},
+																							pos: position{line: 185, col: 5, offset: 4127},
 																							exprs: []any{
 																								&actionExpr{
-																									pos: position{line: 228, col: 5, offset: 4753},
-																									run: (*parser).callonNode112,
+																									pos: position{line: 238, col: 5, offset: 5050},
+																									run: (*parser).callonNode131,
 																									expr: &charClassMatcher{
-																										pos:        position{line: 228, col: 5, offset: 4753},
+																										pos:        position{line: 238, col: 5, offset: 5050},
 																										val:        "[0-9]",
 																										ranges:     []rune{'0', '9'},
 																										ignoreCase: false,
@@ -616,10 +710,10 @@ var g = &grammar{
 																									},
 																								},
 																								&actionExpr{
-																									pos: position{line: 228, col: 5, offset: 4753},
-																									run: (*parser).callonNode114,
+																									pos: position{line: 238, col: 5, offset: 5050},
+																									run: (*parser).callonNode133,
 																									expr: &charClassMatcher{
-																										pos:        position{line: 228, col: 5, offset: 4753},
+																										pos:        position{line: 238, col: 5, offset: 5050},
 																										val:        "[0-9]",
 																										ranges:     []rune{'0', '9'},
 																										ignoreCase: false,
@@ -640,22 +734,22 @@ var g = &grammar{
 												},
 											},
 											&actionExpr{
-												pos: position{line: 185, col: 5, offset: 3960},
-												run: (*parser).callonNode116,
+												pos: position{line: 195, col: 5, offset: 4257},
+												run: (*parser).callonNode135,
 												expr: &seqExpr{
-													pos: position{line: 185, col: 5, offset: 3960},
+													pos: position{line: 195, col: 5, offset: 4257},
 													exprs: []any{
 														&actionExpr{
-															pos: position{line: 155, col: 5, offset: 3560},
-															run: (*parser).callonNode118,
+															pos: position{line: 165, col: 5, offset: 3857},
+															run: (*parser).callonNode137,
 															expr: &seqExpr{
-																pos: position{line: 155, col: 5, offset: 3560},
+																pos: position{line: 165, col: 5, offset: 3857},
 																exprs: []any{
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode120,
+																		pos: position{line: 238, col: 5, offset: 5050},
+																		run: (*parser).callonNode139,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5050},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -663,10 +757,10 @@ var g = &grammar{
 																		},
 																	},
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode122,
+																		pos: position{line: 238, col: 5, offset: 5050},
+																		run: (*parser).callonNode141,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5050},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -674,10 +768,10 @@ var g = &grammar{
 																		},
 																	},
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode124,
+																		pos: position{line: 238, col: 5, offset: 5050},
+																		run: (*parser).callonNode143,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5050},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -685,10 +779,10 @@ var g = &grammar{
 																		},
 																	},
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode126,
+																		pos: position{line: 238, col: 5, offset: 5050},
+																		run: (*parser).callonNode145,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5050},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -699,22 +793,22 @@ var g = &grammar{
 															},
 														},
 														&litMatcher{
-															pos:        position{line: 185, col: 14, offset: 3969},
+															pos:        position{line: 195, col: 14, offset: 4266},
 															val:        "-",
 															ignoreCase: false,
 															want:       "\"-\"",
 														},
 														&actionExpr{
-															pos: position{line: 160, col: 5, offset: 3637},
-															run: (*parser).callonNode129,
+															pos: position{line: 170, col: 5, offset: 3934},
+															run: (*parser).callonNode148,
 															expr: &seqExpr{
-																pos: position{line: 160, col: 5, offset: 3637},
+																pos: position{line: 170, col: 5, offset: 3934},
 																exprs: []any{
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode131,
+																		pos: position{line: 238, col: 5, offset: 5050},
+																		run: (*parser).callonNode150,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5050},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -722,10 +816,10 @@ var g = &grammar{
 																		},
 																	},
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode133,
+																		pos: position{line: 238, col: 5, offset: 5050},
+																		run: (*parser).callonNode152,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5050},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -736,22 +830,22 @@ var g = &grammar{
 															},
 														},
 														&litMatcher{
-															pos:        position{line: 185, col: 28, offset: 3983},
+															pos:        position{line: 195, col: 28, offset: 4280},
 															val:        "-",
 															ignoreCase: false,
 															want:       "\"-\"",
 														},
 														&actionExpr{
-															pos: position{line: 165, col: 5, offset: 3700},
-															run: (*parser).callonNode136,
+															pos: position{line: 175, col: 5, offset: 3997},
+															run: (*parser).callonNode155,
 															expr: &seqExpr{
-																pos: position{line: 165, col: 5, offset: 3700},
+																pos: position{line: 175, col: 5, offset: 3997},
 																exprs: []any{
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode138,
+																		pos: position{line: 238, col: 5, offset: 5050},
+																		run: (*parser).callonNode157,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5050},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -759,10 +853,10 @@ var g = &grammar{
 																		},
 																	},
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode140,
+																		pos: position{line: 238, col: 5, offset: 5050},
+																		run: (*parser).callonNode159,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5050},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -776,22 +870,22 @@ var g = &grammar{
 												},
 											},
 											&actionExpr{
-												pos: position{line: 190, col: 5, offset: 4047},
-												run: (*parser).callonNode142,
+												pos: position{line: 200, col: 5, offset: 4344},
+												run: (*parser).callonNode161,
 												expr: &seqExpr{
-													pos: position{line: 190, col: 5, offset: 4047},
+													pos: position{line: 200, col: 5, offset: 4344},
 													exprs: []any{
 														&actionExpr{
-															pos: position{line: 170, col: 5, offset: 3764},
-															run: (*parser).callonNode144,
+															pos: position{line: 180, col: 5, offset: 4061},
+															run: (*parser).callonNode163,
 															expr: &seqExpr{
-																pos: position{line: 170, col: 5, offset: 3764},
+																pos: position{line: 180, col: 5, offset: 4061},
 																exprs: []any{
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode146,
+																		pos: position{line: 238, col: 5, offset: 5050},
+																		run: (*parser).callonNode165,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5050},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -799,10 +893,10 @@ var g = &grammar{
 																		},
 																	},
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode148,
+																		pos: position{line: 238, col: 5, offset: 5050},
+																		run: (*parser).callonNode167,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5050},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -813,22 +907,22 @@ var g = &grammar{
 															},
 														},
 														&litMatcher{
-															pos:        position{line: 190, col: 14, offset: 4056},
+															pos:        position{line: 200, col: 14, offset: 4353},
 															val:        ":",
 															ignoreCase: false,
 															want:       "\":\"",
 														},
 														&actionExpr{
-															pos: position{line: 175, col: 5, offset: 3830},
-															run: (*parser).callonNode151,
+															pos: position{line: 185, col: 5, offset: 4127},
+															run: (*parser).callonNode170,
 															expr: &seqExpr{
-																pos: position{line: 175, col: 5, offset: 3830},
+																pos: position{line: 185, col: 5, offset: 4127},
 																exprs: []any{
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode153,
+																		pos: position{line: 238, col: 5, offset: 5050},
+																		run: (*parser).callonNode172,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5050},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -836,10 +930,10 @@ var g = &grammar{
 																		},
 																	},
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode155,
+																		pos: position{line: 238, col: 5, offset: 5050},
+																		run: (*parser).callonNode174,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5050},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -850,22 +944,22 @@ var g = &grammar{
 															},
 														},
 														&litMatcher{
-															pos:        position{line: 190, col: 29, offset: 4071},
+															pos:        position{line: 200, col: 29, offset: 4368},
 															val:        ":",
 															ignoreCase: false,
 															want:       "\":\"",
 														},
 														&actionExpr{
-															pos: position{line: 180, col: 5, offset: 3896},
-															run: (*parser).callonNode158,
+															pos: position{line: 190, col: 5, offset: 4193},
+															run: (*parser).callonNode177,
 															expr: &seqExpr{
-																pos: position{line: 180, col: 5, offset: 3896},
+																pos: position{line: 190, col: 5, offset: 4193},
 																exprs: []any{
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode160,
+																		pos: position{line: 238, col: 5, offset: 5050},
+																		run: (*parser).callonNode179,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5050},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -873,10 +967,10 @@ var g = &grammar{
 																		},
 																	},
 																	&actionExpr{
-																		pos: position{line: 228, col: 5, offset: 4753},
-																		run: (*parser).callonNode162,
+																		pos: position{line: 238, col: 5, offset: 5050},
+																		run: (*parser).callonNode181,
 																		expr: &charClassMatcher{
-																			pos:        position{line: 228, col: 5, offset: 4753},
+																			pos:        position{line: 238, col: 5, offset: 5050},
 																			val:        "[0-9]",
 																			ranges:     []rune{'0', '9'},
 																			ignoreCase: false,
@@ -887,23 +981,23 @@ var g = &grammar{
 															},
 														},
 														&zeroOrOneExpr{
-															pos: position{line: 190, col: 44, offset: 4086},
+															pos: position{line: 200, col: 44, offset: 4383},
 															expr: &seqExpr{
-																pos: position{line: 190, col: 45, offset: 4087},
+																pos: position{line: 200, col: 45, offset: 4384},
 																exprs: []any{
 																	&litMatcher{
-																		pos:        position{line: 190, col: 45, offset: 4087},
+																		pos:        position{line: 200, col: 45, offset: 4384},
 																		val:        ".",
 																		ignoreCase: false,
 																		want:       "\".\"",
 																	},
 																	&oneOrMoreExpr{
-																		pos: position{line: 190, col: 49, offset: 4091},
+																		pos: position{line: 200, col: 49, offset: 4388},
 																		expr: &actionExpr{
-																			pos: position{line: 228, col: 5, offset: 4753},
-																			run: (*parser).callonNode168,
+																			pos: position{line: 238, col: 5, offset: 5050},
+																			run: (*parser).callonNode187,
 																			expr: &charClassMatcher{
-																				pos:        position{line: 228, col: 5, offset: 4753},
+																				pos:        position{line: 238, col: 5, offset: 5050},
 																				val:        "[0-9]",
 																				ranges:     []rune{'0', '9'},
 																				ignoreCase: false,
@@ -915,35 +1009,35 @@ var g = &grammar{
 															},
 														},
 														&choiceExpr{
-															pos: position{line: 190, col: 59, offset: 4101},
+															pos: position{line: 200, col: 59, offset: 4398},
 															alternatives: []any{
 																&litMatcher{
-																	pos:        position{line: 190, col: 59, offset: 4101},
+																	pos:        position{line: 200, col: 59, offset: 4398},
 																	val:        "Z",
 																	ignoreCase: false,
 																	want:       "\"Z\"",
 																},
 																&seqExpr{
-																	pos: position{line: 190, col: 65, offset: 4107},
+																	pos: position{line: 200, col: 65, offset: 4404},
 																	exprs: []any{
 																		&charClassMatcher{
-																			pos:        position{line: 190, col: 66, offset: 4108},
+																			pos:        position{line: 200, col: 66, offset: 4405},
 																			val:        "[+-]",
 																			chars:      []rune{'+', '-'},
 																			ignoreCase: false,
 																			inverted:   false,
 																		},
 																		&actionExpr{
-																			pos: position{line: 170, col: 5, offset: 3764},
-																			run: (*parser).callonNode174,
+																			pos: position{line: 180, col: 5, offset: 4061},
+																			run: (*parser).callonNode193,
 																			expr: &seqExpr{
-																				pos: position{line: 170, col: 5, offset: 3764},
+																				pos: position{line: 180, col: 5, offset: 4061},
 																				exprs: []any{
 																					&actionExpr{
-																						pos: position{line: 228, col: 5, offset: 4753},
-																						run: (*parser).callonNode176,
+																						pos: position{line: 238, col: 5, offset: 5050},
+																						run: (*parser).callonNode195,
 																						expr: &charClassMatcher{
-																							pos:        position{line: 228, col: 5, offset: 4753},
+																							pos:        position{line: 238, col: 5, offset: 5050},
 																							val:        "[0-9]",
 																							ranges:     []rune{'0', '9'},
 																							ignoreCase: false,
@@ -951,10 +1045,10 @@ var g = &grammar{
 																						},
 																					},
 																					&actionExpr{
-																						pos: position{line: 228, col: 5, offset: 4753},
-																						run: (*parser).callonNode178,
+																						pos: position{line: 238, col: 5, offset: 5050},
+																						run: (*parser).callonNode197,
 																						expr: &charClassMatcher{
-																							pos:        position{line: 228, col: 5, offset: 4753},
+																							pos:        position{line: 238, col: 5, offset: 5050},
 																							val:        "[0-9]",
 																							ranges:     []rune{'0', '9'},
 																							ignoreCase: false,
@@ -965,22 +1059,22 @@ var g = &grammar{
 																			},
 																		},
 																		&litMatcher{
-																			pos:        position{line: 190, col: 86, offset: 4128},
+																			pos:        position{line: 200, col: 86, offset: 4425},
 																			val:        ":",
 																			ignoreCase: false,
 																			want:       "\":\"",
 																		},
 																		&actionExpr{
-																			pos: position{line: 175, col: 5, offset: 3830},
-																			run: (*parser).callonNode181,
+																			pos: position{line: 185, col: 5, offset: 4127},
+																			run: (*parser).callonNode200,
 																			expr: &seqExpr{
-																				pos: position{line: 175, col: 5, offset: 3830},
+																				pos: position{line: 185, col: 5, offset: 4127},
 																				exprs: []any{
 																					&actionExpr{
-																						pos: position{line: 228, col: 5, offset: 4753},
-																						run: (*parser).callonNode183,
+																						pos: position{line: 238, col: 5, offset: 5050},
+																						run: (*parser).callonNode202,
 																						expr: &charClassMatcher{
-																							pos:        position{line: 228, col: 5, offset: 4753},
+																							pos:        position{line: 238, col: 5, offset: 5050},
 																							val:        "[0-9]",
 																							ranges:     []rune{'0', '9'},
 																							ignoreCase: false,
@@ -988,10 +1082,10 @@ var g = &grammar{
 																						},
 																					},
 																					&actionExpr{
-																						pos: position{line: 228, col: 5, offset: 4753},
-																						run: (*parser).callonNode185,
+																						pos: position{line: 238, col: 5, offset: 5050},
+																						run: (*parser).callonNode204,
 																						expr: &charClassMatcher{
-																							pos:        position{line: 228, col: 5, offset: 4753},
+																							pos:        position{line: 238, col: 5, offset: 5050},
 																							val:        "[0-9]",
 																							ranges:     []rune{'0', '9'},
 																							ignoreCase: false,
@@ -1012,9 +1106,9 @@ var g = &grammar{
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 62, col: 7, offset: 1473},
+									pos: position{line: 72, col: 7, offset: 1770},
 									expr: &litMatcher{
-										pos:        position{line: 62, col: 7, offset: 1473},
+										pos:        position{line: 72, col: 7, offset: 1770},
 										val:        "\"",
 										ignoreCase: false,
 										want:       "\"\\\"\"",
@@ -1024,21 +1118,21 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 65, col: 5, offset: 1549},
-						run: (*parser).callonNode189,
+						pos: position{line: 75, col: 5, offset: 1846},
+						run: (*parser).callonNode208,
 						expr: &seqExpr{
-							pos: position{line: 65, col: 5, offset: 1549},
+							pos: position{line: 75, col: 5, offset: 1846},
 							exprs: []any{
 								&labeledExpr{
-									pos:   position{line: 65, col: 5, offset: 1549},
+									pos:   position{line: 75, col: 5, offset: 1846},
 									label: "k",
 									expr: &oneOrMoreExpr{
-										pos: position{line: 65, col: 7, offset: 1551},
+										pos: position{line: 75, col: 7, offset: 1848},
 										expr: &actionExpr{
-											pos: position{line: 218, col: 5, offset: 4634},
-											run: (*parser).callonNode193,
+											pos: position{line: 228, col: 5, offset: 4931},
+											run: (*parser).callonNode212,
 											expr: &charClassMatcher{
-												pos:        position{line: 218, col: 5, offset: 4634},
+												pos:        position{line: 228, col: 5, offset: 4931},
 												val:        "[A-Za-z]",
 												ranges:     []rune{'A', 'Z', 'a', 'z'},
 												ignoreCase: false,
@@ -1048,23 +1142,23 @@ var g = &grammar{
 									},
 								},
 								&choiceExpr{
-									pos: position{line: 66, col: 9, offset: 1567},
+									pos: position{line: 76, col: 9, offset: 1864},
 									alternatives: []any{
 										&actionExpr{
-											pos: position{line: 125, col: 5, offset: 2997},
-											run: (*parser).callonNode196,
+											pos: position{line: 135, col: 5, offset: 3294},
+											run: (*parser).callonNode215,
 											expr: &litMatcher{
-												pos:        position{line: 125, col: 5, offset: 2997},
+												pos:        position{line: 135, col: 5, offset: 3294},
 												val:        "=",
 												ignoreCase: false,
 												want:       "\"=\"",
 											},
 										},
 										&actionExpr{
-											pos: position{line: 120, col: 5, offset: 2911},
-											run: (*parser).callonNode198,
+											pos: position{line: 130, col: 5, offset: 3208},
+											run: (*parser).callonNode217,
 											expr: &litMatcher{
-												pos:        position{line: 120, col: 5, offset: 2911},
+												pos:        position{line: 130, col: 5, offset: 3208},
 												val:        ":",
 												ignoreCase: false,
 												want:       "\":\"",
@@ -1073,79 +1167,79 @@ var g = &grammar{
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 68, col: 7, offset: 1619},
+									pos: position{line: 78, col: 7, offset: 1916},
 									expr: &litMatcher{
-										pos:        position{line: 68, col: 7, offset: 1619},
+										pos:        position{line: 78, col: 7, offset: 1916},
 										val:        "\"",
 										ignoreCase: false,
 										want:       "\"\\\"\"",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 68, col: 12, offset: 1624},
+									pos:   position{line: 78, col: 12, offset: 1921},
 									label: "v",
 									expr: &choiceExpr{
-										pos: position{line: 200, col: 5, offset: 4285},
+										pos: position{line: 210, col: 5, offset: 4582},
 										alternatives: []any{
 											&litMatcher{
-												pos:        position{line: 200, col: 5, offset: 4285},
+												pos:        position{line: 210, col: 5, offset: 4582},
 												val:        "today",
 												ignoreCase: false,
 												want:       "\"today\"",
 											},
 											&litMatcher{
-												pos:        position{line: 201, col: 5, offset: 4299},
+												pos:        position{line: 211, col: 5, offset: 4596},
 												val:        "yesterday",
 												ignoreCase: false,
 												want:       "\"yesterday\"",
 											},
 											&litMatcher{
-												pos:        position{line: 202, col: 5, offset: 4317},
+												pos:        position{line: 212, col: 5, offset: 4614},
 												val:        "this week",
 												ignoreCase: false,
 												want:       "\"this week\"",
 											},
 											&litMatcher{
-												pos:        position{line: 203, col: 5, offset: 4335},
+												pos:        position{line: 213, col: 5, offset: 4632},
 												val:        "last week",
 												ignoreCase: false,
 												want:       "\"last week\"",
 											},
 											&litMatcher{
-												pos:        position{line: 204, col: 5, offset: 4353},
+												pos:        position{line: 214, col: 5, offset: 4650},
 												val:        "last 7 days",
 												ignoreCase: false,
 												want:       "\"last 7 days\"",
 											},
 											&litMatcher{
-												pos:        position{line: 205, col: 5, offset: 4373},
+												pos:        position{line: 215, col: 5, offset: 4670},
 												val:        "this month",
 												ignoreCase: false,
 												want:       "\"this month\"",
 											},
 											&litMatcher{
-												pos:        position{line: 206, col: 5, offset: 4392},
+												pos:        position{line: 216, col: 5, offset: 4689},
 												val:        "last month",
 												ignoreCase: false,
 												want:       "\"last month\"",
 											},
 											&litMatcher{
-												pos:        position{line: 207, col: 5, offset: 4411},
+												pos:        position{line: 217, col: 5, offset: 4708},
 												val:        "last 30 days",
 												ignoreCase: false,
 												want:       "\"last 30 days\"",
 											},
 											&litMatcher{
-												pos:        position{line: 208, col: 5, offset: 4432},
+												pos:        position{line: 218, col: 5, offset: 4729},
 												val:        "this year",
 												ignoreCase: false,
 												want:       "\"this year\"",
 											},
 											&actionExpr{
-												pos: position{line: 209, col: 5, offset: 4450},
-												run: (*parser).callonNode213,
+												pos: position{line: 219, col: 5, offset: 4747},
+												run: (*parser).callonNode232,
 												expr: &litMatcher{
-													pos:        position{line: 209, col: 5, offset: 4450},
+													pos:        position{line: 219, col: 5, offset: 4747},
 													val:        "last year",
 													ignoreCase: false,
 													want:       "\"last year\"",
@@ -1155,9 +1249,9 @@ var g = &grammar{
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 68, col: 38, offset: 1650},
+									pos: position{line: 78, col: 38, offset: 1947},
 									expr: &litMatcher{
-										pos:        position{line: 68, col: 38, offset: 1650},
+										pos:        position{line: 78, col: 38, offset: 1947},
 										val:        "\"",
 										ignoreCase: false,
 										want:       "\"\\\"\"",
@@ -1167,21 +1261,21 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 73, col: 5, offset: 1769},
-						run: (*parser).callonNode217,
+						pos: position{line: 83, col: 5, offset: 2066},
+						run: (*parser).callonNode236,
 						expr: &seqExpr{
-							pos: position{line: 73, col: 5, offset: 1769},
+							pos: position{line: 83, col: 5, offset: 2066},
 							exprs: []any{
 								&labeledExpr{
-									pos:   position{line: 73, col: 5, offset: 1769},
+									pos:   position{line: 83, col: 5, offset: 2066},
 									label: "k",
 									expr: &oneOrMoreExpr{
-										pos: position{line: 73, col: 7, offset: 1771},
+										pos: position{line: 83, col: 7, offset: 2068},
 										expr: &actionExpr{
-											pos: position{line: 218, col: 5, offset: 4634},
-											run: (*parser).callonNode221,
+											pos: position{line: 228, col: 5, offset: 4931},
+											run: (*parser).callonNode240,
 											expr: &charClassMatcher{
-												pos:        position{line: 218, col: 5, offset: 4634},
+												pos:        position{line: 228, col: 5, offset: 4931},
 												val:        "[A-Za-z]",
 												ranges:     []rune{'A', 'Z', 'a', 'z'},
 												ignoreCase: false,
@@ -1191,23 +1285,23 @@ var g = &grammar{
 									},
 								},
 								&choiceExpr{
-									pos: position{line: 73, col: 14, offset: 1778},
+									pos: position{line: 83, col: 14, offset: 2075},
 									alternatives: []any{
 										&actionExpr{
-											pos: position{line: 120, col: 5, offset: 2911},
-											run: (*parser).callonNode224,
+											pos: position{line: 130, col: 5, offset: 3208},
+											run: (*parser).callonNode243,
 											expr: &litMatcher{
-												pos:        position{line: 120, col: 5, offset: 2911},
+												pos:        position{line: 130, col: 5, offset: 3208},
 												val:        ":",
 												ignoreCase: false,
 												want:       "\":\"",
 											},
 										},
 										&actionExpr{
-											pos: position{line: 125, col: 5, offset: 2997},
-											run: (*parser).callonNode226,
+											pos: position{line: 135, col: 5, offset: 3294},
+											run: (*parser).callonNode245,
 											expr: &litMatcher{
-												pos:        position{line: 125, col: 5, offset: 2997},
+												pos:        position{line: 135, col: 5, offset: 3294},
 												val:        "=",
 												ignoreCase: false,
 												want:       "\"=\"",
@@ -1216,30 +1310,30 @@ var g = &grammar{
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 73, col: 53, offset: 1817},
+									pos:   position{line: 83, col: 53, offset: 2114},
 									label: "v",
 									expr: &choiceExpr{
-										pos: position{line: 73, col: 56, offset: 1820},
+										pos: position{line: 83, col: 56, offset: 2117},
 										alternatives: []any{
 											&actionExpr{
-												pos: position{line: 223, col: 5, offset: 4693},
-												run: (*parser).callonNode230,
+												pos: position{line: 233, col: 5, offset: 4990},
+												run: (*parser).callonNode249,
 												expr: &seqExpr{
-													pos: position{line: 223, col: 5, offset: 4693},
+													pos: position{line: 233, col: 5, offset: 4990},
 													exprs: []any{
 														&litMatcher{
-															pos:        position{line: 223, col: 5, offset: 4693},
+															pos:        position{line: 233, col: 5, offset: 4990},
 															val:        "\"",
 															ignoreCase: false,
 															want:       "\"\\\"\"",
 														},
 														&labeledExpr{
-															pos:   position{line: 223, col: 9, offset: 4697},
+															pos:   position{line: 233, col: 9, offset: 4994},
 															label: "v",
 															expr: &zeroOrMoreExpr{
-																pos: position{line: 223, col: 11, offset: 4699},
+																pos: position{line: 233, col: 11, offset: 4996},
 																expr: &charClassMatcher{
-																	pos:        position{line: 223, col: 11, offset: 4699},
+																	pos:        position{line: 233, col: 11, offset: 4996},
 																	val:        "[^\"]",
 																	chars:      []rune{'"'},
 																	ignoreCase: false,
@@ -1248,7 +1342,7 @@ var g = &grammar{
 															},
 														},
 														&litMatcher{
-															pos:        position{line: 223, col: 17, offset: 4705},
+															pos:        position{line: 233, col: 17, offset: 5002},
 															val:        "\"",
 															ignoreCase: false,
 															want:       "\"\\\"\"",
@@ -1257,9 +1351,9 @@ var g = &grammar{
 												},
 											},
 											&oneOrMoreExpr{
-												pos: position{line: 73, col: 65, offset: 1829},
+												pos: position{line: 83, col: 65, offset: 2126},
 												expr: &charClassMatcher{
-													pos:        position{line: 73, col: 65, offset: 1829},
+													pos:        position{line: 83, col: 65, offset: 2126},
 													val:        "[^ ()]",
 													chars:      []rune{' ', '(', ')'},
 													ignoreCase: false,
@@ -1273,19 +1367,19 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 105, col: 5, offset: 2621},
-						run: (*parser).callonNode239,
+						pos: position{line: 115, col: 5, offset: 2918},
+						run: (*parser).callonNode258,
 						expr: &choiceExpr{
-							pos: position{line: 105, col: 6, offset: 2622},
+							pos: position{line: 115, col: 6, offset: 2919},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 105, col: 6, offset: 2622},
+									pos:        position{line: 115, col: 6, offset: 2919},
 									val:        "AND",
 									ignoreCase: false,
 									want:       "\"AND\"",
 								},
 								&litMatcher{
-									pos:        position{line: 105, col: 14, offset: 2630},
+									pos:        position{line: 115, col: 14, offset: 2927},
 									val:        "+",
 									ignoreCase: false,
 									want:       "\"+\"",
@@ -1294,19 +1388,19 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 110, col: 5, offset: 2722},
-						run: (*parser).callonNode243,
+						pos: position{line: 120, col: 5, offset: 3019},
+						run: (*parser).callonNode262,
 						expr: &choiceExpr{
-							pos: position{line: 110, col: 6, offset: 2723},
+							pos: position{line: 120, col: 6, offset: 3020},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 110, col: 6, offset: 2723},
+									pos:        position{line: 120, col: 6, offset: 3020},
 									val:        "NOT",
 									ignoreCase: false,
 									want:       "\"NOT\"",
 								},
 								&litMatcher{
-									pos:        position{line: 110, col: 14, offset: 2731},
+									pos:        position{line: 120, col: 14, offset: 3028},
 									val:        "-",
 									ignoreCase: false,
 									want:       "\"-\"",
@@ -1315,28 +1409,28 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 115, col: 5, offset: 2822},
-						run: (*parser).callonNode247,
+						pos: position{line: 125, col: 5, offset: 3119},
+						run: (*parser).callonNode266,
 						expr: &litMatcher{
-							pos:        position{line: 115, col: 6, offset: 2823},
+							pos:        position{line: 125, col: 6, offset: 3120},
 							val:        "OR",
 							ignoreCase: false,
 							want:       "\"OR\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 86, col: 6, offset: 2109},
-						run: (*parser).callonNode249,
+						pos: position{line: 96, col: 6, offset: 2406},
+						run: (*parser).callonNode268,
 						expr: &seqExpr{
-							pos: position{line: 86, col: 6, offset: 2109},
+							pos: position{line: 96, col: 6, offset: 2406},
 							exprs: []any{
 								&zeroOrOneExpr{
-									pos: position{line: 86, col: 6, offset: 2109},
+									pos: position{line: 96, col: 6, offset: 2406},
 									expr: &actionExpr{
-										pos: position{line: 120, col: 5, offset: 2911},
-										run: (*parser).callonNode252,
+										pos: position{line: 130, col: 5, offset: 3208},
+										run: (*parser).callonNode271,
 										expr: &litMatcher{
-											pos:        position{line: 120, col: 5, offset: 2911},
+											pos:        position{line: 130, col: 5, offset: 3208},
 											val:        ":",
 											ignoreCase: false,
 											want:       "\":\"",
@@ -1344,12 +1438,12 @@ var g = &grammar{
 									},
 								},
 								&actionExpr{
-									pos: position{line: 233, col: 5, offset: 4804},
-									run: (*parser).callonNode254,
+									pos: position{line: 243, col: 5, offset: 5101},
+									run: (*parser).callonNode273,
 									expr: &zeroOrMoreExpr{
-										pos: position{line: 233, col: 5, offset: 4804},
+										pos: position{line: 243, col: 5, offset: 5101},
 										expr: &charClassMatcher{
-											pos:        position{line: 233, col: 5, offset: 4804},
+											pos:        position{line: 243, col: 5, offset: 5101},
 											val:        "[ \\t]",
 											chars:      []rune{' ', '\t'},
 											ignoreCase: false,
@@ -1358,27 +1452,27 @@ var g = &grammar{
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 86, col: 27, offset: 2130},
+									pos:   position{line: 96, col: 27, offset: 2427},
 									label: "v",
 									expr: &actionExpr{
-										pos: position{line: 223, col: 5, offset: 4693},
-										run: (*parser).callonNode258,
+										pos: position{line: 233, col: 5, offset: 4990},
+										run: (*parser).callonNode277,
 										expr: &seqExpr{
-											pos: position{line: 223, col: 5, offset: 4693},
+											pos: position{line: 233, col: 5, offset: 4990},
 											exprs: []any{
 												&litMatcher{
-													pos:        position{line: 223, col: 5, offset: 4693},
+													pos:        position{line: 233, col: 5, offset: 4990},
 													val:        "\"",
 													ignoreCase: false,
 													want:       "\"\\\"\"",
 												},
 												&labeledExpr{
-													pos:   position{line: 223, col: 9, offset: 4697},
+													pos:   position{line: 233, col: 9, offset: 4994},
 													label: "v",
 													expr: &zeroOrMoreExpr{
-														pos: position{line: 223, col: 11, offset: 4699},
+														pos: position{line: 233, col: 11, offset: 4996},
 														expr: &charClassMatcher{
-															pos:        position{line: 223, col: 11, offset: 4699},
+															pos:        position{line: 233, col: 11, offset: 4996},
 															val:        "[^\"]",
 															chars:      []rune{'"'},
 															ignoreCase: false,
@@ -1387,7 +1481,7 @@ var g = &grammar{
 													},
 												},
 												&litMatcher{
-													pos:        position{line: 223, col: 17, offset: 4705},
+													pos:        position{line: 233, col: 17, offset: 5002},
 													val:        "\"",
 													ignoreCase: false,
 													want:       "\"\\\"\"",
@@ -1397,12 +1491,12 @@ var g = &grammar{
 									},
 								},
 								&actionExpr{
-									pos: position{line: 233, col: 5, offset: 4804},
-									run: (*parser).callonNode265,
+									pos: position{line: 243, col: 5, offset: 5101},
+									run: (*parser).callonNode284,
 									expr: &zeroOrMoreExpr{
-										pos: position{line: 233, col: 5, offset: 4804},
+										pos: position{line: 243, col: 5, offset: 5101},
 										expr: &charClassMatcher{
-											pos:        position{line: 233, col: 5, offset: 4804},
+											pos:        position{line: 243, col: 5, offset: 5101},
 											val:        "[ \\t]",
 											chars:      []rune{' ', '\t'},
 											ignoreCase: false,
@@ -1411,12 +1505,12 @@ var g = &grammar{
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 86, col: 38, offset: 2141},
+									pos: position{line: 96, col: 38, offset: 2438},
 									expr: &actionExpr{
-										pos: position{line: 120, col: 5, offset: 2911},
-										run: (*parser).callonNode269,
+										pos: position{line: 130, col: 5, offset: 3208},
+										run: (*parser).callonNode288,
 										expr: &litMatcher{
-											pos:        position{line: 120, col: 5, offset: 2911},
+											pos:        position{line: 130, col: 5, offset: 3208},
 											val:        ":",
 											ignoreCase: false,
 											want:       "\":\"",
@@ -1427,18 +1521,18 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 91, col: 6, offset: 2239},
-						run: (*parser).callonNode271,
+						pos: position{line: 101, col: 6, offset: 2536},
+						run: (*parser).callonNode290,
 						expr: &seqExpr{
-							pos: position{line: 91, col: 6, offset: 2239},
+							pos: position{line: 101, col: 6, offset: 2536},
 							exprs: []any{
 								&zeroOrOneExpr{
-									pos: position{line: 91, col: 6, offset: 2239},
+									pos: position{line: 101, col: 6, offset: 2536},
 									expr: &actionExpr{
-										pos: position{line: 120, col: 5, offset: 2911},
-										run: (*parser).callonNode274,
+										pos: position{line: 130, col: 5, offset: 3208},
+										run: (*parser).callonNode293,
 										expr: &litMatcher{
-											pos:        position{line: 120, col: 5, offset: 2911},
+											pos:        position{line: 130, col: 5, offset: 3208},
 											val:        ":",
 											ignoreCase: false,
 											want:       "\":\"",
@@ -1446,12 +1540,12 @@ var g = &grammar{
 									},
 								},
 								&actionExpr{
-									pos: position{line: 233, col: 5, offset: 4804},
-									run: (*parser).callonNode276,
+									pos: position{line: 243, col: 5, offset: 5101},
+									run: (*parser).callonNode295,
 									expr: &zeroOrMoreExpr{
-										pos: position{line: 233, col: 5, offset: 4804},
+										pos: position{line: 243, col: 5, offset: 5101},
 										expr: &charClassMatcher{
-											pos:        position{line: 233, col: 5, offset: 4804},
+											pos:        position{line: 243, col: 5, offset: 5101},
 											val:        "[ \\t]",
 											chars:      []rune{' ', '\t'},
 											ignoreCase: false,
@@ -1460,12 +1554,12 @@ var g = &grammar{
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 91, col: 27, offset: 2260},
+									pos:   position{line: 101, col: 27, offset: 2557},
 									label: "v",
 									expr: &oneOrMoreExpr{
-										pos: position{line: 91, col: 29, offset: 2262},
+										pos: position{line: 101, col: 29, offset: 2559},
 										expr: &charClassMatcher{
-											pos:        position{line: 91, col: 29, offset: 2262},
+											pos:        position{line: 101, col: 29, offset: 2559},
 											val:        "[^ :()]",
 											chars:      []rune{' ', ':', '(', ')'},
 											ignoreCase: false,
@@ -1474,12 +1568,12 @@ var g = &grammar{
 									},
 								},
 								&actionExpr{
-									pos: position{line: 233, col: 5, offset: 4804},
-									run: (*parser).callonNode282,
+									pos: position{line: 243, col: 5, offset: 5101},
+									run: (*parser).callonNode301,
 									expr: &zeroOrMoreExpr{
-										pos: position{line: 233, col: 5, offset: 4804},
+										pos: position{line: 243, col: 5, offset: 5101},
 										expr: &charClassMatcher{
-											pos:        position{line: 233, col: 5, offset: 4804},
+											pos:        position{line: 243, col: 5, offset: 5101},
 											val:        "[ \\t]",
 											chars:      []rune{' ', '\t'},
 											ignoreCase: false,
@@ -1488,12 +1582,12 @@ var g = &grammar{
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 91, col: 40, offset: 2273},
+									pos: position{line: 101, col: 40, offset: 2570},
 									expr: &actionExpr{
-										pos: position{line: 120, col: 5, offset: 2911},
-										run: (*parser).callonNode286,
+										pos: position{line: 130, col: 5, offset: 3208},
+										run: (*parser).callonNode305,
 										expr: &litMatcher{
-											pos:        position{line: 120, col: 5, offset: 2911},
+											pos:        position{line: 130, col: 5, offset: 3208},
 											val:        ":",
 											ignoreCase: false,
 											want:       "\":\"",
@@ -1508,25 +1602,25 @@ var g = &grammar{
 		},
 		{
 			name: "GroupNode",
-			pos:  position{line: 31, col: 1, offset: 595},
+			pos:  position{line: 32, col: 1, offset: 613},
 			expr: &actionExpr{
-				pos: position{line: 32, col: 5, offset: 612},
+				pos: position{line: 33, col: 5, offset: 630},
 				run: (*parser).callonGroupNode1,
 				expr: &seqExpr{
-					pos: position{line: 32, col: 5, offset: 612},
+					pos: position{line: 33, col: 5, offset: 630},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 32, col: 5, offset: 612},
+							pos:   position{line: 33, col: 5, offset: 630},
 							label: "k",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 32, col: 7, offset: 614},
+								pos: position{line: 33, col: 7, offset: 632},
 								expr: &oneOrMoreExpr{
-									pos: position{line: 32, col: 8, offset: 615},
+									pos: position{line: 33, col: 8, offset: 633},
 									expr: &actionExpr{
-										pos: position{line: 218, col: 5, offset: 4634},
+										pos: position{line: 228, col: 5, offset: 4931},
 										run: (*parser).callonGroupNode6,
 										expr: &charClassMatcher{
-											pos:        position{line: 218, col: 5, offset: 4634},
+											pos:        position{line: 228, col: 5, offset: 4931},
 											val:        "[A-Za-z]",
 											ranges:     []rune{'A', 'Z', 'a', 'z'},
 											ignoreCase: false,
@@ -1537,25 +1631,25 @@ var g = &grammar{
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 32, col: 16, offset: 623},
+							pos: position{line: 33, col: 16, offset: 641},
 							expr: &choiceExpr{
-								pos: position{line: 32, col: 17, offset: 624},
+								pos: position{line: 33, col: 17, offset: 642},
 								alternatives: []any{
 									&actionExpr{
-										pos: position{line: 120, col: 5, offset: 2911},
+										pos: position{line: 130, col: 5, offset: 3208},
 										run: (*parser).callonGroupNode10,
 										expr: &litMatcher{
-											pos:        position{line: 120, col: 5, offset: 2911},
+											pos:        position{line: 130, col: 5, offset: 3208},
 											val:        ":",
 											ignoreCase: false,
 											want:       "\":\"",
 										},
 									},
 									&actionExpr{
-										pos: position{line: 125, col: 5, offset: 2997},
+										pos: position{line: 135, col: 5, offset: 3294},
 										run: (*parser).callonGroupNode12,
 										expr: &litMatcher{
-											pos:        position{line: 125, col: 5, offset: 2997},
+											pos:        position{line: 135, col: 5, offset: 3294},
 											val:        "=",
 											ignoreCase: false,
 											want:       "\"=\"",
@@ -1565,21 +1659,21 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 32, col: 57, offset: 664},
+							pos:        position{line: 33, col: 57, offset: 682},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 32, col: 61, offset: 668},
+							pos:   position{line: 33, col: 61, offset: 686},
 							label: "v",
 							expr: &ruleRefExpr{
-								pos:  position{line: 32, col: 63, offset: 670},
+								pos:  position{line: 33, col: 63, offset: 688},
 								name: "Nodes",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 32, col: 69, offset: 676},
+							pos:        position{line: 33, col: 69, offset: 694},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -1614,7 +1708,7 @@ func (p *parser) callonNodes3() (any, error) {
 }
 
 func (c *current) onNode7() (any, error) {
-	return c.text, nil
+	return buildOperatorNode(c.text, c.pos)
 
 }
 
@@ -1624,1038 +1718,1082 @@ func (p *parser) callonNode7() (any, error) {
 	return p.cur.onNode7()
 }
 
-func (c *current) onNode10() (any, error) {
+func (c *current) onNode9() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode10() (any, error) {
+func (p *parser) callonNode9() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode10()
+	return p.cur.onNode9()
 }
 
-func (c *current) onNode12() (any, error) {
-	return buildOperatorNode(c.text, c.pos)
+func (c *current) onNode13(v any) (any, error) {
+	return v, nil
 
 }
 
-func (p *parser) callonNode12() (any, error) {
+func (p *parser) callonNode13() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode12()
+	return p.cur.onNode13(stack["v"])
 }
 
-func (c *current) onNode3(k, v any) (any, error) {
-	return buildBooleanNode(k, v, c.text, c.pos)
+func (c *current) onNode3(v any) (any, error) {
+	return buildSimilarNode(v, c.text, c.pos)
 
 }
 
 func (p *parser) callonNode3() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode3(stack["k"], stack["v"])
+	return p.cur.onNode3(stack["v"])
 }
 
-func (c *current) onNode22() (any, error) {
+func (c *current) onNode26() (any, error) {
 	return c.text, nil
 
 }
 
+func (p *parser) callonNode26() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNode26()
+}
+
+func (c *current) onNode29() (any, error) {
+	return buildOperatorNode(c.text, c.pos)
+
+}
+
+func (p *parser) callonNode29() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNode29()
+}
+
+func (c *current) onNode31() (any, error) {
+	return buildOperatorNode(c.text, c.pos)
+
+}
+
+func (p *parser) callonNode31() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNode31()
+}
+
+func (c *current) onNode22(k, v any) (any, error) {
+	return buildBooleanNode(k, v, c.text, c.pos)
+
+}
+
 func (p *parser) callonNode22() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode22()
+	return p.cur.onNode22(stack["k"], stack["v"])
 }
 
-func (c *current) onNode26() (any, error) {
+func (c *current) onNode41() (any, error) {
+	return c.text, nil
+
+}
+
+func (p *parser) callonNode41() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onNode41()
+}
+
+func (c *current) onNode45() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode26() (any, error) {
+func (p *parser) callonNode45() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode26()
+	return p.cur.onNode45()
 }
 
-func (c *current) onNode28() (any, error) {
+func (c *current) onNode47() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode28() (any, error) {
+func (p *parser) callonNode47() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode28()
+	return p.cur.onNode47()
 }
 
-func (c *current) onNode30() (any, error) {
+func (c *current) onNode49() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode30() (any, error) {
+func (p *parser) callonNode49() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode30()
+	return p.cur.onNode49()
 }
 
-func (c *current) onNode32() (any, error) {
+func (c *current) onNode51() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode32() (any, error) {
+func (p *parser) callonNode51() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode32()
+	return p.cur.onNode51()
 }
 
-func (c *current) onNode34() (any, error) {
+func (c *current) onNode53() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode34() (any, error) {
+func (p *parser) callonNode53() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode34()
+	return p.cur.onNode53()
 }
 
-func (c *current) onNode36() (any, error) {
+func (c *current) onNode55() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode36() (any, error) {
+func (p *parser) callonNode55() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode36()
+	return p.cur.onNode55()
 }
 
-func (c *current) onNode48() (any, error) {
+func (c *current) onNode67() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode48() (any, error) {
+func (p *parser) callonNode67() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode48()
+	return p.cur.onNode67()
 }
 
-func (c *current) onNode50() (any, error) {
+func (c *current) onNode69() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode50() (any, error) {
+func (p *parser) callonNode69() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode50()
+	return p.cur.onNode69()
 }
 
-func (c *current) onNode52() (any, error) {
+func (c *current) onNode71() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode52() (any, error) {
+func (p *parser) callonNode71() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode52()
+	return p.cur.onNode71()
 }
 
-func (c *current) onNode54() (any, error) {
+func (c *current) onNode73() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode54() (any, error) {
+func (p *parser) callonNode73() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode54()
+	return p.cur.onNode73()
 }
 
-func (c *current) onNode46() (any, error) {
+func (c *current) onNode65() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode46() (any, error) {
+func (p *parser) callonNode65() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode46()
+	return p.cur.onNode65()
 }
 
-func (c *current) onNode59() (any, error) {
+func (c *current) onNode78() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode59() (any, error) {
+func (p *parser) callonNode78() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode59()
+	return p.cur.onNode78()
 }
 
-func (c *current) onNode61() (any, error) {
+func (c *current) onNode80() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode61() (any, error) {
+func (p *parser) callonNode80() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode61()
+	return p.cur.onNode80()
 }
 
-func (c *current) onNode57() (any, error) {
+func (c *current) onNode76() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode57() (any, error) {
+func (p *parser) callonNode76() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode57()
+	return p.cur.onNode76()
 }
 
-func (c *current) onNode66() (any, error) {
+func (c *current) onNode85() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode66() (any, error) {
+func (p *parser) callonNode85() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode66()
+	return p.cur.onNode85()
 }
 
-func (c *current) onNode68() (any, error) {
+func (c *current) onNode87() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode68() (any, error) {
+func (p *parser) callonNode87() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode68()
+	return p.cur.onNode87()
 }
 
-func (c *current) onNode64() (any, error) {
+func (c *current) onNode83() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode64() (any, error) {
+func (p *parser) callonNode83() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode64()
+	return p.cur.onNode83()
 }
 
-func (c *current) onNode44() (any, error) {
+func (c *current) onNode63() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode44() (any, error) {
+func (p *parser) callonNode63() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode44()
+	return p.cur.onNode63()
 }
 
-func (c *current) onNode75() (any, error) {
+func (c *current) onNode94() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode75() (any, error) {
+func (p *parser) callonNode94() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode75()
+	return p.cur.onNode94()
 }
 
-func (c *current) onNode77() (any, error) {
+func (c *current) onNode96() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode77() (any, error) {
+func (p *parser) callonNode96() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode77()
+	return p.cur.onNode96()
 }
 
-func (c *current) onNode73() (any, error) {
+func (c *current) onNode92() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode73() (any, error) {
+func (p *parser) callonNode92() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode73()
+	return p.cur.onNode92()
 }
 
-func (c *current) onNode82() (any, error) {
+func (c *current) onNode101() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode82() (any, error) {
+func (p *parser) callonNode101() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode82()
+	return p.cur.onNode101()
 }
 
-func (c *current) onNode84() (any, error) {
+func (c *current) onNode103() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode84() (any, error) {
+func (p *parser) callonNode103() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode84()
+	return p.cur.onNode103()
 }
 
-func (c *current) onNode80() (any, error) {
+func (c *current) onNode99() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode80() (any, error) {
+func (p *parser) callonNode99() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode80()
+	return p.cur.onNode99()
 }
 
-func (c *current) onNode89() (any, error) {
+func (c *current) onNode108() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode89() (any, error) {
+func (p *parser) callonNode108() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode89()
+	return p.cur.onNode108()
 }
 
-func (c *current) onNode91() (any, error) {
+func (c *current) onNode110() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode91() (any, error) {
+func (p *parser) callonNode110() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode91()
+	return p.cur.onNode110()
 }
 
-func (c *current) onNode87() (any, error) {
+func (c *current) onNode106() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode87() (any, error) {
+func (p *parser) callonNode106() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode87()
+	return p.cur.onNode106()
 }
 
-func (c *current) onNode97() (any, error) {
+func (c *current) onNode116() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode97() (any, error) {
+func (p *parser) callonNode116() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode97()
+	return p.cur.onNode116()
 }
 
-func (c *current) onNode105() (any, error) {
+func (c *current) onNode124() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode105() (any, error) {
+func (p *parser) callonNode124() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode105()
+	return p.cur.onNode124()
 }
 
-func (c *current) onNode107() (any, error) {
+func (c *current) onNode126() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode107() (any, error) {
+func (p *parser) callonNode126() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode107()
+	return p.cur.onNode126()
 }
 
-func (c *current) onNode103() (any, error) {
+func (c *current) onNode122() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode103() (any, error) {
+func (p *parser) callonNode122() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode103()
+	return p.cur.onNode122()
 }
 
-func (c *current) onNode112() (any, error) {
+func (c *current) onNode131() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode112() (any, error) {
+func (p *parser) callonNode131() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode112()
+	return p.cur.onNode131()
 }
 
-func (c *current) onNode114() (any, error) {
+func (c *current) onNode133() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode114() (any, error) {
+func (p *parser) callonNode133() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode114()
+	return p.cur.onNode133()
 }
 
-func (c *current) onNode110() (any, error) {
+func (c *current) onNode129() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode110() (any, error) {
+func (p *parser) callonNode129() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode110()
+	return p.cur.onNode129()
 }
 
-func (c *current) onNode71() (any, error) {
+func (c *current) onNode90() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode71() (any, error) {
+func (p *parser) callonNode90() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode71()
+	return p.cur.onNode90()
 }
 
-func (c *current) onNode42() (any, error) {
+func (c *current) onNode61() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode42() (any, error) {
+func (p *parser) callonNode61() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode42()
+	return p.cur.onNode61()
 }
 
-func (c *current) onNode120() (any, error) {
+func (c *current) onNode139() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode120() (any, error) {
+func (p *parser) callonNode139() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode120()
+	return p.cur.onNode139()
 }
 
-func (c *current) onNode122() (any, error) {
+func (c *current) onNode141() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode122() (any, error) {
+func (p *parser) callonNode141() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode122()
+	return p.cur.onNode141()
 }
 
-func (c *current) onNode124() (any, error) {
+func (c *current) onNode143() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode124() (any, error) {
+func (p *parser) callonNode143() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode124()
+	return p.cur.onNode143()
 }
 
-func (c *current) onNode126() (any, error) {
+func (c *current) onNode145() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode126() (any, error) {
+func (p *parser) callonNode145() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode126()
+	return p.cur.onNode145()
 }
 
-func (c *current) onNode118() (any, error) {
+func (c *current) onNode137() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode118() (any, error) {
+func (p *parser) callonNode137() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode118()
+	return p.cur.onNode137()
 }
 
-func (c *current) onNode131() (any, error) {
+func (c *current) onNode150() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode131() (any, error) {
+func (p *parser) callonNode150() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode131()
+	return p.cur.onNode150()
 }
 
-func (c *current) onNode133() (any, error) {
+func (c *current) onNode152() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode133() (any, error) {
+func (p *parser) callonNode152() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode133()
+	return p.cur.onNode152()
 }
 
-func (c *current) onNode129() (any, error) {
+func (c *current) onNode148() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode129() (any, error) {
+func (p *parser) callonNode148() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode129()
+	return p.cur.onNode148()
 }
 
-func (c *current) onNode138() (any, error) {
+func (c *current) onNode157() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode138() (any, error) {
+func (p *parser) callonNode157() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode138()
+	return p.cur.onNode157()
 }
 
-func (c *current) onNode140() (any, error) {
+func (c *current) onNode159() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode140() (any, error) {
+func (p *parser) callonNode159() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode140()
+	return p.cur.onNode159()
 }
 
-func (c *current) onNode136() (any, error) {
+func (c *current) onNode155() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode136() (any, error) {
+func (p *parser) callonNode155() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode136()
+	return p.cur.onNode155()
 }
 
-func (c *current) onNode116() (any, error) {
+func (c *current) onNode135() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode116() (any, error) {
+func (p *parser) callonNode135() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode116()
+	return p.cur.onNode135()
 }
 
-func (c *current) onNode146() (any, error) {
+func (c *current) onNode165() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode146() (any, error) {
+func (p *parser) callonNode165() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode146()
+	return p.cur.onNode165()
 }
 
-func (c *current) onNode148() (any, error) {
+func (c *current) onNode167() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode148() (any, error) {
+func (p *parser) callonNode167() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode148()
+	return p.cur.onNode167()
 }
 
-func (c *current) onNode144() (any, error) {
+func (c *current) onNode163() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode144() (any, error) {
+func (p *parser) callonNode163() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode144()
+	return p.cur.onNode163()
 }
 
-func (c *current) onNode153() (any, error) {
+func (c *current) onNode172() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode153() (any, error) {
+func (p *parser) callonNode172() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode153()
+	return p.cur.onNode172()
 }
 
-func (c *current) onNode155() (any, error) {
+func (c *current) onNode174() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode155() (any, error) {
+func (p *parser) callonNode174() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode155()
+	return p.cur.onNode174()
 }
 
-func (c *current) onNode151() (any, error) {
+func (c *current) onNode170() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode151() (any, error) {
+func (p *parser) callonNode170() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode151()
+	return p.cur.onNode170()
 }
 
-func (c *current) onNode160() (any, error) {
+func (c *current) onNode179() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode160() (any, error) {
+func (p *parser) callonNode179() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode160()
+	return p.cur.onNode179()
 }
 
-func (c *current) onNode162() (any, error) {
+func (c *current) onNode181() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode162() (any, error) {
+func (p *parser) callonNode181() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode162()
+	return p.cur.onNode181()
 }
 
-func (c *current) onNode158() (any, error) {
+func (c *current) onNode177() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode158() (any, error) {
+func (p *parser) callonNode177() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode158()
+	return p.cur.onNode177()
 }
 
-func (c *current) onNode168() (any, error) {
+func (c *current) onNode187() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode168() (any, error) {
+func (p *parser) callonNode187() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode168()
+	return p.cur.onNode187()
 }
 
-func (c *current) onNode176() (any, error) {
+func (c *current) onNode195() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode176() (any, error) {
+func (p *parser) callonNode195() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode176()
+	return p.cur.onNode195()
 }
 
-func (c *current) onNode178() (any, error) {
+func (c *current) onNode197() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode178() (any, error) {
+func (p *parser) callonNode197() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode178()
+	return p.cur.onNode197()
 }
 
-func (c *current) onNode174() (any, error) {
+func (c *current) onNode193() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode174() (any, error) {
+func (p *parser) callonNode193() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode174()
+	return p.cur.onNode193()
 }
 
-func (c *current) onNode183() (any, error) {
+func (c *current) onNode202() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode183() (any, error) {
+func (p *parser) callonNode202() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode183()
+	return p.cur.onNode202()
 }
 
-func (c *current) onNode185() (any, error) {
+func (c *current) onNode204() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode185() (any, error) {
+func (p *parser) callonNode204() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode185()
+	return p.cur.onNode204()
 }
 
-func (c *current) onNode181() (any, error) {
+func (c *current) onNode200() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode181() (any, error) {
+func (p *parser) callonNode200() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode181()
+	return p.cur.onNode200()
 }
 
-func (c *current) onNode142() (any, error) {
+func (c *current) onNode161() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode142() (any, error) {
+func (p *parser) callonNode161() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode142()
+	return p.cur.onNode161()
 }
 
-func (c *current) onNode18(k, o, v any) (any, error) {
+func (c *current) onNode37(k, o, v any) (any, error) {
 	return buildDateTimeNode(k, o, v, c.text, c.pos)
 
 }
 
-func (p *parser) callonNode18() (any, error) {
+func (p *parser) callonNode37() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode18(stack["k"], stack["o"], stack["v"])
+	return p.cur.onNode37(stack["k"], stack["o"], stack["v"])
 }
 
-func (c *current) onNode193() (any, error) {
+func (c *current) onNode212() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode193() (any, error) {
+func (p *parser) callonNode212() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode193()
+	return p.cur.onNode212()
 }
 
-func (c *current) onNode196() (any, error) {
+func (c *current) onNode215() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode196() (any, error) {
+func (p *parser) callonNode215() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode196()
+	return p.cur.onNode215()
 }
 
-func (c *current) onNode198() (any, error) {
+func (c *current) onNode217() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode198() (any, error) {
+func (p *parser) callonNode217() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode198()
+	return p.cur.onNode217()
 }
 
-func (c *current) onNode213() (any, error) {
+func (c *current) onNode232() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode213() (any, error) {
+func (p *parser) callonNode232() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode213()
+	return p.cur.onNode232()
 }
 
-func (c *current) onNode189(k, v any) (any, error) {
+func (c *current) onNode208(k, v any) (any, error) {
 	return buildNaturalLanguageDateTimeNodes(k, v, c.text, c.pos)
 
 }
 
-func (p *parser) callonNode189() (any, error) {
+func (p *parser) callonNode208() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode189(stack["k"], stack["v"])
+	return p.cur.onNode208(stack["k"], stack["v"])
 }
 
-func (c *current) onNode221() (any, error) {
+func (c *current) onNode240() (any, error) {
 	return c.text, nil
 
 }
 
-func (p *parser) callonNode221() (any, error) {
+func (p *parser) callonNode240() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode221()
+	return p.cur.onNode240()
 }
 
-func (c *current) onNode224() (any, error) {
+func (c *current) onNode243() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode224() (any, error) {
+func (p *parser) callonNode243() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode224()
+	return p.cur.onNode243()
 }
 
-func (c *current) onNode226() (any, error) {
+func (c *current) onNode245() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode226() (any, error) {
+func (p *parser) callonNode245() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode226()
+	return p.cur.onNode245()
 }
 
-func (c *current) onNode230(v any) (any, error) {
+func (c *current) onNode249(v any) (any, error) {
 	return v, nil
 
 }
 
-func (p *parser) callonNode230() (any, error) {
+func (p *parser) callonNode249() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode230(stack["v"])
+	return p.cur.onNode249(stack["v"])
 }
 
-func (c *current) onNode217(k, v any) (any, error) {
+func (c *current) onNode236(k, v any) (any, error) {
 	return buildStringNode(k, v, c.text, c.pos)
 
 }
 
-func (p *parser) callonNode217() (any, error) {
+func (p *parser) callonNode236() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode217(stack["k"], stack["v"])
+	return p.cur.onNode236(stack["k"], stack["v"])
 }
 
-func (c *current) onNode239() (any, error) {
+func (c *current) onNode258() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode239() (any, error) {
+func (p *parser) callonNode258() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode239()
+	return p.cur.onNode258()
 }
 
-func (c *current) onNode243() (any, error) {
+func (c *current) onNode262() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode243() (any, error) {
+func (p *parser) callonNode262() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode243()
+	return p.cur.onNode262()
 }
 
-func (c *current) onNode247() (any, error) {
+func (c *current) onNode266() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode247() (any, error) {
+func (p *parser) callonNode266() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode247()
+	return p.cur.onNode266()
 }
 
-func (c *current) onNode252() (any, error) {
+func (c *current) onNode271() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode252() (any, error) {
+func (p *parser) callonNode271() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode252()
+	return p.cur.onNode271()
 }
 
-func (c *current) onNode254() (any, error) {
+func (c *current) onNode273() (any, error) {
 	return nil, nil
 
 }
 
-func (p *parser) callonNode254() (any, error) {
+func (p *parser) callonNode273() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode254()
+	return p.cur.onNode273()
 }
 
-func (c *current) onNode258(v any) (any, error) {
+func (c *current) onNode277(v any) (any, error) {
 	return v, nil
 
 }
 
-func (p *parser) callonNode258() (any, error) {
+func (p *parser) callonNode277() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode258(stack["v"])
+	return p.cur.onNode277(stack["v"])
 }
 
-func (c *current) onNode265(v any) (any, error) {
+func (c *current) onNode284(v any) (any, error) {
 	return nil, nil
 
 }
 
-func (p *parser) callonNode265() (any, error) {
+func (p *parser) callonNode284() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode265(stack["v"])
+	return p.cur.onNode284(stack["v"])
 }
 
-func (c *current) onNode269() (any, error) {
+func (c *current) onNode288() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode269() (any, error) {
+func (p *parser) callonNode288() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode269()
+	return p.cur.onNode288()
 }
 
-func (c *current) onNode249(v any) (any, error) {
+func (c *current) onNode268(v any) (any, error) {
 	return buildStringNode("", v, c.text, c.pos)
 
 }
 
-func (p *parser) callonNode249() (any, error) {
+func (p *parser) callonNode268() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode249(stack["v"])
+	return p.cur.onNode268(stack["v"])
 }
 
-func (c *current) onNode274() (any, error) {
+func (c *current) onNode293() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode274() (any, error) {
+func (p *parser) callonNode293() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode274()
+	return p.cur.onNode293()
 }
 
-func (c *current) onNode276() (any, error) {
+func (c *current) onNode295() (any, error) {
 	return nil, nil
 
 }
 
-func (p *parser) callonNode276() (any, error) {
+func (p *parser) callonNode295() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode276()
+	return p.cur.onNode295()
 }
 
-func (c *current) onNode282(v any) (any, error) {
+func (c *current) onNode301(v any) (any, error) {
 	return nil, nil
 
 }
 
-func (p *parser) callonNode282() (any, error) {
+func (p *parser) callonNode301() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode282(stack["v"])
+	return p.cur.onNode301(stack["v"])
 }
 
-func (c *current) onNode286() (any, error) {
+func (c *current) onNode305() (any, error) {
 	return buildOperatorNode(c.text, c.pos)
 
 }
 
-func (p *parser) callonNode286() (any, error) {
+func (p *parser) callonNode305() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode286()
+	return p.cur.onNode305()
 }
 
-func (c *current) onNode271(v any) (any, error) {
+func (c *current) onNode290(v any) (any, error) {
 	return buildStringNode("", v, c.text, c.pos)
 
 }
 
-func (p *parser) callonNode271() (any, error) {
+func (p *parser) callonNode290() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onNode271(stack["v"])
+	return p.cur.onNode290(stack["v"])
 }
 
 func (c *current) onGroupNode6() (any, error) {
@@ -3290,7 +3428,7 @@ func (p *parser) parse(g *grammar) (val any, err error) {
 	}
 
 	p.read() // advance to first rune
-	val, ok = p.parseRule(startRule)
+	val, ok = p.parseRuleWrap(startRule)
 	if !ok {
 		if len(*p.errs) == 0 {
 			// If parsing fails, but no errors have been recorded, the expected values
@@ -3331,17 +3469,33 @@ func listJoin(list []string, sep string, lastSep string) string {
 	}
 }
 
+func (p *parser) parseRuleWrap(rule *rule) (any, bool) {
+	var (
+		val any
+		ok  bool
+	)
+
+	val, ok = p.parseRule(rule)
+
+	return val, ok
+}
+
 func (p *parser) parseRule(rule *rule) (any, bool) {
 	p.rstack = append(p.rstack, rule)
 	p.pushV()
-	val, ok := p.parseExpr(rule.expr)
+	val, ok := p.parseExprWrap(rule.expr)
 	p.popV()
 	p.rstack = p.rstack[:len(p.rstack)-1]
 	return val, ok
 }
 
-func (p *parser) parseExpr(expr any) (any, bool) {
+func (p *parser) parseExprWrap(expr any) (any, bool) {
+	val, ok := p.parseExpr(expr)
+
+	return val, ok
+}
 
+func (p *parser) parseExpr(expr any) (any, bool) {
 	p.ExprCnt++
 	if p.ExprCnt > p.maxExprCnt {
 		panic(errMaxExprCnt)
@@ -3392,7 +3546,7 @@ func (p *parser) parseExpr(expr any) (any, bool) {
 
 func (p *parser) parseActionExpr(act *actionExpr) (any, bool) {
 	start := p.pt
-	val, ok := p.parseExpr(act.expr)
+	val, ok := p.parseExprWrap(act.expr)
 	if ok {
 		p.cur.pos = start.position
 		p.cur.text = p.sliceFrom(start)
@@ -3419,7 +3573,7 @@ func (p *parser) parseAndCodeExpr(and *andCodeExpr) (any, bool) {
 func (p *parser) parseAndExpr(and *andExpr) (any, bool) {
 	pt := p.pt
 	p.pushV()
-	_, ok := p.parseExpr(and.expr)
+	_, ok := p.parseExprWrap(and.expr)
 	p.popV()
 	p.restore(pt)
 
@@ -3501,12 +3655,13 @@ func (p *parser) parseCharClassMatcher(chr *charClassMatcher) (any, bool) {
 }
 
 func (p *parser) parseChoiceExpr(ch *choiceExpr) (any, bool) {
+
 	for altI, alt := range ch.alternatives {
 		// dummy assignment to prevent compile error if optimized
 		_ = altI
 
 		p.pushV()
-		val, ok := p.parseExpr(alt)
+		val, ok := p.parseExprWrap(alt)
 		p.popV()
 		if ok {
 			return val, ok
@@ -3517,7 +3672,7 @@ func (p *parser) parseChoiceExpr(ch *choiceExpr) (any, bool) {
 
 func (p *parser) parseLabeledExpr(lab *labeledExpr) (any, bool) {
 	p.pushV()
-	val, ok := p.parseExpr(lab.expr)
+	val, ok := p.parseExprWrap(lab.expr)
 	p.popV()
 	if ok && lab.label != "" {
 		m := p.vstack[len(p.vstack)-1]
@@ -3557,7 +3712,7 @@ func (p *parser) parseNotExpr(not *notExpr) (any, bool) {
 	pt := p.pt
 	p.pushV()
 	p.maxFailInvertExpected = !p.maxFailInvertExpected
-	_, ok := p.parseExpr(not.expr)
+	_, ok := p.parseExprWrap(not.expr)
 	p.maxFailInvertExpected = !p.maxFailInvertExpected
 	p.popV()
 	p.restore(pt)
@@ -3570,7 +3725,7 @@ func (p *parser) parseOneOrMoreExpr(expr *oneOrMoreExpr) (any, bool) {
 
 	for {
 		p.pushV()
-		val, ok := p.parseExpr(expr.expr)
+		val, ok := p.parseExprWrap(expr.expr)
 		p.popV()
 		if !ok {
 			if len(vals) == 0 {
@@ -3586,7 +3741,7 @@ func (p *parser) parseOneOrMoreExpr(expr *oneOrMoreExpr) (any, bool) {
 func (p *parser) parseRecoveryExpr(recover *recoveryExpr) (any, bool) {
 
 	p.pushRecovery(recover.failureLabel, recover.recoverExpr)
-	val, ok := p.parseExpr(recover.expr)
+	val, ok := p.parseExprWrap(recover.expr)
 	p.popRecovery()
 
 	return val, ok
@@ -3602,7 +3757,7 @@ func (p *parser) parseRuleRefExpr(ref *ruleRefExpr) (any, bool) {
 		p.addErr(fmt.Errorf("undefined rule: %s", ref.name))
 		return nil, false
 	}
-	return p.parseRule(rule)
+	return p.parseRuleWrap(rule)
 }
 
 func (p *parser) parseSeqExpr(seq *seqExpr) (any, bool) {
@@ -3610,7 +3765,7 @@ func (p *parser) parseSeqExpr(seq *seqExpr) (any, bool) {
 
 	pt := p.pt
 	for _, expr := range seq.exprs {
-		val, ok := p.parseExpr(expr)
+		val, ok := p.parseExprWrap(expr)
 		if !ok {
 			p.restore(pt)
 			return nil, false
@@ -3624,7 +3779,7 @@ func (p *parser) parseThrowExpr(expr *throwExpr) (any, bool) {
 
 	for i := len(p.recoveryStack) - 1; i >= 0; i-- {
 		if recoverExpr, ok := p.recoveryStack[i][expr.label]; ok {
-			if val, ok := p.parseExpr(recoverExpr); ok {
+			if val, ok := p.parseExprWrap(recoverExpr); ok {
 				return val, ok
 			}
 		}
@@ -3638,7 +3793,7 @@ func (p *parser) parseZeroOrMoreExpr(expr *zeroOrMoreExpr) (any, bool) {
 
 	for {
 		p.pushV()
-		val, ok := p.parseExpr(expr.expr)
+		val, ok := p.parseExprWrap(expr.expr)
 		p.popV()
 		if !ok {
 			return vals, true
@@ -3649,7 +3804,7 @@ func (p *parser) parseZeroOrMoreExpr(expr *zeroOrMoreExpr) (any, bool) {
 
 func (p *parser) parseZeroOrOneExpr(expr *zeroOrOneExpr) (any, bool) {
 	p.pushV()
-	val, _ := p.parseExpr(expr.expr)
+	val, _ := p.parseExprWrap(expr.expr)
 	p.popV()
 	// whether it matched or not, consider it a match
 	return val, true
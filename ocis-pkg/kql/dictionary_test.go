@@ -858,6 +858,67 @@ func TestParse_DateTimeRestrictionNode(t *testing.T) {
 	}
 }
 
+func TestParse_SimilarNode(t *testing.T) {
+	tests := []testCase{
+		{
+			name: `similar:"budget planning"`,
+			ast: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.SimilarNode{Value: "budget planning"},
+				},
+			},
+		},
+		{
+			name: `Similar:1$2!3`,
+			ast: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.SimilarNode{Value: "1$2!3"},
+				},
+			},
+		},
+		{
+			name: `mediatype:pdf similar:"budget planning"`,
+			ast: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.StringNode{Key: "mediatype", Value: "pdf"},
+					&ast.OperatorNode{Value: kql.BoolAND},
+					&ast.SimilarNode{Value: "budget planning"},
+				},
+			},
+		},
+		{
+			name: `(similar:budget OR name:budget*) NOT similar:"annual report"`,
+			ast: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.GroupNode{Nodes: []ast.Node{
+						&ast.SimilarNode{Value: "budget"},
+						&ast.OperatorNode{Value: kql.BoolOR},
+						&ast.StringNode{Key: "name", Value: "budget*"},
+					}},
+					&ast.OperatorNode{Value: kql.BoolAND},
+					&ast.OperatorNode{Value: kql.BoolNOT},
+					&ast.SimilarNode{Value: "annual report"},
+				},
+			},
+		},
+		{
+			name: `dissimilar:foo`,
+			ast: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.StringNode{Key: "dissimilar", Value: "foo"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			testKQL(t, tc)
+		})
+	}
+}
+
 func TestParse_Errors(t *testing.T) {
 	tests := []testCase{
 		{
@@ -872,6 +933,12 @@ func TestParse_Errors(t *testing.T) {
 				Node: &ast.StringNode{Key: "mammal", Value: "dog"},
 			},
 		},
+		{
+			query: "similar:(cat OR dog)",
+			error: query.SimilarGroupError{
+				Node: &ast.GroupNode{Key: "similar"},
+			},
+		},
 		{
 			query: "animal:(AND cat)",
 			error: query.StartsWithBinaryOperatorError{
@@ -19,6 +19,8 @@ The following spec parts are supported and tested:
   - 3.3.1.1.1 Implicit AND Operator
   - 3.3.5 Date Tokens
 
+The similar: operator is an extension to the spec, it is parsed into an ast.SimilarNode. The search engines resolve it before the query is compiled.
+
 References:
   - https://learn.microsoft.com/en-us/sharepoint/dev/general-development/keyword-query-language-kql-syntax-reference
   - https://learn.microsoft.com/en-us/openspecs/sharepoint_protocols/ms-kql/3bbf06cd-8fc1-4277-bd92-8661ccd3c9b0
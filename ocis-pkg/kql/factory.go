@@ -73,6 +73,23 @@ func buildStringNode(k, v interface{}, text []byte, pos position) (*ast.StringNo
 	}, nil
 }
 
+func buildSimilarNode(v interface{}, text []byte, pos position) (*ast.SimilarNode, error) {
+	b, err := base(text, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := toString(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ast.SimilarNode{
+		Base:  b,
+		Value: value,
+	}, nil
+}
+
 func buildDateTimeNode(k, o, v interface{}, text []byte, pos position) (*ast.DateTimeNode, error) {
 	b, err := base(text, pos)
 	if err != nil {
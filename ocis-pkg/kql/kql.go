@@ -18,6 +18,10 @@ const (
 	BoolNOT = "NOT"
 )
 
+// Similar is the property of the similarity operator, `similar:"some text"` finds resources with a similar meaning as the text,
+// `similar:<resource id>` finds resources similar to the resource.
+const Similar = "similar"
+
 // Builder implements kql Builder interface
 type Builder struct{}
 
@@ -1,6 +1,8 @@
 package kql
 
 import (
+	"strings"
+
 	"github.com/owncloud/ocis/v2/ocis-pkg/ast"
 	"github.com/owncloud/ocis/v2/services/search/pkg/query"
 )
@@ -25,6 +27,10 @@ func validateGroupNode(n *ast.GroupNode) error {
 		}
 	}
 
+	if strings.EqualFold(n.Key, Similar) {
+		return &query.SimilarGroupError{Node: n}
+	}
+
 	if n.Key != "" {
 		for _, node := range n.Nodes {
 			if ast.NodeKey(node) != "" {
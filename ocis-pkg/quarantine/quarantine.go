@@ -0,0 +1,613 @@
+// Package quarantine manages the quarantine space. It holds infected files until they are
+// released to their original location or purged by an admin. Released files are recorded in
+// an index in the quarantine space, so the same content is not quarantined again at the
+// location it was released to.
+package quarantine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	ctxpkg "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+	"github.com/cs3org/reva/v2/pkg/rhttp"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+// SpaceType is the type of the quarantine space
+const SpaceType = "quarantine"
+
+const (
+	spaceName = "Quarantine"
+	// spaceID is the fixed id of the quarantine space, replicas creating the space at the same time create the same one
+	spaceID = "d5b0b7a4-9c1e-4f3a-8e62-3f7d1c9a5b20"
+	// indexFolder holds an entry for every released file, named by its releaseKey
+	indexFolder = ".released"
+	// maxConflicts is the number of alternative names tried when the original path of a released file is taken
+	maxConflicts = 100
+
+	nameKey        = "oc.quarantine.name"
+	spaceKey       = "oc.quarantine.space"
+	pathKey        = "oc.quarantine.path"
+	userKey        = "oc.quarantine.user"
+	descriptionKey = "oc.quarantine.description"
+	scanDateKey    = "oc.quarantine.scandate"
+	checksumKey    = "oc.quarantine.sha256"
+	releasedKey    = "oc.quarantine.released"
+	releasedToKey  = "oc.quarantine.releasedto"
+)
+
+var (
+	// ErrNotFound is returned when a quarantined file does not exist
+	ErrNotFound = errors.New("quarantined file not found")
+	// ErrReleased is returned when a quarantined file has already been released
+	ErrReleased = errors.New("quarantined file already released")
+
+	errAlreadyExists = errors.New("file already exists")
+)
+
+// Item is a file in the quarantine space
+type Item struct {
+	ID string `json:"id"`
+	// Name is the original name of the file
+	Name string `json:"name"`
+	Size uint64 `json:"size"`
+	// SpaceID is the id of the space the file was found in
+	SpaceID string `json:"spaceId"`
+	// Path is the original path of the file relative to the space root
+	Path string `json:"path"`
+	// User is the id of the user who uploaded the file, it is empty for existing files found by a re-scan
+	User        string    `json:"user,omitempty"`
+	Description string    `json:"description"`
+	ScanDate    time.Time `json:"scanDate"`
+	// Checksum is the hex encoded sha256 sum of the content
+	Checksum string `json:"checksum"`
+	// Released is set when the file was restored to its original location
+	Released bool `json:"released"`
+	// ReleasedTo is the path the file was restored to. It differs from the original path if that was taken.
+	ReleasedTo string `json:"releasedTo,omitempty"`
+}
+
+func (i Item) metadata() map[string]string {
+	return map[string]string{
+		nameKey:        i.Name,
+		spaceKey:       i.SpaceID,
+		pathKey:        i.Path,
+		userKey:        i.User,
+		descriptionKey: i.Description,
+		scanDateKey:    i.ScanDate.UTC().Format(time.RFC3339),
+		checksumKey:    i.Checksum,
+		releasedKey:    strconv.FormatBool(i.Released),
+		releasedToKey:  i.ReleasedTo,
+	}
+}
+
+func itemFromResourceInfo(info *provider.ResourceInfo) Item {
+	md := info.GetArbitraryMetadata().GetMetadata()
+	i := Item{
+		ID:          info.GetName(),
+		Name:        md[nameKey],
+		Size:        info.GetSize(),
+		SpaceID:     md[spaceKey],
+		Path:        md[pathKey],
+		User:        md[userKey],
+		Description: md[descriptionKey],
+		Checksum:    md[checksumKey],
+		ReleasedTo:  md[releasedToKey],
+	}
+	i.ScanDate, _ = time.Parse(time.RFC3339, md[scanDateKey])
+	i.Released, _ = strconv.ParseBool(md[releasedKey])
+	return i
+}
+
+// Manager handles the files in the quarantine space. All operations are executed with the service account.
+type Manager struct {
+	gatewaySelector      pool.Selectable[gateway.GatewayAPIClient]
+	client               *http.Client
+	serviceAccountID     string
+	serviceAccountSecret string
+
+	mu    sync.Mutex
+	space *provider.StorageSpace
+}
+
+// NewManager returns a new Manager
+func NewManager(gatewaySelector pool.Selectable[gateway.GatewayAPIClient], client *http.Client, serviceAccountID, serviceAccountSecret string) *Manager {
+	return &Manager{
+		gatewaySelector:      gatewaySelector,
+		client:               client,
+		serviceAccountID:     serviceAccountID,
+		serviceAccountSecret: serviceAccountSecret,
+	}
+}
+
+// Space returns the quarantine space. It is created if it doesn't exist yet.
+func (m *Manager) Space(ctx context.Context) (*provider.StorageSpace, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.space != nil {
+		return m.space, nil
+	}
+
+	ctx, client, err := m.serviceContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	space, err := findSpace(ctx, client)
+	if err != nil || space != nil {
+		m.space = space
+		return space, err
+	}
+
+	u, _ := ctxpkg.ContextGetUser(ctx)
+	cRes, err := client.CreateStorageSpace(ctx, &provider.CreateStorageSpaceRequest{
+		Opaque: utils.AppendPlainToOpaque(nil, "spaceid", spaceID),
+		Type:   SpaceType,
+		Name:   spaceName,
+		Owner:  u,
+	})
+	switch {
+	case err != nil:
+		return nil, err
+	case cRes.GetStatus().GetCode() == rpc.Code_CODE_ALREADY_EXISTS:
+		// another replica created the space in the meantime
+		space, err = findSpace(ctx, client)
+		if err == nil && space == nil {
+			err = errors.New("could not find the quarantine space")
+		}
+	case cRes.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return nil, fmt.Errorf("could not create quarantine space: %s", cRes.GetStatus().GetMessage())
+	default:
+		space = cRes.GetStorageSpace()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m.space = space
+	return m.space, nil
+}
+
+// Contains returns true if the resource is part of the quarantine space
+func (m *Manager) Contains(ctx context.Context, id *provider.ResourceId) (bool, error) {
+	space, err := m.Space(ctx)
+	if err != nil {
+		return false, err
+	}
+	root := space.GetRoot()
+	return id.GetStorageId() == root.GetStorageId() && id.GetSpaceId() == root.GetSpaceId(), nil
+}
+
+// Add uploads the content of an infected file into the quarantine space
+func (m *Manager) Add(ctx context.Context, item Item, content io.Reader) (Item, error) {
+	space, err := m.Space(ctx)
+	if err != nil {
+		return Item{}, err
+	}
+	ctx, client, err := m.serviceContext(ctx)
+	if err != nil {
+		return Item{}, err
+	}
+
+	item.ID = uuid.NewString()
+	item.Released = false
+	ref := itemReference(space, item.ID)
+	if err := m.upload(ctx, client, ref, item.Size, content); err != nil {
+		return Item{}, err
+	}
+
+	if err := setMetadata(ctx, client, ref, item.metadata()); err != nil {
+		return Item{}, err
+	}
+	return item, nil
+}
+
+// List returns all quarantined files, the latest first
+func (m *Manager) List(ctx context.Context) ([]Item, error) {
+	space, err := m.Space(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, client, err := m.serviceContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.ListContainer(ctx, &provider.ListContainerRequest{
+		Ref: &provider.Reference{ResourceId: space.GetRoot(), Path: "."},
+	})
+	switch {
+	case err != nil:
+		return nil, err
+	case res.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return nil, fmt.Errorf("could not list quarantined files: %s", res.GetStatus().GetMessage())
+	}
+
+	items := make([]Item, 0, len(res.GetInfos()))
+	for _, info := range res.GetInfos() {
+		if info.GetType() != provider.ResourceType_RESOURCE_TYPE_FILE {
+			continue
+		}
+		items = append(items, itemFromResourceInfo(info))
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ScanDate.After(items[j].ScanDate) })
+	return items, nil
+}
+
+// Get returns a single quarantined file
+func (m *Manager) Get(ctx context.Context, id string) (Item, error) {
+	info, err := m.stat(ctx, id)
+	if err != nil {
+		return Item{}, err
+	}
+	return itemFromResourceInfo(info), nil
+}
+
+// IsReleased returns true if the content with the given checksum has been released by an admin to the
+// path in the space. Released files are not quarantined again at that location.
+func (m *Manager) IsReleased(ctx context.Context, spaceID, p, checksum string) (bool, error) {
+	space, err := m.Space(ctx)
+	if err != nil {
+		return false, err
+	}
+	ctx, client, err := m.serviceContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := client.Stat(ctx, &provider.StatRequest{Ref: indexReference(space, releaseKey(spaceID, p, checksum))})
+	switch {
+	case err != nil:
+		return false, err
+	case res.GetStatus().GetCode() == rpc.Code_CODE_NOT_FOUND:
+		return false, nil
+	case res.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return false, fmt.Errorf("could not stat the release index: %s", res.GetStatus().GetMessage())
+	}
+	return true, nil
+}
+
+// Release restores a quarantined file to its original location. If the location is taken, the file is
+// restored next to it with a numbered name. The file is kept in the quarantine space and marked as
+// released, so it won't be quarantined again at the location it was restored to.
+func (m *Manager) Release(ctx context.Context, id string) (Item, error) {
+	info, err := m.stat(ctx, id)
+	if err != nil {
+		return Item{}, err
+	}
+	item := itemFromResourceInfo(info)
+	if item.Released {
+		return item, ErrReleased
+	}
+
+	space, err := m.Space(ctx)
+	if err != nil {
+		return item, err
+	}
+	ctx, client, err := m.serviceContext(ctx)
+	if err != nil {
+		return item, err
+	}
+
+	// mark the file as released before restoring it, concurrent releases fail
+	ref := &provider.Reference{ResourceId: info.GetId(), Path: "."}
+	if err := setMetadata(ctx, client, ref, map[string]string{releasedKey: "true"}); err != nil {
+		return item, err
+	}
+
+	p, err := m.restore(ctx, client, space, ref, item)
+	if err != nil {
+		_ = setMetadata(ctx, client, ref, map[string]string{releasedKey: "false"})
+		return item, err
+	}
+
+	item.Released = true
+	item.ReleasedTo = p
+	if err := setMetadata(ctx, client, ref, map[string]string{releasedToKey: p}); err != nil {
+		return item, err
+	}
+	return item, nil
+}
+
+// Purge finally deletes a quarantined file
+func (m *Manager) Purge(ctx context.Context, id string) error {
+	info, err := m.stat(ctx, id)
+	if err != nil {
+		return err
+	}
+	space, err := m.Space(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, client, err := m.serviceContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	dRes, err := client.Delete(ctx, &provider.DeleteRequest{Ref: &provider.Reference{ResourceId: info.GetId(), Path: "."}})
+	switch {
+	case err != nil:
+		return err
+	case dRes.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return fmt.Errorf("could not delete quarantined file: %s", dRes.GetStatus().GetMessage())
+	}
+
+	pRes, err := client.PurgeRecycle(ctx, &provider.PurgeRecycleRequest{
+		Ref: &provider.Reference{ResourceId: space.GetRoot(), Path: "."},
+		Key: info.GetId().GetOpaqueId(),
+	})
+	switch {
+	case err != nil:
+		return err
+	case pRes.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return fmt.Errorf("could not purge quarantined file: %s", pRes.GetStatus().GetMessage())
+	}
+	return nil
+}
+
+func (m *Manager) stat(ctx context.Context, id string) (*provider.ResourceInfo, error) {
+	if id == "" || id == "." || id == ".." || id == indexFolder || strings.ContainsAny(id, `/\`) {
+		return nil, ErrNotFound
+	}
+	space, err := m.Space(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, client, err := m.serviceContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Stat(ctx, &provider.StatRequest{Ref: itemReference(space, id)})
+	switch {
+	case err != nil:
+		return nil, err
+	case res.GetStatus().GetCode() == rpc.Code_CODE_NOT_FOUND:
+		return nil, ErrNotFound
+	case res.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return nil, fmt.Errorf("could not stat quarantined file: %s", res.GetStatus().GetMessage())
+	}
+	return res.GetInfo(), nil
+}
+
+// restore copies the content of the quarantined file back into its space and returns the path it was
+// restored to. Existing files are never overwritten. The release is added to the index before the
+// content is uploaded, the upload is scanned again.
+func (m *Manager) restore(ctx context.Context, client gateway.GatewayAPIClient, space *provider.StorageSpace, source *provider.Reference, item Item) (string, error) {
+	target, err := storagespace.ParseID(item.SpaceID)
+	if err != nil {
+		return "", fmt.Errorf("invalid space id '%s': %w", item.SpaceID, err)
+	}
+	target.OpaqueId = target.SpaceId
+
+	var (
+		p      string
+		ep, tt string
+	)
+	for n := 0; ; n++ {
+		if n > maxConflicts {
+			return "", fmt.Errorf("could not find a free name to restore '%s'", item.Path)
+		}
+		p = numberedPath(item.Path, n)
+		ep, tt, err = initiateUpload(ctx, client, &provider.Reference{ResourceId: &target, Path: utils.MakeRelativePath(p)}, item.Size, true)
+		if !errors.Is(err, errAlreadyExists) {
+			break
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := m.addToIndex(ctx, client, space, releaseKey(item.SpaceID, p, item.Checksum), item.ID); err != nil {
+		return "", err
+	}
+
+	res, err := client.InitiateFileDownload(ctx, &provider.InitiateFileDownloadRequest{Ref: source})
+	switch {
+	case err != nil:
+		return "", err
+	case res.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return "", fmt.Errorf("could not initiate download: %s", res.GetStatus().GetMessage())
+	}
+
+	var dep, dtt string
+	for _, proto := range res.GetProtocols() {
+		if proto.GetProtocol() == "spaces" {
+			dep, dtt = proto.GetDownloadEndpoint(), proto.GetToken()
+			break
+		}
+	}
+	if (dep == "" || dtt == "") && len(res.GetProtocols()) > 0 {
+		dep, dtt = res.GetProtocols()[0].GetDownloadEndpoint(), res.GetProtocols()[0].GetToken()
+	}
+
+	req, err := rhttp.NewRequest(ctx, http.MethodGet, dep, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Reva-Transfer", dtt)
+
+	dRes, err := m.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer dRes.Body.Close()
+	if dRes.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code from download %v", dRes.StatusCode)
+	}
+
+	return p, m.put(ctx, ep, tt, item.Size, dRes.Body)
+}
+
+// addToIndex records a release in the index folder of the quarantine space. The entry holds the id of the released item.
+func (m *Manager) addToIndex(ctx context.Context, client gateway.GatewayAPIClient, space *provider.StorageSpace, key, id string) error {
+	cRes, err := client.CreateContainer(ctx, &provider.CreateContainerRequest{Ref: itemReference(space, indexFolder)})
+	switch {
+	case err != nil:
+		return err
+	case cRes.GetStatus().GetCode() != rpc.Code_CODE_OK && cRes.GetStatus().GetCode() != rpc.Code_CODE_ALREADY_EXISTS:
+		return fmt.Errorf("could not create the release index: %s", cRes.GetStatus().GetMessage())
+	}
+
+	return m.upload(ctx, client, indexReference(space, key), uint64(len(id)), strings.NewReader(id))
+}
+
+func (m *Manager) upload(ctx context.Context, client gateway.GatewayAPIClient, ref *provider.Reference, size uint64, content io.Reader) error {
+	ep, tt, err := initiateUpload(ctx, client, ref, size, false)
+	if err != nil {
+		return err
+	}
+	return m.put(ctx, ep, tt, size, content)
+}
+
+// initiateUpload returns the endpoint and the transfer token of a new upload. If ifNotExist is set and the file
+// exists, errAlreadyExists is returned.
+func initiateUpload(ctx context.Context, client gateway.GatewayAPIClient, ref *provider.Reference, size uint64, ifNotExist bool) (string, string, error) {
+	req := &provider.InitiateFileUploadRequest{
+		Ref:    ref,
+		Opaque: utils.AppendPlainToOpaque(nil, "Upload-Length", strconv.FormatUint(size, 10)),
+	}
+	if ifNotExist {
+		req.Options = &provider.InitiateFileUploadRequest_IfNotExist{IfNotExist: true}
+	}
+
+	res, err := client.InitiateFileUpload(ctx, req)
+	switch {
+	case err != nil:
+		return "", "", err
+	case res.GetStatus().GetCode() == rpc.Code_CODE_ALREADY_EXISTS:
+		return "", "", errAlreadyExists
+	case res.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return "", "", fmt.Errorf("could not initiate upload: %s", res.GetStatus().GetMessage())
+	}
+
+	var ep, tt string
+	for _, p := range res.GetProtocols() {
+		if p.GetProtocol() == "simple" {
+			ep, tt = p.GetUploadEndpoint(), p.GetToken()
+		}
+	}
+	return ep, tt, nil
+}
+
+func (m *Manager) put(ctx context.Context, ep, tt string, size uint64, content io.Reader) error {
+	req, err := rhttp.NewRequest(ctx, http.MethodPut, ep, content)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = int64(size)
+	req.Header.Set("X-Reva-Transfer", tt)
+
+	uRes, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer uRes.Body.Close()
+	if uRes.StatusCode != http.StatusOK && uRes.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unexpected status code from upload %v", uRes.StatusCode)
+	}
+	return nil
+}
+
+// serviceContext authenticates the service account. The token is added to the
+// outgoing grpc metadata and to the context for http requests.
+func (m *Manager) serviceContext(ctx context.Context) (context.Context, gateway.GatewayAPIClient, error) {
+	client, err := m.gatewaySelector.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := client.Authenticate(ctx, &gateway.AuthenticateRequest{
+		Type:         "serviceaccounts",
+		ClientId:     m.serviceAccountID,
+		ClientSecret: m.serviceAccountSecret,
+	})
+	switch {
+	case err != nil:
+		return nil, nil, err
+	case res.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return nil, nil, fmt.Errorf("could not authenticate service account: %s", res.GetStatus().GetMessage())
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, ctxpkg.TokenHeader, res.GetToken())
+	ctx = ctxpkg.ContextSetToken(ctx, res.GetToken())
+	ctx = ctxpkg.ContextSetUser(ctx, res.GetUser())
+	return ctx, client, nil
+}
+
+// findSpace returns the quarantine space or nil if it doesn't exist
+func findSpace(ctx context.Context, client gateway.GatewayAPIClient) (*provider.StorageSpace, error) {
+	res, err := client.ListStorageSpaces(ctx, &provider.ListStorageSpacesRequest{
+		Filters: []*provider.ListStorageSpacesRequest_Filter{
+			{
+				Type: provider.ListStorageSpacesRequest_Filter_TYPE_SPACE_TYPE,
+				Term: &provider.ListStorageSpacesRequest_Filter_SpaceType{SpaceType: SpaceType},
+			},
+		},
+	})
+	switch {
+	case err != nil:
+		return nil, err
+	case res.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return nil, fmt.Errorf("could not list quarantine spaces: %s", res.GetStatus().GetMessage())
+	case len(res.GetStorageSpaces()) == 0:
+		return nil, nil
+	}
+	return res.GetStorageSpaces()[0], nil
+}
+
+func itemReference(space *provider.StorageSpace, id string) *provider.Reference {
+	return &provider.Reference{ResourceId: space.GetRoot(), Path: utils.MakeRelativePath(id)}
+}
+
+func indexReference(space *provider.StorageSpace, key string) *provider.Reference {
+	return itemReference(space, path.Join(indexFolder, key))
+}
+
+// releaseKey identifies the release of the content with the checksum to the path in the space
+func releaseKey(spaceID, p, checksum string) string {
+	h := sha256.Sum256([]byte(spaceID + "\x00" + path.Join("/", p) + "\x00" + checksum))
+	return hex.EncodeToString(h[:])
+}
+
+// numberedPath returns the path with the number appended to the name, e.g. 'report (1).pdf'. The path is returned
+// unchanged for 0.
+func numberedPath(p string, n int) string {
+	if n == 0 {
+		return p
+	}
+	ext := path.Ext(p)
+	return fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(p, ext), n, ext)
+}
+
+func setMetadata(ctx context.Context, client gateway.GatewayAPIClient, ref *provider.Reference, md map[string]string) error {
+	res, err := client.SetArbitraryMetadata(ctx, &provider.SetArbitraryMetadataRequest{
+		Ref:               ref,
+		ArbitraryMetadata: &provider.ArbitraryMetadata{Metadata: md},
+	})
+	switch {
+	case err != nil:
+		return err
+	case res.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return fmt.Errorf("could not set metadata: %s", res.GetStatus().GetMessage())
+	}
+	return nil
+}
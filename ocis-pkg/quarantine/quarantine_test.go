@@ -0,0 +1,253 @@
+package quarantine_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	userpb "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	cs3mocks "github.com/cs3org/reva/v2/tests/cs3mocks/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/quarantine"
+)
+
+var (
+	ok   = &rpc.Status{Code: rpc.Code_CODE_OK}
+	root = &provider.ResourceId{StorageId: "storage", SpaceId: "quarantine", OpaqueId: "quarantine"}
+)
+
+// fakeStorage keeps the uploaded content and metadata of a single file per path
+type fakeStorage struct {
+	content  map[string]string
+	metadata map[string]map[string]string
+}
+
+func newTestManager(t *testing.T) (*quarantine.Manager, *cs3mocks.GatewayAPIClient, *fakeStorage) {
+	fs := &fakeStorage{content: map[string]string{}, metadata: map[string]map[string]string{}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "service-token", r.Header.Get("X-Access-Token"))
+		target := r.Header.Get("X-Reva-Transfer")
+		switch r.Method {
+		case http.MethodPut:
+			b, _ := io.ReadAll(r.Body)
+			fs.content[target] = string(b)
+		case http.MethodGet:
+			_, _ = io.WriteString(w, fs.content[target])
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	gatewayClient, gatewaySelector := newGatewayClient()
+	gatewayClient.On("ListStorageSpaces", mock.Anything, mock.Anything).Return(&provider.ListStorageSpacesResponse{Status: ok}, nil).Once()
+	gatewayClient.On("CreateStorageSpace", mock.Anything, mock.MatchedBy(func(req *provider.CreateStorageSpaceRequest) bool {
+		return req.GetType() == quarantine.SpaceType && req.GetOwner().GetId().GetOpaqueId() == "service-account"
+	})).Return(&provider.CreateStorageSpaceResponse{
+		Status:       ok,
+		StorageSpace: &provider.StorageSpace{Root: root},
+	}, nil).Once()
+	gatewayClient.On("CreateContainer", mock.Anything, mock.Anything).Return(&provider.CreateContainerResponse{Status: ok}, nil)
+	gatewayClient.On("InitiateFileUpload", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req *provider.InitiateFileUploadRequest, _ ...grpc.CallOption) *gateway.InitiateFileUploadResponse {
+			if _, exists := fs.content[fsKey(req.GetRef())]; exists && req.GetIfNotExist() {
+				return &gateway.InitiateFileUploadResponse{Status: &rpc.Status{Code: rpc.Code_CODE_ALREADY_EXISTS}}
+			}
+			return &gateway.InitiateFileUploadResponse{
+				Status:    ok,
+				Protocols: []*gateway.FileUploadProtocol{{Protocol: "simple", UploadEndpoint: srv.URL, Token: fsKey(req.GetRef())}},
+			}
+		}, nil)
+	gatewayClient.On("InitiateFileDownload", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req *provider.InitiateFileDownloadRequest, _ ...grpc.CallOption) *gateway.InitiateFileDownloadResponse {
+			return &gateway.InitiateFileDownloadResponse{
+				Status:    ok,
+				Protocols: []*gateway.FileDownloadProtocol{{Protocol: "spaces", DownloadEndpoint: srv.URL, Token: fsKey(req.GetRef())}},
+			}
+		}, nil)
+	gatewayClient.On("SetArbitraryMetadata", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req *provider.SetArbitraryMetadataRequest, _ ...grpc.CallOption) *provider.SetArbitraryMetadataResponse {
+			k := fsKey(req.GetRef())
+			if fs.metadata[k] == nil {
+				fs.metadata[k] = map[string]string{}
+			}
+			for mk, mv := range req.GetArbitraryMetadata().GetMetadata() {
+				fs.metadata[k][mk] = mv
+			}
+			return &provider.SetArbitraryMetadataResponse{Status: ok}
+		}, nil)
+	gatewayClient.On("Stat", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req *provider.StatRequest, _ ...grpc.CallOption) *provider.StatResponse {
+			k := fsKey(req.GetRef())
+			content, found := fs.content[k]
+			if !found {
+				return &provider.StatResponse{Status: &rpc.Status{Code: rpc.Code_CODE_NOT_FOUND}}
+			}
+			name := strings.TrimPrefix(req.GetRef().GetPath(), "./")
+			return &provider.StatResponse{Status: ok, Info: &provider.ResourceInfo{
+				// the metadata of the item is found via its id
+				Id:                &provider.ResourceId{StorageId: "storage", SpaceId: "quarantine", OpaqueId: name},
+				Type:              provider.ResourceType_RESOURCE_TYPE_FILE,
+				Name:              name,
+				Size:              uint64(len(content)),
+				ArbitraryMetadata: &provider.ArbitraryMetadata{Metadata: fs.metadata[k]},
+			}}
+		}, nil)
+
+	return quarantine.NewManager(gatewaySelector, srv.Client(), "id", "secret"), gatewayClient, fs
+}
+
+func newGatewayClient() (*cs3mocks.GatewayAPIClient, pool.Selectable[gateway.GatewayAPIClient]) {
+	pool.RemoveSelector("GatewaySelector" + "com.owncloud.api.gateway")
+	gatewayClient := &cs3mocks.GatewayAPIClient{}
+	gatewaySelector := pool.GetSelector[gateway.GatewayAPIClient](
+		"GatewaySelector",
+		"com.owncloud.api.gateway",
+		func(cc *grpc.ClientConn) gateway.GatewayAPIClient {
+			return gatewayClient
+		},
+	)
+	gatewayClient.On("Authenticate", mock.Anything, mock.Anything).Return(&gateway.AuthenticateResponse{
+		Status: ok,
+		Token:  "service-token",
+		User:   &userpb.User{Id: &userpb.UserId{OpaqueId: "service-account"}},
+	}, nil)
+	return gatewayClient, gatewaySelector
+}
+
+// fsKey identifies a file by its reference, items are referenced by path or by their id
+func fsKey(ref *provider.Reference) string {
+	id := ref.GetResourceId()
+	if id.GetOpaqueId() != id.GetSpaceId() {
+		return id.GetSpaceId() + "/" + id.GetOpaqueId()
+	}
+	return id.GetSpaceId() + "/" + strings.TrimPrefix(ref.GetPath(), "./")
+}
+
+func TestAddAndRelease(t *testing.T) {
+	m, gatewayClient, fs := newTestManager(t)
+	ctx := context.Background()
+
+	item, err := m.Add(ctx, quarantine.Item{
+		Name:        "file.txt",
+		Size:        5,
+		SpaceID:     "storage$personal",
+		Path:        "/folder/file.txt",
+		Description: "test-virus",
+		ScanDate:    time.Now(),
+		Checksum:    "sum",
+	}, strings.NewReader("virus"))
+	require.NoError(t, err)
+	require.NotEmpty(t, item.ID)
+	assert.Equal(t, "virus", fs.content["quarantine/"+item.ID])
+
+	got, err := m.Get(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "file.txt", got.Name)
+	assert.Equal(t, "/folder/file.txt", got.Path)
+	assert.Equal(t, "test-virus", got.Description)
+	assert.False(t, got.Released)
+
+	released, err := m.Release(ctx, item.ID)
+	require.NoError(t, err)
+	assert.True(t, released.Released)
+	// the content is restored to its original location
+	assert.Equal(t, "virus", fs.content["personal/folder/file.txt"])
+	assert.Equal(t, "/folder/file.txt", released.ReleasedTo)
+
+	// the release only applies to the location the content was restored to
+	isReleased, err := m.IsReleased(ctx, "storage$personal", "/folder/file.txt", "sum")
+	require.NoError(t, err)
+	assert.True(t, isReleased)
+	isReleased, err = m.IsReleased(ctx, "storage$personal", "/other/file.txt", "sum")
+	require.NoError(t, err)
+	assert.False(t, isReleased)
+	isReleased, err = m.IsReleased(ctx, "storage$other", "/folder/file.txt", "sum")
+	require.NoError(t, err)
+	assert.False(t, isReleased)
+
+	_, err = m.Release(ctx, item.ID)
+	assert.ErrorIs(t, err, quarantine.ErrReleased)
+
+	inQuarantine, err := m.Contains(ctx, &provider.ResourceId{StorageId: "storage", SpaceId: "quarantine", OpaqueId: item.ID})
+	require.NoError(t, err)
+	assert.True(t, inQuarantine)
+
+	// the space is only looked up and created once
+	gatewayClient.AssertNumberOfCalls(t, "CreateStorageSpace", 1)
+}
+
+func TestGetNotFound(t *testing.T) {
+	m, _, _ := newTestManager(t)
+
+	_, err := m.Get(context.Background(), "missing")
+	assert.ErrorIs(t, err, quarantine.ErrNotFound)
+
+	_, err = m.Get(context.Background(), "../escape")
+	assert.ErrorIs(t, err, quarantine.ErrNotFound)
+}
+
+func TestReleaseDoesNotOverwrite(t *testing.T) {
+	m, _, fs := newTestManager(t)
+	ctx := context.Background()
+
+	item, err := m.Add(ctx, quarantine.Item{
+		Name:     "file.txt",
+		Size:     5,
+		SpaceID:  "storage$personal",
+		Path:     "/folder/file.txt",
+		Checksum: "sum",
+	}, strings.NewReader("virus"))
+	require.NoError(t, err)
+
+	// a new file was uploaded to the original location in the meantime
+	fs.content["personal/folder/file.txt"] = "new"
+	fs.content["personal/folder/file (1).txt"] = "newer"
+
+	released, err := m.Release(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "/folder/file (2).txt", released.ReleasedTo)
+	assert.Equal(t, "virus", fs.content["personal/folder/file (2).txt"])
+	assert.Equal(t, "new", fs.content["personal/folder/file.txt"])
+
+	got, err := m.Get(ctx, item.ID)
+	require.NoError(t, err)
+	assert.True(t, got.Released)
+	assert.Equal(t, "/folder/file (2).txt", got.ReleasedTo)
+
+	isReleased, err := m.IsReleased(ctx, "storage$personal", "/folder/file (2).txt", "sum")
+	require.NoError(t, err)
+	assert.True(t, isReleased)
+	isReleased, err = m.IsReleased(ctx, "storage$personal", "/folder/file.txt", "sum")
+	require.NoError(t, err)
+	assert.False(t, isReleased)
+}
+
+func TestSpaceCreatedConcurrently(t *testing.T) {
+	gatewayClient, gatewaySelector := newGatewayClient()
+	gatewayClient.On("ListStorageSpaces", mock.Anything, mock.Anything).Return(&provider.ListStorageSpacesResponse{Status: ok}, nil).Once()
+	gatewayClient.On("CreateStorageSpace", mock.Anything, mock.MatchedBy(func(req *provider.CreateStorageSpaceRequest) bool {
+		// all replicas create the space with the same id
+		return utils.ReadPlainFromOpaque(req.GetOpaque(), "spaceid") != ""
+	})).Return(&provider.CreateStorageSpaceResponse{Status: &rpc.Status{Code: rpc.Code_CODE_ALREADY_EXISTS}}, nil).Once()
+	gatewayClient.On("ListStorageSpaces", mock.Anything, mock.Anything).Return(&provider.ListStorageSpacesResponse{
+		Status:        ok,
+		StorageSpaces: []*provider.StorageSpace{{Root: root}},
+	}, nil).Once()
+
+	m := quarantine.NewManager(gatewaySelector, http.DefaultClient, "id", "secret")
+	space, err := m.Space(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, root, space.GetRoot())
+}
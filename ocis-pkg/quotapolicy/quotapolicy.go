@@ -0,0 +1,297 @@
+// Package quotapolicy manages quota policies. The policies are managed by the settings service and kept in a
+// store which is shared with the services applying them.
+package quotapolicy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	microstore "go-micro.dev/v4/store"
+)
+
+const (
+	// Database is the database of the quota policies in the store
+	Database = "settings"
+	// Table is the table of the quota policies in the store
+	Table = "quota-policies"
+
+	// TemplateKey is the arbitrary metadata key on the root of a project space holding the template the space
+	// was created with
+	TemplateKey = "oc.space.template"
+)
+
+const (
+	// SpaceTypePersonal selects personal spaces by the role of their owner
+	SpaceTypePersonal = "personal"
+	// SpaceTypeProject selects project spaces by the template they were created with
+	SpaceTypeProject = "project"
+)
+
+var (
+	// ErrNotFound is returned when a policy does not exist
+	ErrNotFound = errors.New("quota policy not found")
+	// ErrInvalid is returned when a policy is not valid
+	ErrInvalid = errors.New("invalid quota policy")
+	// ErrConflict is returned when a policy for the same spaces and path already exists
+	ErrConflict = errors.New("a quota policy for these spaces and path already exists")
+
+	// _idNamespace is the namespace of the policy ids, which are derived from the spaces a policy applies to
+	_idNamespace = uuid.MustParse("5c3f0b6e-2d0a-4a8e-9f5e-8a1b7c9d2e41")
+)
+
+// Policy is a quota for the spaces of a type or a folder in them. Personal spaces are selected by the role of their
+// owner, project spaces by the template they were created with.
+type Policy struct {
+	ID        string `json:"id"`
+	SpaceType string `json:"spaceType"`
+	// RoleID selects the personal spaces of users with this role
+	RoleID string `json:"roleId,omitempty"`
+	// SpaceTemplate selects the project spaces created with this template, empty selects spaces created without template
+	SpaceTemplate string `json:"spaceTemplate,omitempty"`
+	// Path is a folder relative to the space root. Folder quotas are not enforced by the storage, users are only warned.
+	Path string `json:"path,omitempty"`
+	// Quota in bytes, 0 means unlimited
+	Quota uint64 `json:"quota"`
+	// WarningThresholds are the percentages of the quota at which the users are warned
+	WarningThresholds []int `json:"warningThresholds,omitempty"`
+}
+
+// Validate checks the policy and cleans the path.
+func (p *Policy) Validate() error {
+	switch p.SpaceType {
+	case SpaceTypePersonal:
+		if p.RoleID == "" {
+			return errors.New("roleId is required for personal spaces")
+		}
+		if p.SpaceTemplate != "" {
+			return errors.New("spaceTemplate is only supported for project spaces")
+		}
+	case SpaceTypeProject:
+		if p.RoleID != "" {
+			return errors.New("roleId is only supported for personal spaces")
+		}
+	default:
+		return fmt.Errorf("unsupported spaceType '%s'", p.SpaceType)
+	}
+
+	if p.Path != "" {
+		for _, segment := range strings.Split(p.Path, "/") {
+			if segment == ".." {
+				return fmt.Errorf("invalid path '%s'", p.Path)
+			}
+		}
+		cleaned := path.Clean("/" + p.Path)
+		if cleaned == "/" {
+			return fmt.Errorf("invalid path '%s'", p.Path)
+		}
+		p.Path = strings.TrimPrefix(cleaned, "/")
+		if p.Quota == 0 {
+			return errors.New("a quota is required for folders")
+		}
+	}
+
+	for _, t := range p.WarningThresholds {
+		if t <= 0 || t > 100 {
+			return fmt.Errorf("invalid warning threshold %d, it must be a percentage", t)
+		}
+	}
+	sort.Ints(p.WarningThresholds)
+	return nil
+}
+
+// IsFolderPolicy returns true if the policy applies to a folder instead of the whole space.
+func (p Policy) IsFolderPolicy() bool {
+	return p.Path != ""
+}
+
+// Contains returns true if the file, given relative to the space root, is inside the folder of the policy.
+func (p Policy) Contains(file string) bool {
+	file = strings.TrimPrefix(path.Clean("/"+file), "/")
+	return p.IsFolderPolicy() && strings.HasPrefix(file, p.Path+"/")
+}
+
+// ReachedThreshold returns the highest warning threshold reached by the usage, 0 if none was reached.
+func ReachedThreshold(thresholds []int, used, total uint64) int {
+	if total == 0 {
+		return 0
+	}
+	reached := 0
+	for _, t := range thresholds {
+		if used*100 >= uint64(t)*total && t > reached {
+			reached = t
+		}
+	}
+	return reached
+}
+
+func (p Policy) scope() string {
+	s := []string{p.SpaceType, p.RoleID, p.SpaceTemplate}
+	if p.IsFolderPolicy() {
+		s = append(s, p.Path)
+	}
+	return strings.Join(s, "\x00")
+}
+
+// scopeID returns the id of the policy for the spaces and folder the policy applies to. Policies are stored under
+// this id, so there is only one policy per type, role or template and folder and the policy of a space can be read
+// without listing the policies.
+func (p Policy) scopeID() string {
+	return uuid.NewSHA1(_idNamespace, []byte(p.scope())).String()
+}
+
+// Manager reads and writes the quota policies.
+type Manager struct {
+	store microstore.Store
+	mu    sync.Mutex
+}
+
+// NewManager returns a Manager for the policies in the store.
+func NewManager(store microstore.Store) *Manager {
+	return &Manager{store: store}
+}
+
+// List returns all policies sorted by their space type, role, template and path.
+func (m *Manager) List() ([]Policy, error) {
+	keys, err := m.store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]Policy, 0, len(keys))
+	for _, k := range keys {
+		p, err := m.Get(k)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			// deleted in the meantime
+			continue
+		case err != nil:
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].scope() < policies[j].scope()
+	})
+	return policies, nil
+}
+
+// Get returns the policy with the id.
+func (m *Manager) Get(id string) (Policy, error) {
+	recs, err := m.store.Read(id)
+	switch {
+	case errors.Is(err, microstore.ErrNotFound):
+		return Policy{}, ErrNotFound
+	case err != nil:
+		return Policy{}, err
+	case len(recs) == 0:
+		return Policy{}, ErrNotFound
+	}
+
+	var p Policy
+	if err := json.Unmarshal(recs[0].Value, &p); err != nil {
+		return Policy{}, err
+	}
+	return p, nil
+}
+
+// Write validates and stores the policy. Policies without id are created, the spaces and folder of existing
+// policies can't be changed.
+func (m *Manager) Write(p Policy) (Policy, error) {
+	if err := p.Validate(); err != nil {
+		return Policy{}, fmt.Errorf("%w: %s", ErrInvalid, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := p.scopeID()
+	switch p.ID {
+	case "":
+		// concurrent creations on other instances write the same record, the last one wins
+		_, err := m.Get(id)
+		switch {
+		case err == nil:
+			return Policy{}, ErrConflict
+		case !errors.Is(err, ErrNotFound):
+			return Policy{}, err
+		}
+		p.ID = id
+	case id:
+	default:
+		return Policy{}, fmt.Errorf("%w: the spaces and folder of a policy can't be changed, create a new policy instead", ErrInvalid)
+	}
+
+	v, err := json.Marshal(p)
+	if err != nil {
+		return Policy{}, err
+	}
+	if err := m.store.Write(&microstore.Record{Key: p.ID, Value: v}); err != nil {
+		return Policy{}, err
+	}
+	return p, nil
+}
+
+// Delete removes the policy with the id.
+func (m *Manager) Delete(id string) error {
+	if _, err := m.Get(id); err != nil {
+		return err
+	}
+	return m.store.Delete(id)
+}
+
+// SpacePolicy returns the policy applying to the whole space, nil if there is none. The roleID is the role of the owner
+// of personal spaces, the template is the one project spaces were created with.
+func (m *Manager) SpacePolicy(spaceType, roleID, template string) (*Policy, error) {
+	scope := Policy{SpaceType: spaceType}
+	switch spaceType {
+	case SpaceTypePersonal:
+		scope.RoleID = roleID
+	case SpaceTypeProject:
+		scope.SpaceTemplate = template
+	default:
+		return nil, nil
+	}
+
+	p, err := m.Get(scope.scopeID())
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	return &p, nil
+}
+
+// FolderPolicies returns the policies applying to folders in the space. The roleID is the role of the owner of
+// personal spaces, the template is the one project spaces were created with.
+func (m *Manager) FolderPolicies(spaceType, roleID, template string) ([]Policy, error) {
+	policies, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var matching []Policy
+	for _, p := range policies {
+		if p.IsFolderPolicy() && p.matches(spaceType, roleID, template) {
+			matching = append(matching, p)
+		}
+	}
+	return matching, nil
+}
+
+func (p Policy) matches(spaceType, roleID, template string) bool {
+	switch spaceType {
+	case SpaceTypePersonal:
+		return p.SpaceType == SpaceTypePersonal && p.RoleID == roleID
+	case SpaceTypeProject:
+		return p.SpaceType == SpaceTypeProject && p.SpaceTemplate == template
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package quotapolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	microstore "go-micro.dev/v4/store"
+)
+
+func TestManager(t *testing.T) {
+	m := NewManager(microstore.NewMemoryStore())
+
+	space, err := m.Write(Policy{SpaceType: SpaceTypeProject, SpaceTemplate: "default", Quota: 100, WarningThresholds: []int{95, 80}})
+	require.NoError(t, err)
+	require.NotEmpty(t, space.ID)
+	require.Equal(t, []int{80, 95}, space.WarningThresholds)
+
+	folder, err := m.Write(Policy{SpaceType: SpaceTypeProject, SpaceTemplate: "default", Path: "/Documents/", Quota: 10, WarningThresholds: []int{90}})
+	require.NoError(t, err)
+	require.Equal(t, "Documents", folder.Path)
+	require.NotEqual(t, space.ID, folder.ID)
+
+	_, err = m.Write(Policy{SpaceType: SpaceTypeProject, SpaceTemplate: "default", Quota: 200})
+	require.ErrorIs(t, err, ErrConflict)
+
+	_, err = m.Write(Policy{SpaceType: SpaceTypeProject, SpaceTemplate: "default", Path: "Documents", Quota: 20})
+	require.ErrorIs(t, err, ErrConflict)
+
+	space.Quota = 200
+	space, err = m.Write(space)
+	require.NoError(t, err)
+
+	_, err = m.Write(Policy{ID: space.ID, SpaceType: SpaceTypeProject, SpaceTemplate: "other", Quota: 200})
+	require.ErrorIs(t, err, ErrInvalid)
+
+	_, err = m.Write(Policy{SpaceType: SpaceTypePersonal, Quota: 50})
+	require.ErrorIs(t, err, ErrInvalid)
+
+	user, err := m.Write(Policy{SpaceType: SpaceTypePersonal, RoleID: "user", Quota: 50})
+	require.NoError(t, err)
+
+	policies, err := m.List()
+	require.NoError(t, err)
+	require.Len(t, policies, 3)
+	require.Equal(t, user.ID, policies[0].ID)
+
+	p, err := m.SpacePolicy(SpaceTypeProject, "", "default")
+	require.NoError(t, err)
+	require.Equal(t, space.ID, p.ID)
+	require.Equal(t, uint64(200), p.Quota)
+
+	folders, err := m.FolderPolicies(SpaceTypeProject, "", "default")
+	require.NoError(t, err)
+	require.Len(t, folders, 1)
+	require.Equal(t, folder.ID, folders[0].ID)
+
+	folders, err = m.FolderPolicies(SpaceTypePersonal, "user", "")
+	require.NoError(t, err)
+	require.Empty(t, folders)
+
+	p, err = m.SpacePolicy(SpaceTypePersonal, "user", "")
+	require.NoError(t, err)
+	require.Equal(t, uint64(50), p.Quota)
+
+	p, err = m.SpacePolicy(SpaceTypeProject, "", "")
+	require.NoError(t, err)
+	require.Nil(t, p)
+
+	require.NoError(t, m.Delete(space.ID))
+	require.ErrorIs(t, m.Delete(space.ID), ErrNotFound)
+	_, err = m.Get(space.ID)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, m.Delete(folder.ID))
+	folders, err = m.FolderPolicies(SpaceTypeProject, "", "default")
+	require.NoError(t, err)
+	require.Empty(t, folders)
+}
+
+func TestValidate(t *testing.T) {
+	invalid := map[string]Policy{
+		"missing role":          {SpaceType: SpaceTypePersonal},
+		"template for personal": {SpaceType: SpaceTypePersonal, RoleID: "user", SpaceTemplate: "default"},
+		"role for project":      {SpaceType: SpaceTypeProject, RoleID: "user"},
+		"unknown type":          {SpaceType: "mountpoint"},
+		"root path":             {SpaceType: SpaceTypeProject, Path: "/", Quota: 10},
+		"parent path":           {SpaceType: SpaceTypeProject, Path: "../other", Quota: 10},
+		"unlimited folder":      {SpaceType: SpaceTypeProject, Path: "Documents"},
+		"threshold":             {SpaceType: SpaceTypeProject, WarningThresholds: []int{120}},
+	}
+	for name, p := range invalid {
+		require.Error(t, p.Validate(), name)
+	}
+}
+
+func TestContains(t *testing.T) {
+	p := Policy{SpaceType: SpaceTypeProject, Path: "Documents/Reports"}
+	require.True(t, p.Contains("./Documents/Reports/q1.pdf"))
+	require.True(t, p.Contains("Documents/Reports/2024/q1.pdf"))
+	require.False(t, p.Contains("./Documents/Reports"))
+	require.False(t, p.Contains("./Documents/ReportsOld/q1.pdf"))
+	require.False(t, Policy{SpaceType: SpaceTypeProject}.Contains("./file.txt"))
+}
+
+func TestReachedThreshold(t *testing.T) {
+	thresholds := []int{80, 95}
+	require.Equal(t, 0, ReachedThreshold(thresholds, 79, 100))
+	require.Equal(t, 80, ReachedThreshold(thresholds, 80, 100))
+	require.Equal(t, 95, ReachedThreshold(thresholds, 120, 100))
+	require.Equal(t, 0, ReachedThreshold(thresholds, 120, 0))
+}
@@ -23,6 +23,7 @@ type Options struct {
 	Health               func(http.ResponseWriter, *http.Request)
 	Ready                func(http.ResponseWriter, *http.Request)
 	ConfigDump           func(http.ResponseWriter, *http.Request)
+	Handlers             map[string]http.Handler
 	CorsAllowedOrigins   []string
 	CorsAllowedMethods   []string
 	CorsAllowedHeaders   []string
@@ -117,6 +118,16 @@ func ConfigDump(r func(http.ResponseWriter, *http.Request)) Option {
 	}
 }
 
+// Handler adds an additional handler for the given pattern.
+func Handler(pattern string, h http.Handler) Option {
+	return func(o *Options) {
+		if o.Handlers == nil {
+			o.Handlers = make(map[string]http.Handler)
+		}
+		o.Handlers[pattern] = h
+	}
+}
+
 // CorsAllowedOrigins provides a function to set the CorsAllowedOrigin option.
 func CorsAllowedOrigins(origins []string) Option {
 	return func(o *Options) {
@@ -35,6 +35,10 @@ func NewService(opts ...Option) *http.Server {
 		mux.HandleFunc("/config", dopts.ConfigDump)
 	}
 
+	for pattern, h := range dopts.Handlers {
+		mux.Handle(pattern, h)
+	}
+
 	if dopts.Pprof {
 		mux.HandleFunc("/debug/pprof/", pprof.Index)
 		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
@@ -109,7 +109,8 @@ type ThumbnailSettings struct {
 }
 
 type ThumbnailService struct {
-	Thumbnail ThumbnailSettings
+	Thumbnail      ThumbnailSettings
+	ServiceAccount ServiceAccount `yaml:"service_account"`
 }
 
 type Search struct {
@@ -117,6 +118,10 @@ type Search struct {
 	ServiceAccount ServiceAccount `yaml:"service_account"`
 }
 
+type Antivirus struct {
+	ServiceAccount ServiceAccount `yaml:"service_account"`
+}
+
 type Audit struct {
 	Events Events
 }
@@ -227,6 +232,7 @@ type OcisConfig struct {
 	AuthService       AuthService `yaml:"auth_service"`
 	Clientlog         Clientlog
 	Activitylog       Activitylog
+	Antivirus         Antivirus
 }
 
 func checkConfigPath(configPath string) error {
@@ -398,6 +404,7 @@ func CreateConfig(insecure, forceOverwrite bool, configPath, adminPassword strin
 			Thumbnail: ThumbnailSettings{
 				TransferSecret: thumbnailsTransferSecret,
 			},
+			ServiceAccount: serviceAccount,
 		},
 		Gateway: Gateway{
 			StorageRegistry: StorageRegistry{
@@ -438,6 +445,9 @@ func CreateConfig(insecure, forceOverwrite bool, configPath, adminPassword strin
 		Activitylog: Activitylog{
 			ServiceAccount: serviceAccount,
 		},
+		Antivirus: Antivirus{
+			ServiceAccount: serviceAccount,
+		},
 	}
 
 	if insecure {
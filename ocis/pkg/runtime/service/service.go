@@ -321,7 +321,7 @@ func NewService(options ...Option) (*Service, error) {
 	}
 	areg(opts.Config.Antivirus.Service.Name, func(ctx context.Context, cfg *ociscfg.Config) error {
 		cfg.Antivirus.Context = ctx
-		// cfg.Antivirus.Commons = cfg.Commons // antivirus holds no Commons atm
+		cfg.Antivirus.Commons = cfg.Commons
 		return antivirus.Execute(cfg.Antivirus)
 	})
 	areg(opts.Config.Audit.Service.Name, func(ctx context.Context, cfg *ociscfg.Config) error {
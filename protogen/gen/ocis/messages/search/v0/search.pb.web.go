@@ -297,3 +297,111 @@ func (m *Match) UnmarshalJSON(b []byte) error {
 }
 
 var _ json.Unmarshaler = (*Match)(nil)
+
+// FacetValueJSONMarshaler describes the default jsonpb.Marshaler used by all
+// instances of FacetValue. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var FacetValueJSONMarshaler = new(jsonpb.Marshaler)
+
+// MarshalJSON satisfies the encoding/json Marshaler interface. This method
+// uses the more correct jsonpb package to correctly marshal the message.
+func (m *FacetValue) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return json.Marshal(nil)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := FacetValueJSONMarshaler.Marshal(buf, m); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var _ json.Marshaler = (*FacetValue)(nil)
+
+// FacetValueJSONUnmarshaler describes the default jsonpb.Unmarshaler used by all
+// instances of FacetValue. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var FacetValueJSONUnmarshaler = new(jsonpb.Unmarshaler)
+
+// UnmarshalJSON satisfies the encoding/json Unmarshaler interface. This method
+// uses the more correct jsonpb package to correctly unmarshal the message.
+func (m *FacetValue) UnmarshalJSON(b []byte) error {
+	return FacetValueJSONUnmarshaler.Unmarshal(bytes.NewReader(b), m)
+}
+
+var _ json.Unmarshaler = (*FacetValue)(nil)
+
+// FacetJSONMarshaler describes the default jsonpb.Marshaler used by all
+// instances of Facet. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var FacetJSONMarshaler = new(jsonpb.Marshaler)
+
+// MarshalJSON satisfies the encoding/json Marshaler interface. This method
+// uses the more correct jsonpb package to correctly marshal the message.
+func (m *Facet) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return json.Marshal(nil)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := FacetJSONMarshaler.Marshal(buf, m); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var _ json.Marshaler = (*Facet)(nil)
+
+// FacetJSONUnmarshaler describes the default jsonpb.Unmarshaler used by all
+// instances of Facet. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var FacetJSONUnmarshaler = new(jsonpb.Unmarshaler)
+
+// UnmarshalJSON satisfies the encoding/json Unmarshaler interface. This method
+// uses the more correct jsonpb package to correctly unmarshal the message.
+func (m *Facet) UnmarshalJSON(b []byte) error {
+	return FacetJSONUnmarshaler.Unmarshal(bytes.NewReader(b), m)
+}
+
+var _ json.Unmarshaler = (*Facet)(nil)
+
+// IndexJobJSONMarshaler describes the default jsonpb.Marshaler used by all
+// instances of IndexJob. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var IndexJobJSONMarshaler = new(jsonpb.Marshaler)
+
+// MarshalJSON satisfies the encoding/json Marshaler interface. This method
+// uses the more correct jsonpb package to correctly marshal the message.
+func (m *IndexJob) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return json.Marshal(nil)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err := IndexJobJSONMarshaler.Marshal(buf, m); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var _ json.Marshaler = (*IndexJob)(nil)
+
+// IndexJobJSONUnmarshaler describes the default jsonpb.Unmarshaler used by all
+// instances of IndexJob. This struct is safe to replace or modify but
+// should not be done so concurrently.
+var IndexJobJSONUnmarshaler = new(jsonpb.Unmarshaler)
+
+// UnmarshalJSON satisfies the encoding/json Unmarshaler interface. This method
+// uses the more correct jsonpb package to correctly unmarshal the message.
+func (m *IndexJob) UnmarshalJSON(b []byte) error {
+	return IndexJobJSONUnmarshaler.Unmarshal(bytes.NewReader(b), m)
+}
+
+var _ json.Unmarshaler = (*IndexJob)(nil)
@@ -47,6 +47,12 @@ func NewSearchProviderEndpoints() []*api.Endpoint {
 			Method:  []string{"POST"},
 			Handler: "rpc",
 		},
+		{
+			Name:    "SearchProvider.GetIndexStatus",
+			Path:    []string{"/api/v0/search/index-status"},
+			Method:  []string{"POST"},
+			Handler: "rpc",
+		},
 	}
 }
 
@@ -55,6 +61,7 @@ func NewSearchProviderEndpoints() []*api.Endpoint {
 type SearchProviderService interface {
 	Search(ctx context.Context, in *SearchRequest, opts ...client.CallOption) (*SearchResponse, error)
 	IndexSpace(ctx context.Context, in *IndexSpaceRequest, opts ...client.CallOption) (*IndexSpaceResponse, error)
+	GetIndexStatus(ctx context.Context, in *GetIndexStatusRequest, opts ...client.CallOption) (*GetIndexStatusResponse, error)
 }
 
 type searchProviderService struct {
@@ -89,17 +96,29 @@ func (c *searchProviderService) IndexSpace(ctx context.Context, in *IndexSpaceRe
 	return out, nil
 }
 
+func (c *searchProviderService) GetIndexStatus(ctx context.Context, in *GetIndexStatusRequest, opts ...client.CallOption) (*GetIndexStatusResponse, error) {
+	req := c.c.NewRequest(c.name, "SearchProvider.GetIndexStatus", in)
+	out := new(GetIndexStatusResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for SearchProvider service
 
 type SearchProviderHandler interface {
 	Search(context.Context, *SearchRequest, *SearchResponse) error
 	IndexSpace(context.Context, *IndexSpaceRequest, *IndexSpaceResponse) error
+	GetIndexStatus(context.Context, *GetIndexStatusRequest, *GetIndexStatusResponse) error
 }
 
 func RegisterSearchProviderHandler(s server.Server, hdlr SearchProviderHandler, opts ...server.HandlerOption) error {
 	type searchProvider interface {
 		Search(ctx context.Context, in *SearchRequest, out *SearchResponse) error
 		IndexSpace(ctx context.Context, in *IndexSpaceRequest, out *IndexSpaceResponse) error
+		GetIndexStatus(ctx context.Context, in *GetIndexStatusRequest, out *GetIndexStatusResponse) error
 	}
 	type SearchProvider struct {
 		searchProvider
@@ -117,6 +136,12 @@ func RegisterSearchProviderHandler(s server.Server, hdlr SearchProviderHandler,
 		Method:  []string{"POST"},
 		Handler: "rpc",
 	}))
+	opts = append(opts, api.WithEndpoint(&api.Endpoint{
+		Name:    "SearchProvider.GetIndexStatus",
+		Path:    []string{"/api/v0/search/index-status"},
+		Method:  []string{"POST"},
+		Handler: "rpc",
+	}))
 	return s.Handle(s.NewHandler(&SearchProvider{h}, opts...))
 }
 
@@ -132,6 +157,10 @@ func (h *searchProviderHandler) IndexSpace(ctx context.Context, in *IndexSpaceRe
 	return h.SearchProviderHandler.IndexSpace(ctx, in, out)
 }
 
+func (h *searchProviderHandler) GetIndexStatus(ctx context.Context, in *GetIndexStatusRequest, out *GetIndexStatusResponse) error {
+	return h.SearchProviderHandler.GetIndexStatus(ctx, in, out)
+}
+
 // Api Endpoints for IndexProvider service
 
 func NewIndexProviderEndpoints() []*api.Endpoint {
@@ -114,6 +114,7 @@ func Server(cfg *config.Config) *cli.Command {
 
 			hClient := ehsvc.NewEventHistoryService("com.owncloud.api.eventhistory", grpcClient)
 			vClient := settingssvc.NewValueService("com.owncloud.api.settings", grpcClient)
+			rClient := settingssvc.NewRoleService("com.owncloud.api.settings", grpcClient)
 
 			{
 				svc, err := http.Server(
@@ -127,6 +128,7 @@ func Server(cfg *config.Config) *cli.Command {
 					http.GatewaySelector(gatewaySelector),
 					http.HistoryClient(hClient),
 					http.ValueClient(vClient),
+					http.Role(rClient),
 					http.RegisteredEvents(_registeredEvents),
 				)
 
@@ -17,8 +17,10 @@ type Config struct {
 	Log     *Log     `yaml:"log"`
 	Debug   Debug    `yaml:"debug"`
 
-	Events Events `yaml:"events"`
-	Store  Store  `yaml:"store"`
+	Events    Events    `yaml:"events"`
+	Store     Store     `yaml:"store"`
+	Retention Retention `yaml:"retention"`
+	Digest    Digest    `yaml:"digest"`
 
 	RevaGateway   string                `yaml:"reva_gateway" env:"OCIS_REVA_GATEWAY" desc:"CS3 gateway used to look up user metadata" introductionVersion:"5.0"`
 	GRPCClientTLS *shared.GRPCClientTLS `yaml:"grpc_client_tls"`
@@ -54,6 +56,19 @@ type Store struct {
 	AuthPassword string        `yaml:"password" env:"OCIS_PERSISTENT_STORE_AUTH_PASSWORD;ACTIVITYLOG_STORE_AUTH_PASSWORD" desc:"The password to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"5.0"`
 }
 
+// Retention configures how long activities are kept
+type Retention struct {
+	MaxAge        time.Duration `yaml:"max_age" env:"ACTIVITYLOG_RETENTION_MAX_AGE" desc:"The time activities are kept. Older activities are pruned when new activities are stored and by a periodic sweep. Defaults to '0' which keeps the activities forever. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+	MaxActivities int           `yaml:"max_activities" env:"ACTIVITYLOG_RETENTION_MAX_ACTIVITIES" desc:"The maximum number of activities kept per resource and per user. The oldest activities are pruned first. Defaults to '0' which does not limit the number of activities." introductionVersion:"6.0.0"`
+	PruneInterval time.Duration `yaml:"prune_interval" env:"ACTIVITYLOG_RETENTION_PRUNE_INTERVAL" desc:"The interval of the periodic sweep which prunes the activities of all resources and users. Only applies when ACTIVITYLOG_RETENTION_MAX_AGE is set. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+}
+
+// Digest configures the digests of the activities in the folders and spaces users watch
+type Digest struct {
+	CheckInterval time.Duration `yaml:"check_interval" env:"ACTIVITYLOG_DIGEST_CHECK_INTERVAL" desc:"The interval in which the service checks for due activity digests. Users choose a daily or weekly digest in their profile settings. Set to '0' to disable the digests. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+	MaxActivities int           `yaml:"max_activities" env:"ACTIVITYLOG_DIGEST_MAX_ACTIVITIES" desc:"The maximum number of activities listed per watched folder or space in a digest." introductionVersion:"6.0.0"`
+}
+
 // ServiceAccount is the configuration for the used service account
 type ServiceAccount struct {
 	ServiceAccountID     string `yaml:"service_account_id" env:"OCIS_SERVICE_ACCOUNT_ID;ACTIVITYLOG_SERVICE_ACCOUNT_ID" desc:"The ID of the service account the service should use. See the 'auth-service' service description for more details." introductionVersion:"5.0"`
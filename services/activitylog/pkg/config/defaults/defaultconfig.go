@@ -1,6 +1,8 @@
 package defaults
 
 import (
+	"time"
+
 	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
 	"github.com/owncloud/ocis/v2/ocis-pkg/structs"
 	"github.com/owncloud/ocis/v2/services/activitylog/pkg/config"
@@ -37,6 +39,13 @@ func DefaultConfig() *config.Config {
 			Database: "activitylog",
 			Table:    "",
 		},
+		Retention: config.Retention{
+			PruneInterval: time.Hour,
+		},
+		Digest: config.Digest{
+			CheckInterval: time.Hour,
+			MaxActivities: 50,
+		},
 		RevaGateway: shared.DefaultRevaConfig().Address,
 		HTTP: config.HTTP{
 			Addr:      "127.0.0.1:0",
@@ -44,7 +53,7 @@ func DefaultConfig() *config.Config {
 			Namespace: "com.owncloud.web",
 			CORS: config.CORS{
 				AllowedOrigins:   []string{"*"},
-				AllowedMethods:   []string{"GET"},
+				AllowedMethods:   []string{"GET", "PUT", "DELETE"},
 				AllowedHeaders:   []string{"Authorization", "Origin", "Content-Type", "Accept", "X-Requested-With", "X-Request-Id", "Ocs-Apirequest"},
 				AllowCredentials: true,
 			},
@@ -0,0 +1,33 @@
+package event
+
+import (
+	"encoding/json"
+	"time"
+
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+)
+
+// ActivityDigest is emitted when the digest of the activities in the folders and spaces a user watches is due
+type ActivityDigest struct {
+	Recipient *user.UserId
+	// Interval is either daily or weekly
+	Interval  string
+	Resources []DigestResource
+	Since     time.Time
+	Timestamp time.Time
+}
+
+// DigestResource holds the activities of a watched folder or space
+type DigestResource struct {
+	ID   string
+	Name string
+	// Activities are the messages of the activities in the language of the recipient, the newest first
+	Activities []string
+}
+
+// Unmarshal to fulfill umarshaller interface
+func (ActivityDigest) Unmarshal(v []byte) (interface{}, error) {
+	e := ActivityDigest{}
+	err := json.Unmarshal(v, &e)
+	return e, err
+}
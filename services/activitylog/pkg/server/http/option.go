@@ -33,6 +33,7 @@ type Options struct {
 	TraceProvider    trace.TracerProvider
 	HistoryClient    ehsvc.EventHistoryService
 	ValueClient      settingssvc.ValueService
+	RoleClient       settingssvc.RoleService
 	RegisteredEvents []events.Unmarshaller
 }
 
@@ -137,3 +138,10 @@ func ValueClient(val settingssvc.ValueService) Option {
 		o.ValueClient = val
 	}
 }
+
+// Role provides a function to configure the roles service client
+func Role(rs settingssvc.RoleService) Option {
+	return func(o *Options) {
+		o.RoleClient = rs
+	}
+}
@@ -87,6 +87,7 @@ func Server(opts ...Option) (http.Service, error) {
 		svc.TraceProvider(options.TraceProvider),
 		svc.HistoryClient(options.HistoryClient),
 		svc.ValueClient(options.ValueClient),
+		svc.RoleClient(options.RoleClient),
 		svc.RegisteredEvents(options.RegisteredEvents),
 	)
 	if err != nil {
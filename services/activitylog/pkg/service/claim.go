@@ -0,0 +1,124 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/cs3org/reva/v2/pkg/store"
+	"github.com/google/uuid"
+	microstore "go-micro.dev/v4/store"
+
+	"github.com/owncloud/ocis/v2/services/activitylog/pkg/config"
+)
+
+// _claimTable is the table of the store holding the claims of the digests
+const _claimTable = "activitylog-digest-claims"
+
+// claimer makes sure that a digest is sent by one replica of the service only
+type claimer interface {
+	// claim returns true if the key was claimed. It returns false if the key was claimed before and the claim has
+	// not expired yet.
+	claim(key string) (bool, error)
+}
+
+// newClaimer returns a claimer whose claims expire after the ttl. The claims are kept in the configured store when
+// it is shared between the replicas, the other stores can't be shared between replicas anyway.
+func newClaimer(cfg config.Store, ttl time.Duration) claimer {
+	switch cfg.Store {
+	case store.TypeNatsJSKV, store.TypeNatsJS, store.TypeRedis, store.TypeRedisSentinel, store.TypeEtcd:
+		return &storeClaimer{
+			store: store.Create(
+				store.Store(cfg.Store),
+				store.TTL(ttl),
+				microstore.Nodes(cfg.Nodes...),
+				microstore.Database(cfg.Database),
+				microstore.Table(_claimTable),
+				store.Authentication(cfg.AuthUsername, cfg.AuthPassword),
+			),
+			owner: uuid.New().String(),
+			ttl:   ttl,
+		}
+	}
+	return &memoryClaimer{ttl: ttl, claims: make(map[string]time.Time)}
+}
+
+// storeClaimer claims the keys in a store shared by the replicas. The store can't create a key only if it does not
+// exist, so the claim is written and read again, the replica whose claim was written last owns it.
+type storeClaimer struct {
+	store microstore.Store
+	owner string
+	ttl   time.Duration
+}
+
+// storedClaim is the value of a claim in the store. The expiry is stored as well because not all stores support
+// the expiry of records.
+type storedClaim struct {
+	Owner   string    `json:"owner"`
+	Expires time.Time `json:"expires"`
+}
+
+func (c *storeClaimer) claim(key string) (bool, error) {
+	current, err := c.read(key)
+	if err != nil {
+		return false, err
+	}
+	if current != nil && time.Now().Before(current.Expires) {
+		return false, nil
+	}
+
+	value, err := json.Marshal(storedClaim{Owner: c.owner, Expires: time.Now().Add(c.ttl)})
+	if err != nil {
+		return false, err
+	}
+	if err := c.store.Write(&microstore.Record{Key: key, Value: value, Expiry: c.ttl}); err != nil {
+		return false, err
+	}
+
+	// another replica may have claimed the key in the meantime
+	current, err = c.read(key)
+	if err != nil {
+		return false, err
+	}
+	return current != nil && current.Owner == c.owner, nil
+}
+
+// read returns the claim of the key, nil if the key has not been claimed
+func (c *storeClaimer) read(key string) (*storedClaim, error) {
+	records, err := c.store.Read(key)
+	switch {
+	case errors.Is(err, microstore.ErrNotFound):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	case len(records) == 0:
+		return nil, nil
+	}
+
+	var sc storedClaim
+	if err := json.Unmarshal(records[0].Value, &sc); err != nil {
+		return nil, err
+	}
+	return &sc, nil
+}
+
+// memoryClaimer claims the keys within the process
+type memoryClaimer struct {
+	ttl time.Duration
+
+	mu     sync.Mutex
+	claims map[string]time.Time
+}
+
+func (c *memoryClaimer) claim(key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if expires, ok := c.claims[key]; ok && now.Before(expires) {
+		return false, nil
+	}
+	c.claims[key] = now.Add(c.ttl)
+	return true, nil
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	libregraph "github.com/owncloud/libre-graph-api-go"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/l10n"
+	ehmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/eventhistory/v0"
+	ehsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/eventhistory/v0"
+	"github.com/owncloud/ocis/v2/services/activitylog/pkg/event"
+)
+
+// SendDigests publishes the activity digests which are due
+func (a *ActivitylogService) SendDigests() error {
+	keys, err := a.store.List()
+	if err != nil {
+		return fmt.Errorf("could not list subscribers: %w", err)
+	}
+
+	now := time.Now()
+	for _, key := range keys {
+		uid, ok := strings.CutPrefix(key, _digestKeyPrefix)
+		if !ok {
+			continue
+		}
+		if err := a.sendDigest(uid, now); err != nil {
+			a.log.Error().Err(err).Str("userid", uid).Msg("could not send activity digest")
+		}
+	}
+	return nil
+}
+
+func (a *ActivitylogService) sendDigest(uid string, now time.Time) error {
+	sub, err := a.subscriber(uid)
+	if err != nil {
+		return err
+	}
+
+	gwc, err := a.gws.Next()
+	if err != nil {
+		return fmt.Errorf("cant get gateway client: %w", err)
+	}
+
+	ctx, err := utils.GetServiceUserContext(a.cfg.ServiceAccount.ServiceAccountID, gwc, a.cfg.ServiceAccount.ServiceAccountSecret)
+	if err != nil {
+		return fmt.Errorf("cant get service user context: %w", err)
+	}
+
+	interval, err := a.digestInterval(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if _, due := digestDue(interval, sub.LastSent, now, a.cfg.Digest.CheckInterval); !due {
+		return nil
+	}
+
+	// every replica checks the digests, the one claiming the digest sends it. The subscriber is read again in case
+	// another replica sent the digest before.
+	claimed, err := a.claimer.claim(digestKey(uid))
+	if err != nil || !claimed {
+		return err
+	}
+	sub, err = a.subscriber(uid)
+	if err != nil {
+		return err
+	}
+	since, due := digestDue(interval, sub.LastSent, now, a.cfg.Digest.CheckInterval)
+	if !due {
+		return nil
+	}
+
+	ids, _, err := a.watchedResources(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	userID := &user.UserId{OpaqueId: uid}
+	spaces, err := memberSpaces(ctx, gwc, userID)
+	if err != nil {
+		return err
+	}
+
+	loc := l10n.MustGetUserLocale(ctx, uid, "", a.valService)
+	t := l10n.NewTranslatorFromCommonConfig("en", _domain, "", _localeFS, _localeSubPath)
+
+	digest := event.ActivityDigest{
+		Recipient: userID,
+		Interval:  interval,
+		Since:     since,
+		Timestamp: now,
+	}
+	for _, id := range ids {
+		rid, err := storagespace.ParseID(id)
+		if err != nil {
+			continue
+		}
+		// the user left the space of the resource
+		if _, ok := spaces[storagespace.FormatStorageID(rid.GetStorageId(), rid.GetSpaceId())]; !ok {
+			continue
+		}
+		info, err := utils.GetResource(ctx, &provider.Reference{ResourceId: &rid}, gwc)
+		if err != nil {
+			continue
+		}
+
+		activities, err := a.digestActivities(ctx, id, since, now)
+		if err != nil {
+			return err
+		}
+		if len(activities) == 0 {
+			continue
+		}
+
+		resource := event.DigestResource{ID: id, Name: info.GetName()}
+		for _, act := range activities {
+			resource.Activities = append(resource.Activities, renderMessage(t.Translate(act.Template.Message, loc), act.Template.Variables))
+		}
+		digest.Resources = append(digest.Resources, resource)
+	}
+
+	if len(digest.Resources) > 0 {
+		if err := events.Publish(ctx, a.stream, digest); err != nil {
+			return fmt.Errorf("could not publish activity digest: %w", err)
+		}
+	}
+
+	return a.saveSubscriber(uid, subscriber{LastSent: now})
+}
+
+// digestActivities returns the activities of the resource between the given times, the newest first.
+// The messages of the activities are not translated.
+func (a *ActivitylogService) digestActivities(ctx context.Context, id string, since, until time.Time) ([]libregraph.Activity, error) {
+	raw, err := a.activitiesByKey(id)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(raw, func(i, j int) bool {
+		return raw[i].Timestamp.After(raw[j].Timestamp)
+	})
+
+	ids := make([]string, 0, len(raw))
+	for _, act := range raw {
+		if !act.Timestamp.After(since) || act.Timestamp.After(until) {
+			continue
+		}
+		ids = append(ids, act.EventID)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	evRes, err := a.evHistory.GetEvents(ctx, &ehsvc.GetEventsRequest{Ids: ids})
+	if err != nil {
+		return nil, fmt.Errorf("could not get events: %w", err)
+	}
+
+	evs := make(map[string]*ehmsg.Event, len(evRes.GetEvents()))
+	for _, e := range evRes.GetEvents() {
+		evs[e.GetId()] = e
+	}
+
+	var activities []libregraph.Activity
+	for _, id := range ids {
+		e, ok := evs[id]
+		if !ok {
+			continue
+		}
+
+		if a.cfg.Digest.MaxActivities > 0 && len(activities) >= a.cfg.Digest.MaxActivities {
+			break
+		}
+
+		message, ts, vars, err := a.eventActivity(ctx, e)
+		if err != nil {
+			continue
+		}
+		activities = append(activities, NewActivity(message, ts, e.GetId(), vars))
+	}
+	return activities, nil
+}
+
+// digestDue returns whether the digest of the interval is due and the time of the oldest activity it contains.
+// A digest is due within half of the check interval, so the digests don't drift by the check interval every time.
+func digestDue(interval string, lastSent, now time.Time, checkInterval time.Duration) (time.Time, bool) {
+	var period time.Duration
+	switch interval {
+	case DigestDaily:
+		period = 24 * time.Hour
+	case DigestWeekly:
+		period = 7 * 24 * time.Hour
+	default:
+		return time.Time{}, false
+	}
+
+	if now.Sub(lastSent) < period-checkInterval/2 {
+		return time.Time{}, false
+	}
+
+	// a digest covers one interval at most, e.g. after the digests were disabled for some time
+	since := lastSent
+	if oldest := now.Add(-period); since.Before(oldest) {
+		since = oldest
+	}
+	return since, true
+}
+
+func (a *ActivitylogService) runDigests() {
+	ticker := time.NewTicker(a.cfg.Digest.CheckInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := a.SendDigests(); err != nil {
+			a.log.Error().Err(err).Msg("could not send activity digests")
+		}
+	}
+}
@@ -1,15 +1,20 @@
 package service
 
 import (
+	"context"
 	"embed"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
 	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
 	revactx "github.com/cs3org/reva/v2/pkg/ctx"
 	"github.com/cs3org/reva/v2/pkg/events"
@@ -20,11 +25,18 @@ import (
 	"github.com/owncloud/ocis/v2/ocis-pkg/ast"
 	"github.com/owncloud/ocis/v2/ocis-pkg/kql"
 	"github.com/owncloud/ocis/v2/ocis-pkg/l10n"
+	"github.com/owncloud/ocis/v2/ocis-pkg/roles"
 	ehmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/eventhistory/v0"
 	ehsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/eventhistory/v0"
+	settings "github.com/owncloud/ocis/v2/services/settings/pkg/service/v0"
 )
 
 var (
+	// errUnknownEvent is returned for events which are not registered or can't be unmarshalled
+	errUnknownEvent = errors.New("unknown event")
+	// errForbidden is returned when the user may not read the activities of a space or user
+	errForbidden = errors.New("forbidden")
+
 	//go:embed l10n/locale
 	_localeFS embed.FS
 
@@ -40,36 +52,108 @@ func (s *ActivitylogService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.mux.ServeHTTP(w, r)
 }
 
-// HandleGetItemActivities handles the request to get the activities of an item.
+// HandleGetItemActivities handles the request to get the activities of an item, a space or a user.
 func (s *ActivitylogService) HandleGetItemActivities(w http.ResponseWriter, r *http.Request) {
+	resp, ok := s.getActivities(w, r)
+	if !ok {
+		return
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		s.log.Error().Err(err).Msg("error marshalling activities")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := w.Write(b); err != nil {
+		s.log.Error().Err(err).Msg("error writing response")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// HandleExportActivities handles the request to export the activities of an item, a space or a user as CSV or JSON file.
+func (s *ActivitylogService) HandleExportActivities(w http.ResponseWriter, r *http.Request) {
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	switch format {
+	case "":
+		format = "json"
+	case "json", "csv":
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("unsupported export format: " + format))
+		return
+	}
+
+	resp, ok := s.getActivities(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="activities.`+format+`"`)
+	var err error
+	switch format {
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		err = writeCSV(w, resp.Activities)
+	case "json":
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(resp)
+	}
+	if err != nil {
+		s.log.Error().Err(err).Msg("error writing export")
+	}
+}
+
+// getActivities returns the activities matching the kql query of the request. It writes the error response if it fails.
+func (s *ActivitylogService) getActivities(w http.ResponseWriter, r *http.Request) (GetActivitiesResponse, bool) {
+	var resp GetActivitiesResponse
+
 	ctx := r.Context()
 	ctx = metadata.AppendToOutgoingContext(ctx, revactx.TokenHeader, r.Header.Get("X-Access-Token"))
 
 	activeUser, ok := revactx.ContextGetUser(ctx)
 	if !ok {
 		w.WriteHeader(http.StatusUnauthorized)
-		return
+		return resp, false
 	}
 
-	rid, limit, rawActivityAccepted, activityAccepted, err := s.getFilters(r.URL.Query().Get("kql"))
+	filter, err := s.getFilters(r.URL.Query().Get("kql"))
 	if err != nil {
 		s.log.Info().Str("query", r.URL.Query().Get("kql")).Err(err).Msg("error getting filters")
 		_, _ = w.Write([]byte(err.Error()))
 		w.WriteHeader(http.StatusBadRequest)
-		return
+		return resp, false
+	}
+
+	switch err := s.checkFeedAccess(ctx, activeUser, filter); {
+	case errors.Is(err, errForbidden):
+		w.WriteHeader(http.StatusForbidden)
+		return resp, false
+	case err != nil:
+		s.log.Error().Err(err).Msg("error checking access to the activities")
+		w.WriteHeader(http.StatusInternalServerError)
+		return resp, false
 	}
 
-	raw, err := s.Activities(rid)
+	raw, err := s.activitiesByKey(filter.key)
 	if err != nil {
 		s.log.Error().Err(err).Msg("error getting activities")
 		w.WriteHeader(http.StatusInternalServerError)
-		return
+		return resp, false
 	}
 
+	// the newest activities come first
+	sort.SliceStable(raw, func(i, j int) bool {
+		return raw[i].Timestamp.After(raw[j].Timestamp)
+	})
+
 	ids := make([]string, 0, len(raw))
 	toDelete := make(map[string]struct{}, len(raw))
 	for _, a := range raw {
-		if !rawActivityAccepted(a) {
+		if !filter.rawAccepted(a) {
 			continue
 		}
 		ids = append(ids, a.EventID)
@@ -80,85 +164,42 @@ func (s *ActivitylogService) HandleGetItemActivities(w http.ResponseWriter, r *h
 	if err != nil {
 		s.log.Error().Err(err).Msg("error getting events")
 		w.WriteHeader(http.StatusInternalServerError)
-		return
+		return resp, false
 	}
 
-	var resp GetActivitiesResponse
+	evs := make(map[string]*ehmsg.Event, len(evRes.GetEvents()))
 	for _, e := range evRes.GetEvents() {
+		evs[e.GetId()] = e
 		delete(toDelete, e.GetId())
+	}
 
-		if limit != 0 && len(resp.Activities) >= limit {
+	skipped := 0
+	for _, id := range ids {
+		e, ok := evs[id]
+		if !ok {
 			continue
 		}
 
-		if !activityAccepted(e) {
-			continue
+		if filter.limit != 0 && len(resp.Activities) >= filter.limit {
+			break
 		}
 
-		var (
-			message string
-			ts      time.Time
-			vars    map[string]interface{}
-		)
+		if !filter.accepted(e) {
+			continue
+		}
 
-		switch ev := s.unwrapEvent(e).(type) {
-		case nil:
+		message, ts, vars, err := s.eventActivity(ctx, e)
+		switch {
+		case errors.Is(err, errUnknownEvent):
 			// error already logged in unwrapEvent
 			continue
-		case events.UploadReady:
-			message = MessageResourceCreated
-			ts = utils.TSToTime(ev.Timestamp)
-			vars, err = s.GetVars(ctx, WithResource(ev.FileRef, true), WithUser(ev.ExecutingUser.GetId(), ev.ExecutingUser.GetDisplayName()))
-		case events.FileTouched:
-			message = MessageResourceCreated
-			ts = utils.TSToTime(ev.Timestamp)
-			vars, err = s.GetVars(ctx, WithResource(ev.Ref, true), WithUser(ev.Executant, ""))
-		case events.ContainerCreated:
-			message = MessageResourceCreated
-			ts = utils.TSToTime(ev.Timestamp)
-			vars, err = s.GetVars(ctx, WithResource(ev.Ref, true), WithUser(ev.Executant, ""))
-		case events.ItemTrashed:
-			message = MessageResourceTrashed
-			ts = utils.TSToTime(ev.Timestamp)
-			vars, err = s.GetVars(ctx, WithTrashedResource(ev.Ref, ev.ID), WithUser(ev.Executant, ""), WithSpace(toSpace(ev.Ref)))
-		case events.ItemMoved:
-			switch isRename(ev.OldReference, ev.Ref) {
-			case true:
-				message = MessageResourceRenamed
-				vars, err = s.GetVars(ctx, WithResource(ev.Ref, false), WithOldResource(ev.OldReference), WithUser(ev.Executant, ""))
-			case false:
-				message = MessageResourceMoved
-				vars, err = s.GetVars(ctx, WithResource(ev.Ref, true), WithUser(ev.Executant, ""))
-			}
-			ts = utils.TSToTime(ev.Timestamp)
-		case events.ShareCreated:
-			message = MessageShareCreated
-			ts = utils.TSToTime(ev.CTime)
-			vars, err = s.GetVars(ctx, WithResource(toRef(ev.ItemID), false), WithUser(ev.Executant, ""), WithSharee(ev.GranteeUserID, ev.GranteeGroupID))
-		case events.ShareRemoved:
-			message = MessageShareDeleted
-			ts = ev.Timestamp
-			vars, err = s.GetVars(ctx, WithResource(toRef(ev.ItemID), false), WithUser(ev.Executant, ""), WithSharee(ev.GranteeUserID, ev.GranteeGroupID))
-		case events.LinkCreated:
-			message = MessageLinkCreated
-			ts = utils.TSToTime(ev.CTime)
-			vars, err = s.GetVars(ctx, WithResource(toRef(ev.ItemID), false), WithUser(ev.Executant, ""))
-		case events.LinkRemoved:
-			message = MessageLinkDeleted
-			ts = utils.TSToTime(ev.Timestamp)
-			vars, err = s.GetVars(ctx, WithResource(toRef(ev.ItemID), false), WithUser(ev.Executant, ""))
-		case events.SpaceShared:
-			message = MessageSpaceShared
-			ts = ev.Timestamp
-			vars, err = s.GetVars(ctx, WithSpace(ev.ID), WithUser(ev.Executant, ""), WithSharee(ev.GranteeUserID, ev.GranteeGroupID))
-		case events.SpaceUnshared:
-			message = MessageSpaceUnshared
-			ts = ev.Timestamp
-			vars, err = s.GetVars(ctx, WithSpace(ev.ID), WithUser(ev.Executant, ""), WithSharee(ev.GranteeUserID, ev.GranteeGroupID))
+		case err != nil:
+			s.log.Error().Err(err).Msg("error getting response data")
+			continue
 		}
 
-		if err != nil {
-			s.log.Error().Err(err).Msg("error getting response data")
+		if skipped < filter.skip {
+			skipped++
 			continue
 		}
 
@@ -172,26 +213,73 @@ func (s *ActivitylogService) HandleGetItemActivities(w http.ResponseWriter, r *h
 	// delete activities in separate go routine
 	if len(toDelete) > 0 {
 		go func() {
-			err := s.RemoveActivities(rid, toDelete)
+			err := s.removeActivities(filter.key, toDelete)
 			if err != nil {
 				s.log.Error().Err(err).Msg("error removing activities")
 			}
 		}()
 	}
 
-	b, err := json.Marshal(resp)
-	if err != nil {
-		s.log.Error().Err(err).Msg("error marshalling activities")
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
+	return resp, true
+}
 
-	if _, err := w.Write(b); err != nil {
-		s.log.Error().Err(err).Msg("error writing response")
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+// eventActivity returns the untranslated message, the time and the variables of the activity the event represents
+func (s *ActivitylogService) eventActivity(ctx context.Context, e *ehmsg.Event) (message string, ts time.Time, vars map[string]interface{}, err error) {
+	switch ev := s.unwrapEvent(e).(type) {
+	case nil:
+		return "", time.Time{}, nil, errUnknownEvent
+	case events.UploadReady:
+		message = MessageResourceCreated
+		ts = utils.TSToTime(ev.Timestamp)
+		vars, err = s.GetVars(ctx, WithResource(ev.FileRef, true), WithUser(ev.ExecutingUser.GetId(), ev.ExecutingUser.GetDisplayName()))
+	case events.FileTouched:
+		message = MessageResourceCreated
+		ts = utils.TSToTime(ev.Timestamp)
+		vars, err = s.GetVars(ctx, WithResource(ev.Ref, true), WithUser(ev.Executant, ""))
+	case events.ContainerCreated:
+		message = MessageResourceCreated
+		ts = utils.TSToTime(ev.Timestamp)
+		vars, err = s.GetVars(ctx, WithResource(ev.Ref, true), WithUser(ev.Executant, ""))
+	case events.ItemTrashed:
+		message = MessageResourceTrashed
+		ts = utils.TSToTime(ev.Timestamp)
+		vars, err = s.GetVars(ctx, WithTrashedResource(ev.Ref, ev.ID), WithUser(ev.Executant, ""), WithSpace(toSpace(ev.Ref)))
+	case events.ItemMoved:
+		switch isRename(ev.OldReference, ev.Ref) {
+		case true:
+			message = MessageResourceRenamed
+			vars, err = s.GetVars(ctx, WithResource(ev.Ref, false), WithOldResource(ev.OldReference), WithUser(ev.Executant, ""))
+		case false:
+			message = MessageResourceMoved
+			vars, err = s.GetVars(ctx, WithResource(ev.Ref, true), WithUser(ev.Executant, ""))
+		}
+		ts = utils.TSToTime(ev.Timestamp)
+	case events.ShareCreated:
+		message = MessageShareCreated
+		ts = utils.TSToTime(ev.CTime)
+		vars, err = s.GetVars(ctx, WithResource(toRef(ev.ItemID), false), WithUser(ev.Executant, ""), WithSharee(ev.GranteeUserID, ev.GranteeGroupID))
+	case events.ShareRemoved:
+		message = MessageShareDeleted
+		ts = ev.Timestamp
+		vars, err = s.GetVars(ctx, WithResource(toRef(ev.ItemID), false), WithUser(ev.Executant, ""), WithSharee(ev.GranteeUserID, ev.GranteeGroupID))
+	case events.LinkCreated:
+		message = MessageLinkCreated
+		ts = utils.TSToTime(ev.CTime)
+		vars, err = s.GetVars(ctx, WithResource(toRef(ev.ItemID), false), WithUser(ev.Executant, ""))
+	case events.LinkRemoved:
+		message = MessageLinkDeleted
+		ts = utils.TSToTime(ev.Timestamp)
+		vars, err = s.GetVars(ctx, WithResource(toRef(ev.ItemID), false), WithUser(ev.Executant, ""))
+	case events.SpaceShared:
+		message = MessageSpaceShared
+		ts = ev.Timestamp
+		vars, err = s.GetVars(ctx, WithSpace(ev.ID), WithUser(ev.Executant, ""), WithSharee(ev.GranteeUserID, ev.GranteeGroupID))
+	case events.SpaceUnshared:
+		message = MessageSpaceUnshared
+		ts = ev.Timestamp
+		vars, err = s.GetVars(ctx, WithSpace(ev.ID), WithUser(ev.Executant, ""), WithSharee(ev.GranteeUserID, ev.GranteeGroupID))
 	}
-	w.WriteHeader(http.StatusOK)
+	return message, ts, vars, err
 }
 
 func (s *ActivitylogService) unwrapEvent(e *ehmsg.Event) interface{} {
@@ -210,18 +298,81 @@ func (s *ActivitylogService) unwrapEvent(e *ehmsg.Event) interface{} {
 	return einterface
 }
 
-func (s *ActivitylogService) getFilters(query string) (*provider.ResourceId, int, func(RawActivity) bool, func(*ehmsg.Event) bool, error) {
+// checkFeedAccess returns errForbidden if the user may not read the space or user feed of the filter. Users can read
+// their own feed and the feeds of the spaces they are members of, only admins can read the feeds of other users. The
+// activities of single items are checked when they are read.
+func (s *ActivitylogService) checkFeedAccess(ctx context.Context, u *user.User, filter *activityFilter) error {
+	switch {
+	case filter.userID != "":
+		if filter.userID == u.GetId().GetOpaqueId() {
+			return nil
+		}
+		admin, err := s.isAdmin(ctx, u)
+		switch {
+		case err != nil:
+			return err
+		case !admin:
+			return errForbidden
+		}
+	case filter.spaceRoot != nil:
+		gwc, err := s.gws.Next()
+		if err != nil {
+			return err
+		}
+		res, err := gwc.Stat(ctx, &provider.StatRequest{Ref: &provider.Reference{ResourceId: filter.spaceRoot}})
+		switch {
+		case err != nil:
+			return err
+		case res.GetStatus().GetCode() == rpc.Code_CODE_NOT_FOUND, res.GetStatus().GetCode() == rpc.Code_CODE_PERMISSION_DENIED:
+			return errForbidden
+		case res.GetStatus().GetCode() != rpc.Code_CODE_OK:
+			return fmt.Errorf("could not stat space root: %s", res.GetStatus().GetMessage())
+		}
+	}
+	return nil
+}
+
+// isAdmin returns true if the user has the account management permission
+func (s *ActivitylogService) isAdmin(ctx context.Context, u *user.User) (bool, error) {
+	roleIDs, ok := roles.ReadRoleIDsFromContext(ctx)
+	if !ok {
+		var err error
+		roleIDs, err = s.roles.FindRoleIDsForUser(ctx, u.GetId().GetOpaqueId())
+		if err != nil {
+			return false, err
+		}
+	}
+	return s.roles.FindPermissionByID(ctx, roleIDs, settings.AccountManagementPermissionID) != nil, nil
+}
+
+// activityFilter selects the activities of a request
+type activityFilter struct {
+	// key is the store key of the item, space or user to get the activities of
+	key string
+	// userID is set for the feed of a user
+	userID string
+	// spaceRoot is set for the feed of a space
+	spaceRoot   *provider.ResourceId
+	skip        int
+	limit       int
+	rawAccepted func(RawActivity) bool
+	accepted    func(*ehmsg.Event) bool
+}
+
+func (s *ActivitylogService) getFilters(query string) (*activityFilter, error) {
 	qast, err := kql.Builder{}.Build(query)
 	if err != nil {
-		return nil, 0, nil, nil, err
+		return nil, err
 	}
 
 	prefilters := make([]func(RawActivity) bool, 0)
 	postfilters := make([]func(*ehmsg.Event) bool, 0)
 
 	var (
-		itemID string
-		limit  int
+		itemID  string
+		spaceID string
+		userID  string
+		filter  = &activityFilter{}
 	)
 
 	for _, n := range qast.Nodes {
@@ -230,10 +381,14 @@ func (s *ActivitylogService) getFilters(query string) (*provider.ResourceId, int
 			switch strings.ToLower(v.Key) {
 			case "itemid":
 				itemID = v.Value
+			case "spaceid":
+				spaceID = v.Value
+			case "userid":
+				userID = v.Value
 			case "depth":
 				depth, err := strconv.Atoi(v.Value)
 				if err != nil {
-					return nil, limit, nil, nil, err
+					return nil, err
 				}
 
 				prefilters = append(prefilters, func(a RawActivity) bool {
@@ -242,10 +397,17 @@ func (s *ActivitylogService) getFilters(query string) (*provider.ResourceId, int
 			case "limit":
 				l, err := strconv.Atoi(v.Value)
 				if err != nil {
-					return nil, limit, nil, nil, err
+					return nil, err
+				}
+
+				filter.limit = l
+			case "skip":
+				skip, err := strconv.Atoi(v.Value)
+				if err != nil {
+					return nil, err
 				}
 
-				limit = l
+				filter.skip = skip
 			}
 		case *ast.DateTimeNode:
 			switch v.Operator.Value {
@@ -260,16 +422,35 @@ func (s *ActivitylogService) getFilters(query string) (*provider.ResourceId, int
 			}
 		case *ast.OperatorNode:
 			if v.Value != "AND" {
-				return nil, limit, nil, nil, errors.New("only AND operator is supported")
+				return nil, errors.New("only AND operator is supported")
 			}
 		}
 	}
 
-	rid, err := storagespace.ParseID(itemID)
-	if err != nil {
-		return nil, limit, nil, nil, err
+	switch {
+	case itemID != "" && spaceID == "" && userID == "":
+		rid, err := storagespace.ParseID(itemID)
+		if err != nil {
+			return nil, err
+		}
+		filter.key = storagespace.FormatResourceID(rid)
+	case spaceID != "" && itemID == "" && userID == "":
+		// the activities of a space are stored on its root
+		rid, err := storagespace.ParseID(spaceID)
+		if err != nil {
+			return nil, err
+		}
+		rid.OpaqueId = rid.GetSpaceId()
+		filter.key = storagespace.FormatResourceID(rid)
+		filter.spaceRoot = &rid
+	case userID != "" && itemID == "" && spaceID == "":
+		filter.key = userKey(&user.UserId{OpaqueId: userID})
+		filter.userID = userID
+	default:
+		return nil, errors.New("exactly one of itemid, spaceid or userid is required")
 	}
-	pref := func(a RawActivity) bool {
+
+	filter.rawAccepted = func(a RawActivity) bool {
 		for _, f := range prefilters {
 			if !f(a) {
 				return false
@@ -277,7 +458,7 @@ func (s *ActivitylogService) getFilters(query string) (*provider.ResourceId, int
 		}
 		return true
 	}
-	postf := func(e *ehmsg.Event) bool {
+	filter.accepted = func(e *ehmsg.Event) bool {
 		for _, f := range postfilters {
 			if !f(e) {
 				return false
@@ -285,7 +466,7 @@ func (s *ActivitylogService) getFilters(query string) (*provider.ResourceId, int
 		}
 		return true
 	}
-	return &rid, limit, pref, postf, nil
+	return filter, nil
 }
 
 // returns true if this is just a rename
@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
+	"io"
 	"path/filepath"
+	"strings"
 	"time"
 
 	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
@@ -215,3 +218,50 @@ func (s *ActivitylogService) GetVars(ctx context.Context, opts ...ActivityOption
 
 	return vars, nil
 }
+
+// writeCSV writes the activities as CSV with a header row, the placeholders of the messages are replaced by the names
+func writeCSV(w io.Writer, activities []libregraph.Activity) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"time", "id", "message", "user_id", "user", "resource_id", "resource", "space_id", "space"}); err != nil {
+		return err
+	}
+
+	for _, a := range activities {
+		vars := a.Template.Variables
+		actor, _ := vars["user"].(Actor)
+		resource, _ := vars["resource"].(Resource)
+		space, _ := vars["space"].(Resource)
+
+		if err := cw.Write([]string{
+			a.Times.RecordedTime.UTC().Format(time.RFC3339),
+			a.Id,
+			renderMessage(a.Template.Message, vars),
+			actor.ID,
+			actor.DisplayName,
+			resource.ID,
+			resource.Name,
+			space.ID,
+			space.Name,
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// renderMessage replaces the placeholders of the message by the names of the variables
+func renderMessage(message string, vars map[string]interface{}) string {
+	for k, v := range vars {
+		var name string
+		switch v := v.(type) {
+		case Resource:
+			name = v.Name
+		case Actor:
+			name = v.DisplayName
+		}
+		message = strings.ReplaceAll(message, "{"+k+"}", name)
+	}
+	return message
+}
@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"path/filepath"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
 	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
 	"github.com/cs3org/reva/v2/pkg/events"
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
@@ -19,6 +22,7 @@ import (
 	microstore "go-micro.dev/v4/store"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/ocis-pkg/roles"
 	ehsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/eventhistory/v0"
 	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
 	"github.com/owncloud/ocis/v2/services/activitylog/pkg/config"
@@ -36,12 +40,16 @@ type ActivitylogService struct {
 	cfg        *config.Config
 	log        log.Logger
 	events     <-chan events.Event
+	stream     events.Stream
 	store      microstore.Store
 	gws        pool.Selectable[gateway.GatewayAPIClient]
 	mux        *chi.Mux
 	evHistory  ehsvc.EventHistoryService
 	valService settingssvc.ValueService
+	roles      *roles.Manager
+	retention  config.Retention
 	lock       sync.RWMutex
+	claimer    claimer
 
 	registeredEvents map[string]events.Unmarshaller
 }
@@ -70,16 +78,28 @@ func New(opts ...Option) (*ActivitylogService, error) {
 		log:              o.Logger,
 		cfg:              o.Config,
 		events:           ch,
+		stream:           o.Stream,
 		store:            o.Store,
 		gws:              o.GatewaySelector,
 		mux:              o.Mux,
 		evHistory:        o.HistoryClient,
 		valService:       o.ValueClient,
+		retention:        o.Config.Retention,
 		lock:             sync.RWMutex{},
 		registeredEvents: make(map[string]events.Unmarshaller),
 	}
 
+	m := roles.NewManager(
+		roles.Logger(o.Logger),
+		roles.RoleService(o.RoleClient),
+	)
+	s.roles = &m
+
 	s.mux.Get("/graph/v1beta1/extensions/org.libregraph/activities", s.HandleGetItemActivities)
+	s.mux.Get("/graph/v1beta1/extensions/org.libregraph/activities/export", s.HandleExportActivities)
+	s.mux.Get("/graph/v1beta1/extensions/org.libregraph/activities/subscriptions", s.HandleGetSubscriptions)
+	s.mux.Put("/graph/v1beta1/extensions/org.libregraph/activities/subscriptions/{itemid}", s.HandleWatch)
+	s.mux.Delete("/graph/v1beta1/extensions/org.libregraph/activities/subscriptions/{itemid}", s.HandleUnwatch)
 
 	for _, e := range o.RegisteredEvents {
 		typ := reflect.TypeOf(e)
@@ -88,41 +108,72 @@ func New(opts ...Option) (*ActivitylogService, error) {
 
 	go s.Run()
 
+	if s.retention.MaxAge > 0 && s.retention.PruneInterval > 0 {
+		go s.runRetention()
+	}
+
+	if s.cfg.Digest.CheckInterval > 0 {
+		// the claims expire before the next check, a digest which could not be sent is retried then
+		s.claimer = newClaimer(s.cfg.Store, s.cfg.Digest.CheckInterval/2)
+		go s.runDigests()
+	}
+
 	return s, nil
 }
 
 // Run runs the service
 func (a *ActivitylogService) Run() {
 	for e := range a.events {
-		var err error
+		var (
+			err       error
+			executant *user.UserId
+			ts        time.Time
+		)
 		switch ev := e.Event.(type) {
 		case events.UploadReady:
-			err = a.AddActivity(ev.FileRef, e.ID, utils.TSToTime(ev.Timestamp))
+			executant, ts = ev.ExecutingUser.GetId(), utils.TSToTime(ev.Timestamp)
+			err = a.AddActivity(ev.FileRef, e.ID, ts)
 		case events.FileTouched:
-			err = a.AddActivity(ev.Ref, e.ID, utils.TSToTime(ev.Timestamp))
+			executant, ts = ev.Executant, utils.TSToTime(ev.Timestamp)
+			err = a.AddActivity(ev.Ref, e.ID, ts)
 		case events.ContainerCreated:
-			err = a.AddActivity(ev.Ref, e.ID, utils.TSToTime(ev.Timestamp))
+			executant, ts = ev.Executant, utils.TSToTime(ev.Timestamp)
+			err = a.AddActivity(ev.Ref, e.ID, ts)
 		case events.ItemTrashed:
-			err = a.AddActivityTrashed(ev.ID, ev.Ref, e.ID, utils.TSToTime(ev.Timestamp))
+			executant, ts = ev.Executant, utils.TSToTime(ev.Timestamp)
+			err = a.AddActivityTrashed(ev.ID, ev.Ref, e.ID, ts)
 		case events.ItemMoved:
-			err = a.AddActivity(ev.Ref, e.ID, utils.TSToTime(ev.Timestamp))
+			executant, ts = ev.Executant, utils.TSToTime(ev.Timestamp)
+			err = a.AddActivity(ev.Ref, e.ID, ts)
 		case events.ShareCreated:
-			err = a.AddActivity(toRef(ev.ItemID), e.ID, utils.TSToTime(ev.CTime))
+			executant, ts = ev.Executant, utils.TSToTime(ev.CTime)
+			err = a.AddActivity(toRef(ev.ItemID), e.ID, ts)
 		case events.ShareRemoved:
-			err = a.AddActivity(toRef(ev.ItemID), e.ID, ev.Timestamp)
+			executant, ts = ev.Executant, ev.Timestamp
+			err = a.AddActivity(toRef(ev.ItemID), e.ID, ts)
 		case events.LinkCreated:
-			err = a.AddActivity(toRef(ev.ItemID), e.ID, utils.TSToTime(ev.CTime))
+			executant, ts = ev.Executant, utils.TSToTime(ev.CTime)
+			err = a.AddActivity(toRef(ev.ItemID), e.ID, ts)
 		case events.LinkRemoved:
-			err = a.AddActivity(toRef(ev.ItemID), e.ID, utils.TSToTime(ev.Timestamp))
+			executant, ts = ev.Executant, utils.TSToTime(ev.Timestamp)
+			err = a.AddActivity(toRef(ev.ItemID), e.ID, ts)
 		case events.SpaceShared:
-			err = a.AddSpaceActivity(ev.ID, e.ID, ev.Timestamp)
+			executant, ts = ev.Executant, ev.Timestamp
+			err = a.AddSpaceActivity(ev.ID, e.ID, ts)
 		case events.SpaceUnshared:
-			err = a.AddSpaceActivity(ev.ID, e.ID, ev.Timestamp)
+			executant, ts = ev.Executant, ev.Timestamp
+			err = a.AddSpaceActivity(ev.ID, e.ID, ts)
 		}
 
 		if err != nil {
 			a.log.Error().Err(err).Interface("event", e).Msg("could not process event")
 		}
+
+		if executant.GetOpaqueId() != "" {
+			if err := a.AddUserActivity(executant, e.ID, ts); err != nil {
+				a.log.Error().Err(err).Interface("event", e).Msg("could not add user activity")
+			}
+		}
 	}
 }
 
@@ -185,20 +236,38 @@ func (a *ActivitylogService) AddSpaceActivity(spaceID *provider.StorageSpaceId,
 
 }
 
+// AddUserActivity adds the activity to the activities of the user who executed it
+func (a *ActivitylogService) AddUserActivity(uid *user.UserId, eventID string, timestamp time.Time) error {
+	return a.storeActivity(userKey(uid), eventID, 0, timestamp)
+}
+
 // Activities returns the activities for the given resource
 func (a *ActivitylogService) Activities(rid *provider.ResourceId) ([]RawActivity, error) {
+	return a.activitiesByKey(storagespace.FormatResourceID(*rid))
+}
+
+// UserActivities returns the activities executed by the given user
+func (a *ActivitylogService) UserActivities(uid *user.UserId) ([]RawActivity, error) {
+	return a.activitiesByKey(userKey(uid))
+}
+
+func (a *ActivitylogService) activitiesByKey(key string) ([]RawActivity, error) {
 	a.lock.RLock()
 	defer a.lock.RUnlock()
 
-	return a.activities(rid)
+	return a.activities(key)
 }
 
 // RemoveActivities removes the activities from the given resource
 func (a *ActivitylogService) RemoveActivities(rid *provider.ResourceId, toDelete map[string]struct{}) error {
+	return a.removeActivities(storagespace.FormatResourceID(*rid), toDelete)
+}
+
+func (a *ActivitylogService) removeActivities(key string, toDelete map[string]struct{}) error {
 	a.lock.Lock()
 	defer a.lock.Unlock()
 
-	curActivities, err := a.activities(rid)
+	curActivities, err := a.activities(key)
 	if err != nil {
 		return err
 	}
@@ -216,15 +285,94 @@ func (a *ActivitylogService) RemoveActivities(rid *provider.ResourceId, toDelete
 	}
 
 	return a.store.Write(&microstore.Record{
-		Key:   storagespace.FormatResourceID(*rid),
+		Key:   key,
 		Value: b,
 	})
 }
 
-func (a *ActivitylogService) activities(rid *provider.ResourceId) ([]RawActivity, error) {
-	resourceID := storagespace.FormatResourceID(*rid)
+// PruneActivities applies the retention policy to the stored activities of all resources and users
+func (a *ActivitylogService) PruneActivities() error {
+	keys, err := a.store.List()
+	if err != nil {
+		return fmt.Errorf("could not list activities: %w", err)
+	}
 
-	records, err := a.store.Read(resourceID)
+	for _, key := range keys {
+		// the users watching folders or spaces are stored next to the activities
+		if strings.HasPrefix(key, _digestKeyPrefix) {
+			continue
+		}
+		if err := a.pruneActivities(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *ActivitylogService) pruneActivities(key string) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	activities, err := a.activities(key)
+	if err != nil {
+		return err
+	}
+
+	pruned := a.prune(activities)
+	switch {
+	case len(pruned) == len(activities):
+		return nil
+	case len(pruned) == 0:
+		return a.store.Delete(key)
+	}
+
+	b, err := json.Marshal(pruned)
+	if err != nil {
+		return err
+	}
+
+	return a.store.Write(&microstore.Record{
+		Key:   key,
+		Value: b,
+	})
+}
+
+// prune removes the activities which are older than the maximum age and the oldest activities exceeding the maximum number
+func (a *ActivitylogService) prune(activities []RawActivity) []RawActivity {
+	if a.retention.MaxAge > 0 {
+		cutoff := time.Now().Add(-a.retention.MaxAge)
+		kept := make([]RawActivity, 0, len(activities))
+		for _, act := range activities {
+			if act.Timestamp.After(cutoff) {
+				kept = append(kept, act)
+			}
+		}
+		activities = kept
+	}
+
+	if a.retention.MaxActivities > 0 && len(activities) > a.retention.MaxActivities {
+		sort.SliceStable(activities, func(i, j int) bool {
+			return activities[i].Timestamp.Before(activities[j].Timestamp)
+		})
+		activities = activities[len(activities)-a.retention.MaxActivities:]
+	}
+
+	return activities
+}
+
+func (a *ActivitylogService) runRetention() {
+	ticker := time.NewTicker(a.retention.PruneInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := a.PruneActivities(); err != nil {
+			a.log.Error().Err(err).Msg("could not prune activities")
+		}
+	}
+}
+
+func (a *ActivitylogService) activities(key string) ([]RawActivity, error) {
+	records, err := a.store.Read(key)
 	if err != nil && err != microstore.ErrNotFound {
 		return nil, fmt.Errorf("could not read activities: %w", err)
 	}
@@ -284,12 +432,11 @@ func (a *ActivitylogService) storeActivity(resourceID string, eventID string, de
 		}
 	}
 
-	// TODO: max len check?
-	activities = append(activities, RawActivity{
+	activities = a.prune(append(activities, RawActivity{
 		EventID:   eventID,
 		Depth:     depth,
 		Timestamp: timestamp,
-	})
+	}))
 
 	b, err := json.Marshal(activities)
 	if err != nil {
@@ -308,6 +455,11 @@ func toRef(r *provider.ResourceId) *provider.Reference {
 	}
 }
 
+// userKey returns the store key of the activities executed by the user
+func userKey(uid *user.UserId) string {
+	return "user:" + uid.GetOpaqueId()
+}
+
 func toSpace(r *provider.Reference) *provider.StorageSpaceId {
 	return &provider.StorageSpaceId{
 		OpaqueId: storagespace.FormatStorageID(r.GetResourceId().GetStorageId(), r.GetResourceId().GetSpaceId()),
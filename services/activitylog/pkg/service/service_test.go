@@ -1,12 +1,33 @@
 package service
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
 	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/status"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
 	"github.com/cs3org/reva/v2/pkg/store"
+	cs3mocks "github.com/cs3org/reva/v2/tests/cs3mocks/mocks"
+	libregraph "github.com/owncloud/libre-graph-api-go"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go-micro.dev/v4/client"
+	"google.golang.org/grpc"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/ocis-pkg/roles"
+	settingsmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/settings/v0"
+	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
+	"github.com/owncloud/ocis/v2/services/activitylog/pkg/config"
+	settings "github.com/owncloud/ocis/v2/services/settings/pkg/service/v0"
 )
 
 func TestAddActivity(t *testing.T) {
@@ -143,6 +164,259 @@ func TestAddActivity(t *testing.T) {
 	}
 }
 
+func TestPruneActivities(t *testing.T) {
+	now := time.Now()
+	alog := &ActivitylogService{
+		store:     store.Create(),
+		retention: config.Retention{MaxAge: 24 * time.Hour, MaxActivities: 2},
+	}
+
+	require.NoError(t, alog.storeActivity("recent", "activity1", 0, now.Add(-3*time.Hour)))
+	require.NoError(t, alog.storeActivity("recent", "activity2", 0, now.Add(-2*time.Hour)))
+	require.NoError(t, alog.storeActivity("recent", "activity3", 0, now.Add(-1*time.Hour)))
+	require.NoError(t, alog.storeActivity("old", "activity4", 0, now.Add(-time.Minute)))
+
+	activities, err := alog.activitiesByKey("recent")
+	require.NoError(t, err)
+	require.Equal(t, []string{"activity2", "activity3"}, eventIDs(activities))
+
+	// the activity ages while it is stored
+	alog.retention.MaxAge = 30 * time.Second
+	require.NoError(t, alog.PruneActivities())
+
+	activities, err = alog.activitiesByKey("old")
+	require.NoError(t, err)
+	require.Empty(t, activities)
+
+	keys, err := alog.store.List()
+	require.NoError(t, err)
+	require.Empty(t, keys)
+}
+
+func TestPruneActivitiesKeepsSubscribers(t *testing.T) {
+	alog := &ActivitylogService{
+		store:     store.Create(),
+		retention: config.Retention{MaxAge: time.Hour},
+	}
+
+	lastSent := time.Now().Add(-48 * time.Hour).UTC()
+	require.NoError(t, alog.saveSubscriber("some-user", subscriber{LastSent: lastSent}))
+	require.NoError(t, alog.PruneActivities())
+
+	sub, err := alog.subscriber("some-user")
+	require.NoError(t, err)
+	require.True(t, sub.LastSent.Equal(lastSent))
+}
+
+func TestDigestDue(t *testing.T) {
+	now := time.Date(2024, 6, 15, 8, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		Name     string
+		Interval string
+		LastSent time.Time
+		Due      bool
+		Since    time.Time
+	}{
+		{Name: "never", Interval: DigestNever, LastSent: now.Add(-30 * 24 * time.Hour)},
+		{Name: "unknown interval", Interval: "hourly", LastSent: now.Add(-30 * 24 * time.Hour)},
+		{Name: "daily not due", Interval: DigestDaily, LastSent: now.Add(-20 * time.Hour)},
+		{Name: "daily due", Interval: DigestDaily, LastSent: now.Add(-24 * time.Hour), Due: true, Since: now.Add(-24 * time.Hour)},
+		{Name: "daily due within half of the check interval", Interval: DigestDaily, LastSent: now.Add(-23*time.Hour - 40*time.Minute), Due: true, Since: now.Add(-23*time.Hour - 40*time.Minute)},
+		{Name: "daily after a pause", Interval: DigestDaily, LastSent: now.Add(-72 * time.Hour), Due: true, Since: now.Add(-24 * time.Hour)},
+		{Name: "weekly not due", Interval: DigestWeekly, LastSent: now.Add(-6 * 24 * time.Hour)},
+		{Name: "weekly due", Interval: DigestWeekly, LastSent: now.Add(-7 * 24 * time.Hour), Due: true, Since: now.Add(-7 * 24 * time.Hour)},
+	}
+
+	for _, tc := range testCases {
+		since, due := digestDue(tc.Interval, tc.LastSent, now, time.Hour)
+		require.Equal(t, tc.Due, due, tc.Name)
+		require.Equal(t, tc.Since, since, tc.Name)
+	}
+}
+
+func TestMemoryClaimer(t *testing.T) {
+	c := newClaimer(config.Store{Store: "memory"}, 50*time.Millisecond)
+
+	claimed, err := c.claim("digest:alice")
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	claimed, err = c.claim("digest:alice")
+	require.NoError(t, err)
+	require.False(t, claimed, "the digest is claimed already")
+
+	claimed, err = c.claim("digest:bob")
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	time.Sleep(60 * time.Millisecond)
+	claimed, err = c.claim("digest:alice")
+	require.NoError(t, err)
+	require.True(t, claimed, "the claim expired")
+}
+
+func TestStoreClaimer(t *testing.T) {
+	st := store.Create(store.Store(store.TypeMemory))
+	a := &storeClaimer{store: st, owner: "replica-a", ttl: 50 * time.Millisecond}
+	b := &storeClaimer{store: st, owner: "replica-b", ttl: 50 * time.Millisecond}
+
+	claimed, err := a.claim("digest:alice")
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	claimed, err = b.claim("digest:alice")
+	require.NoError(t, err)
+	require.False(t, claimed, "the digest is claimed by another replica")
+
+	claimed, err = b.claim("digest:bob")
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	time.Sleep(60 * time.Millisecond)
+	claimed, err = b.claim("digest:alice")
+	require.NoError(t, err)
+	require.True(t, claimed, "the claim expired")
+}
+
+func TestGetFilters(t *testing.T) {
+	alog := &ActivitylogService{}
+
+	testCases := []struct {
+		Query string
+		Key   string
+		Skip  int
+		Limit int
+		Err   bool
+	}{
+		{Query: "itemid:storageid$spaceid!opaqueid", Key: "storageid$spaceid!opaqueid"},
+		{Query: "spaceid:storageid$spaceid AND skip:10 AND limit:5", Key: "storageid$spaceid!spaceid", Skip: 10, Limit: 5},
+		{Query: "userid:some-user AND date>=2024-01-01", Key: "user:some-user"},
+		{Query: "depth:1", Err: true},
+		{Query: "itemid:storageid$spaceid!opaqueid AND userid:some-user", Err: true},
+		{Query: "userid:some-user AND skip:x", Err: true},
+	}
+
+	for _, tc := range testCases {
+		filter, err := alog.getFilters(tc.Query)
+		if tc.Err {
+			require.Error(t, err, tc.Query)
+			continue
+		}
+		require.NoError(t, err, tc.Query)
+		require.Equal(t, tc.Key, filter.key, tc.Query)
+		require.Equal(t, tc.Skip, filter.skip, tc.Query)
+		require.Equal(t, tc.Limit, filter.limit, tc.Query)
+	}
+}
+
+func TestCheckFeedAccess(t *testing.T) {
+	ctx := context.Background()
+
+	pool.RemoveSelector("GatewaySelector" + "com.owncloud.api.gateway")
+	gatewayClient := &cs3mocks.GatewayAPIClient{}
+	gatewayClient.On("Stat", mock.Anything, mock.MatchedBy(func(req *provider.StatRequest) bool {
+		return req.GetRef().GetResourceId().GetSpaceId() == "member-space"
+	})).Return(&provider.StatResponse{Status: status.NewOK(ctx)}, nil)
+	gatewayClient.On("Stat", mock.Anything, mock.Anything).Return(&provider.StatResponse{Status: status.NewNotFound(ctx, "not found")}, nil)
+
+	rm := roles.NewManager(
+		roles.Logger(log.NopLogger()),
+		roles.RoleService(roleService{settingssvc.MockRoleService{
+			ListRoleAssignmentsFunc: func(_ context.Context, req *settingssvc.ListRoleAssignmentsRequest, _ ...client.CallOption) (*settingssvc.ListRoleAssignmentsResponse, error) {
+				return &settingssvc.ListRoleAssignmentsResponse{Assignments: []*settingsmsg.UserRoleAssignment{{RoleId: req.GetAccountUuid() + "-role"}}}, nil
+			},
+			ListRolesFunc: func(_ context.Context, req *settingssvc.ListBundlesRequest, _ ...client.CallOption) (*settingssvc.ListBundlesResponse, error) {
+				res := &settingssvc.ListBundlesResponse{}
+				for _, id := range req.GetBundleIds() {
+					role := &settingsmsg.Bundle{Id: id}
+					if id == "admin-role" {
+						role.Settings = []*settingsmsg.Setting{{Id: settings.AccountManagementPermissionID}}
+					}
+					res.Bundles = append(res.Bundles, role)
+				}
+				return res, nil
+			},
+		}}),
+	)
+
+	alog := &ActivitylogService{
+		log:   log.NopLogger(),
+		roles: &rm,
+		gws: pool.GetSelector[gateway.GatewayAPIClient](
+			"GatewaySelector",
+			"com.owncloud.api.gateway",
+			func(cc *grpc.ClientConn) gateway.GatewayAPIClient {
+				return gatewayClient
+			},
+		),
+	}
+
+	testCases := []struct {
+		User   string
+		Query  string
+		Denied bool
+	}{
+		{User: "alice", Query: "userid:alice"},
+		{User: "alice", Query: "userid:bob", Denied: true},
+		{User: "admin", Query: "userid:bob"},
+		{User: "alice", Query: "spaceid:storageid$member-space"},
+		{User: "alice", Query: "spaceid:storageid$other-space", Denied: true},
+		{User: "admin", Query: "spaceid:storageid$other-space", Denied: true},
+		{User: "alice", Query: "itemid:storageid$other-space!opaqueid"},
+	}
+
+	for _, tc := range testCases {
+		filter, err := alog.getFilters(tc.Query)
+		require.NoError(t, err, tc.Query)
+
+		err = alog.checkFeedAccess(ctx, &user.User{Id: &user.UserId{OpaqueId: tc.User}}, filter)
+		if tc.Denied {
+			require.ErrorIs(t, err, errForbidden, tc.User+": "+tc.Query)
+			continue
+		}
+		require.NoError(t, err, tc.User+": "+tc.Query)
+	}
+
+	// the export is denied as well
+	r := httptest.NewRequest(http.MethodGet, "/graph/v1beta1/extensions/org.libregraph/activities/export?format=csv&kql=userid:bob", nil)
+	r = r.WithContext(revactx.ContextSetUser(r.Context(), &user.User{Id: &user.UserId{OpaqueId: "alice"}}))
+	w := httptest.NewRecorder()
+	alog.HandleExportActivities(w, r)
+	require.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestWriteCSV(t *testing.T) {
+	ts := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	activity := NewActivity("{user} added {resource} to {space}", ts, "event1", map[string]interface{}{
+		"user":     Actor{ID: "uid", DisplayName: "Alice"},
+		"resource": Resource{ID: "rid", Name: "report.pdf"},
+		"space":    Resource{ID: "sid", Name: "Project"},
+	})
+
+	var b strings.Builder
+	require.NoError(t, writeCSV(&b, []libregraph.Activity{activity}))
+	require.Equal(t, "time,id,message,user_id,user,resource_id,resource,space_id,space\n"+
+		"2024-05-01T12:00:00Z,event1,Alice added report.pdf to Project,uid,Alice,rid,report.pdf,sid,Project\n", b.String())
+}
+
+// roleService completes the settings mock, which lacks the filtered listing of the role assignments
+type roleService struct {
+	settingssvc.MockRoleService
+}
+
+func (roleService) ListRoleAssignmentsFiltered(context.Context, *settingssvc.ListRoleAssignmentsFilteredRequest, ...client.CallOption) (*settingssvc.ListRoleAssignmentsResponse, error) {
+	panic("ListRoleAssignmentsFiltered was called in test but not mocked")
+}
+
+func eventIDs(activities []RawActivity) []string {
+	ids := make([]string, 0, len(activities))
+	for _, a := range activities {
+		ids = append(ids, a.EventID)
+	}
+	return ids
+}
+
 func activitites(acts ...interface{}) []RawActivity {
 	var activities []RawActivity
 	act := RawActivity{}
@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	merrors "go-micro.dev/v4/errors"
+	micrometadata "go-micro.dev/v4/metadata"
+	microstore "go-micro.dev/v4/store"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/middleware"
+	settingsmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/settings/v0"
+	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
+	"github.com/owncloud/ocis/v2/services/settings/pkg/store/defaults"
+)
+
+// The intervals of the activity digest a user can choose in the profile settings
+const (
+	DigestNever  = "never"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// _digestKeyPrefix prefixes the store keys of the users who watch folders or spaces
+const _digestKeyPrefix = "digest:"
+
+// subscriber is stored for every user who watches folders or spaces, the digests are sent to them
+type subscriber struct {
+	LastSent time.Time `json:"last_sent"`
+}
+
+// GetSubscriptionsResponse is the response on GET subscriptions requests
+type GetSubscriptionsResponse struct {
+	Interval  string     `json:"interval"`
+	Resources []Resource `json:"value"`
+}
+
+// HandleGetSubscriptions handles the request to list the folders and spaces the user watches.
+func (s *ActivitylogService) HandleGetSubscriptions(w http.ResponseWriter, r *http.Request) {
+	ctx, activeUser, ok := requestUser(w, r)
+	if !ok {
+		return
+	}
+	uid := activeUser.GetId().GetOpaqueId()
+
+	ids, _, err := s.watchedResources(ctx, uid)
+	if err != nil {
+		s.log.Error().Err(err).Msg("error getting watched resources")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	interval, err := s.digestInterval(ctx, uid)
+	if err != nil {
+		s.log.Error().Err(err).Msg("error getting digest interval")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	gwc, err := s.gws.Next()
+	if err != nil {
+		s.log.Error().Err(err).Msg("error getting gateway client")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	resp := GetSubscriptionsResponse{Interval: interval, Resources: []Resource{}}
+	for _, id := range ids {
+		rid, err := storagespace.ParseID(id)
+		if err != nil {
+			continue
+		}
+		// resources which were deleted or the user can't access anymore are not listed
+		info, err := utils.GetResource(ctx, &provider.Reference{ResourceId: &rid}, gwc)
+		if err != nil {
+			continue
+		}
+		resp.Resources = append(resp.Resources, Resource{ID: id, Name: info.GetName()})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		s.log.Error().Err(err).Msg("error writing response")
+	}
+}
+
+// HandleWatch handles the request to add a folder or space to the activity digest of the user.
+func (s *ActivitylogService) HandleWatch(w http.ResponseWriter, r *http.Request) {
+	ctx, activeUser, ok := requestUser(w, r)
+	if !ok {
+		return
+	}
+
+	rid, err := itemID(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	gwc, err := s.gws.Next()
+	if err != nil {
+		s.log.Error().Err(err).Msg("error getting gateway client")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	info, err := utils.GetResource(ctx, &provider.Reference{ResourceId: rid}, gwc)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if info.GetType() != provider.ResourceType_RESOURCE_TYPE_CONTAINER {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("only folders and spaces can be watched"))
+		return
+	}
+
+	// the digests are built without the token of the user, they only contain the spaces the user is a member of
+	sctx, err := utils.GetServiceUserContext(s.cfg.ServiceAccount.ServiceAccountID, gwc, s.cfg.ServiceAccount.ServiceAccountSecret)
+	if err != nil {
+		s.log.Error().Err(err).Msg("error getting service user context")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	spaces, err := memberSpaces(sctx, gwc, activeUser.GetId())
+	if err != nil {
+		s.log.Error().Err(err).Msg("error listing spaces")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if _, ok := spaces[storagespace.FormatStorageID(info.GetId().GetStorageId(), info.GetId().GetSpaceId())]; !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("only folders in spaces the user is a member of can be watched"))
+		return
+	}
+
+	uid := activeUser.GetId().GetOpaqueId()
+	id := storagespace.FormatResourceID(*info.GetId())
+	if err := s.updateWatchedResources(ctx, uid, func(ids []string) []string {
+		if slices.Contains(ids, id) {
+			return ids
+		}
+		return append(ids, id)
+	}); err != nil {
+		s.log.Error().Err(err).Msg("error saving watched resources")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleUnwatch handles the request to remove a folder or space from the activity digest of the user.
+func (s *ActivitylogService) HandleUnwatch(w http.ResponseWriter, r *http.Request) {
+	ctx, activeUser, ok := requestUser(w, r)
+	if !ok {
+		return
+	}
+
+	rid, err := itemID(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	id := storagespace.FormatResourceID(*rid)
+	if err := s.updateWatchedResources(ctx, activeUser.GetId().GetOpaqueId(), func(ids []string) []string {
+		return slices.DeleteFunc(ids, func(i string) bool { return i == id })
+	}); err != nil {
+		s.log.Error().Err(err).Msg("error saving watched resources")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// requestUser returns the context with the token of the user for the outgoing requests. It writes the error response if it fails.
+func requestUser(w http.ResponseWriter, r *http.Request) (context.Context, *user.User, bool) {
+	ctx := metadata.AppendToOutgoingContext(r.Context(), revactx.TokenHeader, r.Header.Get("X-Access-Token"))
+
+	activeUser, ok := revactx.ContextGetUser(ctx)
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return nil, nil, false
+	}
+	return ctx, activeUser, true
+}
+
+// itemID returns the resource id of the request path, a space id refers to the root of the space
+func itemID(r *http.Request) (*provider.ResourceId, error) {
+	id, err := url.PathUnescape(chi.URLParam(r, "itemid"))
+	if err != nil {
+		return nil, err
+	}
+
+	rid, err := storagespace.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	if rid.GetOpaqueId() == "" {
+		rid.OpaqueId = rid.GetSpaceId()
+	}
+	return &rid, nil
+}
+
+// watchedResources returns the ids of the folders and spaces the user watches and the id of the settings value
+func (s *ActivitylogService) watchedResources(ctx context.Context, uid string) ([]string, string, error) {
+	res, err := s.valService.GetValueByUniqueIdentifiers(
+		micrometadata.Set(ctx, middleware.AccountID, uid),
+		&settingssvc.GetValueByUniqueIdentifiersRequest{
+			AccountUuid: uid,
+			SettingId:   defaults.SettingUUIDProfileWatchedResources,
+		},
+	)
+	switch {
+	case isNotFound(err):
+		return nil, "", nil
+	case err != nil:
+		return nil, "", err
+	}
+
+	var ids []string
+	for _, v := range res.GetValue().GetValue().GetListValue().GetValues() {
+		if id := v.GetStringValue(); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, res.GetValue().GetValue().GetId(), nil
+}
+
+// updateWatchedResources changes the folders and spaces the user watches and registers the user for the digests
+func (s *ActivitylogService) updateWatchedResources(ctx context.Context, uid string, fn func(ids []string) []string) error {
+	ids, valueID, err := s.watchedResources(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if valueID == "" {
+		valueID = uuid.New().String()
+	}
+
+	ids = fn(ids)
+	values := make([]*settingsmsg.ListOptionValue, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, &settingsmsg.ListOptionValue{
+			Option: &settingsmsg.ListOptionValue_StringValue{StringValue: id},
+		})
+	}
+
+	_, err = s.valService.SaveValue(micrometadata.Set(ctx, middleware.AccountID, uid), &settingssvc.SaveValueRequest{
+		Value: &settingsmsg.Value{
+			Id:          valueID,
+			BundleId:    defaults.BundleUUIDProfile,
+			SettingId:   defaults.SettingUUIDProfileWatchedResources,
+			AccountUuid: uid,
+			Resource: &settingsmsg.Resource{
+				Type: settingsmsg.Resource_TYPE_USER,
+			},
+			Value: &settingsmsg.Value_ListValue{
+				ListValue: &settingsmsg.ListValue{Values: values},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		err := s.store.Delete(digestKey(uid))
+		if err != nil && err != microstore.ErrNotFound {
+			return err
+		}
+		return nil
+	}
+
+	// the first digest contains the activities since the user started watching
+	if _, err := s.subscriber(uid); err == microstore.ErrNotFound {
+		return s.saveSubscriber(uid, subscriber{LastSent: time.Now()})
+	}
+	return nil
+}
+
+// digestInterval returns the interval of the activity digest the user chose in the profile settings
+func (s *ActivitylogService) digestInterval(ctx context.Context, uid string) (string, error) {
+	res, err := s.valService.GetValueByUniqueIdentifiers(
+		micrometadata.Set(ctx, middleware.AccountID, uid),
+		&settingssvc.GetValueByUniqueIdentifiersRequest{
+			AccountUuid: uid,
+			SettingId:   defaults.SettingUUIDProfileActivityDigestInterval,
+		},
+	)
+	switch {
+	case isNotFound(err):
+		return DigestNever, nil
+	case err != nil:
+		return "", err
+	}
+
+	values := res.GetValue().GetValue().GetListValue().GetValues()
+	if len(values) == 0 || values[0].GetStringValue() == "" {
+		return DigestNever, nil
+	}
+	return values[0].GetStringValue(), nil
+}
+
+func (s *ActivitylogService) subscriber(uid string) (subscriber, error) {
+	var sub subscriber
+	records, err := s.store.Read(digestKey(uid))
+	if err != nil {
+		return sub, err
+	}
+	if len(records) == 0 {
+		return sub, microstore.ErrNotFound
+	}
+	return sub, json.Unmarshal(records[0].Value, &sub)
+}
+
+func (s *ActivitylogService) saveSubscriber(uid string, sub subscriber) error {
+	b, err := json.Marshal(sub)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Write(&microstore.Record{
+		Key:   digestKey(uid),
+		Value: b,
+	})
+}
+
+// memberSpaces returns the ids of the spaces the user is a member of, directly or through a group
+func memberSpaces(ctx context.Context, gwc gateway.GatewayAPIClient, uid *user.UserId) (map[string]struct{}, error) {
+	res, err := gwc.ListStorageSpaces(ctx, &provider.ListStorageSpacesRequest{
+		Filters: []*provider.ListStorageSpacesRequest_Filter{
+			{
+				Type: provider.ListStorageSpacesRequest_Filter_TYPE_USER,
+				Term: &provider.ListStorageSpacesRequest_Filter_User{User: uid},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.GetStatus().GetCode() != rpc.Code_CODE_OK {
+		return nil, fmt.Errorf("could not list spaces: %s", res.GetStatus().GetMessage())
+	}
+
+	spaces := make(map[string]struct{}, len(res.GetStorageSpaces()))
+	for _, space := range res.GetStorageSpaces() {
+		spaces[storagespace.FormatStorageID(space.GetRoot().GetStorageId(), space.GetRoot().GetSpaceId())] = struct{}{}
+	}
+	return spaces, nil
+}
+
+// digestKey returns the store key of the user who watches folders or spaces
+func digestKey(uid string) string {
+	return _digestKeyPrefix + uid
+}
+
+func isNotFound(err error) bool {
+	return err != nil && merrors.FromError(err).Code == http.StatusNotFound
+}
@@ -0,0 +1,53 @@
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/google/uuid"
+	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/config"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/config/parser"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/event"
+	"github.com/urfave/cli/v2"
+)
+
+// Rescan is the entrypoint for the rescan command.
+func Rescan(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "rescan",
+		Usage: "scan the existing files of a space or of all spaces again",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "space-id",
+				Usage: "the id of the space to scan, all personal and project spaces are scanned if not set",
+			},
+		},
+		Before: func(c *cli.Context) error {
+			return configlog.ReturnFatal(parser.ParseConfig(cfg))
+		},
+		Action: func(c *cli.Context) error {
+			stream, err := event.NewStream(cfg)
+			if err != nil {
+				return err
+			}
+
+			ev := event.StartRescan{
+				RescanID:  uuid.NewString(),
+				SpaceID:   c.String("space-id"),
+				Timestamp: time.Now(),
+			}
+			if err := events.Publish(c.Context, stream, ev); err != nil {
+				return err
+			}
+
+			// go-micro nats implementation uses async publishing,
+			// therefore we need to manually wait.
+			time.Sleep(5 * time.Second)
+
+			fmt.Printf("Triggered re-scan %s\n", ev.RescanID)
+			return nil
+		},
+	}
+}
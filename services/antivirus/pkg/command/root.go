@@ -13,6 +13,7 @@ func GetCommands(cfg *config.Config) cli.Commands {
 	return []*cli.Command{
 		Server(cfg),
 		Health(cfg),
+		Rescan(cfg),
 		Version(cfg),
 	}
 }
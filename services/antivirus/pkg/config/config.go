@@ -3,10 +3,14 @@ package config
 import (
 	"context"
 	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
 )
 
 // Config combines all available configuration parts.
 type Config struct {
+	Commons *shared.Commons `yaml:"-"` // don't use this directly as configuration for a service
+
 	File string
 	Log  *Log
 
@@ -16,16 +20,33 @@ type Config struct {
 
 	Tracing *Tracing `yaml:"tracing"`
 
-	InfectedFileHandling string `yaml:"infected-file-handling" env:"ANTIVIRUS_INFECTED_FILE_HANDLING" desc:"Defines the behaviour when a virus has been found. Supported options are: 'delete', 'continue' and 'abort '. Delete will delete the file. Continue will mark the file as infected but continues further processing. Abort will keep the file in the uploads folder for further admin inspection and will not move it to its final destination." introductionVersion:"pre5.0"`
+	GRPCClientTLS *shared.GRPCClientTLS `yaml:"grpc_client_tls"`
+
+	InfectedFileHandling string `yaml:"infected-file-handling" env:"ANTIVIRUS_INFECTED_FILE_HANDLING" desc:"Defines the behaviour when a virus has been found. Supported options are: 'delete', 'continue', 'abort ' and 'quarantine'. Delete will delete the file. Continue will mark the file as infected but continues further processing. Abort will keep the file in the uploads folder for further admin inspection and will not move it to its final destination. Quarantine will move the file into the quarantine space where admins can review, release or purge it, this requires a service account." introductionVersion:"pre5.0"`
 	Events               Events
 	Scanner              Scanner
 	MaxScanSize          string `yaml:"max-scan-size" env:"ANTIVIRUS_MAX_SCAN_SIZE" desc:"The maximum scan size the virus scanner can handle. Only this many bytes of a file will be scanned. 0 means unlimited and is the default. Usable common abbreviations: [KB, KiB, MB, MiB, GB, GiB, TB, TiB, PB, PiB, EB, EiB], example: 2GB." introductionVersion:"pre5.0"`
+	Rescan               Rescan `yaml:"rescan"`
+
+	RevaGateway    string         `yaml:"reva_gateway" env:"OCIS_REVA_GATEWAY" desc:"CS3 gateway used to look up and download the files to re-scan." introductionVersion:"6.0.0"`
+	ServiceAccount ServiceAccount `yaml:"service_account"`
 
 	Context context.Context `yaml:"-" json:"-"`
 
 	DebugScanOutcome string `yaml:"-" env:"ANTIVIRUS_DEBUG_SCAN_OUTCOME" desc:"A predefined outcome for virus scanning, FOR DEBUG PURPOSES ONLY! (example values: 'found,infected')" introductionVersion:"pre5.0"`
 }
 
+// ServiceAccount is the configuration for the used service account
+type ServiceAccount struct {
+	ServiceAccountID     string `yaml:"service_account_id" env:"OCIS_SERVICE_ACCOUNT_ID;ANTIVIRUS_SERVICE_ACCOUNT_ID" desc:"The ID of the service account the service should use. It is only required to re-scan existing files and to quarantine infected files. See the 'auth-service' service description for more details." introductionVersion:"6.0.0"`
+	ServiceAccountSecret string `yaml:"service_account_secret" env:"OCIS_SERVICE_ACCOUNT_SECRET;ANTIVIRUS_SERVICE_ACCOUNT_SECRET" desc:"The service account secret." introductionVersion:"6.0.0"`
+}
+
+// Rescan provides configuration options for the re-scan of existing files
+type Rescan struct {
+	Interval time.Duration `yaml:"interval" env:"ANTIVIRUS_RESCAN_INTERVAL" desc:"The interval of the scheduled re-scan of all existing files, for example '168h' to re-scan once a week. Only enable it for a single instance of the service. Defaults to '0s' which disables scheduled re-scans. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+}
+
 // Service defines the available service configuration.
 type Service struct {
 	Name string `yaml:"-"`
@@ -60,10 +81,12 @@ type Events struct {
 
 // Scanner provides configuration options for the virus scanner
 type Scanner struct {
-	Type string `yaml:"type" env:"ANTIVIRUS_SCANNER_TYPE" desc:"The antivirus scanner to use. Supported values are 'clamav' and 'icap'." introductionVersion:"pre5.0"`
+	Type  string   `yaml:"type" env:"ANTIVIRUS_SCANNER_TYPE" desc:"The antivirus scanner to use. Supported values are 'clamav', 'icap', 'command' and 'multi'." introductionVersion:"pre5.0"`
+	Types []string `yaml:"types" env:"ANTIVIRUS_SCANNER_TYPES" desc:"The antivirus scanners to use if ANTIVIRUS_SCANNER_TYPE is set to 'multi'. All scanners scan the file in parallel and it is considered infected if any scanner reports an infection. Supported values are 'clamav', 'icap' and 'command'. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
 
-	ClamAV ClamAV // only if Type == clamav
-	ICAP   ICAP   // only if Type == icap
+	ClamAV  ClamAV  // only if Type == clamav
+	ICAP    ICAP    // only if Type == icap
+	Command Command // only if Type == command
 }
 
 // ClamAV provides configuration option for clamav
@@ -78,3 +101,12 @@ type ICAP struct {
 	URL               string        `yaml:"url" env:"ANTIVIRUS_ICAP_URL" desc:"URL of the ICAP server." introductionVersion:"pre5.0"`
 	Service           string        `yaml:"service" env:"ANTIVIRUS_ICAP_SERVICE" desc:"The name of the ICAP service." introductionVersion:"pre5.0"`
 }
+
+// Command provides configuration options for an external scan command
+type Command struct {
+	Path               string        `yaml:"path" env:"ANTIVIRUS_COMMAND_PATH" desc:"The path to the executable which scans the file. The content of the file is passed via stdin." introductionVersion:"6.0.0"`
+	Args               []string      `yaml:"args" env:"ANTIVIRUS_COMMAND_ARGS" desc:"The arguments passed to the executable. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+	InfectedExitCodes  []int         `yaml:"infected_exit_codes" env:"ANTIVIRUS_COMMAND_INFECTED_EXIT_CODES" desc:"The exit codes of the executable which mean that the file is infected. The exit code 0 means that the file is clean, any other exit code is treated as a scan error. Defaults to '1'. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+	DescriptionPattern string        `yaml:"description_pattern" env:"ANTIVIRUS_COMMAND_DESCRIPTION_PATTERN" desc:"A regular expression to extract the description of the infection from the output of the executable. The first capturing group is used if present. If not set, the first line of the output is used." introductionVersion:"6.0.0"`
+	Timeout            time.Duration `yaml:"scan_timeout" env:"ANTIVIRUS_COMMAND_SCAN_TIMEOUT" desc:"Scan timeout for the executable. Defaults to '5m' (5 minutes). See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+}
@@ -3,6 +3,8 @@ package defaults
 import (
 	"time"
 
+	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
+	"github.com/owncloud/ocis/v2/ocis-pkg/structs"
 	"github.com/owncloud/ocis/v2/services/antivirus/pkg/config"
 )
 
@@ -28,6 +30,7 @@ func DefaultConfig() *config.Config {
 			Endpoint: "127.0.0.1:9233",
 			Cluster:  "ocis-cluster",
 		},
+		RevaGateway:          shared.DefaultRevaConfig().Address,
 		InfectedFileHandling: "delete",
 		Scanner: config.Scanner{
 			Type: "clamav",
@@ -39,6 +42,10 @@ func DefaultConfig() *config.Config {
 				Service: "avscan",
 				Timeout: 5 * time.Minute,
 			},
+			Command: config.Command{
+				InfectedExitCodes: []int{1},
+				Timeout:           5 * time.Minute,
+			},
 		},
 	}
 }
@@ -52,6 +59,13 @@ func EnsureDefaults(cfg *config.Config) {
 	if cfg.Tracing == nil {
 		cfg.Tracing = &config.Tracing{}
 	}
+
+	if cfg.GRPCClientTLS == nil && cfg.Commons != nil {
+		cfg.GRPCClientTLS = structs.CopyOrZeroValue(cfg.Commons.GRPCClientTLS)
+	}
+	if cfg.GRPCClientTLS == nil {
+		cfg.GRPCClientTLS = &shared.GRPCClientTLS{}
+	}
 }
 
 // Sanitize sanitizes the configuration
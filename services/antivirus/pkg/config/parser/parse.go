@@ -6,6 +6,7 @@ import (
 
 	ociscfg "github.com/owncloud/ocis/v2/ocis-pkg/config"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
 	"github.com/owncloud/ocis/v2/services/antivirus/pkg/config"
 	"github.com/owncloud/ocis/v2/services/antivirus/pkg/config/defaults"
 
@@ -41,5 +42,14 @@ func Validate(cfg *config.Config) error {
 		log.Deprecation("ANTIVIRUS_ICAP_TIMEOUT is deprecated, use ANTIVIRUS_ICAP_SCAN_TIMEOUT instead")
 	}
 
+	if cfg.InfectedFileHandling == "quarantine" {
+		if cfg.ServiceAccount.ServiceAccountID == "" {
+			return shared.MissingServiceAccountID(cfg.Service.Name)
+		}
+		if cfg.ServiceAccount.ServiceAccountSecret == "" {
+			return shared.MissingServiceAccountSecret(cfg.Service.Name)
+		}
+	}
+
 	return nil
 }
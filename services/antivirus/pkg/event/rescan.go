@@ -0,0 +1,65 @@
+package event
+
+import (
+	"encoding/json"
+	"time"
+
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/events"
+)
+
+// StartRescan triggers a re-scan of existing files
+type StartRescan struct {
+	RescanID string
+	// SpaceID limits the re-scan to a single space, all personal and project spaces are scanned if empty
+	SpaceID   string
+	Timestamp time.Time
+}
+
+// Unmarshal to fulfill umarshaller interface
+func (StartRescan) Unmarshal(v []byte) (interface{}, error) {
+	e := StartRescan{}
+	err := json.Unmarshal(v, &e)
+	return e, err
+}
+
+// RescanProgress reports the progress of a re-scan
+type RescanProgress struct {
+	RescanID string
+	// Spaces is the number of spaces to scan
+	Spaces int
+	// SpacesDone is the number of spaces which are completely scanned
+	SpacesDone int
+	Scanned    uint64
+	Skipped    uint64
+	Infected   uint64
+	Failed     uint64
+	Finished   bool
+	// Error is set if the re-scan could not be completed
+	Error     string
+	Timestamp time.Time
+}
+
+// Unmarshal to fulfill umarshaller interface
+func (RescanProgress) Unmarshal(v []byte) (interface{}, error) {
+	e := RescanProgress{}
+	err := json.Unmarshal(v, &e)
+	return e, err
+}
+
+// RescanInfected is emitted for every infected file found during a re-scan
+type RescanInfected struct {
+	RescanID    string
+	ResourceID  *provider.ResourceId
+	Path        string
+	Description string
+	Outcome     events.PostprocessingOutcome
+	Timestamp   time.Time
+}
+
+// Unmarshal to fulfill umarshaller interface
+func (RescanInfected) Unmarshal(v []byte) (interface{}, error) {
+	e := RescanInfected{}
+	err := json.Unmarshal(v, &e)
+	return e, err
+}
@@ -0,0 +1,105 @@
+package scanners
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// NewCommand returns a Scanner which runs a local executable. The file is passed via stdin.
+func NewCommand(path string, args []string, infectedExitCodes []int, descriptionPattern string, timeout time.Duration) (Command, error) {
+	if path == "" {
+		return Command{}, errors.New("no command configured")
+	}
+
+	c := Command{
+		path:              path,
+		args:              args,
+		infectedExitCodes: infectedExitCodes,
+		timeout:           timeout,
+	}
+
+	if descriptionPattern != "" {
+		re, err := regexp.Compile(descriptionPattern)
+		if err != nil {
+			return Command{}, fmt.Errorf("invalid description pattern: %w", err)
+		}
+		c.description = re
+	}
+
+	return c, nil
+}
+
+// Command is a Scanner based on an external command. The exit code 0 means the file is clean,
+// the configured infected exit codes mean the file is infected, any other exit code is an error.
+type Command struct {
+	path              string
+	args              []string
+	infectedExitCodes []int
+	description       *regexp.Regexp
+	timeout           time.Duration
+}
+
+// Scan to fulfill Scanner interface
+func (s Command) Scan(in Input) (Result, error) {
+	ctx := context.Background()
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.path, s.args...)
+	cmd.Stdin = in.Body
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return Result{ScanTime: time.Now()}, nil
+	case errors.As(err, &exitErr) && s.isInfected(exitErr.ExitCode()):
+		return Result{
+			Infected:    true,
+			Description: s.describe(output.String()),
+			ScanTime:    time.Now(),
+		}, nil
+	default:
+		return Result{}, fmt.Errorf("scan command failed: %w: %s", err, strings.TrimSpace(output.String()))
+	}
+}
+
+func (s Command) isInfected(code int) bool {
+	for _, c := range s.infectedExitCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// describe extracts the description of the infection from the output of the command.
+// Without a pattern the first line of the output is used.
+func (s Command) describe(output string) string {
+	if s.description == nil {
+		line, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+		return strings.TrimSpace(line)
+	}
+
+	m := s.description.FindStringSubmatch(output)
+	switch len(m) {
+	case 0:
+		return ""
+	case 1:
+		return m[0]
+	default:
+		return m[1]
+	}
+}
@@ -0,0 +1,113 @@
+package scanners
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errScanFinished closes the stream of a scanner which finished before reading the whole file
+var errScanFinished = errors.New("scan finished")
+
+// Scanner is implemented by all scanners
+type Scanner interface {
+	Scan(in Input) (Result, error)
+}
+
+// NamedScanner is a Scanner which is part of a Multi scanner
+type NamedScanner struct {
+	Name    string
+	Scanner Scanner
+}
+
+// NewMulti returns a Scanner which runs all given scanners in parallel
+func NewMulti(scanners ...NamedScanner) Multi {
+	return Multi{scanners: scanners}
+}
+
+// Multi is a Scanner running several scanners on the same file. The file is
+// infected if any of the scanners reports an infection.
+type Multi struct {
+	scanners []NamedScanner
+}
+
+// Scan streams the file to all scanners in parallel
+func (s Multi) Scan(in Input) (Result, error) {
+	type scan struct {
+		res Result
+		err error
+	}
+
+	var (
+		wg      sync.WaitGroup
+		results = make([]scan, len(s.scanners))
+		pipes   = make([]*pipeWriter, len(s.scanners))
+		writers = make([]io.Writer, len(s.scanners))
+	)
+	for i, ns := range s.scanners {
+		pr, pw := io.Pipe()
+		pipes[i] = &pipeWriter{w: pw}
+		writers[i] = pipes[i]
+
+		wg.Add(1)
+		go func(i int, ns NamedScanner, pr *io.PipeReader) {
+			defer wg.Done()
+			scanIn := in
+			scanIn.Body = pr
+			results[i].res, results[i].err = ns.Scanner.Scan(scanIn)
+			// unblock the copy if the scanner did not read the whole file
+			_ = pr.CloseWithError(errScanFinished)
+		}(i, ns, pr)
+	}
+
+	_, copyErr := io.Copy(io.MultiWriter(writers...), in.Body)
+	for _, p := range pipes {
+		_ = p.w.CloseWithError(copyErr)
+	}
+	wg.Wait()
+
+	var (
+		descriptions []string
+		errs         []error
+		res          = Result{ScanTime: time.Now()}
+	)
+	for i, r := range results {
+		name := s.scanners[i].Name
+		switch {
+		case r.err != nil:
+			errs = append(errs, fmt.Errorf("%s: %w", name, r.err))
+		case r.res.Infected:
+			res.Infected = true
+			descriptions = append(descriptions, fmt.Sprintf("%s: %s", name, r.res.Description))
+		}
+	}
+
+	if res.Infected {
+		// an infection is reported even if other scanners failed
+		res.Description = strings.Join(descriptions, "; ")
+		return res, nil
+	}
+	if copyErr != nil {
+		return Result{}, fmt.Errorf("could not read file: %w", copyErr)
+	}
+	if len(errs) > 0 {
+		return Result{}, errors.Join(errs...)
+	}
+	return res, nil
+}
+
+// pipeWriter ignores errors of a single pipe, so a finished scanner doesn't stop the stream for the others
+type pipeWriter struct {
+	w   *io.PipeWriter
+	err error
+}
+
+func (p *pipeWriter) Write(b []byte) (int, error) {
+	if p.err == nil {
+		_, p.err = p.w.Write(b)
+	}
+	return len(b), nil
+}
@@ -0,0 +1,75 @@
+package scanners_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/scanners"
+)
+
+type stubScanner struct {
+	// read is the number of bytes to read before returning
+	read   int64
+	result scanners.Result
+	err    error
+	body   string
+}
+
+func (s *stubScanner) Scan(in scanners.Input) (scanners.Result, error) {
+	b, _ := io.ReadAll(io.LimitReader(in.Body, s.read))
+	s.body = string(b)
+	return s.result, s.err
+}
+
+func TestCommand(t *testing.T) {
+	script := `read line; case "$line" in *EICAR*) echo "stdin: Eicar-Signature FOUND"; exit 1;; *) exit 0;; esac`
+
+	s, err := scanners.NewCommand("sh", []string{"-c", script}, []int{1}, `: (.*) FOUND`, 0)
+	require.NoError(t, err)
+
+	res, err := s.Scan(scanners.Input{Body: strings.NewReader("clean\n")})
+	require.NoError(t, err)
+	assert.False(t, res.Infected)
+
+	res, err = s.Scan(scanners.Input{Body: strings.NewReader("X5O!P%@AP EICAR\n")})
+	require.NoError(t, err)
+	assert.True(t, res.Infected)
+	assert.Equal(t, "Eicar-Signature", res.Description)
+
+	s, err = scanners.NewCommand("sh", []string{"-c", "echo broken; exit 2"}, []int{1}, "", 0)
+	require.NoError(t, err)
+	_, err = s.Scan(scanners.Input{Body: strings.NewReader("")})
+	assert.ErrorContains(t, err, "broken")
+}
+
+func TestMulti(t *testing.T) {
+	clean := &stubScanner{read: 1 << 20}
+	infected := &stubScanner{read: 2, result: scanners.Result{Infected: true, Description: "virus"}}
+	s := scanners.NewMulti(
+		scanners.NamedScanner{Name: "clean", Scanner: clean},
+		scanners.NamedScanner{Name: "infected", Scanner: infected},
+	)
+
+	res, err := s.Scan(scanners.Input{Body: strings.NewReader("content")})
+	require.NoError(t, err)
+	assert.True(t, res.Infected)
+	assert.Equal(t, "infected: virus", res.Description)
+	// the scanner which stopped early does not block the others
+	assert.Equal(t, "content", clean.body)
+	assert.Equal(t, "co", infected.body)
+}
+
+func TestMultiError(t *testing.T) {
+	s := scanners.NewMulti(
+		scanners.NamedScanner{Name: "clean", Scanner: &stubScanner{read: 1 << 20}},
+		scanners.NamedScanner{Name: "broken", Scanner: &stubScanner{err: errors.New("unreachable")}},
+	)
+
+	_, err := s.Scan(scanners.Input{Body: strings.NewReader("content")})
+	assert.ErrorContains(t, err, "broken: unreachable")
+}
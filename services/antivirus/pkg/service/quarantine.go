@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+	"github.com/cs3org/reva/v2/pkg/utils"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/quarantine"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/scanners"
+)
+
+// outcomeQuarantine moves infected files into the quarantine space. It is not a postprocessing outcome,
+// uploads are finished with the 'delete' outcome after the file has been copied.
+const outcomeQuarantine events.PostprocessingOutcome = "quarantine"
+
+// quarantineUpload copies an infected upload into the quarantine space and returns the outcome for the postprocessing.
+// If the file can't be quarantined the upload is aborted, so it can still be inspected by an admin.
+func (av Antivirus) quarantineUpload(ctx context.Context, ev events.StartPostprocessingStep, res scanners.Result) events.PostprocessingOutcome {
+	logger := av.l.With().Str("uploadid", ev.UploadID).Interface("resourceID", ev.ResourceID).Logger()
+
+	// the upload of a quarantined file into the quarantine space is scanned as well
+	inQuarantine, err := av.quarantine.Contains(ctx, ev.ResourceID)
+	switch {
+	case err != nil:
+		logger.Error().Err(err).Msg("could not get the quarantine space")
+		return events.PPOutcomeAbort
+	case inQuarantine:
+		return events.PPOutcomeContinue
+	}
+
+	item := quarantine.Item{
+		Name:        ev.Filename,
+		SpaceID:     storagespace.FormatStorageID(ev.ResourceID.GetStorageId(), ev.ResourceID.GetSpaceId()),
+		Path:        av.uploadPath(ctx, ev),
+		User:        ev.ExecutingUser.GetId().GetOpaqueId(),
+		Description: res.Description,
+		ScanDate:    res.ScanTime,
+	}
+	released, err := av.quarantineFile(ctx, item, func() (io.ReadCloser, error) { return av.download(ev) })
+	switch {
+	case err != nil:
+		logger.Error().Err(err).Msg("could not quarantine file")
+		return events.PPOutcomeAbort
+	case released:
+		logger.Info().Msg("file has been released from quarantine before")
+		return events.PPOutcomeContinue
+	default:
+		return events.PPOutcomeDelete
+	}
+}
+
+// quarantineResource copies an infected existing file into the quarantine space and deletes it from its space.
+func (av Antivirus) quarantineResource(ctx context.Context, p string, info *provider.ResourceInfo, res scanners.Result) (events.PostprocessingOutcome, error) {
+	item := quarantine.Item{
+		Name:        info.GetName(),
+		SpaceID:     storagespace.FormatStorageID(info.GetId().GetStorageId(), info.GetId().GetSpaceId()),
+		Path:        path.Join("/", p),
+		Description: res.Description,
+		ScanDate:    res.ScanTime,
+	}
+	released, err := av.quarantineFile(ctx, item, func() (io.ReadCloser, error) { return av.downloadResource(ctx, info) })
+	switch {
+	case err != nil:
+		return outcomeQuarantine, err
+	case released:
+		return events.PPOutcomeContinue, nil
+	}
+
+	return outcomeQuarantine, av.deleteResource(ctx, info.GetId())
+}
+
+// quarantineFile adds the file to the quarantine space unless the same content has been released to its location before.
+// The file is downloaded into a temporary file to calculate its checksum first.
+func (av Antivirus) quarantineFile(ctx context.Context, item quarantine.Item, download func() (io.ReadCloser, error)) (bool, error) {
+	rc, err := download()
+	if err != nil {
+		return false, err
+	}
+	defer rc.Close()
+
+	f, err := os.CreateTemp("", "antivirus-quarantine-")
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, h), rc)
+	if err != nil {
+		return false, fmt.Errorf("could not download file: %w", err)
+	}
+	item.Size = uint64(n)
+	item.Checksum = hex.EncodeToString(h.Sum(nil))
+
+	released, err := av.quarantine.IsReleased(ctx, item.SpaceID, item.Path, item.Checksum)
+	if err != nil || released {
+		return released, err
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+	item, err = av.quarantine.Add(ctx, item, f)
+	if err != nil {
+		return false, err
+	}
+
+	av.l.Info().Str("quarantineid", item.ID).Str("spaceid", item.SpaceID).Str("path", item.Path).Str("virus", item.Description).Msg("file moved to quarantine")
+	return false, nil
+}
+
+// uploadPath returns the path of the upload relative to the space root. The file name is used if the path can't be determined.
+func (av Antivirus) uploadPath(ctx context.Context, ev events.StartPostprocessingStep) string {
+	fallback := path.Join("/", ev.Filename)
+
+	client, err := av.gatewaySelector.Next()
+	if err != nil {
+		return fallback
+	}
+	ctx, err = utils.GetServiceUserContextWithContext(ctx, client, av.c.ServiceAccount.ServiceAccountID, av.c.ServiceAccount.ServiceAccountSecret)
+	if err != nil {
+		return fallback
+	}
+	res, err := client.GetPath(ctx, &provider.GetPathRequest{ResourceId: ev.ResourceID})
+	if err != nil || res.GetStatus().GetCode() != rpc.Code_CODE_OK || res.GetPath() == "" {
+		return fallback
+	}
+	return path.Join("/", res.GetPath())
+}
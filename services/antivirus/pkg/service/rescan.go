@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	ctxpkg "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/storage/utils/walker"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/event"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/scanners"
+)
+
+// progressInterval is the number of files after which the progress of a re-scan is published
+const progressInterval = 100
+
+// ErrRescanRunning is returned when a re-scan is requested while another one is still running
+var ErrRescanRunning = errors.New("another re-scan is running")
+
+// scheduleRescans triggers a re-scan of all spaces in the given interval until the context is done.
+func (av Antivirus) scheduleRescans(ctx context.Context, interval time.Duration, pub events.Publisher) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ev := event.StartRescan{RescanID: uuid.NewString(), Timestamp: time.Now()}
+			if err := events.Publish(ctx, pub, ev); err != nil {
+				av.l.Error().Err(err).Msg("could not trigger the scheduled re-scan")
+			}
+		}
+	}
+}
+
+// startRescan runs the requested re-scan in the background. Only one re-scan runs at a time.
+func (av Antivirus) startRescan(ctx context.Context, ev event.StartRescan, pub events.Publisher) {
+	if !av.rescanning.CompareAndSwap(false, true) {
+		av.l.Warn().Str("rescanid", ev.RescanID).Msg("skipping re-scan, another re-scan is running")
+		av.publishProgress(ctx, pub, event.RescanProgress{RescanID: ev.RescanID, Finished: true, Error: ErrRescanRunning.Error()})
+		return
+	}
+
+	go func() {
+		defer av.rescanning.Store(false)
+		av.rescan(ctx, ev, pub)
+	}()
+}
+
+// rescan scans the existing files of the requested spaces and handles infected files like infected uploads
+func (av Antivirus) rescan(ctx context.Context, ev event.StartRescan, pub events.Publisher) event.RescanProgress {
+	av.l.Info().Str("rescanid", ev.RescanID).Str("spaceid", ev.SpaceID).Msg("starting re-scan")
+
+	progress := event.RescanProgress{RescanID: ev.RescanID}
+	if err := av.walkSpaces(ctx, ev, pub, &progress); err != nil {
+		av.l.Error().Err(err).Str("rescanid", ev.RescanID).Msg("re-scan failed")
+		progress.Error = err.Error()
+	}
+
+	progress.Finished = true
+	av.publishProgress(ctx, pub, progress)
+	av.l.Info().Str("rescanid", ev.RescanID).Uint64("scanned", progress.Scanned).Uint64("infected", progress.Infected).Uint64("failed", progress.Failed).Msg("re-scan finished")
+	return progress
+}
+
+func (av Antivirus) walkSpaces(ctx context.Context, ev event.StartRescan, pub events.Publisher, progress *event.RescanProgress) error {
+	if av.c.ServiceAccount.ServiceAccountID == "" || av.c.ServiceAccount.ServiceAccountSecret == "" {
+		return errors.New("no service account configured")
+	}
+
+	client, err := av.gatewaySelector.Next()
+	if err != nil {
+		return fmt.Errorf("could not select gateway client: %w", err)
+	}
+	ctx, err = utils.GetServiceUserContextWithContext(ctx, client, av.c.ServiceAccount.ServiceAccountID, av.c.ServiceAccount.ServiceAccountSecret)
+	if err != nil {
+		return fmt.Errorf("could not get service user context: %w", err)
+	}
+
+	spaces, err := listSpaces(ctx, client, ev.SpaceID)
+	if err != nil {
+		return err
+	}
+	progress.Spaces = len(spaces)
+
+	w := walker.NewWalker(av.gatewaySelector)
+	for _, space := range spaces {
+		err := w.Walk(ctx, space.GetRoot(), func(wd string, info *provider.ResourceInfo, err error) error {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			if err != nil {
+				av.l.Error().Err(err).Str("rescanid", ev.RescanID).Str("path", wd).Msg("could not list folder")
+				progress.Failed++
+				return nil
+			}
+			if info.GetType() != provider.ResourceType_RESOURCE_TYPE_FILE {
+				return nil
+			}
+
+			av.rescanFile(ctx, ev.RescanID, filepath.Join(wd, info.GetPath()), info, pub, progress)
+			if (progress.Scanned+progress.Skipped+progress.Failed)%progressInterval == 0 {
+				av.publishProgress(ctx, pub, *progress)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("could not walk space %s: %w", space.GetId().GetOpaqueId(), err)
+		}
+
+		progress.SpacesDone++
+		av.publishProgress(ctx, pub, *progress)
+	}
+	return nil
+}
+
+// rescanFile scans a single file and applies the infected file handling
+func (av Antivirus) rescanFile(ctx context.Context, rescanID string, path string, info *provider.ResourceInfo, pub events.Publisher, progress *event.RescanProgress) {
+	logger := av.l.With().Str("rescanid", rescanID).Str("path", path).Interface("resourceID", info.GetId()).Logger()
+
+	if info.GetSize() == 0 || (0 < av.m && av.m < info.GetSize()) {
+		progress.Skipped++
+		return
+	}
+
+	res, err := av.scanResource(ctx, info)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not scan file")
+		progress.Failed++
+		return
+	}
+	progress.Scanned++
+	if !res.Infected {
+		return
+	}
+
+	progress.Infected++
+	outcome, err := av.handleInfected(ctx, path, info, res)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not handle infected file")
+	}
+
+	logger.Info().Str("virus", res.Description).Str("outcome", string(outcome)).Msg("infected file found")
+	if err := events.Publish(ctx, pub, event.RescanInfected{
+		RescanID:    rescanID,
+		ResourceID:  info.GetId(),
+		Path:        path,
+		Description: res.Description,
+		Outcome:     outcome,
+		Timestamp:   time.Now(),
+	}); err != nil {
+		logger.Error().Err(err).Msg("could not publish infected file")
+	}
+}
+
+// scanResource downloads the file with the token of the service account and scans it
+func (av Antivirus) scanResource(ctx context.Context, info *provider.ResourceInfo) (scanners.Result, error) {
+	rc, err := av.downloadResource(ctx, info)
+	if err != nil {
+		return scanners.Result{}, err
+	}
+	defer rc.Close()
+
+	return av.s.Scan(scanners.Input{Body: rc, Size: int64(info.GetSize()), Url: info.GetPath(), Name: info.GetName()})
+}
+
+// downloadResource downloads the file with the token of the service account
+func (av Antivirus) downloadResource(ctx context.Context, info *provider.ResourceInfo) (io.ReadCloser, error) {
+	client, err := av.gatewaySelector.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.InitiateFileDownload(ctx, &provider.InitiateFileDownloadRequest{Ref: &provider.Reference{ResourceId: info.GetId(), Path: "."}})
+	switch {
+	case err != nil:
+		return nil, err
+	case res.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return nil, fmt.Errorf("could not initiate download: %s", res.GetStatus().GetMessage())
+	}
+
+	var ep, tt string
+	for _, p := range res.GetProtocols() {
+		if p.GetProtocol() == "spaces" {
+			ep, tt = p.GetDownloadEndpoint(), p.GetToken()
+			break
+		}
+	}
+	if (ep == "" || tt == "") && len(res.GetProtocols()) > 0 {
+		ep, tt = res.GetProtocols()[0].GetDownloadEndpoint(), res.GetProtocols()[0].GetToken()
+	}
+
+	// the service user token is only part of the outgoing grpc metadata
+	var token string
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		if t := md.Get(ctxpkg.TokenHeader); len(t) > 0 {
+			token = t[0]
+		}
+	}
+	return av.downloadViaReva(ep, tt, token)
+}
+
+// handleInfected applies the infected file handling to an existing file and returns the applied outcome.
+// Existing files are deleted or quarantined, 'continue' and 'abort' keep the file in place.
+func (av Antivirus) handleInfected(ctx context.Context, path string, info *provider.ResourceInfo, res scanners.Result) (events.PostprocessingOutcome, error) {
+	switch av.o {
+	case events.PPOutcomeDelete:
+		return av.o, av.deleteResource(ctx, info.GetId())
+	case outcomeQuarantine:
+		return av.quarantineResource(ctx, path, info, res)
+	default:
+		return av.o, nil
+	}
+}
+
+// deleteResource deletes an existing file. Like infected uploads it is removed for good, the trashed file is purged
+// from the trash-bin of its space right away.
+func (av Antivirus) deleteResource(ctx context.Context, id *provider.ResourceId) error {
+	client, err := av.gatewaySelector.Next()
+	if err != nil {
+		return err
+	}
+	res, err := client.Delete(ctx, &provider.DeleteRequest{Ref: &provider.Reference{ResourceId: id, Path: "."}})
+	switch {
+	case err != nil:
+		return err
+	case res.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return fmt.Errorf("could not delete file: %s", res.GetStatus().GetMessage())
+	}
+
+	pRes, err := client.PurgeRecycle(ctx, &provider.PurgeRecycleRequest{
+		Ref: &provider.Reference{ResourceId: &provider.ResourceId{StorageId: id.GetStorageId(), SpaceId: id.GetSpaceId(), OpaqueId: id.GetSpaceId()}, Path: "."},
+		Key: id.GetOpaqueId(),
+	})
+	switch {
+	case err != nil:
+		return err
+	case pRes.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return fmt.Errorf("could not purge deleted file: %s", pRes.GetStatus().GetMessage())
+	}
+	return nil
+}
+
+func (av Antivirus) publishProgress(ctx context.Context, pub events.Publisher, progress event.RescanProgress) {
+	progress.Timestamp = time.Now()
+	if err := events.Publish(ctx, pub, progress); err != nil {
+		av.l.Error().Err(err).Str("rescanid", progress.RescanID).Msg("could not publish re-scan progress")
+	}
+}
+
+// listSpaces returns the given space or all personal and project spaces if spaceID is empty
+func listSpaces(ctx context.Context, client gateway.GatewayAPIClient, spaceID string) ([]*provider.StorageSpace, error) {
+	var filters [][]*provider.ListStorageSpacesRequest_Filter
+	if spaceID != "" {
+		filters = append(filters, []*provider.ListStorageSpacesRequest_Filter{
+			{
+				Type: provider.ListStorageSpacesRequest_Filter_TYPE_ID,
+				Term: &provider.ListStorageSpacesRequest_Filter_Id{
+					Id: &provider.StorageSpaceId{OpaqueId: spaceID},
+				},
+			},
+		})
+	} else {
+		for _, spaceType := range []string{"personal", "project"} {
+			filters = append(filters, []*provider.ListStorageSpacesRequest_Filter{
+				{
+					Type: provider.ListStorageSpacesRequest_Filter_TYPE_SPACE_TYPE,
+					Term: &provider.ListStorageSpacesRequest_Filter_SpaceType{
+						SpaceType: spaceType,
+					},
+				},
+			})
+		}
+	}
+
+	var spaces []*provider.StorageSpace
+	for _, f := range filters {
+		res, err := client.ListStorageSpaces(ctx, &provider.ListStorageSpacesRequest{Filters: f})
+		if err != nil {
+			return nil, err
+		}
+		if res.GetStatus().GetCode() != rpc.Code_CODE_OK {
+			return nil, fmt.Errorf("could not list spaces: %s", res.GetStatus().GetMessage())
+		}
+		spaces = append(spaces, res.GetStorageSpaces()...)
+	}
+
+	if spaceID != "" && len(spaces) == 0 {
+		return nil, fmt.Errorf("space %s not found", spaceID)
+	}
+	return spaces, nil
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+	cs3mocks "github.com/cs3org/reva/v2/tests/cs3mocks/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	mevents "go-micro.dev/v4/events"
+	"google.golang.org/grpc"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/config"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/event"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/scanners"
+)
+
+type contentScanner struct{}
+
+func (contentScanner) Scan(in scanners.Input) (scanners.Result, error) {
+	b, err := io.ReadAll(in.Body)
+	if err != nil {
+		return scanners.Result{}, err
+	}
+	return scanners.Result{Infected: string(b) == "virus", Description: "test-virus"}, nil
+}
+
+type recordingPublisher struct {
+	events []interface{}
+}
+
+func (p *recordingPublisher) Publish(_ string, ev interface{}, _ ...mevents.PublishOption) error {
+	p.events = append(p.events, ev)
+	return nil
+}
+
+func TestRescan(t *testing.T) {
+	files := map[string]string{"clean": "content", "infected": "virus"}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "service-token", r.Header.Get("X-Access-Token"))
+		_, _ = io.WriteString(w, files[r.Header.Get("X-Reva-Transfer")])
+	}))
+	defer srv.Close()
+
+	pool.RemoveSelector("GatewaySelector" + "com.owncloud.api.gateway")
+	gatewayClient := &cs3mocks.GatewayAPIClient{}
+	gatewaySelector := pool.GetSelector[gateway.GatewayAPIClient](
+		"GatewaySelector",
+		"com.owncloud.api.gateway",
+		func(cc *grpc.ClientConn) gateway.GatewayAPIClient {
+			return gatewayClient
+		},
+	)
+
+	root := &provider.ResourceId{StorageId: "storage", SpaceId: "space", OpaqueId: "space"}
+	fileInfo := func(name string, size uint64) *provider.ResourceInfo {
+		return &provider.ResourceInfo{
+			Id:   &provider.ResourceId{StorageId: "storage", SpaceId: "space", OpaqueId: name},
+			Type: provider.ResourceType_RESOURCE_TYPE_FILE,
+			Path: name,
+			Name: name,
+			Size: size,
+		}
+	}
+	ok := &rpc.Status{Code: rpc.Code_CODE_OK}
+
+	gatewayClient.On("Authenticate", mock.Anything, mock.Anything).Return(&gateway.AuthenticateResponse{Status: ok, Token: "service-token"}, nil)
+	gatewayClient.On("ListStorageSpaces", mock.Anything, mock.Anything).Return(&provider.ListStorageSpacesResponse{
+		Status:        ok,
+		StorageSpaces: []*provider.StorageSpace{{Id: &provider.StorageSpaceId{OpaqueId: "storage$space"}, Root: root}},
+	}, nil)
+	gatewayClient.On("Stat", mock.Anything, mock.Anything).Return(&provider.StatResponse{
+		Status: ok,
+		Info:   &provider.ResourceInfo{Id: root, Type: provider.ResourceType_RESOURCE_TYPE_CONTAINER, Path: "."},
+	}, nil)
+	gatewayClient.On("ListContainer", mock.Anything, mock.Anything).Return(&provider.ListContainerResponse{
+		Status: ok,
+		Infos:  []*provider.ResourceInfo{fileInfo("clean", 7), fileInfo("infected", 5), fileInfo("empty", 0)},
+	}, nil)
+	gatewayClient.On("InitiateFileDownload", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req *provider.InitiateFileDownloadRequest, _ ...grpc.CallOption) *gateway.InitiateFileDownloadResponse {
+			return &gateway.InitiateFileDownloadResponse{
+				Status:    ok,
+				Protocols: []*gateway.FileDownloadProtocol{{Protocol: "spaces", DownloadEndpoint: srv.URL, Token: req.GetRef().GetResourceId().GetOpaqueId()}},
+			}
+		}, nil)
+	gatewayClient.On("Delete", mock.Anything, mock.MatchedBy(func(req *provider.DeleteRequest) bool {
+		return req.GetRef().GetResourceId().GetOpaqueId() == "infected"
+	})).Return(&provider.DeleteResponse{Status: ok}, nil).Once()
+	gatewayClient.On("PurgeRecycle", mock.Anything, mock.MatchedBy(func(req *provider.PurgeRecycleRequest) bool {
+		return req.GetKey() == "infected" && req.GetRef().GetResourceId().GetOpaqueId() == "space"
+	})).Return(&provider.PurgeRecycleResponse{Status: ok}, nil).Once()
+
+	av := Antivirus{
+		c: &config.Config{ServiceAccount: config.ServiceAccount{ServiceAccountID: "id", ServiceAccountSecret: "secret"}},
+		l: log.NopLogger(),
+		s: contentScanner{},
+		o: events.PPOutcomeDelete,
+
+		client:          srv.Client(),
+		gatewaySelector: gatewaySelector,
+		rescanning:      &atomic.Bool{},
+	}
+
+	pub := &recordingPublisher{}
+	progress := av.rescan(context.Background(), event.StartRescan{RescanID: "rescan", SpaceID: "storage$space"}, pub)
+
+	assert.Empty(t, progress.Error)
+	assert.True(t, progress.Finished)
+	assert.Equal(t, 1, progress.SpacesDone)
+	assert.Equal(t, uint64(2), progress.Scanned)
+	assert.Equal(t, uint64(1), progress.Skipped)
+	assert.Equal(t, uint64(1), progress.Infected)
+	gatewayClient.AssertExpectations(t)
+
+	require.NotEmpty(t, pub.events)
+	infected, ok2 := pub.events[0].(event.RescanInfected)
+	require.True(t, ok2)
+	assert.Equal(t, "infected", infected.Path)
+	assert.Equal(t, "test-virus", infected.Description)
+	assert.Equal(t, events.PPOutcomeDelete, infected.Outcome)
+	last, ok2 := pub.events[len(pub.events)-1].(event.RescanProgress)
+	require.True(t, ok2)
+	assert.True(t, last.Finished)
+	assert.Equal(t, progress.Scanned, last.Scanned)
+}
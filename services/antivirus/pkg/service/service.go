@@ -9,17 +9,23 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"sync/atomic"
 	"time"
 
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
 	"github.com/cs3org/reva/v2/pkg/bytesize"
 	ctxpkg "github.com/cs3org/reva/v2/pkg/ctx"
 	"github.com/cs3org/reva/v2/pkg/events"
 	"github.com/cs3org/reva/v2/pkg/events/stream"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
 	"github.com/cs3org/reva/v2/pkg/rhttp"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quarantine"
+	"github.com/owncloud/ocis/v2/ocis-pkg/registry"
 	"github.com/owncloud/ocis/v2/services/antivirus/pkg/config"
+	"github.com/owncloud/ocis/v2/services/antivirus/pkg/event"
 	"github.com/owncloud/ocis/v2/services/antivirus/pkg/scanners"
 )
 
@@ -31,9 +37,7 @@ var (
 )
 
 // Scanner is an abstraction for the actual virus scan
-type Scanner interface {
-	Scan(body scanners.Input) (scanners.Result, error)
-}
+type Scanner = scanners.Scanner
 
 // NewAntivirus returns a service implementation for Service.
 func NewAntivirus(c *config.Config, l log.Logger, tp trace.TracerProvider) (Antivirus, error) {
@@ -41,22 +45,55 @@ func NewAntivirus(c *config.Config, l log.Logger, tp trace.TracerProvider) (Anti
 	var scanner Scanner
 	var err error
 	switch c.Scanner.Type {
+	case "multi":
+		if len(c.Scanner.Types) == 0 {
+			return Antivirus{}, errors.New("no scanners configured for the multi scanner")
+		}
+		named := make([]scanners.NamedScanner, 0, len(c.Scanner.Types))
+		for _, t := range c.Scanner.Types {
+			s, err := newScanner(t, c.Scanner)
+			if err != nil {
+				return Antivirus{}, err
+			}
+			named = append(named, scanners.NamedScanner{Name: t, Scanner: s})
+		}
+		scanner = scanners.NewMulti(named...)
 	default:
-		return Antivirus{}, fmt.Errorf("unknown av scanner: '%s'", c.Scanner.Type)
-	case "clamav":
-		scanner = scanners.NewClamAV(c.Scanner.ClamAV.Socket)
-	case "icap":
-		scanner, err = scanners.NewICAP(c.Scanner.ICAP.URL, c.Scanner.ICAP.Service, c.Scanner.ICAP.Timeout)
+		scanner, err = newScanner(c.Scanner.Type, c.Scanner)
+	}
+	if err != nil {
+		return Antivirus{}, err
 	}
+
+	tm, err := pool.StringToTLSMode(c.GRPCClientTLS.Mode)
 	if err != nil {
 		return Antivirus{}, err
 	}
+	gatewaySelector, err := pool.GatewaySelector(c.RevaGateway,
+		pool.WithTLSCACert(c.GRPCClientTLS.CACert),
+		pool.WithTLSMode(tm),
+		pool.WithRegistry(registry.GetRegistry()),
+	)
+	if err != nil {
+		return Antivirus{}, fmt.Errorf("could not get gateway selector: %w", err)
+	}
 
-	av := Antivirus{c: c, l: l, tp: tp, s: scanner, client: rhttp.GetHTTPClient(rhttp.Insecure(true))}
+	av := Antivirus{
+		c:               c,
+		l:               l,
+		tp:              tp,
+		s:               scanner,
+		client:          rhttp.GetHTTPClient(rhttp.Insecure(true)),
+		gatewaySelector: gatewaySelector,
+		rescanning:      &atomic.Bool{},
+	}
 
 	switch o := events.PostprocessingOutcome(c.InfectedFileHandling); o {
 	case events.PPOutcomeContinue, events.PPOutcomeAbort, events.PPOutcomeDelete:
 		av.o = o
+	case outcomeQuarantine:
+		av.o = o
+		av.quarantine = quarantine.NewManager(gatewaySelector, av.client, c.ServiceAccount.ServiceAccountID, c.ServiceAccount.ServiceAccountSecret)
 	default:
 		return av, fmt.Errorf("unknown infected file handling '%s'", o)
 	}
@@ -73,6 +110,19 @@ func NewAntivirus(c *config.Config, l log.Logger, tp trace.TracerProvider) (Anti
 	return av, nil
 }
 
+func newScanner(t string, c config.Scanner) (Scanner, error) {
+	switch t {
+	default:
+		return nil, fmt.Errorf("unknown av scanner: '%s'", t)
+	case "clamav":
+		return scanners.NewClamAV(c.ClamAV.Socket), nil
+	case "icap":
+		return scanners.NewICAP(c.ICAP.URL, c.ICAP.Service, c.ICAP.Timeout)
+	case "command":
+		return scanners.NewCommand(c.Command.Path, c.Command.Args, c.Command.InfectedExitCodes, c.Command.DescriptionPattern, c.Command.Timeout)
+	}
+}
+
 // Antivirus defines implements the business logic for Service.
 type Antivirus struct {
 	c  *config.Config
@@ -82,7 +132,10 @@ type Antivirus struct {
 	m  uint64
 	tp trace.TracerProvider
 
-	client *http.Client
+	client          *http.Client
+	gatewaySelector pool.Selectable[gateway.GatewayAPIClient]
+	rescanning      *atomic.Bool
+	quarantine      *quarantine.Manager
 }
 
 // Run runs the service
@@ -111,12 +164,25 @@ func (av Antivirus) Run() error {
 		return err
 	}
 
-	ch, err := events.Consume(natsStream, "antivirus", events.StartPostprocessingStep{})
+	ch, err := events.Consume(natsStream, "antivirus", events.StartPostprocessingStep{}, event.StartRescan{})
 	if err != nil {
 		return err
 	}
 
+	ctx := av.c.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if av.c.Rescan.Interval > 0 {
+		go av.scheduleRescans(ctx, av.c.Rescan.Interval, natsStream)
+	}
+
 	for e := range ch {
+		if ev, ok := e.Event.(event.StartRescan); ok {
+			av.startRescan(ctx, ev, natsStream)
+			continue
+		}
+
 		err := av.processEvent(e, natsStream)
 		if err != nil {
 			switch {
@@ -175,6 +241,8 @@ func (av Antivirus) processEvent(e events.Event, s events.Publisher) error {
 
 	var outcome events.PostprocessingOutcome
 	switch {
+	case res.Infected && av.o == outcomeQuarantine:
+		outcome = av.quarantineUpload(ctx, ev, res)
 	case res.Infected:
 		outcome = av.o
 	case !res.Infected && err == nil:
@@ -216,15 +284,7 @@ func (av Antivirus) process(ev events.StartPostprocessingStep) (scanners.Result,
 		}, nil
 	}
 
-	var err error
-	var rrc io.ReadCloser
-
-	switch ev.UploadID {
-	default:
-		rrc, err = av.downloadViaToken(ev.URL)
-	case "":
-		rrc, err = av.downloadViaReva(ev.URL, ev.Token, ev.RevaToken)
-	}
+	rrc, err := av.download(ev)
 	if err != nil {
 		av.l.Error().Err(err).Str("uploadid", ev.UploadID).Msg("error downloading file")
 		return scanners.Result{}, err
@@ -240,6 +300,14 @@ func (av Antivirus) process(ev events.StartPostprocessingStep) (scanners.Result,
 	return res, err
 }
 
+// download will download the file of the event
+func (av Antivirus) download(ev events.StartPostprocessingStep) (io.ReadCloser, error) {
+	if ev.UploadID == "" {
+		return av.downloadViaReva(ev.URL, ev.Token, ev.RevaToken)
+	}
+	return av.downloadViaToken(ev.URL)
+}
+
 // download will download the file
 func (av Antivirus) downloadViaToken(url string) (io.ReadCloser, error) {
 	req, err := http.NewRequest(http.MethodGet, url, nil)
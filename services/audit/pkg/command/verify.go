@@ -0,0 +1,74 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
+	"github.com/owncloud/ocis/v2/services/audit/pkg/config"
+	"github.com/owncloud/ocis/v2/services/audit/pkg/config/parser"
+	svc "github.com/owncloud/ocis/v2/services/audit/pkg/service"
+	"github.com/urfave/cli/v2"
+)
+
+// Verify is the entrypoint for the verify command.
+func Verify(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:      "verify",
+		Usage:     "verify the hash chain of audit log files",
+		ArgsUsage: "FILE [FILE...]",
+		Description: "Rotated files must be passed from the oldest to the newest file to verify the chain across files. " +
+			"If no file is given, the rotated files and the current file of the configured audit log are verified.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "key",
+				Usage: "the key the hash chain has been signed with, defaults to the configured key",
+			},
+		},
+		Before: func(c *cli.Context) error {
+			return configlog.ReturnFatal(parser.ParseConfig(cfg))
+		},
+		Action: func(c *cli.Context) error {
+			key := cfg.Auditlog.HashChain.Key
+			if c.IsSet("key") {
+				key = c.String("key")
+			}
+
+			files := c.Args().Slice()
+			if len(files) == 0 {
+				if cfg.Auditlog.FilePath == "" {
+					return errors.New("no audit log file given")
+				}
+				backups, err := svc.Backups(cfg.Auditlog.FilePath)
+				if err != nil {
+					return err
+				}
+				files = append(backups, cfg.Auditlog.FilePath)
+			}
+
+			prev := ""
+			for _, name := range files {
+				res, err := verifyFile(name, []byte(key), prev)
+				if err != nil {
+					return fmt.Errorf("hash chain verification failed: %w", err)
+				}
+				if res.Records > 0 {
+					prev = res.LastHash
+				}
+				fmt.Printf("%s: %d records verified\n", name, res.Records)
+			}
+			fmt.Println("hash chain is valid")
+			return nil
+		},
+	}
+}
+
+func verifyFile(name string, key []byte, prev string) (svc.VerifyResult, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return svc.VerifyResult{}, err
+	}
+	defer f.Close()
+	return svc.VerifyHashChain(f, name, key, prev)
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"context"
+	"time"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
 )
@@ -37,8 +38,34 @@ type Events struct {
 type Auditlog struct {
 	LogToConsole bool   `yaml:"log_to_console" env:"AUDIT_LOG_TO_CONSOLE" desc:"Logs to stdout if set to 'true'. Independent of the LOG_TO_FILE option." introductionVersion:"pre5.0"`
 	LogToFile    bool   `yaml:"log_to_file" env:"AUDIT_LOG_TO_FILE" desc:"Logs to file if set to 'true'. Independent of the LOG_TO_CONSOLE option." introductionVersion:"pre5.0"`
+	LogToSyslog  bool   `yaml:"log_to_syslog" env:"AUDIT_LOG_TO_SYSLOG" desc:"Logs to syslog using the RFC5424 format if set to 'true'. Independent of the LOG_TO_CONSOLE and LOG_TO_FILE options." introductionVersion:"6.0.0"`
 	FilePath     string `yaml:"filepath" env:"AUDIT_FILEPATH" desc:"Filepath of the logfile. Mandatory if LOG_TO_FILE is set to 'true'." introductionVersion:"pre5.0"`
 	Format       string `yaml:"format" env:"AUDIT_FORMAT" desc:"Log format. Supported values are '' (empty) and 'json'. Using 'json' is advised, '' (empty) renders the 'minimal' format. See the text description for more details." introductionVersion:"pre5.0"`
+
+	Rotation  Rotation  `yaml:"rotation"`
+	Syslog    Syslog    `yaml:"syslog"`
+	HashChain HashChain `yaml:"hash_chain"`
+}
+
+// Rotation holds the rotation configuration of the audit log file
+type Rotation struct {
+	MaxSize    string        `yaml:"max_size" env:"AUDIT_FILE_ROTATION_MAX_SIZE" desc:"The size after which the audit log file is rotated. Usable common abbreviations: [KB, KiB, MB, MiB, GB, GiB, TB, TiB, PB, PiB, EB, EiB], example: 100MB. Leave empty to not rotate by size." introductionVersion:"6.0.0"`
+	Interval   time.Duration `yaml:"interval" env:"AUDIT_FILE_ROTATION_INTERVAL" desc:"The interval after which the audit log file is rotated, for example '24h'. Set to '0' to not rotate by time. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+	MaxBackups int           `yaml:"max_backups" env:"AUDIT_FILE_ROTATION_MAX_BACKUPS" desc:"The number of rotated audit log files to keep. Older files are removed. Set to '0' to keep all rotated files." introductionVersion:"6.0.0"`
+}
+
+// Syslog holds the syslog configuration
+type Syslog struct {
+	Network  string `yaml:"network" env:"AUDIT_SYSLOG_NETWORK" desc:"The network used to connect to the syslog server. Supported values are 'udp', 'tcp', 'unix' and 'unixgram'. Leave empty to use the local syslog socket." introductionVersion:"6.0.0"`
+	Address  string `yaml:"address" env:"AUDIT_SYSLOG_ADDRESS" desc:"The address of the syslog server, for example 'localhost:514'. Mandatory if AUDIT_SYSLOG_NETWORK is set." introductionVersion:"6.0.0"`
+	Facility string `yaml:"facility" env:"AUDIT_SYSLOG_FACILITY" desc:"The syslog facility of the audit messages. Supported values are 'kern', 'user', 'mail', 'daemon', 'auth', 'syslog', 'lpr', 'news', 'uucp', 'cron', 'authpriv', 'ftp', 'audit' and 'local0' to 'local7'." introductionVersion:"6.0.0"`
+	AppName  string `yaml:"app_name" env:"AUDIT_SYSLOG_APP_NAME" desc:"The APP-NAME of the syslog messages." introductionVersion:"6.0.0"`
+}
+
+// HashChain holds the configuration of the tamper-evident hash chain
+type HashChain struct {
+	Enabled bool   `yaml:"enabled" env:"AUDIT_HASH_CHAIN_ENABLED" desc:"Adds a hash to every audit record that is chained to the hash of the previous record if set to 'true'. Requires the 'json' format. See the text description for more details." introductionVersion:"6.0.0"`
+	Key     string `yaml:"key" env:"AUDIT_HASH_CHAIN_KEY" desc:"The secret key used to sign the hash chain with HMAC-SHA256. If empty, plain SHA256 hashes are used which only detect accidental modifications." introductionVersion:"6.0.0"`
 }
 
 // Tracing defines the available tracing configuration.
@@ -31,6 +31,10 @@ func DefaultConfig() *config.Config {
 		Auditlog: config.Auditlog{
 			LogToConsole: true,
 			Format:       "json",
+			Syslog: config.Syslog{
+				Facility: "local0",
+				AppName:  "ocis-audit",
+			},
 		},
 	}
 }
@@ -2,6 +2,9 @@ package parser
 
 import (
 	"errors"
+	"fmt"
+
+	"github.com/cs3org/reva/v2/pkg/bytesize"
 
 	ociscfg "github.com/owncloud/ocis/v2/ocis-pkg/config"
 	"github.com/owncloud/ocis/v2/services/audit/pkg/config"
@@ -34,5 +37,35 @@ func ParseConfig(cfg *config.Config) error {
 
 // Validate validates the configuration
 func Validate(cfg *config.Config) error {
+	al := cfg.Auditlog
+	if al.LogToFile && al.FilePath == "" {
+		return errors.New("the audit log file path must be set when logging to a file")
+	}
+
+	if al.Rotation.MaxSize != "" {
+		if _, err := bytesize.Parse(al.Rotation.MaxSize); err != nil {
+			return fmt.Errorf("invalid audit log rotation max size '%s': %w", al.Rotation.MaxSize, err)
+		}
+	}
+
+	if al.LogToSyslog {
+		switch al.Syslog.Network {
+		case "":
+		case "udp", "tcp", "unix", "unixgram":
+			if al.Syslog.Address == "" {
+				return fmt.Errorf("the syslog address must be set when using the '%s' network", al.Syslog.Network)
+			}
+		default:
+			return fmt.Errorf("unknown syslog network '%s'", al.Syslog.Network)
+		}
+		if _, ok := al.Syslog.FacilityCode(); !ok {
+			return fmt.Errorf("unknown syslog facility '%s'", al.Syslog.Facility)
+		}
+	}
+
+	if al.HashChain.Enabled && al.Format != "json" {
+		return errors.New("the audit log hash chain requires the 'json' format")
+	}
+
 	return nil
 }
@@ -0,0 +1,33 @@
+package config
+
+import "strings"
+
+var syslogFacilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"audit":    13,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+// FacilityCode returns the numeric syslog facility for the name of the configured facility
+func (s Syslog) FacilityCode() (int, bool) {
+	f, ok := syslogFacilities[strings.ToLower(s.Facility)]
+	return f, ok
+}
@@ -0,0 +1,200 @@
+package svc
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"regexp"
+	"sync"
+)
+
+// The hash chain appends the fields "PrevHash" and "Hash" to every json record. The hash of a record is calculated
+// over the hash of the previous record and the record without these two fields, so removing, reordering or
+// changing records breaks the chain.
+const (
+	prevHashField = `"PrevHash":"`
+	hashField     = `"Hash":"`
+)
+
+var chainSuffix = regexp.MustCompile(`,?"PrevHash":"([0-9a-f]*)","Hash":"([0-9a-f]{64})"}$`)
+
+// ChainError is returned when the hash chain of an audit log is broken
+type ChainError struct {
+	File   string
+	Line   int
+	Reason string
+}
+
+// Error implements the error interface
+func (e ChainError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Reason)
+}
+
+// VerifyResult holds the result of a hash chain verification
+type VerifyResult struct {
+	Records   int
+	FirstHash string
+	LastHash  string
+}
+
+// HashChain returns a Marshaller which chains the records of the given Marshaller.
+// The chain continues from the hash of the previous record `prev`, the first record of a new chain uses an empty hash.
+func HashChain(marshaller Marshaller, key []byte, prev string) Marshaller {
+	var mu sync.Mutex
+	return func(ev interface{}) ([]byte, error) {
+		b, err := marshaller(ev)
+		if err != nil {
+			return nil, err
+		}
+		b = bytes.TrimSpace(b)
+		if len(b) < 2 || b[0] != '{' || b[len(b)-1] != '}' {
+			return nil, errors.New("hash chain requires json objects")
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		sum := chainHash(key, prev, b)
+
+		out := make([]byte, 0, len(b)+len(prev)+len(sum)+32)
+		out = append(out, b[:len(b)-1]...)
+		if len(b) > 2 {
+			out = append(out, ',')
+		}
+		out = append(out, prevHashField+prev+`",`+hashField+sum+`"}`...)
+
+		prev = sum
+		return out, nil
+	}
+}
+
+// VerifyHashChain verifies the hash chain of the records read from r. The first record may continue a chain
+// of previous files, its PrevHash is only checked if `prev` is not empty.
+func VerifyHashChain(r io.Reader, name string, key []byte, prev string) (VerifyResult, error) {
+	res := VerifyResult{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		record := bytes.TrimSpace(scanner.Bytes())
+		if len(record) == 0 {
+			continue
+		}
+
+		m := chainSuffix.FindSubmatchIndex(record)
+		if m == nil {
+			return res, ChainError{File: name, Line: line, Reason: "record has no hash"}
+		}
+		recordPrev := string(record[m[2]:m[3]])
+		recordHash := string(record[m[4]:m[5]])
+		content := append(record[:m[0]:m[0]], '}')
+
+		if (res.Records > 0 || prev != "") && recordPrev != prev {
+			return res, ChainError{File: name, Line: line, Reason: "previous hash does not match, records have been removed or reordered"}
+		}
+		if chainHash(key, recordPrev, content) != recordHash {
+			return res, ChainError{File: name, Line: line, Reason: "hash does not match, the record has been modified"}
+		}
+
+		if res.Records == 0 {
+			res.FirstHash = recordHash
+		}
+		res.Records++
+		res.LastHash = recordHash
+		prev = recordHash
+	}
+	return res, scanner.Err()
+}
+
+// LastHash returns the hash of the last record of an audit log file. If the file is empty or doesn't exist yet,
+// the last hash of its newest rotated file is used.
+func LastHash(path string) (string, error) {
+	backups, err := Backups(path)
+	if err != nil {
+		return "", err
+	}
+
+	// the current file first, then the rotated files from the newest to the oldest
+	files := []string{path}
+	for i := len(backups) - 1; i >= 0; i-- {
+		files = append(files, backups[i])
+	}
+	for _, f := range files {
+		record, err := lastRecord(f)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			continue
+		case err != nil:
+			return "", err
+		case record == nil:
+			continue
+		}
+
+		m := chainSuffix.FindSubmatch(record)
+		if m == nil {
+			return "", fmt.Errorf("the last record of '%s' has no hash", f)
+		}
+		return string(m[2]), nil
+	}
+	return "", nil
+}
+
+// lastRecord reads the last non-empty line of a file from its end
+func lastRecord(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	const chunkSize = 64 * 1024
+	var buf []byte
+	for offset := info.Size(); offset > 0; {
+		n := int64(chunkSize)
+		if offset < n {
+			n = offset
+		}
+		offset -= n
+
+		chunk := make([]byte, n)
+		if _, err := f.ReadAt(chunk, offset); err != nil {
+			return nil, err
+		}
+		buf = append(chunk, buf...)
+
+		trimmed := bytes.TrimRight(buf, "\r\n\t ")
+		if i := bytes.LastIndexByte(trimmed, '\n'); i >= 0 {
+			return trimmed[i+1:], nil
+		}
+		if offset == 0 && len(trimmed) > 0 {
+			return trimmed, nil
+		}
+	}
+	return nil, nil
+}
+
+func chainHash(key []byte, prev string, content []byte) string {
+	var h hash.Hash
+	if len(key) > 0 {
+		h = hmac.New(sha256.New, key)
+	} else {
+		h = sha256.New()
+	}
+	h.Write([]byte(prev))
+	h.Write([]byte{'\n'})
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,99 @@
+package svc
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/owncloud/ocis/v2/services/audit/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func chainedRecords(t *testing.T, key []byte, prev string, messages ...string) []string {
+	m := HashChain(json.Marshal, key, prev)
+	records := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		b, err := m(types.AuditEvent{Message: msg, Action: "file_create"})
+		require.NoError(t, err)
+		records = append(records, string(b))
+	}
+	return records
+}
+
+func TestHashChain(t *testing.T) {
+	key := []byte("secret")
+	records := chainedRecords(t, key, "", "one", "two", "three")
+
+	// the records are still valid json
+	ev := struct {
+		types.AuditEvent
+		PrevHash string
+		Hash     string
+	}{}
+	require.NoError(t, json.Unmarshal([]byte(records[1]), &ev))
+	require.Equal(t, "two", ev.Message)
+	require.Len(t, ev.Hash, 64)
+	require.NotEmpty(t, ev.PrevHash)
+
+	res, err := VerifyHashChain(strings.NewReader(strings.Join(records, "\n")), "audit.log", key, "")
+	require.NoError(t, err)
+	require.Equal(t, 3, res.Records)
+	require.Equal(t, ev.PrevHash, res.FirstHash)
+
+	t.Run("modified record", func(t *testing.T) {
+		modified := append([]string{}, records...)
+		modified[1] = strings.Replace(modified[1], "two", "TWO", 1)
+		_, err := VerifyHashChain(strings.NewReader(strings.Join(modified, "\n")), "audit.log", key, "")
+		require.Equal(t, ChainError{File: "audit.log", Line: 2, Reason: "hash does not match, the record has been modified"}, err)
+	})
+
+	t.Run("removed record", func(t *testing.T) {
+		removed := []string{records[0], records[2]}
+		_, err := VerifyHashChain(strings.NewReader(strings.Join(removed, "\n")), "audit.log", key, "")
+		require.Equal(t, ChainError{File: "audit.log", Line: 2, Reason: "previous hash does not match, records have been removed or reordered"}, err)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		_, err := VerifyHashChain(strings.NewReader(strings.Join(records, "\n")), "audit.log", []byte("other"), "")
+		require.Error(t, err)
+	})
+
+	t.Run("continued chain", func(t *testing.T) {
+		next := chainedRecords(t, key, res.LastHash, "four")
+		_, err := VerifyHashChain(strings.NewReader(next[0]), "audit.log.1", key, res.LastHash)
+		require.NoError(t, err)
+		_, err = VerifyHashChain(strings.NewReader(next[0]), "audit.log.1", key, res.FirstHash)
+		require.Error(t, err)
+	})
+}
+
+func TestLastHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	prev, err := LastHash(path)
+	require.NoError(t, err)
+	require.Equal(t, "", prev)
+
+	records := chainedRecords(t, nil, "", "one", "two")
+	require.NoError(t, os.WriteFile(path+".20261018T100000.000000000", []byte(strings.Join(records, "\n")+"\n"), 0600))
+	res, err := VerifyHashChain(strings.NewReader(strings.Join(records, "\n")), "", nil, "")
+	require.NoError(t, err)
+
+	// the current file doesn't exist, the rotated file is used
+	prev, err = LastHash(path)
+	require.NoError(t, err)
+	require.Equal(t, res.LastHash, prev)
+
+	// long records are read across chunks
+	records = chainedRecords(t, nil, prev, string(bytes.Repeat([]byte("x"), 100*1024)))
+	require.NoError(t, os.WriteFile(path, []byte(records[0]+"\n\n"), 0600))
+	res, err = VerifyHashChain(strings.NewReader(records[0]), "", nil, prev)
+	require.NoError(t, err)
+
+	prev, err = LastHash(path)
+	require.NoError(t, err)
+	require.Equal(t, res.LastHash, prev)
+}
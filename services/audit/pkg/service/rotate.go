@@ -0,0 +1,150 @@
+package svc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cs3org/reva/v2/pkg/bytesize"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/audit/pkg/config"
+)
+
+// backupTimeFormat is appended to the name of rotated audit log files. It sorts in chronological order.
+const backupTimeFormat = "20060102T150405.000000000"
+
+// rotatingFile is an audit log file that is rotated by size and age
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	interval   time.Duration
+	maxBackups int
+
+	file    *os.File
+	size    int64
+	started time.Time
+	now     func() time.Time
+}
+
+// WriteToRotatingFile returns a Log function writing to a file that is rotated by size and age
+func WriteToRotatingFile(path string, cfg config.Rotation, log log.Logger) Log {
+	rf := newRotatingFile(path, cfg)
+	return func(content []byte) {
+		if err := rf.write(append(content, '\n')); err != nil {
+			log.Error().Err(err).Msgf("error writing to file '%s'", path)
+		}
+	}
+}
+
+func newRotatingFile(path string, cfg config.Rotation) *rotatingFile {
+	maxSize, _ := bytesize.Parse(cfg.MaxSize)
+	return &rotatingFile{
+		path:       path,
+		maxSize:    int64(maxSize),
+		interval:   cfg.Interval,
+		maxBackups: cfg.MaxBackups,
+		now:        time.Now,
+	}
+}
+
+func (rf *rotatingFile) write(b []byte) error {
+	if rf.file == nil {
+		if err := rf.open(); err != nil {
+			return err
+		}
+	}
+
+	if rf.needsRotation(int64(len(b))) {
+		if err := rf.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := rf.file.Write(b)
+	rf.size += int64(n)
+	return err
+}
+
+// needsRotation checks if the file is too big or too old. Files are never rotated while empty,
+// so a single record which is larger than the max size still gets written.
+func (rf *rotatingFile) needsRotation(n int64) bool {
+	switch {
+	case rf.size == 0:
+		return false
+	case rf.maxSize > 0 && rf.size+n > rf.maxSize:
+		return true
+	case rf.interval > 0 && rf.now().Sub(rf.started) >= rf.interval:
+		return true
+	default:
+		return false
+	}
+}
+
+func (rf *rotatingFile) open() error {
+	file, err := os.OpenFile(rf.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	rf.file = file
+	rf.size = info.Size()
+	rf.started = rf.now()
+	return nil
+}
+
+func (rf *rotatingFile) rotate() error {
+	if err := rf.file.Close(); err != nil {
+		return err
+	}
+	rf.file = nil
+
+	backup := rf.path + "." + rf.now().UTC().Format(backupTimeFormat)
+	if err := os.Rename(rf.path, backup); err != nil {
+		return fmt.Errorf("could not rotate file: %w", err)
+	}
+	if err := rf.removeOldBackups(); err != nil {
+		return err
+	}
+	return rf.open()
+}
+
+func (rf *rotatingFile) removeOldBackups() error {
+	if rf.maxBackups <= 0 {
+		return nil
+	}
+	backups, err := Backups(rf.path)
+	if err != nil {
+		return err
+	}
+	for len(backups) > rf.maxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// Backups returns the rotated files of an audit log file, the oldest file first
+func Backups(path string) ([]string, error) {
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return nil, err
+	}
+
+	backups := make([]string, 0, len(matches))
+	for _, m := range matches {
+		if _, err := time.Parse(backupTimeFormat, strings.TrimPrefix(m, path+".")); err == nil {
+			backups = append(backups, m)
+		}
+	}
+	sort.Strings(backups)
+	return backups, nil
+}
@@ -0,0 +1,44 @@
+package svc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/owncloud/ocis/v2/services/audit/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+
+	rf := newRotatingFile(path, config.Rotation{MaxSize: "10", Interval: time.Hour, MaxBackups: 2})
+	rf.now = func() time.Time { return now }
+
+	require.NoError(t, rf.write([]byte("1234\n")))
+	require.NoError(t, rf.write([]byte("1234\n")))
+	// exceeds the max size
+	now = now.Add(time.Second)
+	require.NoError(t, rf.write([]byte("abc\n")))
+	// exceeds the interval
+	now = now.Add(time.Hour)
+	require.NoError(t, rf.write([]byte("def\n")))
+	now = now.Add(time.Hour)
+	require.NoError(t, rf.write([]byte("ghi\n")))
+
+	backups, err := Backups(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		path + ".20261018T110001.000000000",
+		path + ".20261018T120001.000000000",
+	}, backups)
+
+	b, err := os.ReadFile(backups[0])
+	require.NoError(t, err)
+	require.Equal(t, "abc\n", string(b))
+	b, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "ghi\n", string(b))
+}
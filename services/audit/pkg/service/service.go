@@ -19,7 +19,7 @@ type Log func([]byte)
 type Marshaller func(interface{}) ([]byte, error)
 
 // AuditLoggerFromConfig will start a new AuditLogger generated from the config
-func AuditLoggerFromConfig(ctx context.Context, cfg config.Auditlog, ch <-chan events.Event, log log.Logger) {
+func AuditLoggerFromConfig(ctx context.Context, cfg config.Auditlog, ch <-chan events.Event, log log.Logger) error {
 	var logs []Log
 
 	if cfg.LogToConsole {
@@ -27,11 +27,32 @@ func AuditLoggerFromConfig(ctx context.Context, cfg config.Auditlog, ch <-chan e
 	}
 
 	if cfg.LogToFile {
-		logs = append(logs, WriteToFile(cfg.FilePath, log))
+		if cfg.Rotation.MaxSize != "" || cfg.Rotation.Interval > 0 {
+			logs = append(logs, WriteToRotatingFile(cfg.FilePath, cfg.Rotation, log))
+		} else {
+			logs = append(logs, WriteToFile(cfg.FilePath, log))
+		}
+	}
+
+	if cfg.LogToSyslog {
+		logs = append(logs, WriteToSyslog(cfg.Syslog, log))
 	}
 
-	StartAuditLogger(ctx, ch, log, Marshal(cfg.Format, log), logs...)
+	marshaller := Marshal(cfg.Format, log)
+	if cfg.HashChain.Enabled {
+		// continue the chain of the existing audit log file
+		var prev string
+		if cfg.LogToFile {
+			var err error
+			if prev, err = LastHash(cfg.FilePath); err != nil {
+				return fmt.Errorf("could not read the hash chain of '%s': %w", cfg.FilePath, err)
+			}
+		}
+		marshaller = HashChain(marshaller, []byte(cfg.HashChain.Key), prev)
+	}
 
+	StartAuditLogger(ctx, ch, log, marshaller, logs...)
+	return nil
 }
 
 // StartAuditLogger will block. run in separate go routine
@@ -0,0 +1,107 @@
+package svc
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/audit/pkg/config"
+)
+
+// severityInfo is the syslog severity used for all audit messages
+const severityInfo = 6
+
+// localSyslogSockets are tried in order when no syslog network is configured
+var localSyslogSockets = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// syslogWriter sends RFC5424 formatted messages to a syslog server. The connection is established lazily and
+// re-established once if writing fails.
+type syslogWriter struct {
+	network  string
+	address  string
+	priority int
+	hostname string
+	appName  string
+	procID   string
+
+	conn net.Conn
+	now  func() time.Time
+}
+
+// WriteToSyslog returns a Log function writing RFC5424 formatted messages to syslog
+func WriteToSyslog(cfg config.Syslog, log log.Logger) Log {
+	w := newSyslogWriter(cfg)
+	return func(content []byte) {
+		if err := w.write(content); err != nil {
+			log.Error().Err(err).Msgf("error writing to syslog '%s'", w.address)
+		}
+	}
+}
+
+func newSyslogWriter(cfg config.Syslog) *syslogWriter {
+	facility, _ := cfg.FacilityCode()
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	appName := cfg.AppName
+	if appName == "" {
+		appName = "-"
+	}
+	return &syslogWriter{
+		network:  cfg.Network,
+		address:  cfg.Address,
+		priority: facility*8 + severityInfo,
+		hostname: hostname,
+		appName:  appName,
+		procID:   fmt.Sprint(os.Getpid()),
+		now:      time.Now,
+	}
+}
+
+// format renders a message in the RFC5424 format
+func (w *syslogWriter) format(content []byte) string {
+	return fmt.Sprintf("<%d>1 %s %s %s %s audit - %s",
+		w.priority, w.now().UTC().Format(time.RFC3339Nano), w.hostname, w.appName, w.procID, content)
+}
+
+func (w *syslogWriter) write(content []byte) error {
+	msg := w.format(content)
+	// stream based connections need a framing, see RFC6587 octet counting
+	if w.network == "tcp" {
+		msg = fmt.Sprintf("%d %s", len(msg), msg)
+	}
+
+	var err error
+	for i := 0; i < 2; i++ {
+		if w.conn == nil {
+			if w.conn, err = w.dial(); err != nil {
+				return err
+			}
+		}
+		if _, err = w.conn.Write([]byte(msg)); err == nil {
+			return nil
+		}
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+	return err
+}
+
+func (w *syslogWriter) dial() (net.Conn, error) {
+	if w.network != "" {
+		return net.Dial(w.network, w.address)
+	}
+
+	for _, socket := range localSyslogSockets {
+		for _, network := range []string{"unixgram", "unix"} {
+			if conn, err := net.Dial(network, socket); err == nil {
+				return conn, nil
+			}
+		}
+	}
+	return nil, errors.New("no local syslog socket found")
+}
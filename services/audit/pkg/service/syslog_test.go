@@ -0,0 +1,27 @@
+package svc
+
+import (
+	"net"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/owncloud/ocis/v2/services/audit/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyslog(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	w := newSyslogWriter(config.Syslog{Network: "udp", Address: conn.LocalAddr().String(), Facility: "local0", AppName: "ocis-audit"})
+	w.now = func() time.Time { return time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC) }
+	require.NoError(t, w.write([]byte(`{"Action":"file_create"}`)))
+
+	buf := make([]byte, 1024)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	require.Regexp(t, regexp.MustCompile(`^<134>1 2026-10-18T10:00:00Z \S+ ocis-audit \d+ audit - \{"Action":"file_create"\}$`), string(buf[:n]))
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/owncloud/ocis/v2/services/clientlog/pkg/logging"
 	"github.com/owncloud/ocis/v2/services/clientlog/pkg/metrics"
 	"github.com/owncloud/ocis/v2/services/clientlog/pkg/service"
+	collaboration "github.com/owncloud/ocis/v2/services/collaboration/pkg/event"
 	"github.com/urfave/cli/v2"
 )
 
@@ -42,6 +43,7 @@ var _registeredEvents = []events.Unmarshaller{
 	events.LinkCreated{},
 	events.LinkUpdated{},
 	events.LinkRemoved{},
+	collaboration.EditingStarted{},
 }
 
 // Server is the entrypoint for the server command.
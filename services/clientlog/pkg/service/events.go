@@ -11,3 +11,11 @@ type FileEvent struct {
 	// Only in case of sharing (refactor this into separate struct when more fields are needed)
 	AffectedUserIDs []string `json:"affecteduserids"`
 }
+
+// EditingEvent is emitted when a user starts editing a file in an office app
+type EditingEvent struct {
+	FileEvent
+	UserID      string `json:"userid"`
+	DisplayName string `json:"displayname"`
+	AppName     string `json:"appname"`
+}
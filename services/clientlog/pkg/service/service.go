@@ -22,6 +22,7 @@ import (
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/services/clientlog/pkg/config"
+	collaboration "github.com/owncloud/ocis/v2/services/collaboration/pkg/event"
 )
 
 // ClientlogService is the service responsible for user activities
@@ -159,6 +160,9 @@ func (cl *ClientlogService) processEvent(event events.Event) {
 		fileEv("link-updated", &provider.Reference{ResourceId: e.ItemID})
 	case events.LinkRemoved:
 		fileEv("link-removed", &provider.Reference{ResourceId: e.ItemID})
+	case collaboration.EditingStarted:
+		evType = "editing-started"
+		users, data, err = processEditingStartedEvent(ctx, e, gwc, event.InitiatorID)
 	}
 
 	if err != nil {
@@ -215,6 +219,21 @@ func processShareEvent(ctx context.Context, ref *provider.Reference, gwc gateway
 	return addShareeData(ctx, gwc, data, users, shareeID, shareeGroupID)
 }
 
+// adds the editing user to the file event
+func processEditingStartedEvent(ctx context.Context, e collaboration.EditingStarted, gwc gateway.GatewayAPIClient, initiatorid string) ([]string, EditingEvent, error) {
+	users, data, err := processFileEvent(ctx, e.Ref, gwc, initiatorid)
+	if err != nil {
+		return users, EditingEvent{}, err
+	}
+
+	return users, EditingEvent{
+		FileEvent:   data,
+		UserID:      e.Executant.GetOpaqueId(),
+		DisplayName: e.DisplayName,
+		AppName:     e.AppName,
+	}, nil
+}
+
 // custom logic for item trashed event
 func processItemTrashedEvent(ctx context.Context, ref *provider.Reference, gwc gateway.GatewayAPIClient, initiatorid string, itemID *provider.ResourceId) ([]string, FileEvent, error) {
 	resp, err := gwc.ListRecycle(ctx, &provider.ListRecycleRequest{
@@ -5,7 +5,12 @@ package mocks
 import (
 	context "context"
 
+	connector "github.com/owncloud/ocis/v2/services/collaboration/pkg/connector"
+
 	fileinfo "github.com/owncloud/ocis/v2/services/collaboration/pkg/connector/fileinfo"
+
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -194,6 +199,116 @@ func (_c *FileConnectorService_Lock_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// PutRelativeFile provides a mock function with given fields: ctx, stream, streamLength, target, isSuggested, overwrite
+func (_m *FileConnectorService) PutRelativeFile(ctx context.Context, stream io.Reader, streamLength int64, target string, isSuggested bool, overwrite bool) (*connector.PutRelativeResponse, error) {
+	ret := _m.Called(ctx, stream, streamLength, target, isSuggested, overwrite)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutRelativeFile")
+	}
+
+	var r0 *connector.PutRelativeResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader, int64, string, bool, bool) (*connector.PutRelativeResponse, error)); ok {
+		return rf(ctx, stream, streamLength, target, isSuggested, overwrite)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader, int64, string, bool, bool) *connector.PutRelativeResponse); ok {
+		r0 = rf(ctx, stream, streamLength, target, isSuggested, overwrite)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*connector.PutRelativeResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, io.Reader, int64, string, bool, bool) error); ok {
+		r1 = rf(ctx, stream, streamLength, target, isSuggested, overwrite)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FileConnectorService_PutRelativeFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutRelativeFile'
+type FileConnectorService_PutRelativeFile_Call struct {
+	*mock.Call
+}
+
+// PutRelativeFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - stream io.Reader
+//   - streamLength int64
+//   - target string
+//   - isSuggested bool
+//   - overwrite bool
+func (_e *FileConnectorService_Expecter) PutRelativeFile(ctx interface{}, stream interface{}, streamLength interface{}, target interface{}, isSuggested interface{}, overwrite interface{}) *FileConnectorService_PutRelativeFile_Call {
+	return &FileConnectorService_PutRelativeFile_Call{Call: _e.mock.On("PutRelativeFile", ctx, stream, streamLength, target, isSuggested, overwrite)}
+}
+
+func (_c *FileConnectorService_PutRelativeFile_Call) Run(run func(ctx context.Context, stream io.Reader, streamLength int64, target string, isSuggested bool, overwrite bool)) *FileConnectorService_PutRelativeFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(io.Reader), args[2].(int64), args[3].(string), args[4].(bool), args[5].(bool))
+	})
+	return _c
+}
+
+func (_c *FileConnectorService_PutRelativeFile_Call) Return(_a0 *connector.PutRelativeResponse, _a1 error) *FileConnectorService_PutRelativeFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FileConnectorService_PutRelativeFile_Call) RunAndReturn(run func(context.Context, io.Reader, int64, string, bool, bool) (*connector.PutRelativeResponse, error)) *FileConnectorService_PutRelativeFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PutUserInfo provides a mock function with given fields: ctx, userInfo
+func (_m *FileConnectorService) PutUserInfo(ctx context.Context, userInfo string) error {
+	ret := _m.Called(ctx, userInfo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutUserInfo")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userInfo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FileConnectorService_PutUserInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutUserInfo'
+type FileConnectorService_PutUserInfo_Call struct {
+	*mock.Call
+}
+
+// PutUserInfo is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userInfo string
+func (_e *FileConnectorService_Expecter) PutUserInfo(ctx interface{}, userInfo interface{}) *FileConnectorService_PutUserInfo_Call {
+	return &FileConnectorService_PutUserInfo_Call{Call: _e.mock.On("PutUserInfo", ctx, userInfo)}
+}
+
+func (_c *FileConnectorService_PutUserInfo_Call) Run(run func(ctx context.Context, userInfo string)) *FileConnectorService_PutUserInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *FileConnectorService_PutUserInfo_Call) Return(_a0 error) *FileConnectorService_PutUserInfo_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *FileConnectorService_PutUserInfo_Call) RunAndReturn(run func(context.Context, string) error) *FileConnectorService_PutUserInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RefreshLock provides a mock function with given fields: ctx, lockID
 func (_m *FileConnectorService) RefreshLock(ctx context.Context, lockID string) (string, error) {
 	ret := _m.Called(ctx, lockID)
@@ -251,6 +366,64 @@ func (_c *FileConnectorService_RefreshLock_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// RenameFile provides a mock function with given fields: ctx, lockID, target
+func (_m *FileConnectorService) RenameFile(ctx context.Context, lockID string, target string) (string, error) {
+	ret := _m.Called(ctx, lockID, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameFile")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, lockID, target)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, lockID, target)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, lockID, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FileConnectorService_RenameFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameFile'
+type FileConnectorService_RenameFile_Call struct {
+	*mock.Call
+}
+
+// RenameFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lockID string
+//   - target string
+func (_e *FileConnectorService_Expecter) RenameFile(ctx interface{}, lockID interface{}, target interface{}) *FileConnectorService_RenameFile_Call {
+	return &FileConnectorService_RenameFile_Call{Call: _e.mock.On("RenameFile", ctx, lockID, target)}
+}
+
+func (_c *FileConnectorService_RenameFile_Call) Run(run func(ctx context.Context, lockID string, target string)) *FileConnectorService_RenameFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *FileConnectorService_RenameFile_Call) Return(_a0 string, _a1 error) *FileConnectorService_RenameFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FileConnectorService_RenameFile_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *FileConnectorService_RenameFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UnLock provides a mock function with given fields: ctx, lockID
 func (_m *FileConnectorService) UnLock(ctx context.Context, lockID string) (string, error) {
 	ret := _m.Called(ctx, lockID)
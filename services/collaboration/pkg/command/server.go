@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/events/stream"
+	"github.com/cs3org/reva/v2/pkg/store"
 	"github.com/oklog/run"
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
+	"github.com/owncloud/ocis/v2/ocis-pkg/editingsessions"
 	"github.com/owncloud/ocis/v2/ocis-pkg/tracing"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/config"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/config/parser"
@@ -17,6 +21,7 @@ import (
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/server/grpc"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/server/http"
 	"github.com/urfave/cli/v2"
+	microstore "go-micro.dev/v4/store"
 )
 
 // Server is the entrypoint for the server command.
@@ -44,48 +49,85 @@ func Server(cfg *config.Config) *cli.Command {
 			}()
 			defer cancel()
 
-			// prepare components
-			if err := helpers.RegisterOcisService(ctx, cfg, logger); err != nil {
-				return err
-			}
-
 			gwc, err := helpers.GetCS3apiClient(cfg, false)
 			if err != nil {
 				return err
 			}
 
-			appUrls, err := helpers.GetAppURLs(cfg, logger)
-			if err != nil {
-				return err
-			}
+			st := store.Create(
+				store.Store(cfg.Store.Store),
+				store.TTL(cfg.Store.TTL),
+				store.Size(cfg.Store.Size),
+				microstore.Nodes(cfg.Store.Nodes...),
+				microstore.Database(cfg.Store.Database),
+				microstore.Table(cfg.Store.Table),
+				store.Authentication(cfg.Store.AuthUsername, cfg.Store.AuthPassword),
+			)
 
-			if err := helpers.RegisterAppProvider(ctx, cfg, logger, gwc, appUrls); err != nil {
-				return err
-			}
+			// the editing sessions are read by the graph service
+			sessions := editingsessions.NewManager(store.Create(
+				store.Store(cfg.Store.Store),
+				microstore.Nodes(cfg.Store.Nodes...),
+				microstore.Database(editingsessions.Database),
+				microstore.Table(editingsessions.Table),
+				store.Authentication(cfg.Store.AuthUsername, cfg.Store.AuthPassword),
+			))
 
-			// start GRPC server
-			grpcServer, teardown, err := grpc.Server(
-				grpc.AppURLs(appUrls),
-				grpc.Config(cfg),
-				grpc.Logger(logger),
-			)
-			defer teardown()
-			if err != nil {
-				logger.Error().Err(err).Str("transport", "grpc").Msg("Failed to initialize server")
-				return err
+			var publisher events.Stream
+			if cfg.Events.Endpoint != "" {
+				publisher, err = stream.NatsFromConfig(cfg.Service.Name, false, stream.NatsConfig(cfg.Events))
+				if err != nil {
+					logger.Error().Err(err).Msg("Failed to initialize the events publisher")
+					return err
+				}
 			}
 
-			gr.Add(func() error {
-				l, err := net.Listen("tcp", cfg.GRPC.Addr)
+			// each app is registered with its own GRPC service, the WOPI
+			// requests of all apps are served by the same HTTP service
+			adapters := make(map[string]*connector.HttpAdapter, len(cfg.Apps))
+			for _, app := range cfg.Apps {
+				appCfg := cfg.ForApp(app)
+
+				// prepare components
+				if err := helpers.RegisterOcisService(ctx, appCfg, logger); err != nil {
+					return err
+				}
+
+				appUrls, err := helpers.GetAppURLs(appCfg, logger)
+				if err != nil {
+					return err
+				}
+
+				if err := helpers.RegisterAppProvider(ctx, appCfg, logger, gwc, appUrls); err != nil {
+					return err
+				}
+
+				// start GRPC server
+				grpcServer, teardown, err := grpc.Server(
+					grpc.AppURLs(appUrls),
+					grpc.Config(appCfg),
+					grpc.Logger(logger),
+				)
+				defer teardown()
 				if err != nil {
+					logger.Error().Err(err).Str("transport", "grpc").Str("app", app.Name).Msg("Failed to initialize server")
 					return err
 				}
-				return grpcServer.Serve(l)
-			},
-				func(err error) {
-					logger.Error().Err(err).Str("server", "grpc").Msg("shutting down server")
-					cancel()
-				})
+
+				gr.Add(func() error {
+					l, err := net.Listen("tcp", appCfg.GRPC.Addr)
+					if err != nil {
+						return err
+					}
+					return grpcServer.Serve(l)
+				},
+					func(err error) {
+						logger.Error().Err(err).Str("server", "grpc").Str("app", app.Name).Msg("shutting down server")
+						cancel()
+					})
+
+				adapters[app.Name] = connector.NewHttpAdapter(gwc, appCfg, st, sessions, publisher)
+			}
 
 			// start debug server
 			debugServer, err := debug.Server(
@@ -105,7 +147,7 @@ func Server(cfg *config.Config) *cli.Command {
 
 			// start HTTP server
 			httpServer, err := http.Server(
-				http.Adapter(connector.NewHttpAdapter(gwc, cfg)),
+				http.Adapters(adapters),
 				http.Logger(logger),
 				http.Config(cfg),
 				http.Context(ctx),
@@ -9,4 +9,23 @@ type App struct {
 
 	Addr     string `yaml:"addr" env:"COLLABORATION_APP_ADDR" desc:"The URL where the WOPI app is located, such as https://127.0.0.1:8080." introductionVersion:"6.0.0"`
 	Insecure bool   `yaml:"insecure" env:"COLLABORATION_APP_INSECURE" desc:"Skip TLS certificate verification when connecting to the WOPI app" introductionVersion:"6.0.0"`
+
+	MimeTypes []string `yaml:"mimetypes" env:"COLLABORATION_APP_MIMETYPES" desc:"A list of mimetypes the app will be registered for. Mimetypes which aren't supported by the app will be ignored. If empty, the app will be registered for all the mimetypes it supports. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+
+	// GRPCAddr is the address the GRPC service of the app will listen on.
+	// It's only used for the apps configured in the "apps" list, each of
+	// them needs its own address. The GRPC address of the service is used
+	// for the first app if empty.
+	GRPCAddr string `yaml:"grpc_addr"`
+}
+
+// ForApp returns a copy of the configuration for the app. The copy can be
+// used by the components which only serve a single app.
+func (c *Config) ForApp(app App) *Config {
+	appCfg := *c
+	appCfg.App = app
+	if app.GRPCAddr != "" {
+		appCfg.GRPC.Addr = app.GRPCAddr
+	}
+	return &appCfg
 }
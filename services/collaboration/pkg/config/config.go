@@ -13,6 +13,7 @@ type Config struct {
 
 	Service Service `yaml:"-"`
 	App     App     `yaml:"app"`
+	Apps    []App   `yaml:"apps"`
 
 	TokenManager *TokenManager `yaml:"token_manager"`
 
@@ -21,6 +22,8 @@ type Config struct {
 
 	Wopi   Wopi   `yaml:"wopi"`
 	CS3Api CS3Api `yaml:"cs3api"`
+	Store  Store  `yaml:"store"`
+	Events Events `yaml:"events"`
 
 	Tracing *Tracing `yaml:"tracing"`
 	Log     *Log     `yaml:"log"`
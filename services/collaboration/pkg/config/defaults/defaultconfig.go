@@ -52,6 +52,16 @@ func DefaultConfig() *config.Config {
 				Insecure: false,
 			},
 		},
+		Store: config.Store{
+			Store:    "nats-js-kv",
+			Nodes:    []string{"127.0.0.1:9233"},
+			Database: "collaboration",
+			Table:    "",
+		},
+		Events: config.Events{
+			Endpoint: "127.0.0.1:9233",
+			Cluster:  "ocis-cluster",
+		},
 	}
 }
 
@@ -92,5 +102,23 @@ func EnsureDefaults(cfg *config.Config) {
 
 // Sanitize sanitized the configuration
 func Sanitize(cfg *config.Config) {
-	// sanitize config
+	// the app configured via the COLLABORATION_APP_* variables is only
+	// served if there is no list of apps
+	if len(cfg.Apps) == 0 {
+		cfg.Apps = []config.App{cfg.App}
+	}
+
+	for i := range cfg.Apps {
+		if cfg.Apps[i].LockName == "" {
+			cfg.Apps[i].LockName = DefaultConfig().App.LockName
+		}
+	}
+
+	if cfg.Apps[0].GRPCAddr == "" {
+		cfg.Apps[0].GRPCAddr = cfg.GRPC.Addr
+	}
+
+	// the first app is the main app, its name is used for the services
+	// which are shared by all apps, such as the HTTP service
+	cfg.App = cfg.Apps[0]
 }
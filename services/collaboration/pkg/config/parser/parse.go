@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 
 	ociscfg "github.com/owncloud/ocis/v2/ocis-pkg/config"
 	ocisdefaults "github.com/owncloud/ocis/v2/ocis-pkg/config/defaults"
@@ -59,5 +60,35 @@ func Validate(cfg *config.Config) error {
 			cfg.Service.Name, ocisdefaults.BaseConfigPath())
 	}
 
+	return validateApps(cfg)
+}
+
+// validateApps checks that the apps can be served together
+func validateApps(cfg *config.Config) error {
+	names := make(map[string]bool, len(cfg.Apps))
+	grpcAddrs := make(map[string]bool, len(cfg.Apps))
+	for _, app := range cfg.Apps {
+		if app.Name == "" {
+			return fmt.Errorf("The name of a WOPI app is missing in your config for %s", cfg.Service.Name)
+		}
+		if app.Addr == "" {
+			return fmt.Errorf("The address of the WOPI app %s is missing in your config for %s", app.Name, cfg.Service.Name)
+		}
+		if app.GRPCAddr == "" {
+			return fmt.Errorf("The GRPC address of the WOPI app %s is missing in your config for %s. "+
+				"Each app needs its own GRPC address", app.Name, cfg.Service.Name)
+		}
+
+		name := strings.ToLower(app.Name)
+		if names[name] {
+			return fmt.Errorf("The WOPI app %s is configured more than once in your config for %s", app.Name, cfg.Service.Name)
+		}
+		names[name] = true
+
+		if grpcAddrs[app.GRPCAddr] {
+			return fmt.Errorf("The GRPC address %s is used by more than one WOPI app in your config for %s", app.GRPCAddr, cfg.Service.Name)
+		}
+		grpcAddrs[app.GRPCAddr] = true
+	}
 	return nil
 }
@@ -240,6 +240,39 @@ func (c *ContentConnector) PutFile(ctx context.Context, stream io.Reader, stream
 		return "", NewConnectorError(409, "File must be locked first")
 	}
 
+	req := &providerv1beta1.InitiateFileUploadRequest{
+		Ref:    &wopiContext.FileReference,
+		LockId: lockID,
+		Options: &providerv1beta1.InitiateFileUploadRequest_IfMatch{
+			IfMatch: statRes.GetInfo().GetEtag(),
+		},
+	}
+
+	if err := uploadFile(ctx, c.gwc, c.cfg, req, stream, streamLength); err != nil {
+		return "", err
+	}
+
+	logger.Debug().Msg("PutFile: success")
+	return "", nil
+}
+
+// uploadFile initiates the upload described by the request and uploads the
+// stream up to the stream length to the returned upload endpoint.
+//
+// The context MUST have a WOPI context, the access token of the WOPI context
+// will be used for the upload. The upload length will be added to the
+// request.
+func uploadFile(ctx context.Context, gwc gatewayv1beta1.GatewayAPIClient, cfg *config.Config, req *providerv1beta1.InitiateFileUploadRequest, stream io.Reader, streamLength int64) error {
+	wopiContext, err := middleware.WopiContextFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := zerolog.Ctx(ctx).With().
+		Str("RequestedLockID", req.GetLockId()).
+		Int64("UploadLength", streamLength).
+		Logger()
+
 	// Prepare the data to initiate the upload
 	opaque := &types.Opaque{
 		Map: make(map[string]*types.OpaqueEntry),
@@ -252,20 +285,13 @@ func (c *ContentConnector) PutFile(ctx context.Context, stream io.Reader, stream
 		}
 	}
 
-	req := &providerv1beta1.InitiateFileUploadRequest{
-		Opaque: opaque,
-		Ref:    &wopiContext.FileReference,
-		LockId: lockID,
-		Options: &providerv1beta1.InitiateFileUploadRequest_IfMatch{
-			IfMatch: statRes.GetInfo().GetEtag(),
-		},
-	}
+	req.Opaque = opaque
 
 	// Initiate the upload request
-	resp, err := c.gwc.InitiateFileUpload(ctx, req)
+	resp, err := gwc.InitiateFileUpload(ctx, req)
 	if err != nil {
 		logger.Error().Err(err).Msg("UploadHelper: InitiateFileUpload failed")
-		return "", err
+		return err
 	}
 
 	if resp.GetStatus().GetCode() != rpcv1beta1.Code_CODE_OK {
@@ -273,7 +299,7 @@ func (c *ContentConnector) PutFile(ctx context.Context, stream io.Reader, stream
 			Str("StatusCode", resp.GetStatus().GetCode().String()).
 			Str("StatusMsg", resp.GetStatus().GetMessage()).
 			Msg("UploadHelper: InitiateFileUpload failed with wrong status")
-		return "", NewConnectorError(500, resp.GetStatus().GetCode().String()+" "+resp.GetStatus().GetMessage())
+		return NewConnectorError(500, resp.GetStatus().GetCode().String()+" "+resp.GetStatus().GetMessage())
 	}
 
 	// if the content length is greater than 0, we need to upload the content to the
@@ -298,13 +324,13 @@ func (c *ContentConnector) PutFile(ctx context.Context, stream io.Reader, stream
 				Str("Endpoint", uploadEndpoint).
 				Bool("HasUploadToken", hasUploadToken).
 				Msg("UploadHelper: Upload endpoint or token is missing")
-			return "", NewConnectorError(500, "upload endpoint or token is missing")
+			return NewConnectorError(500, "upload endpoint or token is missing")
 		}
 
 		httpClient := http.Client{
 			Transport: &http.Transport{
 				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: c.cfg.CS3Api.DataGateway.Insecure,
+					InsecureSkipVerify: cfg.CS3Api.DataGateway.Insecure,
 				},
 			},
 			Timeout: 10 * time.Second,
@@ -318,7 +344,7 @@ func (c *ContentConnector) PutFile(ctx context.Context, stream io.Reader, stream
 				Str("Endpoint", uploadEndpoint).
 				Bool("HasUploadToken", hasUploadToken).
 				Msg("UploadHelper: Could not create the request to the endpoint")
-			return "", err
+			return err
 		}
 		// "stream" is an *http.body and doesn't fill the httpReq.ContentLength automatically
 		// we need to fill the ContentLength ourselves, and must match the stream length in order
@@ -331,7 +357,7 @@ func (c *ContentConnector) PutFile(ctx context.Context, stream io.Reader, stream
 		}
 		httpReq.Header.Add("X-Access-Token", wopiContext.AccessToken)
 
-		httpReq.Header.Add("X-Lock-Id", lockID)
+		httpReq.Header.Add("X-Lock-Id", req.GetLockId())
 		// TODO: better mechanism for the upload while locked, relies on patch in REVA
 		//if lockID, ok := ctxpkg.ContextGetLockID(ctx); ok {
 		//	httpReq.Header.Add("X-Lock-Id", lockID)
@@ -344,7 +370,7 @@ func (c *ContentConnector) PutFile(ctx context.Context, stream io.Reader, stream
 				Str("Endpoint", uploadEndpoint).
 				Bool("HasUploadToken", hasUploadToken).
 				Msg("UploadHelper: Put request to the upload endpoint failed")
-			return "", err
+			return err
 		}
 		defer httpResp.Body.Close()
 
@@ -354,10 +380,9 @@ func (c *ContentConnector) PutFile(ctx context.Context, stream io.Reader, stream
 				Bool("HasUploadToken", hasUploadToken).
 				Int("HttpCode", httpResp.StatusCode).
 				Msg("UploadHelper: Put request to the upload endpoint failed with unexpected status")
-			return "", NewConnectorError(500, "PutFile: Uploading the file failed")
+			return NewConnectorError(500, "PutFile: Uploading the file failed")
 		}
 	}
 
-	logger.Debug().Msg("PutFile: success")
-	return "", nil
+	return nil
 }
@@ -3,10 +3,15 @@ package connector
 import (
 	"context"
 	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
 	"path"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	appproviderv1beta1 "github.com/cs3org/go-cs3apis/cs3/app/provider/v1beta1"
 	gatewayv1beta1 "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
@@ -14,20 +19,49 @@ import (
 	rpcv1beta1 "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
 	providerv1beta1 "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
 	typesv1beta1 "github.com/cs3org/go-cs3apis/cs3/types/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
 	"github.com/cs3org/reva/v2/pkg/utils"
 	"github.com/google/uuid"
+	"github.com/owncloud/ocis/v2/ocis-pkg/editingsessions"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/config"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/connector/fileinfo"
+	"github.com/owncloud/ocis/v2/services/collaboration/pkg/event"
+	"github.com/owncloud/ocis/v2/services/collaboration/pkg/helpers"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/middleware"
 	"github.com/rs/zerolog"
+	microstore "go-micro.dev/v4/store"
 )
 
 const (
 	// WOPI Locks generally have a lock duration of 30 minutes and will be refreshed before expiration if needed
 	// https://docs.microsoft.com/en-us/microsoft-365/cloud-storage-partner-program/rest/concepts#lock
 	lockDuration time.Duration = 30 * time.Minute
+
+	// maxNameCandidates is the number of numbered names, such as
+	// "name (1).docx", that will be tried to find a free file name
+	maxNameCandidates = 100
+	// maxFileNameLength is the maximum length of a file name in bytes
+	maxFileNameLength = 255
 )
 
+// PutRelativeResponse contains the result of the PutRelativeFile operation.
+//
+// If the operation is successful, the Name, Url, HostViewUrl and HostEditUrl
+// properties describe the new file and will be sent to the WOPI client as
+// json. In case of a 409 conflict, the ValidTarget and LockID properties
+// contain the values for the "X-WOPI-ValidRelativeTarget" and "X-WOPI-Lock"
+// headers
+type PutRelativeResponse struct {
+	Name        string `json:"Name"`
+	Url         string `json:"Url"`
+	HostViewUrl string `json:"HostViewUrl,omitempty"`
+	HostEditUrl string `json:"HostEditUrl,omitempty"`
+
+	ValidTarget string `json:"-"`
+	LockID      string `json:"-"`
+}
+
 // FileConnectorService is the interface to implement the "Files"
 // endpoint. Basically lock operations on the file plus the CheckFileInfo.
 // All operations need a context containing a WOPI context and, optionally,
@@ -52,21 +86,49 @@ type FileConnectorService interface {
 	UnLock(ctx context.Context, lockID string) (string, error)
 	// CheckFileInfo will return the file information of the target file
 	CheckFileInfo(ctx context.Context) (fileinfo.FileInfo, error)
+	// PutRelativeFile uploads the stream up to the stream length as a new
+	// file next to the target file. The target is either the exact name of
+	// the new file, or a suggestion (a name or just an extension) which can
+	// be adjusted to avoid conflicts. An existing file will only be replaced
+	// if the target isn't a suggestion and overwrite is true.
+	// The name and URLs of the new file will be returned
+	PutRelativeFile(ctx context.Context, stream io.Reader, streamLength int64, target string, isSuggested, overwrite bool) (*PutRelativeResponse, error)
+	// RenameFile renames the target file keeping its extension. The target
+	// name doesn't contain the extension. The current lockID needs to be
+	// provided if the file is locked.
+	// The new name (without extension) will be returned, or the current
+	// lockID if a conflict happens
+	RenameFile(ctx context.Context, lockID, target string) (string, error)
+	// PutUserInfo stores the user info of the current user. It will be
+	// returned in the CheckFileInfo response for that user
+	PutUserInfo(ctx context.Context, userInfo string) error
 }
 
 // FileConnector implements the "File" endpoint.
-// Currently, it handles file locks and getting the file info.
+// Currently, it handles file locks, getting the file info, creating files
+// relative to the target file, renaming the target file and storing the
+// user info.
 // Note that operations might return any kind of error, not just ConnectorError
 type FileConnector struct {
-	gwc gatewayv1beta1.GatewayAPIClient
-	cfg *config.Config
+	gwc       gatewayv1beta1.GatewayAPIClient
+	cfg       *config.Config
+	store     microstore.Store
+	sessions  *editingsessions.Manager
+	publisher events.Publisher
 }
 
-// NewFileConnector creates a new file connector
-func NewFileConnector(gwc gatewayv1beta1.GatewayAPIClient, cfg *config.Config) *FileConnector {
+// NewFileConnector creates a new file connector. The store will be used to
+// keep the user info provided by the WOPI client. The editing sessions of
+// the users will be tracked with the sessions manager, and an EditingStarted
+// event will be published when a user starts editing a file. Both the
+// sessions manager and the publisher are optional
+func NewFileConnector(gwc gatewayv1beta1.GatewayAPIClient, cfg *config.Config, st microstore.Store, sessions *editingsessions.Manager, publisher events.Publisher) *FileConnector {
 	return &FileConnector{
-		gwc: gwc,
-		cfg: cfg,
+		gwc:       gwc,
+		cfg:       cfg,
+		store:     st,
+		sessions:  sessions,
+		publisher: publisher,
 	}
 }
 
@@ -203,6 +265,7 @@ func (f *FileConnector) Lock(ctx context.Context, lockID, oldLockID string) (str
 	switch setOrRefreshStatus.GetCode() {
 	case rpcv1beta1.Code_CODE_OK:
 		logger.Debug().Msg("SetLock successful")
+		f.refreshSessions(ctx, wopiContext)
 		return "", nil
 
 	case rpcv1beta1.Code_CODE_FAILED_PRECONDITION, rpcv1beta1.Code_CODE_ABORTED:
@@ -312,6 +375,7 @@ func (f *FileConnector) RefreshLock(ctx context.Context, lockID string) (string,
 	switch resp.GetStatus().GetCode() {
 	case rpcv1beta1.Code_CODE_OK:
 		logger.Debug().Msg("RefreshLock successful")
+		f.refreshSessions(ctx, wopiContext)
 		return "", nil
 
 	case rpcv1beta1.Code_CODE_NOT_FOUND:
@@ -416,6 +480,7 @@ func (f *FileConnector) UnLock(ctx context.Context, lockID string) (string, erro
 	switch resp.GetStatus().GetCode() {
 	case rpcv1beta1.Code_CODE_OK:
 		logger.Debug().Msg("Unlock successful")
+		f.endSessions(ctx, wopiContext)
 		return "", nil
 	case rpcv1beta1.Code_CODE_ABORTED:
 		// File isn't locked. Need to return 409 with empty lock
@@ -529,13 +594,18 @@ func (f *FileConnector) CheckFileInfo(ctx context.Context) (fileinfo.FileInfo, e
 		// if we have a wopiContext.User
 		isPublicShare = utils.ExistsInOpaque(wopiContext.User.GetOpaque(), "public-share-role")
 		if !isPublicShare {
-			hexEncodedWopiUserId := hex.EncodeToString([]byte(wopiContext.User.GetId().GetOpaqueId() + "@" + wopiContext.User.GetId().GetIdp()))
 			isAnonymousUser = false
 			userFriendlyName = wopiContext.User.GetDisplayName()
-			userId = hexEncodedWopiUserId
+			userId = hexEncodedUserID(wopiContext.User)
 		}
 	}
 
+	canWrite := wopiContext.ViewMode == appproviderv1beta1.ViewMode_VIEW_MODE_READ_WRITE
+	if canWrite {
+		// every user opening the file for editing calls CheckFileInfo, the lock is shared between all of them
+		f.trackSession(ctx, wopiContext)
+	}
+
 	// fileinfo map
 	infoMap := map[string]interface{}{
 		fileinfo.KeyOwnerID:           hexEncodedOwnerId,
@@ -553,14 +623,28 @@ func (f *FileConnector) CheckFileInfo(ctx context.Context) (fileinfo.FileInfo, e
 		fileinfo.KeySupportsExtendedLockLength: true,
 		fileinfo.KeySupportsGetLock:            true,
 		fileinfo.KeySupportsLocks:              true,
+		fileinfo.KeySupportsRename:             true,
 		fileinfo.KeySupportsUpdate:             true,
+		fileinfo.KeySupportsUserInfo:           !isAnonymousUser,
 
-		fileinfo.KeyUserCanNotWriteRelative: true,
+		fileinfo.KeyUserCanNotWriteRelative: !canWrite,
+		fileinfo.KeyUserCanRename:           canWrite,
 		fileinfo.KeyIsAnonymousUser:         isAnonymousUser,
 		fileinfo.KeyUserFriendlyName:        userFriendlyName,
 		fileinfo.KeyUserID:                  userId,
 	}
 
+	if !isAnonymousUser {
+		records, err := f.store.Read(f.userInfoKey(wopiContext.User))
+		switch {
+		case err == nil && len(records) > 0:
+			infoMap[fileinfo.KeyUserInfo] = string(records[0].Value)
+		case err != nil && !errors.Is(err, microstore.ErrNotFound):
+			// the user info is optional, don't fail because of it
+			logger.Error().Err(err).Msg("CheckFileInfo: failed to read the user info")
+		}
+	}
+
 	switch wopiContext.ViewMode {
 	case appproviderv1beta1.ViewMode_VIEW_MODE_READ_WRITE:
 		infoMap[fileinfo.KeyUserCanWrite] = true
@@ -583,6 +667,470 @@ func (f *FileConnector) CheckFileInfo(ctx context.Context) (fileinfo.FileInfo, e
 	return info, nil
 }
 
+// PutRelativeFile creates a new file next to the target file
+// https://docs.microsoft.com/en-us/microsoft-365/cloud-storage-partner-program/rest/files/putrelativefile
+//
+// The context MUST have a WOPI context, otherwise an error will be returned.
+// You can pass a pre-configured zerologger instance through the context that
+// will be used to log messages.
+//
+// The contents of the new file will be read from the stream. The full
+// stream length must be provided in order to upload the file.
+//
+// If isSuggested is true, the target is a suggested name for the new file,
+// or just the extension if it starts with a dot (the name of the target file
+// will be used then). If a file with that name already exists, a numbered
+// name such as "name (1).docx" will be used instead.
+//
+// Otherwise, the target is the exact name for the new file. If a file with
+// that name already exists and overwrite is false, a 409 ConnectorError will
+// be returned along with a response containing a valid target name. If the
+// existing file is locked, a 409 ConnectorError will be returned along with a
+// response containing the current lock id, even if overwrite is true.
+//
+// If the operation is successful, the response contains the name of the new
+// file, the WOPI URL (including an access token) and the app URLs to open it.
+// Invalid target names will cause a 400 ConnectorError.
+func (f *FileConnector) PutRelativeFile(ctx context.Context, stream io.Reader, streamLength int64, target string, isSuggested, overwrite bool) (*PutRelativeResponse, error) {
+	wopiContext, err := middleware.WopiContextFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := zerolog.Ctx(ctx).With().
+		Str("RequestedTarget", target).
+		Bool("IsSuggested", isSuggested).
+		Bool("Overwrite", overwrite).
+		Int64("UploadLength", streamLength).
+		Logger()
+
+	if wopiContext.ViewMode != appproviderv1beta1.ViewMode_VIEW_MODE_READ_WRITE {
+		logger.Error().Msg("PutRelativeFile: not supported in the current view mode")
+		return nil, NewConnectorError(501, "PutRelativeFile isn't supported in the current view mode")
+	}
+
+	info, err := f.stat(ctx, &wopiContext.FileReference)
+	if err != nil {
+		logger.Error().Err(err).Msg("PutRelativeFile: stat failed")
+		return nil, err
+	}
+
+	parentID := info.GetParentId()
+	name := target
+	if isSuggested && strings.HasPrefix(target, ".") {
+		// only the extension was suggested
+		currentName := path.Base(info.GetPath())
+		name = strings.TrimSuffix(currentName, path.Ext(currentName)) + target
+	}
+
+	if !validFileName(name) {
+		logger.Error().Str("Name", name).Msg("PutRelativeFile: invalid target name")
+		return nil, NewConnectorError(400, "Invalid target name")
+	}
+
+	targetInfo, err := f.statInContainer(ctx, parentID, name)
+	if err != nil {
+		logger.Error().Err(err).Msg("PutRelativeFile: stat of the target failed")
+		return nil, err
+	}
+
+	req := &providerv1beta1.InitiateFileUploadRequest{
+		Options: &providerv1beta1.InitiateFileUploadRequest_IfNotExist{
+			IfNotExist: true,
+		},
+	}
+
+	if targetInfo != nil {
+		switch {
+		case isSuggested:
+			name, err = f.freeName(ctx, parentID, name)
+			if err != nil {
+				logger.Error().Err(err).Msg("PutRelativeFile: no free name found")
+				return nil, err
+			}
+
+		case !overwrite:
+			validTarget, err := f.freeName(ctx, parentID, name)
+			if err != nil {
+				logger.Error().Err(err).Msg("PutRelativeFile: no free name found")
+				return nil, err
+			}
+			logger.Error().
+				Str("ValidTarget", validTarget).
+				Msg("PutRelativeFile: target already exists")
+			return &PutRelativeResponse{ValidTarget: validTarget}, NewConnectorError(409, "Target already exists")
+
+		case targetInfo.GetLock() != nil:
+			logger.Error().
+				Str("LockID", targetInfo.GetLock().GetLockId()).
+				Msg("PutRelativeFile: target is locked")
+			return &PutRelativeResponse{LockID: targetInfo.GetLock().GetLockId()}, NewConnectorError(409, "Target is locked")
+
+		default:
+			req.Options = &providerv1beta1.InitiateFileUploadRequest_IfMatch{
+				IfMatch: targetInfo.GetEtag(),
+			}
+		}
+	}
+
+	req.Ref = &providerv1beta1.Reference{
+		ResourceId: parentID,
+		Path:       utils.MakeRelativePath(name),
+	}
+	if err := uploadFile(ctx, f.gwc, f.cfg, req, stream, streamLength); err != nil {
+		return nil, err
+	}
+
+	newInfo, err := f.statInContainer(ctx, parentID, name)
+	if err != nil {
+		logger.Error().Err(err).Msg("PutRelativeFile: stat of the new file failed")
+		return nil, err
+	}
+	if newInfo == nil {
+		logger.Error().Str("Name", name).Msg("PutRelativeFile: new file not found")
+		return nil, NewConnectorError(500, "New file not found")
+	}
+
+	response, err := f.newFileResponse(wopiContext, newInfo.GetId(), name)
+	if err != nil {
+		logger.Error().Err(err).Msg("PutRelativeFile: failed to generate the URLs of the new file")
+		return nil, err
+	}
+
+	logger.Debug().Str("Name", name).Msg("PutRelativeFile: success")
+	return response, nil
+}
+
+// RenameFile renames the target file
+// https://docs.microsoft.com/en-us/microsoft-365/cloud-storage-partner-program/rest/files/renamefile
+//
+// The context MUST have a WOPI context, otherwise an error will be returned.
+// You can pass a pre-configured zerologger instance through the context that
+// will be used to log messages.
+//
+// The target is the requested name without the extension, the extension
+// of the file will be kept. If a file with the requested name already exists,
+// a numbered name such as "name (1)" will be used instead.
+//
+// If the operation is successful, the new name of the file (without the
+// extension) will be returned. If the file is locked with a different lock
+// id, the current lock id will be returned along with a 409 ConnectorError.
+// Invalid names will cause a 400 ConnectorError.
+func (f *FileConnector) RenameFile(ctx context.Context, lockID, target string) (string, error) {
+	wopiContext, err := middleware.WopiContextFromCtx(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	logger := zerolog.Ctx(ctx).With().
+		Str("RequestedLockID", lockID).
+		Str("RequestedName", target).
+		Logger()
+
+	if wopiContext.ViewMode != appproviderv1beta1.ViewMode_VIEW_MODE_READ_WRITE {
+		logger.Error().Msg("RenameFile: not supported in the current view mode")
+		return "", NewConnectorError(501, "RenameFile isn't supported in the current view mode")
+	}
+
+	info, err := f.stat(ctx, &wopiContext.FileReference)
+	if err != nil {
+		logger.Error().Err(err).Msg("RenameFile: stat failed")
+		return "", err
+	}
+
+	if info.GetLock() != nil && info.GetLock().GetLockId() != lockID {
+		logger.Error().
+			Str("LockID", info.GetLock().GetLockId()).
+			Msg("RenameFile: wrong lock")
+		return info.GetLock().GetLockId(), NewConnectorError(409, "Wrong lock")
+	}
+
+	currentName := path.Base(info.GetPath())
+	ext := path.Ext(currentName)
+	name := target + ext
+	if target == "" || !validFileName(name) {
+		logger.Error().Str("Name", name).Msg("RenameFile: invalid name")
+		return "", NewConnectorError(400, "Invalid file name")
+	}
+
+	if name == currentName {
+		logger.Debug().Msg("RenameFile: name unchanged")
+		return target, nil
+	}
+
+	name, err = f.freeName(ctx, info.GetParentId(), name)
+	if err != nil {
+		logger.Error().Err(err).Msg("RenameFile: no free name found")
+		return "", err
+	}
+
+	resp, err := f.gwc.Move(ctx, &providerv1beta1.MoveRequest{
+		Source: &wopiContext.FileReference,
+		Destination: &providerv1beta1.Reference{
+			ResourceId: info.GetParentId(),
+			Path:       utils.MakeRelativePath(name),
+		},
+		LockId: lockID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("RenameFile: move failed")
+		return "", err
+	}
+
+	switch resp.GetStatus().GetCode() {
+	case rpcv1beta1.Code_CODE_OK:
+		logger.Debug().Str("Name", name).Msg("RenameFile: success")
+		return strings.TrimSuffix(name, ext), nil
+
+	case rpcv1beta1.Code_CODE_NOT_FOUND:
+		logger.Error().
+			Str("StatusCode", resp.GetStatus().GetCode().String()).
+			Str("StatusMsg", resp.GetStatus().GetMessage()).
+			Msg("RenameFile: move failed, file not found")
+		return "", NewConnectorError(404, "File not found")
+
+	case rpcv1beta1.Code_CODE_LOCKED, rpcv1beta1.Code_CODE_FAILED_PRECONDITION:
+		// the lock changed after the stat
+		logger.Error().
+			Str("StatusCode", resp.GetStatus().GetCode().String()).
+			Str("StatusMsg", resp.GetStatus().GetMessage()).
+			Msg("RenameFile: move failed, lock mismatch")
+		return "", NewConnectorError(409, "Lock mismatch")
+
+	default:
+		logger.Error().
+			Str("StatusCode", resp.GetStatus().GetCode().String()).
+			Str("StatusMsg", resp.GetStatus().GetMessage()).
+			Msg("RenameFile: move failed with unexpected status")
+		return "", NewConnectorError(500, resp.GetStatus().GetCode().String()+" "+resp.GetStatus().GetMessage())
+	}
+}
+
+// PutUserInfo stores the user info for the current user
+// https://docs.microsoft.com/en-us/microsoft-365/cloud-storage-partner-program/rest/files/putuserinfo
+//
+// The context MUST have a WOPI context, otherwise an error will be returned.
+// You can pass a pre-configured zerologger instance through the context that
+// will be used to log messages.
+//
+// The user info is stored per user and WOPI app, and it will be returned in
+// the "UserInfo" property of the CheckFileInfo response of any file for that
+// user. Anonymous users and public link users can't store user info, a 501
+// ConnectorError will be returned for them.
+func (f *FileConnector) PutUserInfo(ctx context.Context, userInfo string) error {
+	wopiContext, err := middleware.WopiContextFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := zerolog.Ctx(ctx)
+
+	if wopiContext.User == nil || utils.ExistsInOpaque(wopiContext.User.GetOpaque(), "public-share-role") {
+		logger.Error().Msg("PutUserInfo: not supported for anonymous users")
+		return NewConnectorError(501, "PutUserInfo isn't supported for anonymous users")
+	}
+
+	err = f.store.Write(&microstore.Record{
+		Key:   f.userInfoKey(wopiContext.User),
+		Value: []byte(userInfo),
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("PutUserInfo: failed to store the user info")
+		return err
+	}
+
+	logger.Debug().Msg("PutUserInfo: success")
+	return nil
+}
+
+// stat returns the resource info of the reference. A ConnectorError will be
+// returned if the stat fails with an unexpected status
+func (f *FileConnector) stat(ctx context.Context, ref *providerv1beta1.Reference) (*providerv1beta1.ResourceInfo, error) {
+	statRes, err := f.gwc.Stat(ctx, &providerv1beta1.StatRequest{
+		Ref: ref,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch statRes.GetStatus().GetCode() {
+	case rpcv1beta1.Code_CODE_OK:
+		return statRes.GetInfo(), nil
+	case rpcv1beta1.Code_CODE_NOT_FOUND:
+		return nil, NewConnectorError(404, "File not found")
+	default:
+		return nil, NewConnectorError(500, statRes.GetStatus().GetCode().String()+" "+statRes.GetStatus().GetMessage())
+	}
+}
+
+// statInContainer returns the resource info of the named file inside the
+// container, or nil if there is no such file
+func (f *FileConnector) statInContainer(ctx context.Context, containerID *providerv1beta1.ResourceId, name string) (*providerv1beta1.ResourceInfo, error) {
+	info, err := f.stat(ctx, &providerv1beta1.Reference{
+		ResourceId: containerID,
+		Path:       utils.MakeRelativePath(name),
+	})
+	var conError *ConnectorError
+	if errors.As(err, &conError) && conError.HttpCodeOut == 404 {
+		return nil, nil
+	}
+	return info, err
+}
+
+// freeName returns the name if there is no such file in the container yet.
+// Otherwise, the first numbered name such as "name (1).docx" which is free
+// will be returned. A 400 ConnectorError will be returned if there is
+// no free name
+func (f *FileConnector) freeName(ctx context.Context, containerID *providerv1beta1.ResourceId, name string) (string, error) {
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	candidate := name
+	for i := 1; i <= maxNameCandidates; i++ {
+		info, err := f.statInContainer(ctx, containerID, candidate)
+		if err != nil {
+			return "", err
+		}
+		if info == nil {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s (%d)%s", base, i, ext)
+		if !validFileName(candidate) {
+			break
+		}
+	}
+	return "", NewConnectorError(400, "No free file name found")
+}
+
+// newFileResponse prepares the PutRelativeFile response for the new file.
+// The new file will be opened with the same user, view mode and apps as
+// the file of the WOPI context
+func (f *FileConnector) newFileResponse(wopiContext middleware.WopiContext, id *providerv1beta1.ResourceId, name string) (*PutRelativeResponse, error) {
+	wopiSrcURL, err := helpers.GetWopiSrcURL(f.cfg.Wopi.WopiSrc, id)
+	if err != nil {
+		return nil, err
+	}
+
+	newWopiContext := wopiContext
+	newWopiContext.FileReference = providerv1beta1.Reference{
+		ResourceId: id,
+		Path:       ".",
+	}
+	newWopiContext.ViewOnlyToken = ""
+	if wopiContext.EditAppUrl != "" {
+		if newWopiContext.EditAppUrl, err = helpers.SetWopiSrcQueryParam(wopiContext.EditAppUrl, wopiSrcURL); err != nil {
+			return nil, err
+		}
+	}
+	if wopiContext.ViewAppUrl != "" {
+		if newWopiContext.ViewAppUrl, err = helpers.SetWopiSrcQueryParam(wopiContext.ViewAppUrl, wopiSrcURL); err != nil {
+			return nil, err
+		}
+	}
+
+	accessToken, _, err := middleware.GenerateWopiToken(newWopiContext, f.cfg.Wopi.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	q := wopiSrcURL.Query()
+	q.Set("access_token", accessToken)
+	wopiSrcURL.RawQuery = q.Encode()
+
+	return &PutRelativeResponse{
+		Name:        name,
+		Url:         wopiSrcURL.String(),
+		HostViewUrl: newWopiContext.ViewAppUrl,
+		HostEditUrl: newWopiContext.EditAppUrl,
+	}, nil
+}
+
+// trackSession starts or refreshes the editing session of the user on the
+// target file. An EditingStarted event is published for new sessions.
+// Anonymous users and public link users aren't tracked. Failures are only
+// logged, they must not fail the WOPI operations
+func (f *FileConnector) trackSession(ctx context.Context, wopiContext middleware.WopiContext) {
+	if f.sessions == nil || wopiContext.User == nil || utils.ExistsInOpaque(wopiContext.User.GetOpaque(), "public-share-role") {
+		return
+	}
+
+	logger := zerolog.Ctx(ctx)
+	started, err := f.sessions.Touch(editingsessions.Session{
+		ResourceID:  storagespace.FormatResourceID(*wopiContext.FileReference.GetResourceId()),
+		UserID:      wopiContext.User.GetId().GetOpaqueId(),
+		DisplayName: wopiContext.User.GetDisplayName(),
+		App:         f.cfg.App.Name,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to track the editing session")
+		return
+	}
+	if !started || f.publisher == nil {
+		return
+	}
+
+	ref := wopiContext.FileReference
+	err = events.Publish(ctx, f.publisher, event.EditingStarted{
+		Ref:         &ref,
+		Executant:   wopiContext.User.GetId(),
+		DisplayName: wopiContext.User.GetDisplayName(),
+		AppName:     f.cfg.App.Name,
+		Timestamp:   time.Now(),
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to publish the EditingStarted event")
+	}
+}
+
+// refreshSessions extends the editing sessions of all users on the target
+// file. Failures are only logged, they must not fail the lock operations
+func (f *FileConnector) refreshSessions(ctx context.Context, wopiContext middleware.WopiContext) {
+	if f.sessions == nil {
+		return
+	}
+
+	if err := f.sessions.Refresh(storagespace.FormatResourceID(*wopiContext.FileReference.GetResourceId())); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to refresh the editing sessions")
+	}
+}
+
+// endSessions ends the editing sessions of all users on the target file.
+// Failures are only logged, the sessions will expire anyway
+func (f *FileConnector) endSessions(ctx context.Context, wopiContext middleware.WopiContext) {
+	if f.sessions == nil {
+		return
+	}
+
+	if err := f.sessions.End(storagespace.FormatResourceID(*wopiContext.FileReference.GetResourceId())); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("failed to end the editing sessions")
+	}
+}
+
+// userInfoKey returns the store key of the user info of the user for the
+// configured WOPI app
+func (f *FileConnector) userInfoKey(user *userv1beta1.User) string {
+	return "userinfo:" + strings.ToLower(f.cfg.App.Name) + ":" + hexEncodedUserID(user)
+}
+
+// hexEncodedUserID returns the hex encoded "opaqueId@idp" of the user, which
+// is used as WOPI user id
+func hexEncodedUserID(user *userv1beta1.User) string {
+	return hex.EncodeToString([]byte(user.GetId().GetOpaqueId() + "@" + user.GetId().GetIdp()))
+}
+
+// validFileName checks that the name can be used as file name
+func validFileName(name string) bool {
+	if name == "" || name == "." || name == ".." || len(name) > maxFileNameLength || !utf8.ValidString(name) {
+		return false
+	}
+	for _, r := range name {
+		if r == '/' || r == '\\' || unicode.IsControl(r) {
+			return false
+		}
+	}
+	return true
+}
+
 func (f *FileConnector) watermarkText(user *userv1beta1.User) string {
 	if user != nil {
 		return strings.TrimSpace(user.GetDisplayName() + " " + user.GetMail())
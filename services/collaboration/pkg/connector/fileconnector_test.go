@@ -2,22 +2,33 @@ package connector_test
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"net/url"
+	"strings"
+	"time"
 
 	appproviderv1beta1 "github.com/cs3org/go-cs3apis/cs3/app/provider/v1beta1"
+	gatewayv1beta1 "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
 	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
 	providerv1beta1 "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
 	typesv1beta1 "github.com/cs3org/go-cs3apis/cs3/types/v1beta1"
 	"github.com/cs3org/reva/v2/pkg/rgrpc/status"
+	"github.com/cs3org/reva/v2/pkg/store"
 	cs3mocks "github.com/cs3org/reva/v2/tests/cs3mocks/mocks"
+	"github.com/golang-jwt/jwt/v4"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/owncloud/ocis/v2/ocis-pkg/editingsessions"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/config"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/connector"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/connector/fileinfo"
+	"github.com/owncloud/ocis/v2/services/collaboration/pkg/event"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/middleware"
 	"github.com/stretchr/testify/mock"
+	mevents "go-micro.dev/v4/events"
+	microstore "go-micro.dev/v4/store"
 )
 
 var _ = Describe("FileConnector", func() {
@@ -25,6 +36,9 @@ var _ = Describe("FileConnector", func() {
 		fc            *connector.FileConnector
 		gatewayClient *cs3mocks.GatewayAPIClient
 		cfg           *config.Config
+		st            microstore.Store
+		sessions      *editingsessions.Manager
+		publisher     *recordingPublisher
 		wopiCtx       middleware.WopiContext
 	)
 
@@ -35,7 +49,10 @@ var _ = Describe("FileConnector", func() {
 			},
 		}
 		gatewayClient = &cs3mocks.GatewayAPIClient{}
-		fc = connector.NewFileConnector(gatewayClient, cfg)
+		st = store.Create()
+		sessions = editingsessions.NewManager(microstore.NewMemoryStore())
+		publisher = &recordingPublisher{}
+		fc = connector.NewFileConnector(gatewayClient, cfg, st, sessions, publisher)
 
 		wopiCtx = middleware.WopiContext{
 			AccessToken: "abcdef123456",
@@ -728,6 +745,138 @@ var _ = Describe("FileConnector", func() {
 		})
 	})
 
+	Describe("Editing sessions", func() {
+		var statRes *providerv1beta1.StatResponse
+
+		BeforeEach(func() {
+			statRes = &providerv1beta1.StatResponse{
+				Status: status.NewOK(context.Background()),
+				Info: &providerv1beta1.ResourceInfo{
+					Owner: &userv1beta1.UserId{Idp: "customIdp", OpaqueId: "aabbcc"},
+					Path:  "/path/to/test.docx",
+				},
+			}
+		})
+
+		It("CheckFileInfo starts a session for every editing user", func() {
+			cfg.App.Name = "Collabora"
+			gatewayClient.On("Stat", mock.Anything, mock.Anything).Return(statRes, nil)
+			gatewayClient.On("SetLock", mock.Anything, mock.Anything).Times(1).Return(&providerv1beta1.SetLockResponse{
+				Status: status.NewOK(context.Background()),
+			}, nil)
+
+			ctx := middleware.WopiContextToCtx(context.Background(), wopiCtx)
+			_, err := fc.CheckFileInfo(ctx)
+			Expect(err).To(Succeed())
+			_, err = fc.Lock(ctx, "abcdef123", "")
+			Expect(err).To(Succeed())
+
+			// the co-editor joins the document which is already locked
+			coEditorCtx := wopiCtx
+			coEditorCtx.User = &userv1beta1.User{
+				Id:          &userv1beta1.UserId{Idp: "inmemory", OpaqueId: "coEditor"},
+				DisplayName: "Co Editor",
+			}
+			_, err = fc.CheckFileInfo(middleware.WopiContextToCtx(context.Background(), coEditorCtx))
+			Expect(err).To(Succeed())
+			_, err = fc.CheckFileInfo(ctx)
+			Expect(err).To(Succeed())
+
+			list, err := sessions.List("abc$zzz!12345")
+			Expect(err).To(Succeed())
+			Expect(list).To(HaveLen(2))
+			Expect(list[0].UserID).To(Equal("opaqueId"))
+			Expect(list[0].DisplayName).To(Equal("Pet Shaft"))
+			Expect(list[0].App).To(Equal("Collabora"))
+			Expect(list[1].UserID).To(Equal("coEditor"))
+
+			// reopening the file doesn't start a new session
+			Expect(publisher.events).To(HaveLen(2))
+			ev, ok := publisher.events[0].(event.EditingStarted)
+			Expect(ok).To(BeTrue())
+			Expect(ev.Ref.GetResourceId().GetOpaqueId()).To(Equal("12345"))
+			Expect(ev.Executant.GetOpaqueId()).To(Equal("opaqueId"))
+			Expect(ev.DisplayName).To(Equal("Pet Shaft"))
+			Expect(ev.AppName).To(Equal("Collabora"))
+			ev, ok = publisher.events[1].(event.EditingStarted)
+			Expect(ok).To(BeTrue())
+			Expect(ev.Executant.GetOpaqueId()).To(Equal("coEditor"))
+		})
+
+		It("RefreshLock refreshes the sessions of all users", func() {
+			_, err := sessions.Touch(editingsessions.Session{ResourceID: "abc$zzz!12345", UserID: "coEditor"})
+			Expect(err).To(Succeed())
+			before, err := sessions.List("abc$zzz!12345")
+			Expect(err).To(Succeed())
+
+			gatewayClient.On("RefreshLock", mock.Anything, mock.Anything).Times(1).Return(&providerv1beta1.RefreshLockResponse{
+				Status: status.NewOK(context.Background()),
+			}, nil)
+
+			ctx := middleware.WopiContextToCtx(context.Background(), wopiCtx)
+			_, err = fc.RefreshLock(ctx, "abcdef123")
+			Expect(err).To(Succeed())
+
+			// the lock doesn't start sessions, only the users who opened the file are editing it
+			list, err := sessions.List("abc$zzz!12345")
+			Expect(err).To(Succeed())
+			Expect(list).To(HaveLen(1))
+			Expect(list[0].UserID).To(Equal("coEditor"))
+			Expect(list[0].LastSeen).To(BeTemporally(">=", before[0].LastSeen))
+			Expect(publisher.events).To(BeEmpty())
+		})
+
+		It("Viewing users aren't tracked", func() {
+			wopiCtx.ViewMode = appproviderv1beta1.ViewMode_VIEW_MODE_VIEW_ONLY
+			gatewayClient.On("Stat", mock.Anything, mock.Anything).Times(1).Return(statRes, nil)
+
+			_, err := fc.CheckFileInfo(middleware.WopiContextToCtx(context.Background(), wopiCtx))
+			Expect(err).To(Succeed())
+
+			list, err := sessions.List("abc$zzz!12345")
+			Expect(err).To(Succeed())
+			Expect(list).To(BeEmpty())
+			Expect(publisher.events).To(BeEmpty())
+		})
+
+		It("Public link users aren't tracked", func() {
+			wopiCtx.User.Opaque = &typesv1beta1.Opaque{
+				Map: map[string]*typesv1beta1.OpaqueEntry{
+					"public-share-role": {
+						Decoder: "plain",
+						Value:   []byte("editor"),
+					},
+				},
+			}
+			gatewayClient.On("Stat", mock.Anything, mock.Anything).Times(1).Return(statRes, nil)
+
+			_, err := fc.CheckFileInfo(middleware.WopiContextToCtx(context.Background(), wopiCtx))
+			Expect(err).To(Succeed())
+
+			list, err := sessions.List("abc$zzz!12345")
+			Expect(err).To(Succeed())
+			Expect(list).To(BeEmpty())
+			Expect(publisher.events).To(BeEmpty())
+		})
+
+		It("Unlock ends the sessions", func() {
+			gatewayClient.On("Stat", mock.Anything, mock.Anything).Times(1).Return(statRes, nil)
+			gatewayClient.On("Unlock", mock.Anything, mock.Anything).Times(1).Return(&providerv1beta1.UnlockResponse{
+				Status: status.NewOK(context.Background()),
+			}, nil)
+
+			ctx := middleware.WopiContextToCtx(context.Background(), wopiCtx)
+			_, err := fc.CheckFileInfo(ctx)
+			Expect(err).To(Succeed())
+			_, err = fc.UnLock(ctx, "abcdef123")
+			Expect(err).To(Succeed())
+
+			list, err := sessions.List("abc$zzz!12345")
+			Expect(err).To(Succeed())
+			Expect(list).To(BeEmpty())
+		})
+	})
+
 	Describe("CheckFileInfo", func() {
 		It("No valid context", func() {
 			ctx := context.Background()
@@ -790,13 +939,15 @@ var _ = Describe("FileConnector", func() {
 				Version:                    "16273849.0",
 				BaseFileName:               "test.txt",
 				BreadcrumbDocName:          "test.txt",
-				UserCanNotWriteRelative:    true,
 				HostViewURL:                "http://test.ex.prv/view",
 				HostEditURL:                "http://test.ex.prv/edit",
 				SupportsExtendedLockLength: true,
 				SupportsGetLock:            true,
 				SupportsLocks:              true,
+				SupportsRename:             true,
 				SupportsUpdate:             true,
+				SupportsUserInfo:           true,
+				UserCanRename:              true,
 				UserCanWrite:               true,
 				UserID:                     "6f7061717565496440696e6d656d6f7279", // hex of opaqueId@inmemory
 				UserFriendlyName:           "Pet Shaft",
@@ -854,6 +1005,7 @@ var _ = Describe("FileConnector", func() {
 				UserFriendlyName:        "guest zzz000",
 				EnableOwnerTermination:  true,
 				SupportsLocks:           true,
+				SupportsRename:          true,
 				BreadcrumbDocName:       "test.txt",
 			}
 
@@ -910,6 +1062,7 @@ var _ = Describe("FileConnector", func() {
 				EnableOwnerTermination:  true,
 				WatermarkText:           "Pet Shaft shaft@example.com",
 				SupportsLocks:           true,
+				SupportsRename:          true,
 				BreadcrumbDocName:       "test.txt",
 			}
 
@@ -919,4 +1072,395 @@ var _ = Describe("FileConnector", func() {
 			Expect(newFileInfo.(*fileinfo.Collabora)).To(Equal(expectedFileInfo))
 		})
 	})
+
+	Describe("PutRelativeFile", func() {
+		var (
+			statRef   func(p string) interface{}
+			fileInfo  *providerv1beta1.ResourceInfo
+			parentID  *providerv1beta1.ResourceId
+			newFileID *providerv1beta1.ResourceId
+		)
+
+		BeforeEach(func() {
+			cfg.Wopi.WopiSrc = "https://wopiserver.test.prv"
+			cfg.Wopi.Secret = "my_supa_secret"
+
+			// the access token of the new file is generated from the reva token
+			wopiCtx.AccessToken = mintRevaToken(time.Now().Add(time.Hour))
+
+			statRef = func(p string) interface{} {
+				return mock.MatchedBy(func(req *providerv1beta1.StatRequest) bool {
+					return req.GetRef().GetPath() == p
+				})
+			}
+			parentID = &providerv1beta1.ResourceId{
+				StorageId: "abc",
+				OpaqueId:  "parent",
+				SpaceId:   "zzz",
+			}
+			newFileID = &providerv1beta1.ResourceId{
+				StorageId: "abc",
+				OpaqueId:  "newfile",
+				SpaceId:   "zzz",
+			}
+			fileInfo = &providerv1beta1.ResourceInfo{
+				Id:       wopiCtx.FileReference.GetResourceId(),
+				ParentId: parentID,
+				Path:     "test.docx",
+			}
+		})
+
+		It("No valid context", func() {
+			ctx := context.Background()
+			response, err := fc.PutRelativeFile(ctx, strings.NewReader(""), 0, ".pdf", true, false)
+			Expect(err).To(HaveOccurred())
+			Expect(response).To(BeNil())
+		})
+
+		It("Read only view mode", func() {
+			wopiCtx.ViewMode = appproviderv1beta1.ViewMode_VIEW_MODE_READ_ONLY
+			ctx := middleware.WopiContextToCtx(context.Background(), wopiCtx)
+
+			response, err := fc.PutRelativeFile(ctx, strings.NewReader(""), 0, ".pdf", true, false)
+			Expect(err).To(HaveOccurred())
+			conErr := err.(*connector.ConnectorError)
+			Expect(conErr.HttpCodeOut).To(Equal(501))
+			Expect(response).To(BeNil())
+		})
+
+		It("Invalid target", func() {
+			ctx := middleware.WopiContextToCtx(context.Background(), wopiCtx)
+
+			gatewayClient.On("Stat", mock.Anything, statRef(".")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewOK(ctx),
+				Info:   fileInfo,
+			}, nil)
+
+			response, err := fc.PutRelativeFile(ctx, strings.NewReader(""), 0, "../test.pdf", false, false)
+			Expect(err).To(HaveOccurred())
+			conErr := err.(*connector.ConnectorError)
+			Expect(conErr.HttpCodeOut).To(Equal(400))
+			Expect(response).To(BeNil())
+		})
+
+		It("Relative target exists", func() {
+			ctx := middleware.WopiContextToCtx(context.Background(), wopiCtx)
+
+			gatewayClient.On("Stat", mock.Anything, statRef(".")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewOK(ctx),
+				Info:   fileInfo,
+			}, nil)
+			gatewayClient.On("Stat", mock.Anything, statRef("./copy.docx")).Return(&providerv1beta1.StatResponse{
+				Status: status.NewOK(ctx),
+				Info:   &providerv1beta1.ResourceInfo{Path: "copy.docx"},
+			}, nil)
+			gatewayClient.On("Stat", mock.Anything, statRef("./copy (1).docx")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewNotFound(ctx, "not found"),
+			}, nil)
+
+			response, err := fc.PutRelativeFile(ctx, strings.NewReader(""), 0, "copy.docx", false, false)
+			Expect(err).To(HaveOccurred())
+			conErr := err.(*connector.ConnectorError)
+			Expect(conErr.HttpCodeOut).To(Equal(409))
+			Expect(response.ValidTarget).To(Equal("copy (1).docx"))
+		})
+
+		It("Relative target exists and is locked", func() {
+			ctx := middleware.WopiContextToCtx(context.Background(), wopiCtx)
+
+			gatewayClient.On("Stat", mock.Anything, statRef(".")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewOK(ctx),
+				Info:   fileInfo,
+			}, nil)
+			gatewayClient.On("Stat", mock.Anything, statRef("./copy.docx")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewOK(ctx),
+				Info: &providerv1beta1.ResourceInfo{
+					Path: "copy.docx",
+					Lock: &providerv1beta1.Lock{
+						LockId: "zzz999",
+						Type:   providerv1beta1.LockType_LOCK_TYPE_WRITE,
+					},
+				},
+			}, nil)
+
+			response, err := fc.PutRelativeFile(ctx, strings.NewReader(""), 0, "copy.docx", false, true)
+			Expect(err).To(HaveOccurred())
+			conErr := err.(*connector.ConnectorError)
+			Expect(conErr.HttpCodeOut).To(Equal(409))
+			Expect(response.LockID).To(Equal("zzz999"))
+		})
+
+		It("Relative target overwritten", func() {
+			ctx := middleware.WopiContextToCtx(context.Background(), wopiCtx)
+
+			gatewayClient.On("Stat", mock.Anything, statRef(".")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewOK(ctx),
+				Info:   fileInfo,
+			}, nil)
+			gatewayClient.On("Stat", mock.Anything, statRef("./copy.docx")).Times(2).Return(&providerv1beta1.StatResponse{
+				Status: status.NewOK(ctx),
+				Info: &providerv1beta1.ResourceInfo{
+					Id:   newFileID,
+					Path: "copy.docx",
+					Etag: "etag001",
+				},
+			}, nil)
+			gatewayClient.On("InitiateFileUpload", mock.Anything, mock.MatchedBy(func(req *providerv1beta1.InitiateFileUploadRequest) bool {
+				return req.GetRef().GetPath() == "./copy.docx" && req.GetIfMatch() == "etag001"
+			})).Times(1).Return(&gatewayv1beta1.InitiateFileUploadResponse{
+				Status: status.NewOK(ctx),
+			}, nil)
+
+			response, err := fc.PutRelativeFile(ctx, strings.NewReader(""), 0, "copy.docx", false, true)
+			Expect(err).To(Succeed())
+			Expect(response.Name).To(Equal("copy.docx"))
+		})
+
+		It("Suggested extension", func() {
+			ctx := middleware.WopiContextToCtx(context.Background(), wopiCtx)
+
+			gatewayClient.On("Stat", mock.Anything, statRef(".")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewOK(ctx),
+				Info:   fileInfo,
+			}, nil)
+			gatewayClient.On("Stat", mock.Anything, statRef("./test.pdf")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewNotFound(ctx, "not found"),
+			}, nil)
+			gatewayClient.On("InitiateFileUpload", mock.Anything, mock.MatchedBy(func(req *providerv1beta1.InitiateFileUploadRequest) bool {
+				return req.GetRef().GetResourceId() == parentID && req.GetRef().GetPath() == "./test.pdf" && req.GetIfNotExist()
+			})).Times(1).Return(&gatewayv1beta1.InitiateFileUploadResponse{
+				Status: status.NewOK(ctx),
+			}, nil)
+			gatewayClient.On("Stat", mock.Anything, statRef("./test.pdf")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewOK(ctx),
+				Info: &providerv1beta1.ResourceInfo{
+					Id:   newFileID,
+					Path: "test.pdf",
+				},
+			}, nil)
+
+			response, err := fc.PutRelativeFile(ctx, strings.NewReader(""), 0, ".pdf", true, false)
+			Expect(err).To(Succeed())
+			Expect(response.Name).To(Equal("test.pdf"))
+
+			wopiSrc := "https://wopiserver.test.prv/wopi/files/" + hex.EncodeToString(sha256Sum("abc$zzz!newfile"))
+			Expect(response.Url).To(HavePrefix(wopiSrc + "?access_token="))
+			Expect(response.HostEditUrl).To(Equal("http://test.ex.prv/edit?WOPISrc=" + url.QueryEscape(wopiSrc)))
+			Expect(response.HostViewUrl).To(Equal("http://test.ex.prv/view?WOPISrc=" + url.QueryEscape(wopiSrc)))
+		})
+
+		It("Suggested name exists", func() {
+			ctx := middleware.WopiContextToCtx(context.Background(), wopiCtx)
+
+			gatewayClient.On("Stat", mock.Anything, statRef(".")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewOK(ctx),
+				Info:   fileInfo,
+			}, nil)
+			gatewayClient.On("Stat", mock.Anything, statRef("./report.pdf")).Return(&providerv1beta1.StatResponse{
+				Status: status.NewOK(ctx),
+				Info:   &providerv1beta1.ResourceInfo{Path: "report.pdf"},
+			}, nil)
+			gatewayClient.On("Stat", mock.Anything, statRef("./report (1).pdf")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewNotFound(ctx, "not found"),
+			}, nil)
+			gatewayClient.On("InitiateFileUpload", mock.Anything, mock.MatchedBy(func(req *providerv1beta1.InitiateFileUploadRequest) bool {
+				return req.GetRef().GetPath() == "./report (1).pdf" && req.GetIfNotExist()
+			})).Times(1).Return(&gatewayv1beta1.InitiateFileUploadResponse{
+				Status: status.NewOK(ctx),
+			}, nil)
+			gatewayClient.On("Stat", mock.Anything, statRef("./report (1).pdf")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewOK(ctx),
+				Info: &providerv1beta1.ResourceInfo{
+					Id:   newFileID,
+					Path: "report (1).pdf",
+				},
+			}, nil)
+
+			response, err := fc.PutRelativeFile(ctx, strings.NewReader(""), 0, "report.pdf", true, false)
+			Expect(err).To(Succeed())
+			Expect(response.Name).To(Equal("report (1).pdf"))
+		})
+	})
+
+	Describe("RenameFile", func() {
+		var (
+			statRef  func(p string) interface{}
+			fileInfo *providerv1beta1.ResourceInfo
+		)
+
+		BeforeEach(func() {
+			statRef = func(p string) interface{} {
+				return mock.MatchedBy(func(req *providerv1beta1.StatRequest) bool {
+					return req.GetRef().GetPath() == p
+				})
+			}
+			fileInfo = &providerv1beta1.ResourceInfo{
+				Id: wopiCtx.FileReference.GetResourceId(),
+				ParentId: &providerv1beta1.ResourceId{
+					StorageId: "abc",
+					OpaqueId:  "parent",
+					SpaceId:   "zzz",
+				},
+				Path: "test.docx",
+				Lock: &providerv1beta1.Lock{
+					LockId: "zzz999",
+					Type:   providerv1beta1.LockType_LOCK_TYPE_WRITE,
+				},
+			}
+		})
+
+		It("No valid context", func() {
+			ctx := context.Background()
+			newName, err := fc.RenameFile(ctx, "zzz999", "renamed")
+			Expect(err).To(HaveOccurred())
+			Expect(newName).To(Equal(""))
+		})
+
+		It("Wrong lock", func() {
+			ctx := middleware.WopiContextToCtx(context.Background(), wopiCtx)
+
+			gatewayClient.On("Stat", mock.Anything, statRef(".")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewOK(ctx),
+				Info:   fileInfo,
+			}, nil)
+
+			lockID, err := fc.RenameFile(ctx, "abc123", "renamed")
+			Expect(err).To(HaveOccurred())
+			conErr := err.(*connector.ConnectorError)
+			Expect(conErr.HttpCodeOut).To(Equal(409))
+			Expect(lockID).To(Equal("zzz999"))
+		})
+
+		It("Invalid name", func() {
+			ctx := middleware.WopiContextToCtx(context.Background(), wopiCtx)
+
+			gatewayClient.On("Stat", mock.Anything, statRef(".")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewOK(ctx),
+				Info:   fileInfo,
+			}, nil)
+
+			newName, err := fc.RenameFile(ctx, "zzz999", "sub/renamed")
+			Expect(err).To(HaveOccurred())
+			conErr := err.(*connector.ConnectorError)
+			Expect(conErr.HttpCodeOut).To(Equal(400))
+			Expect(newName).To(Equal(""))
+		})
+
+		It("Move failed", func() {
+			ctx := middleware.WopiContextToCtx(context.Background(), wopiCtx)
+
+			gatewayClient.On("Stat", mock.Anything, statRef(".")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewOK(ctx),
+				Info:   fileInfo,
+			}, nil)
+			gatewayClient.On("Stat", mock.Anything, statRef("./renamed.docx")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewNotFound(ctx, "not found"),
+			}, nil)
+			gatewayClient.On("Move", mock.Anything, mock.Anything).Times(1).Return(&providerv1beta1.MoveResponse{
+				Status: status.NewInternal(ctx, "Something failed"),
+			}, nil)
+
+			newName, err := fc.RenameFile(ctx, "zzz999", "renamed")
+			Expect(err).To(HaveOccurred())
+			conErr := err.(*connector.ConnectorError)
+			Expect(conErr.HttpCodeOut).To(Equal(500))
+			Expect(newName).To(Equal(""))
+		})
+
+		It("Rename success with name conflict", func() {
+			ctx := middleware.WopiContextToCtx(context.Background(), wopiCtx)
+
+			gatewayClient.On("Stat", mock.Anything, statRef(".")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewOK(ctx),
+				Info:   fileInfo,
+			}, nil)
+			gatewayClient.On("Stat", mock.Anything, statRef("./renamed.docx")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewOK(ctx),
+				Info:   &providerv1beta1.ResourceInfo{Path: "renamed.docx"},
+			}, nil)
+			gatewayClient.On("Stat", mock.Anything, statRef("./renamed (1).docx")).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewNotFound(ctx, "not found"),
+			}, nil)
+			gatewayClient.On("Move", mock.Anything, mock.MatchedBy(func(req *providerv1beta1.MoveRequest) bool {
+				return req.GetDestination().GetPath() == "./renamed (1).docx" && req.GetLockId() == "zzz999"
+			})).Times(1).Return(&providerv1beta1.MoveResponse{
+				Status: status.NewOK(ctx),
+			}, nil)
+
+			newName, err := fc.RenameFile(ctx, "zzz999", "renamed")
+			Expect(err).To(Succeed())
+			Expect(newName).To(Equal("renamed (1)"))
+		})
+	})
+
+	Describe("PutUserInfo", func() {
+		It("No valid context", func() {
+			ctx := context.Background()
+			err := fc.PutUserInfo(ctx, "some user info")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Public share user", func() {
+			wopiCtx.User.Opaque = &typesv1beta1.Opaque{
+				Map: map[string]*typesv1beta1.OpaqueEntry{
+					"public-share-role": {
+						Decoder: "plain",
+						Value:   []byte("editor"),
+					},
+				},
+			}
+			ctx := middleware.WopiContextToCtx(context.Background(), wopiCtx)
+
+			err := fc.PutUserInfo(ctx, "some user info")
+			Expect(err).To(HaveOccurred())
+			conErr := err.(*connector.ConnectorError)
+			Expect(conErr.HttpCodeOut).To(Equal(501))
+		})
+
+		It("User info is returned by CheckFileInfo", func() {
+			ctx := middleware.WopiContextToCtx(context.Background(), wopiCtx)
+
+			err := fc.PutUserInfo(ctx, "some user info")
+			Expect(err).To(Succeed())
+
+			gatewayClient.On("Stat", mock.Anything, mock.Anything).Times(1).Return(&providerv1beta1.StatResponse{
+				Status: status.NewOK(ctx),
+				Info: &providerv1beta1.ResourceInfo{
+					Owner: &userv1beta1.UserId{
+						Idp:      "customIdp",
+						OpaqueId: "aabbcc",
+						Type:     userv1beta1.UserType_USER_TYPE_PRIMARY,
+					},
+					Mtime: &typesv1beta1.Timestamp{},
+					Path:  "/path/to/test.txt",
+				},
+			}, nil)
+
+			newFileInfo, err := fc.CheckFileInfo(ctx)
+			Expect(err).To(Succeed())
+			Expect(newFileInfo.(*fileinfo.Microsoft).UserInfo).To(Equal("some user info"))
+		})
+	})
 })
+
+func mintRevaToken(expiresAt time.Time) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	})
+	signed, _ := token.SignedString([]byte("reva_secret"))
+	return signed
+}
+
+func sha256Sum(s string) []byte {
+	sum := sha256.Sum256([]byte(s))
+	return sum[:]
+}
+
+type recordingPublisher struct {
+	events []interface{}
+}
+
+func (p *recordingPublisher) Publish(_ string, ev interface{}, _ ...mevents.PublishOption) error {
+	p.events = append(p.events, ev)
+	return nil
+}
@@ -3,19 +3,36 @@ package connector
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	gatewayv1beta1 "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/owncloud/ocis/v2/ocis-pkg/editingsessions"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/config"
 	"github.com/rs/zerolog"
+	microstore "go-micro.dev/v4/store"
 )
 
 const (
 	HeaderWopiLock    string = "X-WOPI-Lock"
 	HeaderWopiOldLock string = "X-WOPI-OldLock"
+
+	HeaderWopiSuggestedTarget         string = "X-WOPI-SuggestedTarget"
+	HeaderWopiRelativeTarget          string = "X-WOPI-RelativeTarget"
+	HeaderWopiOverwriteRelativeTarget string = "X-WOPI-OverwriteRelativeTarget"
+	HeaderWopiValidRelativeTarget     string = "X-WOPI-ValidRelativeTarget"
+	HeaderWopiRequestedName           string = "X-WOPI-RequestedName"
+	HeaderWopiInvalidFileNameError    string = "X-WOPI-InvalidFileNameError"
+	HeaderWopiSize                    string = "X-WOPI-Size"
 )
 
+// maxUserInfoLength is the maximum length of the user info sent by the
+// PutUserInfo operation
+const maxUserInfoLength = 1024
+
 // HttpAdapter will adapt the responses from the connector to HTTP.
 //
 // The adapter will use the request's context for the connector operations,
@@ -29,11 +46,12 @@ type HttpAdapter struct {
 }
 
 // NewHttpAdapter will create a new HTTP adapter. A new connector using the
-// provided gateway API client and configuration will be used in the adapter
-func NewHttpAdapter(gwc gatewayv1beta1.GatewayAPIClient, cfg *config.Config) *HttpAdapter {
+// provided gateway API client, configuration, store, editing sessions manager
+// and events publisher will be used in the adapter
+func NewHttpAdapter(gwc gatewayv1beta1.GatewayAPIClient, cfg *config.Config, st microstore.Store, sessions *editingsessions.Manager, publisher events.Publisher) *HttpAdapter {
 	return &HttpAdapter{
 		con: NewConnector(
-			NewFileConnector(gwc, cfg),
+			NewFileConnector(gwc, cfg, st, sessions, publisher),
 			NewContentConnector(gwc, cfg),
 		),
 	}
@@ -232,3 +250,163 @@ func (h *HttpAdapter) PutFile(w http.ResponseWriter, r *http.Request) {
 	// If no error, a HTTP 200 should be sent automatically.
 	// X-WOPI-Lock header isn't needed on HTTP 200
 }
+
+// PutRelativeFile adapts the "PutRelativeFile" operation for WOPI.
+// The request's context and its body are needed (content length is also
+// needed). In addition, either the "X-WOPI-SuggestedTarget" or the
+// "X-WOPI-RelativeTarget" header is needed, and the
+// "X-WOPI-OverwriteRelativeTarget" header might be needed (check spec).
+// The target names are expected to be UTF-7 encoded.
+// The operation's response will be sent through the response writer and
+// the headers according to the spec
+func (h *HttpAdapter) PutRelativeFile(w http.ResponseWriter, r *http.Request) {
+	logger := zerolog.Ctx(r.Context())
+
+	suggestedTarget := r.Header.Get(HeaderWopiSuggestedTarget)
+	relativeTarget := r.Header.Get(HeaderWopiRelativeTarget)
+
+	// exactly one of the headers must be present
+	if (suggestedTarget == "") == (relativeTarget == "") {
+		logger.Error().Msg("PutRelativeFile: either a suggested or a relative target is required")
+		http.Error(w, http.StatusText(http.StatusNotImplemented), http.StatusNotImplemented)
+		return
+	}
+
+	isSuggested := suggestedTarget != ""
+	target, err := decodeUTF7(suggestedTarget + relativeTarget)
+	if err != nil {
+		logger.Error().Err(err).Msg("PutRelativeFile: invalid target")
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	overwrite := strings.EqualFold(r.Header.Get(HeaderWopiOverwriteRelativeTarget), "true")
+
+	// the "X-WOPI-Size" header contains the size of the file if the body
+	// doesn't have a content length
+	streamLength := r.ContentLength
+	if streamLength < 0 {
+		if size, err := strconv.ParseInt(r.Header.Get(HeaderWopiSize), 10, 64); err == nil {
+			streamLength = size
+		}
+	}
+
+	fileCon := h.con.GetFileConnector()
+	response, err := fileCon.PutRelativeFile(r.Context(), r.Body, streamLength, target, isSuggested, overwrite)
+	if err != nil {
+		var conError *ConnectorError
+		if errors.As(err, &conError) {
+			if conError.HttpCodeOut == 409 && response != nil {
+				if response.ValidTarget != "" {
+					w.Header().Set(HeaderWopiValidRelativeTarget, encodeUTF7(response.ValidTarget))
+				}
+				if response.LockID != "" {
+					w.Header().Set(HeaderWopiLock, response.LockID)
+				}
+			}
+			http.Error(w, http.StatusText(conError.HttpCodeOut), conError.HttpCodeOut)
+		} else {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.writeJSON(w, r, response, "PutRelativeFile")
+}
+
+// RenameFile adapts the "RenameFile" operation for WOPI.
+// The request's context is needed in order to extract the WOPI context. In
+// addition, the "X-WOPI-RequestedName" header is needed, and the
+// "X-WOPI-Lock" header might be needed (check spec).
+// The requested name is expected to be UTF-7 encoded.
+// The operation's response will be sent through the response writer and
+// the headers according to the spec
+func (h *HttpAdapter) RenameFile(w http.ResponseWriter, r *http.Request) {
+	logger := zerolog.Ctx(r.Context())
+
+	lockID := r.Header.Get(HeaderWopiLock)
+	target, err := decodeUTF7(r.Header.Get(HeaderWopiRequestedName))
+	if err != nil {
+		logger.Error().Err(err).Msg("RenameFile: invalid requested name")
+		w.Header().Set(HeaderWopiInvalidFileNameError, "Invalid file name")
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	fileCon := h.con.GetFileConnector()
+	newName, err := fileCon.RenameFile(r.Context(), lockID, target)
+	if err != nil {
+		var conError *ConnectorError
+		if errors.As(err, &conError) {
+			switch conError.HttpCodeOut {
+			case 409:
+				w.Header().Set(HeaderWopiLock, newName)
+			case 400:
+				w.Header().Set(HeaderWopiInvalidFileNameError, conError.Msg)
+			}
+			http.Error(w, http.StatusText(conError.HttpCodeOut), conError.HttpCodeOut)
+		} else {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	h.writeJSON(w, r, map[string]string{"Name": newName}, "RenameFile")
+}
+
+// PutUserInfo adapts the "PutUserInfo" operation for WOPI.
+// The request's context and its body are needed. The body contains the user
+// info, which can't be longer than 1024 characters.
+// The operation's response will be sent through the response writer and
+// the headers according to the spec
+func (h *HttpAdapter) PutUserInfo(w http.ResponseWriter, r *http.Request) {
+	logger := zerolog.Ctx(r.Context())
+
+	// read one byte more to detect a user info which is too long
+	userInfo, err := io.ReadAll(io.LimitReader(r.Body, maxUserInfoLength+1))
+	if err != nil {
+		logger.Error().Err(err).Msg("PutUserInfo: failed to read the body")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if len(userInfo) > maxUserInfoLength {
+		logger.Error().Msg("PutUserInfo: user info is too long")
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	fileCon := h.con.GetFileConnector()
+	if err := fileCon.PutUserInfo(r.Context(), string(userInfo)); err != nil {
+		var conError *ConnectorError
+		if errors.As(err, &conError) {
+			http.Error(w, http.StatusText(conError.HttpCodeOut), conError.HttpCodeOut)
+		} else {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+		return
+	}
+	// If no error, a HTTP 200 should be sent automatically.
+}
+
+// writeJSON writes the value as json response with HTTP 200
+func (h *HttpAdapter) writeJSON(w http.ResponseWriter, r *http.Request, v interface{}, operation string) {
+	logger := zerolog.Ctx(r.Context())
+
+	body, err := json.Marshal(v)
+	if err != nil {
+		logger.Error().Err(err).Msg(operation + ": failed to marshal the response")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	bytes, err := w.Write(body)
+	if err != nil {
+		logger.Error().
+			Err(err).
+			Int("TotalBytes", len(body)).
+			Int("WrittenBytes", bytes).
+			Msg(operation + ": failed to write contents in the HTTP response")
+	}
+}
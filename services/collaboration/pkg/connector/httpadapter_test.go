@@ -474,4 +474,197 @@ var _ = Describe("HttpAdapter", func() {
 			Expect(resp.StatusCode).To(Equal(200))
 		})
 	})
+
+	Describe("PutRelativeFile", func() {
+		It("Missing target", func() {
+			req := httptest.NewRequest("POST", "/wopi/files/abcdef", strings.NewReader("new content"))
+			req.Header.Set("X-WOPI-Override", "PUT_RELATIVE")
+
+			w := httptest.NewRecorder()
+
+			httpAdapter.PutRelativeFile(w, req)
+			resp := w.Result()
+			Expect(resp.StatusCode).To(Equal(501))
+		})
+
+		It("Both targets", func() {
+			req := httptest.NewRequest("POST", "/wopi/files/abcdef", strings.NewReader("new content"))
+			req.Header.Set("X-WOPI-Override", "PUT_RELATIVE")
+			req.Header.Set(connector.HeaderWopiSuggestedTarget, ".pdf")
+			req.Header.Set(connector.HeaderWopiRelativeTarget, "test.pdf")
+
+			w := httptest.NewRecorder()
+
+			httpAdapter.PutRelativeFile(w, req)
+			resp := w.Result()
+			Expect(resp.StatusCode).To(Equal(501))
+		})
+
+		It("Invalid UTF-7 target", func() {
+			req := httptest.NewRequest("POST", "/wopi/files/abcdef", strings.NewReader("new content"))
+			req.Header.Set("X-WOPI-Override", "PUT_RELATIVE")
+			req.Header.Set(connector.HeaderWopiRelativeTarget, "+AG-.pdf")
+
+			w := httptest.NewRecorder()
+
+			httpAdapter.PutRelativeFile(w, req)
+			resp := w.Result()
+			Expect(resp.StatusCode).To(Equal(400))
+		})
+
+		It("Conflict", func() {
+			contentBody := "new content"
+			req := httptest.NewRequest("POST", "/wopi/files/abcdef", strings.NewReader(contentBody))
+			req.Header.Set("X-WOPI-Override", "PUT_RELATIVE")
+			req.Header.Set(connector.HeaderWopiRelativeTarget, "Bericht f+APw-r M+AOQ-rz.docx")
+
+			w := httptest.NewRecorder()
+
+			fc.On("PutRelativeFile", mock.Anything, mock.Anything, int64(len(contentBody)), "Bericht für März.docx", false, false).Times(1).Return(&connector.PutRelativeResponse{
+				ValidTarget: "Bericht für März (1).docx",
+			}, connector.NewConnectorError(409, "Target already exists"))
+
+			httpAdapter.PutRelativeFile(w, req)
+			resp := w.Result()
+			Expect(resp.StatusCode).To(Equal(409))
+			Expect(resp.Header.Get(connector.HeaderWopiValidRelativeTarget)).To(Equal("Bericht f+APw-r M+AOQ-rz (1).docx"))
+		})
+
+		It("Locked target", func() {
+			contentBody := "new content"
+			req := httptest.NewRequest("POST", "/wopi/files/abcdef", strings.NewReader(contentBody))
+			req.Header.Set("X-WOPI-Override", "PUT_RELATIVE")
+			req.Header.Set(connector.HeaderWopiRelativeTarget, "test.docx")
+			req.Header.Set(connector.HeaderWopiOverwriteRelativeTarget, "True")
+
+			w := httptest.NewRecorder()
+
+			fc.On("PutRelativeFile", mock.Anything, mock.Anything, int64(len(contentBody)), "test.docx", false, true).Times(1).Return(&connector.PutRelativeResponse{
+				LockID: "zzz111",
+			}, connector.NewConnectorError(409, "Target is locked"))
+
+			httpAdapter.PutRelativeFile(w, req)
+			resp := w.Result()
+			Expect(resp.StatusCode).To(Equal(409))
+			Expect(resp.Header.Get(connector.HeaderWopiLock)).To(Equal("zzz111"))
+		})
+
+		It("Success", func() {
+			contentBody := "new content"
+			req := httptest.NewRequest("POST", "/wopi/files/abcdef", strings.NewReader(contentBody))
+			req.Header.Set("X-WOPI-Override", "PUT_RELATIVE")
+			req.Header.Set(connector.HeaderWopiSuggestedTarget, ".pdf")
+
+			w := httptest.NewRecorder()
+
+			expected := &connector.PutRelativeResponse{
+				Name:        "test.pdf",
+				Url:         "https://wopiserver.test.prv/wopi/files/aabbcc?access_token=token",
+				HostViewUrl: "https://test.server.prv/view?WOPISrc=aabbcc",
+				HostEditUrl: "https://test.server.prv/edit?WOPISrc=aabbcc",
+			}
+			fc.On("PutRelativeFile", mock.Anything, mock.Anything, int64(len(contentBody)), ".pdf", true, false).Times(1).Return(expected, nil)
+
+			httpAdapter.PutRelativeFile(w, req)
+			resp := w.Result()
+			Expect(resp.StatusCode).To(Equal(200))
+			Expect(resp.Header.Get("Content-Type")).To(Equal("application/json"))
+
+			var response *connector.PutRelativeResponse
+			body, _ := io.ReadAll(resp.Body)
+			json.Unmarshal(body, &response)
+			Expect(response).To(Equal(expected))
+		})
+	})
+
+	Describe("RenameFile", func() {
+		It("Conflict", func() {
+			req := httptest.NewRequest("POST", "/wopi/files/abcdef", nil)
+			req.Header.Set("X-WOPI-Override", "RENAME_FILE")
+			req.Header.Set(connector.HeaderWopiLock, "abc123")
+			req.Header.Set(connector.HeaderWopiRequestedName, "renamed")
+
+			w := httptest.NewRecorder()
+
+			fc.On("RenameFile", mock.Anything, "abc123", "renamed").Times(1).Return("zzz111", connector.NewConnectorError(409, "Wrong lock"))
+
+			httpAdapter.RenameFile(w, req)
+			resp := w.Result()
+			Expect(resp.StatusCode).To(Equal(409))
+			Expect(resp.Header.Get(connector.HeaderWopiLock)).To(Equal("zzz111"))
+		})
+
+		It("Invalid name", func() {
+			req := httptest.NewRequest("POST", "/wopi/files/abcdef", nil)
+			req.Header.Set("X-WOPI-Override", "RENAME_FILE")
+			req.Header.Set(connector.HeaderWopiLock, "abc123")
+			req.Header.Set(connector.HeaderWopiRequestedName, "sub/renamed")
+
+			w := httptest.NewRecorder()
+
+			fc.On("RenameFile", mock.Anything, "abc123", "sub/renamed").Times(1).Return("", connector.NewConnectorError(400, "Invalid file name"))
+
+			httpAdapter.RenameFile(w, req)
+			resp := w.Result()
+			Expect(resp.StatusCode).To(Equal(400))
+			Expect(resp.Header.Get(connector.HeaderWopiInvalidFileNameError)).To(Equal("Invalid file name"))
+		})
+
+		It("Success", func() {
+			req := httptest.NewRequest("POST", "/wopi/files/abcdef", nil)
+			req.Header.Set("X-WOPI-Override", "RENAME_FILE")
+			req.Header.Set(connector.HeaderWopiLock, "abc123")
+			req.Header.Set(connector.HeaderWopiRequestedName, "+AMk-t+AOk-")
+
+			w := httptest.NewRecorder()
+
+			fc.On("RenameFile", mock.Anything, "abc123", "Été").Times(1).Return("Été (1)", nil)
+
+			httpAdapter.RenameFile(w, req)
+			resp := w.Result()
+			Expect(resp.StatusCode).To(Equal(200))
+
+			body, _ := io.ReadAll(resp.Body)
+			Expect(body).To(MatchJSON(`{"Name":"Été (1)"}`))
+		})
+	})
+
+	Describe("PutUserInfo", func() {
+		It("User info too long", func() {
+			req := httptest.NewRequest("POST", "/wopi/files/abcdef", strings.NewReader(strings.Repeat("a", 1025)))
+			req.Header.Set("X-WOPI-Override", "PUT_USER_INFO")
+
+			w := httptest.NewRecorder()
+
+			httpAdapter.PutUserInfo(w, req)
+			resp := w.Result()
+			Expect(resp.StatusCode).To(Equal(400))
+		})
+
+		It("Not supported", func() {
+			req := httptest.NewRequest("POST", "/wopi/files/abcdef", strings.NewReader("some user info"))
+			req.Header.Set("X-WOPI-Override", "PUT_USER_INFO")
+
+			w := httptest.NewRecorder()
+
+			fc.On("PutUserInfo", mock.Anything, "some user info").Times(1).Return(connector.NewConnectorError(501, "Not supported"))
+
+			httpAdapter.PutUserInfo(w, req)
+			resp := w.Result()
+			Expect(resp.StatusCode).To(Equal(501))
+		})
+
+		It("Success", func() {
+			req := httptest.NewRequest("POST", "/wopi/files/abcdef", strings.NewReader("some user info"))
+			req.Header.Set("X-WOPI-Override", "PUT_USER_INFO")
+
+			w := httptest.NewRecorder()
+
+			fc.On("PutUserInfo", mock.Anything, "some user info").Times(1).Return(nil)
+
+			httpAdapter.PutUserInfo(w, req)
+			resp := w.Result()
+			Expect(resp.StatusCode).To(Equal(200))
+		})
+	})
 })
@@ -0,0 +1,122 @@
+package connector
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Some WOPI headers, such as "X-WOPI-SuggestedTarget" or
+// "X-WOPI-RequestedName", contain UTF-7 encoded file names (RFC 2152).
+// https://learn.microsoft.com/en-us/microsoft-365/cloud-storage-partner-program/rest/files/putrelativefile
+
+var errInvalidUTF7 = errors.New("invalid UTF-7 string")
+
+// utf7Direct contains the characters that will be encoded directly
+const utf7Direct = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789'(),-./:? "
+
+// decodeUTF7 decodes the UTF-7 encoded string.
+// Some WOPI clients send plain UTF-8 values instead, those will be returned
+// without changes.
+func decodeUTF7(s string) (string, error) {
+	if !isASCII(s) {
+		if !utf8.ValidString(s) {
+			return "", errInvalidUTF7
+		}
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '+' {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		// find the end of the shifted sequence
+		end := i + 1
+		for end < len(s) && isBase64Char(s[end]) {
+			end++
+		}
+
+		if end == i+1 {
+			// "+-" is an encoded '+'
+			if end < len(s) && s[end] == '-' {
+				b.WriteByte('+')
+				i = end
+				continue
+			}
+			return "", errInvalidUTF7
+		}
+
+		raw, err := base64.RawStdEncoding.DecodeString(s[i+1 : end])
+		if err != nil || len(raw)%2 != 0 {
+			return "", errInvalidUTF7
+		}
+		units := make([]uint16, 0, len(raw)/2)
+		for j := 0; j < len(raw); j += 2 {
+			units = append(units, uint16(raw[j])<<8|uint16(raw[j+1]))
+		}
+		b.WriteString(string(utf16.Decode(units)))
+
+		// an explicit '-' terminating the sequence is absorbed
+		if end < len(s) && s[end] == '-' {
+			i = end
+		} else {
+			i = end - 1
+		}
+	}
+	return b.String(), nil
+}
+
+// encodeUTF7 encodes the string as UTF-7. Any character not directly encodable
+// will be base64 encoded. The shifted sequences are always terminated
+// with '-'
+func encodeUTF7(s string) string {
+	var b strings.Builder
+	var shifted []rune
+	flush := func() {
+		if len(shifted) == 0 {
+			return
+		}
+		units := utf16.Encode(shifted)
+		raw := make([]byte, 0, len(units)*2)
+		for _, u := range units {
+			raw = append(raw, byte(u>>8), byte(u))
+		}
+		b.WriteByte('+')
+		b.WriteString(base64.RawStdEncoding.EncodeToString(raw))
+		b.WriteByte('-')
+		shifted = shifted[:0]
+	}
+
+	for _, r := range s {
+		switch {
+		case r == '+':
+			flush()
+			b.WriteString("+-")
+		case r < utf8.RuneSelf && strings.ContainsRune(utf7Direct, r):
+			flush()
+			b.WriteRune(r)
+		default:
+			shifted = append(shifted, r)
+		}
+	}
+	flush()
+	return b.String()
+}
+
+func isBase64Char(c byte) bool {
+	return c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '+' || c == '/'
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,27 @@
+// Package event contains the events emitted by the collaboration service
+package event
+
+import (
+	"encoding/json"
+	"time"
+
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+)
+
+// EditingStarted is emitted when a user starts editing a file in an office app
+type EditingStarted struct {
+	Ref         *provider.Reference
+	Executant   *user.UserId
+	DisplayName string
+	// AppName is the name of the office app the file is edited with
+	AppName   string
+	Timestamp time.Time
+}
+
+// Unmarshal to fulfill umarshaller interface
+func (EditingStarted) Unmarshal(v []byte) (interface{}, error) {
+	e := EditingStarted{}
+	err := json.Unmarshal(v, &e)
+	return e, err
+}
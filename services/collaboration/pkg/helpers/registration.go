@@ -49,6 +49,10 @@ func RegisterAppProvider(
 		mimeTypes = append(mimeTypes, m)
 	}
 
+	if len(cfg.App.MimeTypes) > 0 {
+		mimeTypes = filterMimeTypes(mimeTypesMap, cfg.App.MimeTypes, logger)
+	}
+
 	logger.Debug().
 		Str("AppName", cfg.App.Name).
 		Strs("Mimetypes", mimeTypes).
@@ -80,3 +84,19 @@ func RegisterAppProvider(
 
 	return nil
 }
+
+// filterMimeTypes returns the configured mimetypes which are supported by the
+// app. The configured mimetypes which aren't supported will be ignored.
+func filterMimeTypes(supported map[string]bool, configured []string, logger log.Logger) []string {
+	mimeTypes := make([]string, 0, len(configured))
+	for _, m := range configured {
+		if !supported[m] {
+			logger.Warn().
+				Str("Mimetype", m).
+				Msg("The app doesn't support the configured mimetype, it won't be registered")
+			continue
+		}
+		mimeTypes = append(mimeTypes, m)
+	}
+	return mimeTypes
+}
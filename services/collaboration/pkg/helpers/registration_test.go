@@ -0,0 +1,72 @@
+package helpers_test
+
+import (
+	"context"
+
+	registryv1beta1 "github.com/cs3org/go-cs3apis/cs3/app/registry/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/status"
+	cs3mocks "github.com/cs3org/reva/v2/tests/cs3mocks/mocks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/collaboration/pkg/config"
+	"github.com/owncloud/ocis/v2/services/collaboration/pkg/helpers"
+)
+
+var _ = Describe("Registration", func() {
+	var (
+		cfg           *config.Config
+		gatewayClient *cs3mocks.GatewayAPIClient
+		appUrls       map[string]map[string]string
+	)
+
+	BeforeEach(func() {
+		cfg = &config.Config{}
+		cfg.Service.Name = "collaboration"
+		cfg.GRPC.Namespace = "com.owncloud.api"
+		cfg.App.Name = "OnlyOffice"
+		gatewayClient = &cs3mocks.GatewayAPIClient{}
+		appUrls = map[string]map[string]string{
+			"view": {
+				".pdf":  "https://test.server.prv/hosting/wopi/word/view",
+				".docx": "https://test.server.prv/hosting/wopi/word/view",
+			},
+			"edit": {
+				".docx": "https://test.server.prv/hosting/wopi/word/edit",
+				".xlsx": "https://test.server.prv/hosting/wopi/cell/edit",
+			},
+		}
+	})
+
+	Describe("RegisterAppProvider", func() {
+		It("Registers all the mimetypes of the app", func() {
+			gatewayClient.On("AddAppProvider", mock.Anything, mock.MatchedBy(func(req *registryv1beta1.AddAppProviderRequest) bool {
+				return req.GetProvider().GetName() == "OnlyOffice" &&
+					req.GetProvider().GetAddress() == "com.owncloud.api.collaboration.OnlyOffice" &&
+					len(req.GetProvider().GetMimeTypes()) == 3
+			})).Times(1).Return(&registryv1beta1.AddAppProviderResponse{Status: status.NewOK(context.Background())}, nil)
+
+			err := helpers.RegisterAppProvider(context.Background(), cfg, log.NopLogger(), gatewayClient, appUrls)
+			Expect(err).ToNot(HaveOccurred())
+			gatewayClient.AssertExpectations(GinkgoT())
+		})
+
+		It("Registers the configured mimetypes supported by the app", func() {
+			cfg.App.MimeTypes = []string{
+				"application/pdf",
+				"application/vnd.oasis.opendocument.text",
+			}
+
+			gatewayClient.On("AddAppProvider", mock.Anything, mock.MatchedBy(func(req *registryv1beta1.AddAppProviderRequest) bool {
+				mimeTypes := req.GetProvider().GetMimeTypes()
+				return len(mimeTypes) == 1 && mimeTypes[0] == "application/pdf"
+			})).Times(1).Return(&registryv1beta1.AddAppProviderResponse{Status: status.NewOK(context.Background())}, nil)
+
+			err := helpers.RegisterAppProvider(context.Background(), cfg, log.NopLogger(), gatewayClient, appUrls)
+			Expect(err).ToNot(HaveOccurred())
+			gatewayClient.AssertExpectations(GinkgoT())
+		})
+	})
+})
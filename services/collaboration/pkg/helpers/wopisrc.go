@@ -0,0 +1,43 @@
+package helpers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"path"
+
+	providerv1beta1 "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+)
+
+// GetWopiSrcURL returns the WOPISrc URL of the provided resource, based on
+// the configured WOPISrc base URL.
+//
+// The URL contains a urlsafe and stable file reference that can be used for
+// proxy routing, so that all sessions on one file end on the same office server
+func GetWopiSrcURL(wopiSrc string, id *providerv1beta1.ResourceId) (*url.URL, error) {
+	c := sha256.New()
+	c.Write([]byte(id.GetStorageId() + "$" + id.GetSpaceId() + "!" + id.GetOpaqueId()))
+	fileRef := hex.EncodeToString(c.Sum(nil))
+
+	wopiSrcURL, err := url.Parse(wopiSrc)
+	if err != nil {
+		return nil, err
+	}
+	wopiSrcURL.Path = path.Join("wopi", "files", fileRef)
+	return wopiSrcURL, nil
+}
+
+// SetWopiSrcQueryParam sets the "WOPISrc" query parameter of the app URL.
+// An existing "WOPISrc" query parameter will be replaced.
+func SetWopiSrcQueryParam(appURL string, wopiSrcURL *url.URL) (string, error) {
+	u, err := url.Parse(appURL)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	q.Set("WOPISrc", wopiSrcURL.String())
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
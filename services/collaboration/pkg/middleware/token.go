@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// GenerateWopiToken generates a WOPI access token containing the provided
+// WopiContext. The WOPI token will be signed with the WOPI secret.
+//
+// The access token inside the WopiContext must be the plain REVA token. It
+// will be encrypted with the WOPI secret before being embedded in the WOPI
+// token, which will expire at the same time as the REVA token.
+//
+// The WOPI token and its TTL (in milliseconds since Jan 1, 1970 UTC, as
+// required by the WOPI "access_token_ttl" parameter) will be returned.
+func GenerateWopiToken(wopiContext WopiContext, secret string) (string, int64, error) {
+	cryptedReqAccessToken, err := EncryptAES([]byte(secret), wopiContext.AccessToken)
+	if err != nil {
+		return "", 0, err
+	}
+
+	cs3Claims := &jwt.RegisteredClaims{}
+	cs3JWTparser := jwt.Parser{}
+	_, _, err = cs3JWTparser.ParseUnverified(wopiContext.AccessToken, cs3Claims)
+	if err != nil {
+		return "", 0, err
+	}
+	if cs3Claims.ExpiresAt == nil {
+		return "", 0, errors.New("the access token doesn't expire")
+	}
+
+	wopiContext.AccessToken = cryptedReqAccessToken
+	claims := &Claims{
+		WopiContext: wopiContext,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: cs3Claims.ExpiresAt,
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	accessToken, err := token.SignedString([]byte(secret))
+	if err != nil {
+		return "", 0, err
+	}
+
+	return accessToken, claims.ExpiresAt.UnixMilli(), nil
+}
@@ -30,6 +30,9 @@ type WopiContext struct {
 	ViewMode      appproviderv1beta1.ViewMode
 	EditAppUrl    string
 	ViewAppUrl    string
+	// AppName is the name of the app the token was issued for. WOPI requests
+	// will be handled by the connector of this app
+	AppName string
 }
 
 // WopiContextAuthMiddleware will prepare an HTTP handler to be used as
@@ -92,6 +95,7 @@ func WopiContextAuthMiddleware(jwtSecret string, next http.Handler) http.Handler
 			Str("WopiOverride", r.Header.Get("X-WOPI-Override")).
 			Str("FileReference", claims.WopiContext.FileReference.String()).
 			Str("ViewMode", claims.WopiContext.ViewMode.String()).
+			Str("AppName", claims.WopiContext.AppName).
 			Str("Requester", claims.WopiContext.User.GetId().String()).
 			Logger().WithContext(ctx)
 
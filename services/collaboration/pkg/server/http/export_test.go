@@ -0,0 +1,3 @@
+package http
+
+var PrepareRoutes = prepareRoutes
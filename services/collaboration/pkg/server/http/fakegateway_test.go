@@ -0,0 +1,379 @@
+package http_test
+
+import (
+	"context"
+	"io"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	gatewayv1beta1 "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	rpcv1beta1 "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	providerv1beta1 "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	typesv1beta1 "github.com/cs3org/go-cs3apis/cs3/types/v1beta1"
+	"google.golang.org/grpc"
+)
+
+const (
+	fakeStorageID = "storageid"
+	fakeSpaceID   = "spaceid"
+	fakeFolderID  = "folderid"
+)
+
+// fakeFile is a file stored by the fakeGateway
+type fakeFile struct {
+	id      string
+	name    string
+	content []byte
+	lock    *providerv1beta1.Lock
+	version int
+}
+
+// fakeUpload is an upload initiated but not finished yet
+type fakeUpload struct {
+	id   string
+	name string
+}
+
+// fakeGateway is an in-memory CS3 gateway storing files in a single folder.
+// Only the methods used by the WOPI endpoints are implemented, any other
+// method will panic. The file contents are uploaded and downloaded through
+// the data server, like in the real data gateway.
+type fakeGateway struct {
+	gatewayv1beta1.GatewayAPIClient
+
+	mu      sync.Mutex
+	files   map[string]*fakeFile
+	uploads map[string]fakeUpload
+	nextID  int
+
+	dataServer *httptest.Server
+}
+
+// newFakeGateway returns a fakeGateway with a running data server. The data
+// server must be closed with Close
+func newFakeGateway() *fakeGateway {
+	g := &fakeGateway{
+		files:   map[string]*fakeFile{},
+		uploads: map[string]fakeUpload{},
+	}
+	g.dataServer = httptest.NewServer(stdhttp.HandlerFunc(g.serveData))
+	return g
+}
+
+// Close stops the data server
+func (g *fakeGateway) Close() {
+	g.dataServer.Close()
+}
+
+// AddFile stores a new file in the folder and returns its id
+func (g *fakeGateway) AddFile(name, content string) *providerv1beta1.ResourceId {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	f := g.newFile(name)
+	f.content = []byte(content)
+	return resourceID(f.id)
+}
+
+// FileByName returns the file with the name, or nil if there is no such file
+func (g *fakeGateway) FileByName(name string) *fakeFile {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.byName(name)
+}
+
+func (g *fakeGateway) Stat(ctx context.Context, in *providerv1beta1.StatRequest, opts ...grpc.CallOption) (*providerv1beta1.StatResponse, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	f, _ := g.resolve(in.GetRef())
+	if f == nil {
+		return &providerv1beta1.StatResponse{Status: notFound()}, nil
+	}
+	return &providerv1beta1.StatResponse{Status: ok(), Info: f.info()}, nil
+}
+
+func (g *fakeGateway) GetLock(ctx context.Context, in *providerv1beta1.GetLockRequest, opts ...grpc.CallOption) (*providerv1beta1.GetLockResponse, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	f, _ := g.resolve(in.GetRef())
+	if f == nil {
+		return &providerv1beta1.GetLockResponse{Status: notFound()}, nil
+	}
+	return &providerv1beta1.GetLockResponse{Status: ok(), Lock: f.lock}, nil
+}
+
+func (g *fakeGateway) SetLock(ctx context.Context, in *providerv1beta1.SetLockRequest, opts ...grpc.CallOption) (*providerv1beta1.SetLockResponse, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	f, _ := g.resolve(in.GetRef())
+	switch {
+	case f == nil:
+		return &providerv1beta1.SetLockResponse{Status: notFound()}, nil
+	case f.lock != nil:
+		return &providerv1beta1.SetLockResponse{Status: status(rpcv1beta1.Code_CODE_FAILED_PRECONDITION)}, nil
+	}
+	f.lock = in.GetLock()
+	return &providerv1beta1.SetLockResponse{Status: ok()}, nil
+}
+
+func (g *fakeGateway) RefreshLock(ctx context.Context, in *providerv1beta1.RefreshLockRequest, opts ...grpc.CallOption) (*providerv1beta1.RefreshLockResponse, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	f, _ := g.resolve(in.GetRef())
+	if f == nil {
+		return &providerv1beta1.RefreshLockResponse{Status: notFound()}, nil
+	}
+
+	expected := in.GetLock().GetLockId()
+	if in.GetExistingLockId() != "" {
+		expected = in.GetExistingLockId()
+	}
+	if f.lock == nil || f.lock.GetLockId() != expected {
+		return &providerv1beta1.RefreshLockResponse{Status: status(rpcv1beta1.Code_CODE_ABORTED)}, nil
+	}
+	f.lock = in.GetLock()
+	return &providerv1beta1.RefreshLockResponse{Status: ok()}, nil
+}
+
+func (g *fakeGateway) Unlock(ctx context.Context, in *providerv1beta1.UnlockRequest, opts ...grpc.CallOption) (*providerv1beta1.UnlockResponse, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	f, _ := g.resolve(in.GetRef())
+	switch {
+	case f == nil:
+		return &providerv1beta1.UnlockResponse{Status: notFound()}, nil
+	case f.lock == nil:
+		return &providerv1beta1.UnlockResponse{Status: status(rpcv1beta1.Code_CODE_ABORTED)}, nil
+	case f.lock.GetLockId() != in.GetLock().GetLockId():
+		return &providerv1beta1.UnlockResponse{Status: status(rpcv1beta1.Code_CODE_LOCKED)}, nil
+	}
+	f.lock = nil
+	return &providerv1beta1.UnlockResponse{Status: ok()}, nil
+}
+
+func (g *fakeGateway) InitiateFileUpload(ctx context.Context, in *providerv1beta1.InitiateFileUploadRequest, opts ...grpc.CallOption) (*gatewayv1beta1.InitiateFileUploadResponse, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	f, name := g.resolve(in.GetRef())
+	if f == nil && name == "" {
+		return &gatewayv1beta1.InitiateFileUploadResponse{Status: notFound()}, nil
+	}
+
+	if f != nil {
+		switch {
+		case in.GetIfNotExist():
+			return &gatewayv1beta1.InitiateFileUploadResponse{Status: status(rpcv1beta1.Code_CODE_ALREADY_EXISTS)}, nil
+		case in.GetIfMatch() != "" && in.GetIfMatch() != f.etag():
+			return &gatewayv1beta1.InitiateFileUploadResponse{Status: status(rpcv1beta1.Code_CODE_FAILED_PRECONDITION)}, nil
+		case f.lock != nil && f.lock.GetLockId() != in.GetLockId():
+			return &gatewayv1beta1.InitiateFileUploadResponse{Status: status(rpcv1beta1.Code_CODE_LOCKED)}, nil
+		}
+	}
+
+	upload := fakeUpload{name: name}
+	if f != nil {
+		upload.id = f.id
+	}
+
+	// empty files won't be uploaded to the data server
+	if string(in.GetOpaque().GetMap()["Upload-Length"].GetValue()) == "0" {
+		g.finishUpload(upload, nil)
+		return &gatewayv1beta1.InitiateFileUploadResponse{Status: ok()}, nil
+	}
+
+	g.nextID++
+	token := "upload-" + strconv.Itoa(g.nextID)
+	g.uploads[token] = upload
+	return &gatewayv1beta1.InitiateFileUploadResponse{
+		Status: ok(),
+		Protocols: []*gatewayv1beta1.FileUploadProtocol{
+			{
+				Protocol:       "simple",
+				UploadEndpoint: g.dataServer.URL + "/upload/" + token,
+			},
+		},
+	}, nil
+}
+
+func (g *fakeGateway) InitiateFileDownload(ctx context.Context, in *providerv1beta1.InitiateFileDownloadRequest, opts ...grpc.CallOption) (*gatewayv1beta1.InitiateFileDownloadResponse, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	f, _ := g.resolve(in.GetRef())
+	if f == nil {
+		return &gatewayv1beta1.InitiateFileDownloadResponse{Status: notFound()}, nil
+	}
+	return &gatewayv1beta1.InitiateFileDownloadResponse{
+		Status: ok(),
+		Protocols: []*gatewayv1beta1.FileDownloadProtocol{
+			{
+				Protocol:         "simple",
+				DownloadEndpoint: g.dataServer.URL + "/download/" + f.id,
+			},
+		},
+	}, nil
+}
+
+func (g *fakeGateway) Move(ctx context.Context, in *providerv1beta1.MoveRequest, opts ...grpc.CallOption) (*providerv1beta1.MoveResponse, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	f, _ := g.resolve(in.GetSource())
+	if f == nil {
+		return &providerv1beta1.MoveResponse{Status: notFound()}, nil
+	}
+	existing, name := g.resolve(in.GetDestination())
+	switch {
+	case name == "":
+		return &providerv1beta1.MoveResponse{Status: status(rpcv1beta1.Code_CODE_INVALID_ARGUMENT)}, nil
+	case existing != nil:
+		return &providerv1beta1.MoveResponse{Status: status(rpcv1beta1.Code_CODE_ALREADY_EXISTS)}, nil
+	case f.lock != nil && f.lock.GetLockId() != in.GetLockId():
+		return &providerv1beta1.MoveResponse{Status: status(rpcv1beta1.Code_CODE_LOCKED)}, nil
+	}
+	f.name = name
+	f.version++
+	return &providerv1beta1.MoveResponse{Status: ok()}, nil
+}
+
+// serveData implements the upload and download endpoints of the data server
+func (g *fakeGateway) serveData(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+	switch {
+	case r.Method == stdhttp.MethodPut && strings.HasPrefix(r.URL.Path, "/upload/"):
+		content, err := io.ReadAll(r.Body)
+		if err != nil {
+			stdhttp.Error(w, err.Error(), stdhttp.StatusBadRequest)
+			return
+		}
+
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		token := path.Base(r.URL.Path)
+		upload, found := g.uploads[token]
+		if !found {
+			stdhttp.Error(w, "unknown upload", stdhttp.StatusNotFound)
+			return
+		}
+		delete(g.uploads, token)
+		g.finishUpload(upload, content)
+
+	case r.Method == stdhttp.MethodGet && strings.HasPrefix(r.URL.Path, "/download/"):
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		f, found := g.files[path.Base(r.URL.Path)]
+		if !found {
+			stdhttp.Error(w, "unknown file", stdhttp.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(f.content)
+
+	default:
+		stdhttp.Error(w, "unexpected request", stdhttp.StatusBadRequest)
+	}
+}
+
+// finishUpload stores the uploaded content, creating the file if needed.
+// The mutex must be held by the caller
+func (g *fakeGateway) finishUpload(upload fakeUpload, content []byte) {
+	f, found := g.files[upload.id]
+	if !found {
+		f = g.newFile(upload.name)
+	}
+	f.content = content
+	f.version++
+}
+
+// newFile creates an empty file. The mutex must be held by the caller
+func (g *fakeGateway) newFile(name string) *fakeFile {
+	g.nextID++
+	f := &fakeFile{
+		id:   "file-" + strconv.Itoa(g.nextID),
+		name: name,
+	}
+	g.files[f.id] = f
+	return f
+}
+
+// byName returns the file with the name. The mutex must be held by the caller
+func (g *fakeGateway) byName(name string) *fakeFile {
+	for _, f := range g.files {
+		if f.name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// resolve returns the referenced file and its name. If the reference points
+// to a missing file inside the folder, only the name will be returned.
+// The mutex must be held by the caller
+func (g *fakeGateway) resolve(ref *providerv1beta1.Reference) (*fakeFile, string) {
+	if ref.GetResourceId().GetOpaqueId() == fakeFolderID {
+		name := strings.TrimPrefix(ref.GetPath(), "./")
+		if name == "" || strings.Contains(name, "/") {
+			return nil, ""
+		}
+		return g.byName(name), name
+	}
+
+	if ref.GetPath() != "" && ref.GetPath() != "." {
+		return nil, ""
+	}
+	if f, found := g.files[ref.GetResourceId().GetOpaqueId()]; found {
+		return f, f.name
+	}
+	return nil, ""
+}
+
+func (f *fakeFile) etag() string {
+	return f.id + "-" + strconv.Itoa(f.version)
+}
+
+func (f *fakeFile) info() *providerv1beta1.ResourceInfo {
+	return &providerv1beta1.ResourceInfo{
+		Type:     providerv1beta1.ResourceType_RESOURCE_TYPE_FILE,
+		Id:       resourceID(f.id),
+		ParentId: resourceID(fakeFolderID),
+		Path:     f.name,
+		Size:     uint64(len(f.content)),
+		Etag:     f.etag(),
+		Lock:     f.lock,
+		Mtime: &typesv1beta1.Timestamp{
+			Seconds: uint64(f.version),
+		},
+		Owner: &userv1beta1.UserId{
+			Idp:      "https://idp.test.prv",
+			OpaqueId: "owner",
+		},
+	}
+}
+
+func resourceID(id string) *providerv1beta1.ResourceId {
+	return &providerv1beta1.ResourceId{
+		StorageId: fakeStorageID,
+		SpaceId:   fakeSpaceID,
+		OpaqueId:  id,
+	}
+}
+
+func status(code rpcv1beta1.Code) *rpcv1beta1.Status {
+	return &rpcv1beta1.Status{Code: code, Message: code.String()}
+}
+
+func ok() *rpcv1beta1.Status {
+	return status(rpcv1beta1.Code_CODE_OK)
+}
+
+func notFound() *rpcv1beta1.Status {
+	return status(rpcv1beta1.Code_CODE_NOT_FOUND)
+}
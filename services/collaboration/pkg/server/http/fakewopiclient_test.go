@@ -0,0 +1,121 @@
+package http_test
+
+import (
+	"encoding/json"
+	"io"
+	stdhttp "net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/owncloud/ocis/v2/services/collaboration/pkg/connector"
+)
+
+// fakeWopiClient sends the requests a WOPI client (the office app) would send
+// to the WOPI endpoints of a file. The file names sent in headers must be
+// UTF-7 encoded by the caller, as a real WOPI client would do
+type fakeWopiClient struct {
+	wopiSrc     string
+	accessToken string
+}
+
+// newFakeWopiClient returns a client for the file with the WOPISrc
+func newFakeWopiClient(wopiSrc, accessToken string) *fakeWopiClient {
+	return &fakeWopiClient{
+		wopiSrc:     wopiSrc,
+		accessToken: accessToken,
+	}
+}
+
+// newFakeWopiClientFromURL returns a client for the URL of a file, including
+// the access token, as returned by PutRelativeFile
+func newFakeWopiClientFromURL(fileURL string) (*fakeWopiClient, error) {
+	u, err := url.Parse(fileURL)
+	if err != nil {
+		return nil, err
+	}
+	accessToken := u.Query().Get("access_token")
+	u.RawQuery = ""
+	return newFakeWopiClient(u.String(), accessToken), nil
+}
+
+// CheckFileInfo returns the decoded file info
+func (c *fakeWopiClient) CheckFileInfo() (map[string]interface{}, error) {
+	resp, err := c.do(stdhttp.MethodGet, "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	info := map[string]interface{}{}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// GetFile returns the contents of the file
+func (c *fakeWopiClient) GetFile() (string, error) {
+	resp, err := c.do(stdhttp.MethodGet, "/contents", nil, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	return string(content), err
+}
+
+// Lock locks the file
+func (c *fakeWopiClient) Lock(lockID string) (*stdhttp.Response, error) {
+	return c.do(stdhttp.MethodPost, "", map[string]string{
+		"X-WOPI-Override":        "LOCK",
+		connector.HeaderWopiLock: lockID,
+	}, nil)
+}
+
+// PutRelativeFile creates a new file with the content. The target is either
+// suggested or relative, as in the "X-WOPI-SuggestedTarget" and
+// "X-WOPI-RelativeTarget" headers
+func (c *fakeWopiClient) PutRelativeFile(target string, suggested, overwrite bool, content string) (*stdhttp.Response, error) {
+	headers := map[string]string{
+		"X-WOPI-Override":                           "PUT_RELATIVE",
+		connector.HeaderWopiSize:                    strconv.Itoa(len(content)),
+		connector.HeaderWopiOverwriteRelativeTarget: strconv.FormatBool(overwrite),
+	}
+	if suggested {
+		headers[connector.HeaderWopiSuggestedTarget] = target
+	} else {
+		headers[connector.HeaderWopiRelativeTarget] = target
+	}
+	return c.do(stdhttp.MethodPost, "", headers, strings.NewReader(content))
+}
+
+// RenameFile renames the file, the requested name doesn't contain the
+// extension
+func (c *fakeWopiClient) RenameFile(lockID, requestedName string) (*stdhttp.Response, error) {
+	return c.do(stdhttp.MethodPost, "", map[string]string{
+		"X-WOPI-Override":                 "RENAME_FILE",
+		connector.HeaderWopiLock:          lockID,
+		connector.HeaderWopiRequestedName: requestedName,
+	}, nil)
+}
+
+// PutUserInfo stores the user info
+func (c *fakeWopiClient) PutUserInfo(userInfo string) (*stdhttp.Response, error) {
+	return c.do(stdhttp.MethodPost, "", map[string]string{
+		"X-WOPI-Override": "PUT_USER_INFO",
+	}, strings.NewReader(userInfo))
+}
+
+func (c *fakeWopiClient) do(method, endpoint string, headers map[string]string, body io.Reader) (*stdhttp.Response, error) {
+	target := c.wopiSrc + endpoint + "?access_token=" + url.QueryEscape(c.accessToken)
+	req, err := stdhttp.NewRequest(method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return stdhttp.DefaultClient.Do(req)
+}
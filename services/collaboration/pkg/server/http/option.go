@@ -14,7 +14,7 @@ type Option func(o *Options)
 
 // Options defines the available options for this package.
 type Options struct {
-	Adapter        *connector.HttpAdapter
+	Adapters       map[string]*connector.HttpAdapter
 	Logger         log.Logger
 	Context        context.Context
 	Config         *config.Config
@@ -32,10 +32,11 @@ func newOptions(opts ...Option) Options {
 	return opt
 }
 
-// App provides a function to set the logger option.
-func Adapter(val *connector.HttpAdapter) Option {
+// Adapters provides a function to set the adapters option. There is one
+// adapter per app, using the app name as key.
+func Adapters(val map[string]*connector.HttpAdapter) Option {
 	return func(o *Options) {
-		o.Adapter = val
+		o.Adapters = val
 	}
 }
 
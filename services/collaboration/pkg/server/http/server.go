@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"fmt"
 	stdhttp "net/http"
 
@@ -12,8 +13,10 @@ import (
 	"github.com/owncloud/ocis/v2/ocis-pkg/service/http"
 	"github.com/owncloud/ocis/v2/ocis-pkg/tracing"
 	"github.com/owncloud/ocis/v2/ocis-pkg/version"
+	"github.com/owncloud/ocis/v2/services/collaboration/pkg/connector"
 	colabmiddleware "github.com/owncloud/ocis/v2/services/collaboration/pkg/middleware"
 	"github.com/riandyrn/otelchi"
+	"github.com/rs/zerolog"
 	"go-micro.dev/v4"
 )
 
@@ -91,9 +94,14 @@ func Server(opts ...Option) (http.Service, error) {
 	return service, nil
 }
 
+type key int
+
+const (
+	adapterKey key = iota
+)
+
 // prepareRoutes will prepare all the implemented routes
 func prepareRoutes(r *chi.Mux, options Options) {
-	adapter := options.Adapter
 	logger := options.Logger
 	// prepare basic logger for the request
 	r.Use(func(h stdhttp.Handler) stdhttp.Handler {
@@ -121,11 +129,18 @@ func prepareRoutes(r *chi.Mux, options Options) {
 			},
 			)
 
+			r.Use(func(h stdhttp.Handler) stdhttp.Handler {
+				// the request will be handled by the adapter of the app
+				return appAdapterMiddleware(options, h)
+			},
+			)
+
 			r.Get("/", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
-				adapter.CheckFileInfo(w, r)
+				adapterFromCtx(r.Context()).CheckFileInfo(w, r)
 			})
 
 			r.Post("/", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+				adapter := adapterFromCtx(r.Context())
 				action := r.Header.Get("X-WOPI-Override")
 				switch action {
 
@@ -140,14 +155,12 @@ func prepareRoutes(r *chi.Mux, options Options) {
 					adapter.UnLock(w, r)
 
 				case "PUT_USER_INFO":
-					// https://docs.microsoft.com/en-us/microsoft-365/cloud-storage-partner-program/rest/files/putuserinfo
-					stdhttp.Error(w, stdhttp.StatusText(stdhttp.StatusNotImplemented), stdhttp.StatusNotImplemented)
+					adapter.PutUserInfo(w, r)
 				case "PUT_RELATIVE":
-					// https://docs.microsoft.com/en-us/microsoft-365/cloud-storage-partner-program/rest/files/putrelativefile
-					stdhttp.Error(w, stdhttp.StatusText(stdhttp.StatusNotImplemented), stdhttp.StatusNotImplemented)
+					// "Save as" and format conversions go through here
+					adapter.PutRelativeFile(w, r)
 				case "RENAME_FILE":
-					// https://docs.microsoft.com/en-us/microsoft-365/cloud-storage-partner-program/rest/files/renamefile
-					stdhttp.Error(w, stdhttp.StatusText(stdhttp.StatusNotImplemented), stdhttp.StatusNotImplemented)
+					adapter.RenameFile(w, r)
 				case "DELETE":
 					// https://docs.microsoft.com/en-us/microsoft-365/cloud-storage-partner-program/rest/files/deletefile
 					stdhttp.Error(w, stdhttp.StatusText(stdhttp.StatusNotImplemented), stdhttp.StatusNotImplemented)
@@ -159,10 +172,11 @@ func prepareRoutes(r *chi.Mux, options Options) {
 
 			r.Route("/contents", func(r chi.Router) {
 				r.Get("/", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
-					adapter.GetFile(w, r)
+					adapterFromCtx(r.Context()).GetFile(w, r)
 				})
 
 				r.Post("/", func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+					adapter := adapterFromCtx(r.Context())
 					action := r.Header.Get("X-WOPI-Override")
 					switch action {
 
@@ -177,3 +191,39 @@ func prepareRoutes(r *chi.Mux, options Options) {
 		})
 	})
 }
+
+// appAdapterMiddleware will add the adapter of the app the WOPI token was
+// issued for to the request's context. Tokens without app name were issued
+// before several apps could be served, so the main app will be used for them.
+// The WOPI context must have been added to the request's context already.
+func appAdapterMiddleware(options Options, next stdhttp.Handler) stdhttp.Handler {
+	return stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		wopiContext, err := colabmiddleware.WopiContextFromCtx(r.Context())
+		if err != nil {
+			stdhttp.Error(w, stdhttp.StatusText(stdhttp.StatusUnauthorized), stdhttp.StatusUnauthorized)
+			return
+		}
+
+		appName := wopiContext.AppName
+		if appName == "" {
+			appName = options.Config.App.Name
+		}
+
+		adapter, ok := options.Adapters[appName]
+		if !ok {
+			zerolog.Ctx(r.Context()).Error().
+				Str("AppName", appName).
+				Msg("the app isn't served by this service")
+			stdhttp.Error(w, stdhttp.StatusText(stdhttp.StatusNotFound), stdhttp.StatusNotFound)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), adapterKey, adapter)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// adapterFromCtx returns the adapter added by the appAdapterMiddleware
+func adapterFromCtx(ctx context.Context) *connector.HttpAdapter {
+	return ctx.Value(adapterKey).(*connector.HttpAdapter)
+}
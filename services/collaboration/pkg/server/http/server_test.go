@@ -0,0 +1,344 @@
+package http_test
+
+import (
+	"encoding/json"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"time"
+
+	appproviderv1beta1 "github.com/cs3org/go-cs3apis/cs3/app/provider/v1beta1"
+	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	providerv1beta1 "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	typesv1beta1 "github.com/cs3org/go-cs3apis/cs3/types/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/collaboration/pkg/config"
+	"github.com/owncloud/ocis/v2/services/collaboration/pkg/connector"
+	"github.com/owncloud/ocis/v2/services/collaboration/pkg/helpers"
+	"github.com/owncloud/ocis/v2/services/collaboration/pkg/middleware"
+	"github.com/owncloud/ocis/v2/services/collaboration/pkg/server/http"
+)
+
+// These tests run the WOPI endpoints end to end: a fake WOPI client sends
+// HTTP requests to the real routes, which use an in-memory fake gateway
+// as storage.
+var _ = Describe("WOPI server", func() {
+	var (
+		gw      *fakeGateway
+		srv     *httptest.Server
+		cfg     *config.Config
+		user    *userv1beta1.User
+		appName string
+		client  *fakeWopiClient
+	)
+
+	// clientFor opens the file with a new WOPI client, as the app provider
+	// would do for the user
+	clientFor := func(id *providerv1beta1.ResourceId, viewMode appproviderv1beta1.ViewMode) *fakeWopiClient {
+		wopiSrcURL, err := helpers.GetWopiSrcURL(cfg.Wopi.WopiSrc, id)
+		Expect(err).ToNot(HaveOccurred())
+		editURL, err := helpers.SetWopiSrcQueryParam("https://office.test.prv/edit", wopiSrcURL)
+		Expect(err).ToNot(HaveOccurred())
+		viewURL, err := helpers.SetWopiSrcQueryParam("https://office.test.prv/view", wopiSrcURL)
+		Expect(err).ToNot(HaveOccurred())
+
+		revaToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}).SignedString([]byte("reva_secret"))
+		Expect(err).ToNot(HaveOccurred())
+
+		accessToken, _, err := middleware.GenerateWopiToken(middleware.WopiContext{
+			AccessToken: revaToken,
+			FileReference: providerv1beta1.Reference{
+				ResourceId: id,
+				Path:       ".",
+			},
+			User:       user,
+			ViewMode:   viewMode,
+			EditAppUrl: editURL,
+			ViewAppUrl: viewURL,
+			AppName:    appName,
+		}, cfg.Wopi.Secret)
+		Expect(err).ToNot(HaveOccurred())
+
+		return newFakeWopiClient(wopiSrcURL.String(), accessToken)
+	}
+
+	decodeJSON := func(resp *stdhttp.Response) map[string]string {
+		defer resp.Body.Close()
+		body := map[string]string{}
+		Expect(json.NewDecoder(resp.Body).Decode(&body)).To(Succeed())
+		return body
+	}
+
+	BeforeEach(func() {
+		gw = newFakeGateway()
+
+		mux := chi.NewMux()
+		srv = httptest.NewServer(mux)
+
+		cfg = &config.Config{}
+		cfg.Apps = []config.App{
+			{Name: "FakeOffice", LockName: "com.github.owncloud.collaboration"},
+			{Name: "Collabora", LockName: "com.github.owncloud.collaboration"},
+		}
+		cfg.App = cfg.Apps[0]
+		cfg.Wopi.WopiSrc = srv.URL
+		cfg.Wopi.Secret = "wopi_secret"
+
+		st := store.Create()
+		adapters := map[string]*connector.HttpAdapter{}
+		for _, app := range cfg.Apps {
+			adapters[app.Name] = connector.NewHttpAdapter(gw, cfg.ForApp(app), st, nil, nil)
+		}
+
+		http.PrepareRoutes(mux, http.Options{
+			Adapters: adapters,
+			Logger:   log.NopLogger(),
+			Config:   cfg,
+		})
+
+		user = &userv1beta1.User{
+			Id: &userv1beta1.UserId{
+				Idp:      "https://idp.test.prv",
+				OpaqueId: "aabbcc",
+			},
+			Username:    "alice",
+			DisplayName: "Alice",
+		}
+		appName = "FakeOffice"
+		client = clientFor(gw.AddFile("test.docx", "original content"), appproviderv1beta1.ViewMode_VIEW_MODE_READ_WRITE)
+	})
+
+	AfterEach(func() {
+		srv.Close()
+		gw.Close()
+	})
+
+	Describe("PutRelativeFile", func() {
+		It("Saves the file as a new file which can be opened", func() {
+			resp, err := client.PutRelativeFile(".pdf", true, false, "pdf content")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(200))
+
+			body := decodeJSON(resp)
+			Expect(body["Name"]).To(Equal("test.pdf"))
+			Expect(body["HostEditUrl"]).To(HavePrefix("https://office.test.prv/edit?WOPISrc="))
+
+			newClient, err := newFakeWopiClientFromURL(body["Url"])
+			Expect(err).ToNot(HaveOccurred())
+
+			info, err := newClient.CheckFileInfo()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info["BaseFileName"]).To(Equal("test.pdf"))
+			Expect(info["Size"]).To(BeEquivalentTo(len("pdf content")))
+			Expect(info["HostEditUrl"]).To(Equal(body["HostEditUrl"]))
+
+			content, err := newClient.GetFile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(content).To(Equal("pdf content"))
+
+			content, err = client.GetFile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(content).To(Equal("original content"))
+		})
+
+		It("Uses a numbered name for a suggested name which already exists", func() {
+			gw.AddFile("test.pdf", "existing")
+
+			resp, err := client.PutRelativeFile(".pdf", true, false, "pdf content")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(200))
+			Expect(decodeJSON(resp)["Name"]).To(Equal("test (1).pdf"))
+			Expect(string(gw.FileByName("test.pdf").content)).To(Equal("existing"))
+		})
+
+		It("Creates an empty file", func() {
+			resp, err := client.PutRelativeFile("empty.txt", false, false, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(200))
+			Expect(decodeJSON(resp)["Name"]).To(Equal("empty.txt"))
+			Expect(gw.FileByName("empty.txt").content).To(BeEmpty())
+		})
+
+		It("Returns a valid target if the relative target exists", func() {
+			gw.AddFile("Bericht für März.docx", "existing")
+
+			resp, err := client.PutRelativeFile("Bericht f+APw-r M+AOQ-rz.docx", false, false, "new content")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(409))
+			Expect(resp.Header.Get(connector.HeaderWopiValidRelativeTarget)).To(Equal("Bericht f+APw-r M+AOQ-rz (1).docx"))
+			Expect(string(gw.FileByName("Bericht für März.docx").content)).To(Equal("existing"))
+		})
+
+		It("Overwrites the relative target", func() {
+			gw.AddFile("copy.docx", "existing")
+
+			resp, err := client.PutRelativeFile("copy.docx", false, true, "new content")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(200))
+			Expect(decodeJSON(resp)["Name"]).To(Equal("copy.docx"))
+			Expect(string(gw.FileByName("copy.docx").content)).To(Equal("new content"))
+		})
+
+		It("Doesn't overwrite a locked relative target", func() {
+			lockedClient := clientFor(gw.AddFile("copy.docx", "existing"), appproviderv1beta1.ViewMode_VIEW_MODE_READ_WRITE)
+			resp, err := lockedClient.Lock("abcdef123")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(200))
+
+			resp, err = client.PutRelativeFile("copy.docx", false, true, "new content")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(409))
+			Expect(resp.Header.Get(connector.HeaderWopiLock)).To(Equal("abcdef123"))
+			Expect(string(gw.FileByName("copy.docx").content)).To(Equal("existing"))
+		})
+
+		It("Isn't supported in read only mode", func() {
+			client = clientFor(gw.AddFile("readonly.docx", "content"), appproviderv1beta1.ViewMode_VIEW_MODE_READ_ONLY)
+
+			info, err := client.CheckFileInfo()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info["UserCanNotWriteRelative"]).To(BeTrue())
+
+			resp, err := client.PutRelativeFile(".pdf", true, false, "pdf content")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(501))
+			Expect(gw.FileByName("readonly.pdf")).To(BeNil())
+		})
+	})
+
+	Describe("RenameFile", func() {
+		It("Renames the locked file and keeps the extension", func() {
+			resp, err := client.Lock("abcdef123")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(200))
+
+			resp, err = client.RenameFile("abcdef123", "+AMk-t+AOk-")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(200))
+			Expect(decodeJSON(resp)["Name"]).To(Equal("Été"))
+
+			info, err := client.CheckFileInfo()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info["BaseFileName"]).To(Equal("Été.docx"))
+		})
+
+		It("Uses a numbered name if the name already exists", func() {
+			gw.AddFile("renamed.docx", "existing")
+
+			resp, err := client.RenameFile("", "renamed")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(200))
+			Expect(decodeJSON(resp)["Name"]).To(Equal("renamed (1)"))
+			Expect(gw.FileByName("test.docx")).To(BeNil())
+		})
+
+		It("Doesn't rename a file locked with another lock", func() {
+			resp, err := client.Lock("abcdef123")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(200))
+
+			resp, err = client.RenameFile("zzz999", "renamed")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(409))
+			Expect(resp.Header.Get(connector.HeaderWopiLock)).To(Equal("abcdef123"))
+			Expect(gw.FileByName("test.docx")).ToNot(BeNil())
+		})
+
+		It("Rejects invalid names", func() {
+			resp, err := client.RenameFile("", "a/b")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(400))
+			Expect(resp.Header.Get(connector.HeaderWopiInvalidFileNameError)).ToNot(BeEmpty())
+			Expect(gw.FileByName("test.docx")).ToNot(BeNil())
+		})
+	})
+
+	Describe("PutUserInfo", func() {
+		It("Returns the user info for any file of the user", func() {
+			info, err := client.CheckFileInfo()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info["SupportsUserInfo"]).To(BeTrue())
+			Expect(info).ToNot(HaveKey("UserInfo"))
+
+			resp, err := client.PutUserInfo("theme=dark")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(200))
+
+			otherClient := clientFor(gw.AddFile("other.docx", "content"), appproviderv1beta1.ViewMode_VIEW_MODE_READ_ONLY)
+			info, err = otherClient.CheckFileInfo()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info["UserInfo"]).To(Equal("theme=dark"))
+		})
+
+		It("Isn't supported for public link users", func() {
+			user.Opaque = &typesv1beta1.Opaque{
+				Map: map[string]*typesv1beta1.OpaqueEntry{
+					"public-share-role": {Decoder: "plain", Value: []byte("viewer")},
+				},
+			}
+			client = clientFor(gw.AddFile("public.docx", "content"), appproviderv1beta1.ViewMode_VIEW_MODE_READ_ONLY)
+
+			resp, err := client.PutUserInfo("theme=dark")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(501))
+		})
+	})
+
+	Describe("Apps", func() {
+		It("Handles the requests with the connector of the app", func() {
+			appName = "Collabora"
+			client = clientFor(gw.AddFile("collabora.docx", "content"), appproviderv1beta1.ViewMode_VIEW_MODE_READ_WRITE)
+
+			info, err := client.CheckFileInfo()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info["EnableOwnerTermination"]).To(BeTrue())
+
+			resp, err := client.Lock("abcdef123")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(200))
+			Expect(gw.FileByName("collabora.docx").lock.GetAppName()).To(Equal("com.github.owncloud.collaboration.Collabora"))
+		})
+
+		It("Keeps the app for files created with PutRelativeFile", func() {
+			appName = "Collabora"
+			client = clientFor(gw.AddFile("collabora.docx", "content"), appproviderv1beta1.ViewMode_VIEW_MODE_READ_WRITE)
+
+			resp, err := client.PutRelativeFile(".odt", true, false, "odt content")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(200))
+
+			newClient, err := newFakeWopiClientFromURL(decodeJSON(resp)["Url"])
+			Expect(err).ToNot(HaveOccurred())
+			resp, err = newClient.Lock("abcdef123")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(200))
+			Expect(gw.FileByName("collabora.odt").lock.GetAppName()).To(Equal("com.github.owncloud.collaboration.Collabora"))
+		})
+
+		It("Uses the main app for tokens without app", func() {
+			appName = ""
+			client = clientFor(gw.AddFile("old.docx", "content"), appproviderv1beta1.ViewMode_VIEW_MODE_READ_WRITE)
+
+			resp, err := client.Lock("abcdef123")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(200))
+			Expect(gw.FileByName("old.docx").lock.GetAppName()).To(Equal("com.github.owncloud.collaboration.FakeOffice"))
+		})
+
+		It("Rejects tokens of apps which aren't served", func() {
+			appName = "OnlyOffice"
+			client = clientFor(gw.AddFile("onlyoffice.docx", "content"), appproviderv1beta1.ViewMode_VIEW_MODE_READ_WRITE)
+
+			resp, err := client.Lock("abcdef123")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(404))
+			Expect(gw.FileByName("onlyoffice.docx").lock).To(BeNil())
+		})
+	})
+})
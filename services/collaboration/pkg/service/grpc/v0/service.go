@@ -2,10 +2,7 @@ package service
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
 	"fmt"
-	"net/url"
 	"path"
 	"strconv"
 
@@ -16,10 +13,10 @@ import (
 	providerv1beta1 "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
 	"github.com/cs3org/reva/v2/pkg/utils"
-	"github.com/golang-jwt/jwt/v4"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/config"
+	"github.com/owncloud/ocis/v2/services/collaboration/pkg/helpers"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/middleware"
 )
 
@@ -79,13 +76,6 @@ func (s *Service) OpenInApp(
 		Path:       ".",
 	}
 
-	// build a urlsafe and stable file reference that can be used for proxy routing,
-	// so that all sessions on one file end on the same office server
-
-	c := sha256.New()
-	c.Write([]byte(req.GetResourceInfo().GetId().GetStorageId() + "$" + req.GetResourceInfo().GetId().GetSpaceId() + "!" + req.GetResourceInfo().GetId().GetOpaqueId()))
-	fileRef := hex.EncodeToString(c.Sum(nil))
-
 	// get the file extension to use the right wopi app url
 	fileExt := path.Ext(req.GetResourceInfo().GetPath())
 
@@ -123,27 +113,12 @@ func (s *Service) OpenInApp(
 		viewAppURL = editAppURL
 	}
 
-	wopiSrcURL, err := url.Parse(s.config.Wopi.WopiSrc)
+	wopiSrcURL, err := helpers.GetWopiSrcURL(s.config.Wopi.WopiSrc, req.GetResourceInfo().GetId())
 	if err != nil {
 		return nil, err
 	}
-	wopiSrcURL.Path = path.Join("wopi", "files", fileRef)
-
-	addWopiSrcQueryParam := func(baseURL string) (string, error) {
-		u, err := url.Parse(baseURL)
-		if err != nil {
-			return "", err
-		}
-
-		q := u.Query()
-		q.Add("WOPISrc", wopiSrcURL.String())
-		qs := q.Encode()
-		u.RawQuery = qs
-
-		return u.String(), nil
-	}
 
-	viewAppURL, err = addWopiSrcQueryParam(viewAppURL)
+	viewAppURL, err = helpers.SetWopiSrcQueryParam(viewAppURL, wopiSrcURL)
 	if err != nil {
 		s.logger.Error().
 			Err(err).
@@ -153,7 +128,7 @@ func (s *Service) OpenInApp(
 			Msg("OpenInApp: error parsing viewAppUrl")
 		return nil, err
 	}
-	editAppURL, err = addWopiSrcQueryParam(editAppURL)
+	editAppURL, err = helpers.SetWopiSrcQueryParam(editAppURL, wopiSrcURL)
 	if err != nil {
 		s.logger.Error().
 			Err(err).
@@ -169,64 +144,28 @@ func (s *Service) OpenInApp(
 		appURL = editAppURL
 	}
 
-	cryptedReqAccessToken, err := middleware.EncryptAES([]byte(s.config.Wopi.Secret), req.GetAccessToken())
-	if err != nil {
-		s.logger.Error().
-			Err(err).
-			Str("FileReference", providerFileRef.String()).
-			Str("ViewMode", req.GetViewMode().String()).
-			Str("Requester", user.GetId().String()).
-			Msg("OpenInApp: error encrypting access token")
-		return &appproviderv1beta1.OpenInAppResponse{
-			Status: &rpcv1beta1.Status{Code: rpcv1beta1.Code_CODE_INTERNAL},
-		}, err
-	}
-
 	wopiContext := middleware.WopiContext{
-		AccessToken:   cryptedReqAccessToken,
+		AccessToken:   req.GetAccessToken(),
 		ViewOnlyToken: utils.ReadPlainFromOpaque(req.GetOpaque(), "viewOnlyToken"),
 		FileReference: providerFileRef,
 		User:          user,
 		ViewMode:      req.GetViewMode(),
 		EditAppUrl:    editAppURL,
 		ViewAppUrl:    viewAppURL,
+		AppName:       s.config.App.Name,
 	}
 
-	cs3Claims := &jwt.RegisteredClaims{}
-	cs3JWTparser := jwt.Parser{}
-	_, _, err = cs3JWTparser.ParseUnverified(req.GetAccessToken(), cs3Claims)
+	accessToken, accessTokenTTL, err := middleware.GenerateWopiToken(wopiContext, s.config.Wopi.Secret)
 	if err != nil {
 		s.logger.Error().
 			Err(err).
 			Str("FileReference", providerFileRef.String()).
 			Str("ViewMode", req.GetViewMode().String()).
 			Str("Requester", user.GetId().String()).
-			Msg("OpenInApp: error parsing JWT token")
+			Msg("OpenInApp: error generating access token")
 		return nil, err
 	}
 
-	claims := &middleware.Claims{
-		WopiContext: wopiContext,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: cs3Claims.ExpiresAt,
-		},
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	accessToken, err := token.SignedString([]byte(s.config.Wopi.Secret))
-
-	if err != nil {
-		s.logger.Error().
-			Err(err).
-			Str("FileReference", providerFileRef.String()).
-			Str("ViewMode", req.GetViewMode().String()).
-			Str("Requester", user.GetId().String()).
-			Msg("OpenInApp: error signing access token")
-		return &appproviderv1beta1.OpenInAppResponse{
-			Status: &rpcv1beta1.Status{Code: rpcv1beta1.Code_CODE_INTERNAL},
-		}, err
-	}
-
 	s.logger.Debug().
 		Str("FileReference", providerFileRef.String()).
 		Str("ViewMode", req.GetViewMode().String()).
@@ -242,7 +181,7 @@ func (s *Service) OpenInApp(
 				// these parameters will be passed to the web server by the app provider application
 				"access_token": accessToken,
 				// milliseconds since Jan 1, 1970 UTC as required in https://docs.microsoft.com/en-us/microsoft-365/cloud-storage-partner-program/rest/concepts#access_token_ttl
-				"access_token_ttl": strconv.FormatInt(claims.ExpiresAt.UnixMilli(), 10),
+				"access_token_ttl": strconv.FormatInt(accessTokenTTL, 10),
 			},
 		},
 	}, nil
@@ -22,6 +22,7 @@ import (
 	cs3mocks "github.com/cs3org/reva/v2/tests/cs3mocks/mocks"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/services/collaboration/pkg/config"
+	"github.com/owncloud/ocis/v2/services/collaboration/pkg/middleware"
 	service "github.com/owncloud/ocis/v2/services/collaboration/pkg/service/grpc/v0"
 )
 
@@ -141,6 +142,7 @@ var _ = Describe("Discovery", func() {
 
 			cfg.Wopi.WopiSrc = "https://wopiserver.test.prv"
 			cfg.Wopi.Secret = "my_supa_secret"
+			cfg.App.Name = "Collabora"
 
 			myself := &userv1beta1.User{
 				Id: &userv1beta1.UserId{
@@ -175,6 +177,13 @@ var _ = Describe("Discovery", func() {
 			Expect(resp.GetAppUrl().GetMethod()).To(Equal("POST"))
 			Expect(resp.GetAppUrl().GetAppUrl()).To(Equal("https://test.server.prv/hosting/wopi/word/edit?WOPISrc=https%3A%2F%2Fwopiserver.test.prv%2Fwopi%2Ffiles%2F2f6ec18696dd1008106749bd94106e5cfad5c09e15de7b77088d03843e71b43e"))
 			Expect(resp.GetAppUrl().GetFormParameters()["access_token_ttl"]).To(Equal(strconv.FormatInt(nowTime.Add(5*time.Hour).Unix()*1000, 10)))
+
+			claims := &middleware.Claims{}
+			_, err = jwt.ParseWithClaims(resp.GetAppUrl().GetFormParameters()["access_token"], claims, func(token *jwt.Token) (interface{}, error) {
+				return []byte(cfg.Wopi.Secret), nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claims.WopiContext.AppName).To(Equal("Collabora"))
 		})
 	})
 })
@@ -157,6 +157,11 @@ func spacesProviders(cfg *config.Config, logger log.Logger) map[string]map[strin
 					"mount_point":   "/projects",
 					"path_template": "/projects/{{.Space.Name}}",
 				},
+				// holds infected files for the review by an admin, see the antivirus service
+				"quarantine": map[string]interface{}{
+					"mount_point":   "/quarantine",
+					"path_template": "/quarantine/{{.Space.Root.OpaqueId}}",
+				},
 			},
 		},
 		cfg.StorageSharesEndpoint: {
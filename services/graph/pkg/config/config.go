@@ -33,6 +33,9 @@ type Config struct {
 	Keycloak       Keycloak       `yaml:"keycloak"`
 	ServiceAccount ServiceAccount `yaml:"service_account"`
 
+	QuotaPolicies   QuotaPolicies   `yaml:"quota_policies"`
+	EditingSessions EditingSessions `yaml:"editing_sessions"`
+
 	Context context.Context `yaml:"-"`
 }
 
@@ -47,6 +50,22 @@ type Spaces struct {
 	DefaultLanguage                 string `yaml:"default_language" env:"OCIS_DEFAULT_LANGUAGE" desc:"The default language used by services and the WebUI. If not defined, English will be used as default. See the documentation for more details." introductionVersion:"5.0"`
 }
 
+// QuotaPolicies configures the store of the quota policies managed by the settings service
+type QuotaPolicies struct {
+	Store        string   `yaml:"store" env:"OCIS_PERSISTENT_STORE;GRAPH_QUOTA_POLICIES_STORE" desc:"The type of the store for the quota policies. Supported values are: 'memory', 'redis-sentinel' and 'nats-js-kv'. The store must be shared with the settings service. See the text description for details." introductionVersion:"6.0.0"`
+	Nodes        []string `yaml:"nodes" env:"OCIS_PERSISTENT_STORE_NODES;GRAPH_QUOTA_POLICIES_STORE_NODES" desc:"A list of nodes to access the configured store. This has no effect when 'memory' store is configured. Note that the behaviour how nodes are used is dependent on the library of the configured store. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+	AuthUsername string   `yaml:"username" env:"OCIS_PERSISTENT_STORE_AUTH_USERNAME;GRAPH_QUOTA_POLICIES_STORE_AUTH_USERNAME" desc:"The username to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"6.0.0"`
+	AuthPassword string   `yaml:"password" env:"OCIS_PERSISTENT_STORE_AUTH_PASSWORD;GRAPH_QUOTA_POLICIES_STORE_AUTH_PASSWORD" desc:"The password to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"6.0.0"`
+}
+
+// EditingSessions configures the store of the editing sessions tracked by the collaboration service
+type EditingSessions struct {
+	Store        string   `yaml:"store" env:"OCIS_PERSISTENT_STORE;GRAPH_EDITING_SESSIONS_STORE" desc:"The type of the store for the editing sessions. Supported values are: 'memory', 'redis-sentinel' and 'nats-js-kv'. The store must be shared with the collaboration service. See the text description for details." introductionVersion:"6.0.0"`
+	Nodes        []string `yaml:"nodes" env:"OCIS_PERSISTENT_STORE_NODES;GRAPH_EDITING_SESSIONS_STORE_NODES" desc:"A list of nodes to access the configured store. This has no effect when 'memory' store is configured. Note that the behaviour how nodes are used is dependent on the library of the configured store. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+	AuthUsername string   `yaml:"username" env:"OCIS_PERSISTENT_STORE_AUTH_USERNAME;GRAPH_EDITING_SESSIONS_STORE_AUTH_USERNAME" desc:"The username to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"6.0.0"`
+	AuthPassword string   `yaml:"password" env:"OCIS_PERSISTENT_STORE_AUTH_PASSWORD;GRAPH_EDITING_SESSIONS_STORE_AUTH_PASSWORD" desc:"The password to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"6.0.0"`
+}
+
 type LDAP struct {
 	URI                string `yaml:"uri" env:"OCIS_LDAP_URI;GRAPH_LDAP_URI" desc:"URI of the LDAP Server to connect to. Supported URI schemes are 'ldaps://' and 'ldap://'" introductionVersion:"pre5.0"`
 	CACert             string `yaml:"cacert" env:"OCIS_LDAP_CACERT;GRAPH_LDAP_CACERT" desc:"Path/File name for the root CA certificate (in PEM format) used to validate TLS server certificates of the LDAP service. If not defined, the root directory derives from $OCIS_BASE_DATA_PATH:/idm." introductionVersion:"pre5.0"`
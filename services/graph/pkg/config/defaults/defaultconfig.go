@@ -102,6 +102,14 @@ func DefaultConfig() *config.Config {
 			Database: "cache-roles",
 			TTL:      time.Hour * 336,
 		},
+		QuotaPolicies: config.QuotaPolicies{
+			Store: "nats-js-kv",
+			Nodes: []string{"127.0.0.1:9233"},
+		},
+		EditingSessions: config.EditingSessions{
+			Store: "nats-js-kv",
+			Nodes: []string{"127.0.0.1:9233"},
+		},
 		Events: config.Events{
 			Endpoint:  "127.0.0.1:9233",
 			Cluster:   "ocis-cluster",
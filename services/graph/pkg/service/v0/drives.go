@@ -29,6 +29,7 @@ import (
 	"github.com/cs3org/reva/v2/pkg/utils"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/l10n"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quotapolicy"
 	"github.com/owncloud/ocis/v2/ocis-pkg/service/grpc"
 	v0 "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/settings/v0"
 	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
@@ -379,10 +380,23 @@ func (g Graph) CreateDrive(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	template := r.URL.Query().Get(TemplateParameter)
+	quota := getQuota(drive.Quota, g.config.Spaces.DefaultQuota)
+	if !drive.Quota.HasTotal() {
+		// a quota policy for the template takes precedence over the default quota
+		p, err := g.quotaPolicies.SpacePolicy(quotapolicy.SpaceTypeProject, "", spaceTemplateName(template))
+		switch {
+		case err != nil:
+			logger.Error().Err(err).Msg("could not read the quota policies, using the default quota")
+		case p != nil:
+			quota = &storageprovider.Quota{QuotaMaxBytes: p.Quota}
+		}
+	}
+
 	csr := storageprovider.CreateStorageSpaceRequest{
 		Type:  driveType,
 		Name:  spaceName,
-		Quota: getQuota(drive.Quota, g.config.Spaces.DefaultQuota),
+		Quota: quota,
 	}
 
 	if drive.Description != nil {
@@ -428,7 +442,12 @@ func (g Graph) CreateDrive(w http.ResponseWriter, r *http.Request) {
 	}
 
 	space := resp.GetStorageSpace()
-	if t := r.URL.Query().Get(TemplateParameter); t != "" && driveType == _spaceTypeProject {
+	if t := spaceTemplateName(template); t != "" && driveType == _spaceTypeProject {
+		// remember the template, quota policies select project spaces by it
+		if err := setSpaceTemplateName(ctx, gatewayClient, space.GetRoot(), t); err != nil {
+			logger.Error().Err(err).Msg("could not record the template of the space")
+		}
+
 		loc := l10n.MustGetUserLocale(ctx, us.GetId().GetOpaqueId(), r.Header.Get(HeaderAcceptLanguage), g.valueService)
 		if err := g.applySpaceTemplate(ctx, gatewayClient, space.GetRoot(), t, loc); err != nil {
 			logger.Error().Err(err).Msg("could not apply template to space")
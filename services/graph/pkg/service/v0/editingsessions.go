@@ -0,0 +1,56 @@
+package svc
+
+import (
+	"net/http"
+
+	cs3rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	storageprovider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+	"github.com/go-chi/render"
+
+	"github.com/owncloud/ocis/v2/services/graph/pkg/errorcode"
+)
+
+// ListEditingSessions lists the users who are currently editing the driveItem in an office app
+func (g Graph) ListEditingSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := g.logger.SubloggerWithRequestID(ctx)
+
+	_, itemID, err := GetDriveAndItemIDParam(r, &logger)
+	if err != nil {
+		errorcode.RenderError(w, r, err)
+		return
+	}
+
+	gatewayClient, ok := g.GetGatewayClient(w, r)
+	if !ok {
+		return
+	}
+
+	// only users who can see the item may see who is editing it
+	res, err := gatewayClient.Stat(ctx, &storageprovider.StatRequest{Ref: &storageprovider.Reference{ResourceId: &itemID}})
+	switch {
+	case err != nil:
+		logger.Error().Err(err).Msg("could not stat the driveItem")
+		errorcode.GeneralException.Render(w, r, http.StatusInternalServerError, "could not stat the driveItem")
+		return
+	case res.GetStatus().GetCode() == cs3rpc.Code_CODE_OK:
+		// ok
+	case res.GetStatus().GetCode() == cs3rpc.Code_CODE_NOT_FOUND, res.GetStatus().GetCode() == cs3rpc.Code_CODE_PERMISSION_DENIED:
+		errorcode.ItemNotFound.Render(w, r, http.StatusNotFound, "driveItem not found")
+		return
+	default:
+		errorcode.GeneralException.Render(w, r, http.StatusInternalServerError, res.GetStatus().GetMessage())
+		return
+	}
+
+	sessions, err := g.editingSessions.List(storagespace.FormatResourceID(*res.GetInfo().GetId()))
+	if err != nil {
+		logger.Error().Err(err).Msg("could not list the editing sessions")
+		errorcode.GeneralException.Render(w, r, http.StatusInternalServerError, "could not list the editing sessions")
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, &ListResponse{Value: sessions})
+}
@@ -0,0 +1,108 @@
+package svc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/status"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+	cs3mocks "github.com/cs3org/reva/v2/tests/cs3mocks/mocks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	microstore "go-micro.dev/v4/store"
+	"google.golang.org/grpc"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/editingsessions"
+	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
+	"github.com/owncloud/ocis/v2/services/graph/mocks"
+	"github.com/owncloud/ocis/v2/services/graph/pkg/config/defaults"
+	service "github.com/owncloud/ocis/v2/services/graph/pkg/service/v0"
+)
+
+var _ = Describe("EditingSessions", func() {
+	var (
+		svc             service.Service
+		ctx             context.Context
+		gatewayClient   *cs3mocks.GatewayAPIClient
+		editingSessions *editingsessions.Manager
+	)
+
+	list := func() *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/graph/v1beta1/drives/storageid$spaceid/items/storageid$spaceid!nodeid/sessions", nil).WithContext(ctx)
+		rr := httptest.NewRecorder()
+		svc.ServeHTTP(rr, r)
+		return rr
+	}
+
+	BeforeEach(func() {
+		pool.RemoveSelector("GatewaySelector" + "com.owncloud.api.gateway")
+		gatewayClient = &cs3mocks.GatewayAPIClient{}
+		gatewaySelector := pool.GetSelector[gateway.GatewayAPIClient](
+			"GatewaySelector",
+			"com.owncloud.api.gateway",
+			func(cc *grpc.ClientConn) gateway.GatewayAPIClient {
+				return gatewayClient
+			},
+		)
+		editingSessions = editingsessions.NewManager(microstore.NewMemoryStore())
+
+		ctx = revactx.ContextSetUser(context.Background(), &userv1beta1.User{Id: &userv1beta1.UserId{OpaqueId: "einstein"}})
+		cfg := defaults.FullDefaultConfig()
+		cfg.Identity.LDAP.CACert = "" // skip the startup checks, we don't use LDAP at all in this tests
+		cfg.TokenManager.JWTSecret = "loremipsum"
+		cfg.Commons = &shared.Commons{}
+		cfg.GRPCClientTLS = &shared.GRPCClientTLS{}
+
+		svc, _ = service.NewService(
+			service.Config(cfg),
+			service.WithGatewaySelector(gatewaySelector),
+			service.WithRoleService(&mocks.RoleService{}),
+			service.PermissionService(&mocks.Permissions{}),
+			service.EditingSessions(editingSessions),
+		)
+	})
+
+	It("lists the users editing the item", func() {
+		_, err := editingSessions.Touch(editingsessions.Session{ResourceID: "storageid$spaceid!nodeid", UserID: "marie", DisplayName: "Marie Curie", App: "Collabora"})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = editingSessions.Touch(editingsessions.Session{ResourceID: "storageid$spaceid!othernode", UserID: "richard", App: "Collabora"})
+		Expect(err).ToNot(HaveOccurred())
+
+		gatewayClient.On("Stat", mock.Anything, mock.Anything).Return(&provider.StatResponse{
+			Status: status.NewOK(ctx),
+			Info: &provider.ResourceInfo{
+				Id: &provider.ResourceId{StorageId: "storageid", SpaceId: "spaceid", OpaqueId: "nodeid"},
+			},
+		}, nil)
+
+		rr := list()
+		Expect(rr.Code).To(Equal(http.StatusOK))
+
+		var res struct {
+			Value []editingsessions.Session `json:"value"`
+		}
+		Expect(json.Unmarshal(rr.Body.Bytes(), &res)).To(Succeed())
+		Expect(res.Value).To(HaveLen(1))
+		Expect(res.Value[0].UserID).To(Equal("marie"))
+		Expect(res.Value[0].DisplayName).To(Equal("Marie Curie"))
+		Expect(res.Value[0].App).To(Equal("Collabora"))
+	})
+
+	It("hides the sessions of items the user can't see", func() {
+		_, err := editingSessions.Touch(editingsessions.Session{ResourceID: "storageid$spaceid!nodeid", UserID: "marie", App: "Collabora"})
+		Expect(err).ToNot(HaveOccurred())
+
+		gatewayClient.On("Stat", mock.Anything, mock.Anything).Return(&provider.StatResponse{
+			Status: status.NewNotFound(ctx, "not found"),
+		}, nil)
+
+		Expect(list().Code).To(Equal(http.StatusNotFound))
+	})
+})
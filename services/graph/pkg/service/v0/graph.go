@@ -20,7 +20,10 @@ import (
 	"github.com/cs3org/reva/v2/pkg/events"
 	"github.com/cs3org/reva/v2/pkg/storagespace"
 
+	"github.com/owncloud/ocis/v2/ocis-pkg/editingsessions"
 	"github.com/owncloud/ocis/v2/ocis-pkg/keycloak"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quarantine"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quotapolicy"
 	ehsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/eventhistory/v0"
 	searchsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
 	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
@@ -72,6 +75,9 @@ type Graph struct {
 	keycloakClient           keycloak.Client
 	historyClient            ehsvc.EventHistoryService
 	traceProvider            trace.TracerProvider
+	quarantine               *quarantine.Manager
+	quotaPolicies            *quotapolicy.Manager
+	editingSessions          *editingsessions.Manager
 }
 
 // ServeHTTP implements the Service interface.
@@ -6,8 +6,10 @@ import (
 	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
 	"github.com/cs3org/reva/v2/pkg/events"
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+	"github.com/owncloud/ocis/v2/ocis-pkg/editingsessions"
 	"github.com/owncloud/ocis/v2/ocis-pkg/keycloak"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quotapolicy"
 	"github.com/owncloud/ocis/v2/ocis-pkg/roles"
 	ehsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/eventhistory/v0"
 	searchsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
@@ -38,6 +40,8 @@ type Options struct {
 	KeycloakClient           keycloak.Client
 	EventHistoryClient       ehsvc.EventHistoryService
 	TraceProvider            trace.TracerProvider
+	QuotaPolicies            *quotapolicy.Manager
+	EditingSessions          *editingsessions.Manager
 }
 
 // newOptions initializes the available default options.
@@ -162,3 +166,17 @@ func TraceProvider(val trace.TracerProvider) Option {
 		o.TraceProvider = val
 	}
 }
+
+// EditingSessions provides a function to set the EditingSessions option.
+func EditingSessions(val *editingsessions.Manager) Option {
+	return func(o *Options) {
+		o.EditingSessions = val
+	}
+}
+
+// QuotaPolicies provides a function to set the QuotaPolicies option.
+func QuotaPolicies(val *quotapolicy.Manager) Option {
+	return func(o *Options) {
+		o.QuotaPolicies = val
+	}
+}
@@ -0,0 +1,99 @@
+package svc
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/quarantine"
+	"github.com/owncloud/ocis/v2/services/graph/pkg/errorcode"
+)
+
+// ListQuarantine lists the files in the quarantine space
+func (g Graph) ListQuarantine(w http.ResponseWriter, r *http.Request) {
+	items, err := g.quarantine.List(r.Context())
+	if err != nil {
+		g.logger.Error().Err(err).Msg("could not list quarantined files")
+		errorcode.GeneralException.Render(w, r, http.StatusInternalServerError, "could not list quarantined files")
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, &ListResponse{Value: items})
+}
+
+// GetQuarantineItem returns a single file in the quarantine space
+func (g Graph) GetQuarantineItem(w http.ResponseWriter, r *http.Request) {
+	id, ok := g.quarantineItemID(w, r)
+	if !ok {
+		return
+	}
+
+	item, err := g.quarantine.Get(r.Context(), id)
+	if err != nil {
+		g.renderQuarantineError(w, r, err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, item)
+}
+
+// ReleaseQuarantineItem restores a quarantined file to its original location
+func (g Graph) ReleaseQuarantineItem(w http.ResponseWriter, r *http.Request) {
+	id, ok := g.quarantineItemID(w, r)
+	if !ok {
+		return
+	}
+
+	item, err := g.quarantine.Release(r.Context(), id)
+	if err != nil {
+		g.renderQuarantineError(w, r, err)
+		return
+	}
+
+	g.logger.Info().Str("quarantineid", id).Str("spaceid", item.SpaceID).Str("path", item.Path).Msg("released quarantined file")
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, item)
+}
+
+// PurgeQuarantineItem finally deletes a quarantined file
+func (g Graph) PurgeQuarantineItem(w http.ResponseWriter, r *http.Request) {
+	id, ok := g.quarantineItemID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := g.quarantine.Purge(r.Context(), id); err != nil {
+		g.renderQuarantineError(w, r, err)
+		return
+	}
+
+	g.logger.Info().Str("quarantineid", id).Msg("purged quarantined file")
+	render.Status(r, http.StatusNoContent)
+	render.NoContent(w, r)
+}
+
+func (g Graph) quarantineItemID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	id, err := url.PathUnescape(chi.URLParam(r, "itemID"))
+	if err != nil || id == "" {
+		errorcode.InvalidRequest.Render(w, r, http.StatusBadRequest, "invalid item id")
+		return "", false
+	}
+	return id, true
+}
+
+func (g Graph) renderQuarantineError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, quarantine.ErrNotFound):
+		errorcode.ItemNotFound.Render(w, r, http.StatusNotFound, err.Error())
+	case errors.Is(err, quarantine.ErrReleased):
+		errorcode.NotAllowed.Render(w, r, http.StatusConflict, err.Error())
+	default:
+		g.logger.Error().Err(err).Msg("could not process quarantined file")
+		errorcode.GeneralException.Render(w, r, http.StatusInternalServerError, err.Error())
+	}
+}
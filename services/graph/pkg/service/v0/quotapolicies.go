@@ -0,0 +1,196 @@
+package svc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	cs3rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	storageprovider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/quotapolicy"
+	settingsmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/settings/v0"
+	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
+	"github.com/owncloud/ocis/v2/services/graph/pkg/errorcode"
+)
+
+// QuotaPolicyApplyResult is the result of applying a quota policy to the existing spaces
+type QuotaPolicyApplyResult struct {
+	Updated int                     `json:"updated"`
+	Failed  []QuotaPolicyApplyError `json:"failed,omitempty"`
+}
+
+// QuotaPolicyApplyError is a space the quota policy could not be applied to
+type QuotaPolicyApplyError struct {
+	DriveID string `json:"driveId"`
+	Error   string `json:"error"`
+}
+
+// ApplyQuotaPolicy sets the quota of the policy on all existing spaces it applies to.
+// New spaces get the quota of the policies when they are created.
+func (g Graph) ApplyQuotaPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := g.logger.SubloggerWithRequestID(ctx)
+
+	policy, err := g.quotaPolicies.Get(chi.URLParam(r, "policyID"))
+	switch {
+	case errors.Is(err, quotapolicy.ErrNotFound):
+		errorcode.ItemNotFound.Render(w, r, http.StatusNotFound, "quota policy not found")
+		return
+	case err != nil:
+		logger.Error().Err(err).Msg("could not read quota policy")
+		errorcode.GeneralException.Render(w, r, http.StatusInternalServerError, "could not read quota policy")
+		return
+	case policy.IsFolderPolicy():
+		errorcode.InvalidRequest.Render(w, r, http.StatusBadRequest, "folder quotas are not enforced, there is nothing to apply")
+		return
+	}
+
+	spaces, err := g.quotaPolicySpaces(ctx, policy)
+	if err != nil {
+		logger.Error().Err(err).Str("policyid", policy.ID).Msg("could not find the spaces of the quota policy")
+		errorcode.GeneralException.Render(w, r, http.StatusInternalServerError, "could not find the spaces of the quota policy")
+		return
+	}
+
+	gatewayClient, err := g.gatewaySelector.Next()
+	if err != nil {
+		logger.Error().Err(err).Msg("could not select next gateway client")
+		errorcode.ServiceNotAvailable.Render(w, r, http.StatusInternalServerError, "could not select next gateway client")
+		return
+	}
+
+	result := QuotaPolicyApplyResult{}
+	for _, space := range spaces {
+		res, err := gatewayClient.UpdateStorageSpace(ctx, &storageprovider.UpdateStorageSpaceRequest{
+			StorageSpace: &storageprovider.StorageSpace{
+				Id:    space.GetId(),
+				Root:  space.GetRoot(),
+				Quota: &storageprovider.Quota{QuotaMaxBytes: policy.Quota},
+			},
+		})
+		switch {
+		case err != nil:
+			result.Failed = append(result.Failed, QuotaPolicyApplyError{DriveID: space.GetId().GetOpaqueId(), Error: err.Error()})
+		case res.GetStatus().GetCode() != cs3rpc.Code_CODE_OK:
+			result.Failed = append(result.Failed, QuotaPolicyApplyError{DriveID: space.GetId().GetOpaqueId(), Error: res.GetStatus().GetMessage()})
+		default:
+			result.Updated++
+		}
+	}
+
+	logger.Info().Str("policyid", policy.ID).Int("updated", result.Updated).Int("failed", len(result.Failed)).Msg("applied quota policy")
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, result)
+}
+
+// quotaPolicySpaces returns the spaces the policy applies to
+func (g Graph) quotaPolicySpaces(ctx context.Context, policy quotapolicy.Policy) ([]*storageprovider.StorageSpace, error) {
+	res, err := g.ListStorageSpacesWithFilters(ctx, []*storageprovider.ListStorageSpacesRequest_Filter{listStorageSpacesTypeFilter(policy.SpaceType)}, true)
+	switch {
+	case err != nil:
+		return nil, err
+	case res.GetStatus().GetCode() != cs3rpc.Code_CODE_OK:
+		return nil, fmt.Errorf("could not list spaces: %s", res.GetStatus().GetMessage())
+	}
+
+	var matches func(*storageprovider.StorageSpace) bool
+	switch policy.SpaceType {
+	case quotapolicy.SpaceTypePersonal:
+		owners, err := g.roleMembers(ctx, policy.RoleID)
+		if err != nil {
+			return nil, err
+		}
+		matches = func(space *storageprovider.StorageSpace) bool {
+			_, ok := owners[space.GetOwner().GetId().GetOpaqueId()]
+			return ok
+		}
+	default:
+		// the templates are only readable by the members of the spaces
+		gatewayClient, err := g.gatewaySelector.Next()
+		if err != nil {
+			return nil, err
+		}
+		sctx, err := utils.GetServiceUserContextWithContext(ctx, gatewayClient, g.config.ServiceAccount.ServiceAccountID, g.config.ServiceAccount.ServiceAccountSecret)
+		if err != nil {
+			return nil, err
+		}
+		matches = func(space *storageprovider.StorageSpace) bool {
+			template, err := spaceTemplateOf(sctx, gatewayClient, space.GetRoot())
+			if err != nil {
+				g.logger.Error().Err(err).Str("driveid", space.GetId().GetOpaqueId()).Msg("could not read the template of the space")
+				return false
+			}
+			return template == policy.SpaceTemplate
+		}
+	}
+
+	var spaces []*storageprovider.StorageSpace
+	for _, space := range res.GetStorageSpaces() {
+		if matches(space) {
+			spaces = append(spaces, space)
+		}
+	}
+	return spaces, nil
+}
+
+// roleMembers returns the ids of the users assigned to the role
+func (g Graph) roleMembers(ctx context.Context, roleID string) (map[string]struct{}, error) {
+	res, err := g.roleService.ListRoleAssignmentsFiltered(ctx, &settingssvc.ListRoleAssignmentsFilteredRequest{
+		Filters: []*settingsmsg.UserRoleAssignmentFilter{{
+			Type: settingsmsg.UserRoleAssignmentFilter_TYPE_ROLE,
+			Term: &settingsmsg.UserRoleAssignmentFilter_RoleId{RoleId: roleID},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[string]struct{}, len(res.GetAssignments()))
+	for _, a := range res.GetAssignments() {
+		members[a.GetAccountUuid()] = struct{}{}
+	}
+	return members, nil
+}
+
+// spaceTemplateName returns the name of the template a space is created with, empty if none is applied
+func spaceTemplateName(template string) string {
+	if template == "none" {
+		return ""
+	}
+	return template
+}
+
+func setSpaceTemplateName(ctx context.Context, gatewayClient gateway.GatewayAPIClient, root *storageprovider.ResourceId, template string) error {
+	res, err := gatewayClient.SetArbitraryMetadata(ctx, &storageprovider.SetArbitraryMetadataRequest{
+		Ref: &storageprovider.Reference{ResourceId: root, Path: "."},
+		ArbitraryMetadata: &storageprovider.ArbitraryMetadata{
+			Metadata: map[string]string{quotapolicy.TemplateKey: template},
+		},
+	})
+	switch {
+	case err != nil:
+		return err
+	case res.GetStatus().GetCode() != cs3rpc.Code_CODE_OK:
+		return fmt.Errorf("could not set metadata: %s", res.GetStatus().GetMessage())
+	}
+	return nil
+}
+
+func spaceTemplateOf(ctx context.Context, gatewayClient gateway.GatewayAPIClient, root *storageprovider.ResourceId) (string, error) {
+	res, err := gatewayClient.Stat(ctx, &storageprovider.StatRequest{
+		Ref:                   &storageprovider.Reference{ResourceId: root, Path: "."},
+		ArbitraryMetadataKeys: []string{quotapolicy.TemplateKey},
+	})
+	switch {
+	case err != nil:
+		return "", err
+	case res.GetStatus().GetCode() != cs3rpc.Code_CODE_OK:
+		return "", fmt.Errorf("could not stat space root: %s", res.GetStatus().GetMessage())
+	}
+	return res.GetInfo().GetArbitraryMetadata().GetMetadata()[quotapolicy.TemplateKey], nil
+}
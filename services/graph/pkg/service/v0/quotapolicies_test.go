@@ -0,0 +1,148 @@
+package svc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/status"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+	cs3mocks "github.com/cs3org/reva/v2/tests/cs3mocks/mocks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	microstore "go-micro.dev/v4/store"
+	"google.golang.org/grpc"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/quotapolicy"
+	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
+	settingsmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/settings/v0"
+	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
+	"github.com/owncloud/ocis/v2/services/graph/mocks"
+	"github.com/owncloud/ocis/v2/services/graph/pkg/config/defaults"
+	service "github.com/owncloud/ocis/v2/services/graph/pkg/service/v0"
+)
+
+var _ = Describe("QuotaPolicies", func() {
+	var (
+		svc               service.Service
+		ctx               context.Context
+		gatewayClient     *cs3mocks.GatewayAPIClient
+		roleService       *mocks.RoleService
+		permissionService *mocks.Permissions
+		quotaPolicies     *quotapolicy.Manager
+	)
+
+	BeforeEach(func() {
+		pool.RemoveSelector("GatewaySelector" + "com.owncloud.api.gateway")
+		gatewayClient = &cs3mocks.GatewayAPIClient{}
+		gatewaySelector := pool.GetSelector[gateway.GatewayAPIClient](
+			"GatewaySelector",
+			"com.owncloud.api.gateway",
+			func(cc *grpc.ClientConn) gateway.GatewayAPIClient {
+				return gatewayClient
+			},
+		)
+		roleService = &mocks.RoleService{}
+		permissionService = &mocks.Permissions{}
+		quotaPolicies = quotapolicy.NewManager(microstore.NewMemoryStore())
+
+		ctx = revactx.ContextSetUser(context.Background(), &userv1beta1.User{Id: &userv1beta1.UserId{OpaqueId: "admin"}})
+		cfg := defaults.FullDefaultConfig()
+		cfg.Identity.LDAP.CACert = "" // skip the startup checks, we don't use LDAP at all in this tests
+		cfg.TokenManager.JWTSecret = "loremipsum"
+		cfg.Commons = &shared.Commons{}
+		cfg.GRPCClientTLS = &shared.GRPCClientTLS{}
+
+		svc, _ = service.NewService(
+			service.Config(cfg),
+			service.WithGatewaySelector(gatewaySelector),
+			service.WithRoleService(roleService),
+			service.PermissionService(permissionService),
+			service.QuotaPolicies(quotaPolicies),
+			service.WithRequireAdminMiddleware(func(next http.Handler) http.Handler { return next }),
+		)
+	})
+
+	Describe("CreateDrive", func() {
+		It("uses the quota of the policy for the template", func() {
+			_, err := quotaPolicies.Write(quotapolicy.Policy{SpaceType: quotapolicy.SpaceTypeProject, Quota: 1000})
+			Expect(err).ToNot(HaveOccurred())
+
+			permissionService.On("GetPermissionByID", mock.Anything, mock.Anything).Return(&settingssvc.GetPermissionByIDResponse{
+				Permission: &settingsmsg.Permission{
+					Operation:  settingsmsg.Permission_OPERATION_READWRITE,
+					Constraint: settingsmsg.Permission_CONSTRAINT_ALL,
+				},
+			}, nil)
+			gatewayClient.On("CreateStorageSpace", mock.Anything, mock.Anything).Return(&provider.CreateStorageSpaceResponse{
+				Status: status.NewPermissionDenied(ctx, nil, "stop here"),
+			}, nil)
+
+			r := httptest.NewRequest(http.MethodPost, "/graph/v1.0/drives", bytes.NewBufferString(`{"name": "Test Space"}`)).WithContext(ctx)
+			svc.CreateDrive(httptest.NewRecorder(), r)
+
+			gatewayClient.AssertCalled(GinkgoT(), "CreateStorageSpace", mock.Anything, mock.MatchedBy(func(req *provider.CreateStorageSpaceRequest) bool {
+				return req.GetQuota().GetQuotaMaxBytes() == 1000
+			}))
+		})
+	})
+
+	Describe("ApplyQuotaPolicy", func() {
+		apply := func(id string) *httptest.ResponseRecorder {
+			r := httptest.NewRequest(http.MethodPost, "/graph/v1beta1/quotaPolicies/"+id+"/apply", nil).WithContext(ctx)
+			rr := httptest.NewRecorder()
+			svc.ServeHTTP(rr, r)
+			return rr
+		}
+
+		It("fails for unknown policies", func() {
+			Expect(apply("unknown").Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("rejects folder policies", func() {
+			p, err := quotaPolicies.Write(quotapolicy.Policy{SpaceType: quotapolicy.SpaceTypeProject, Path: "Photos", Quota: 1000})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(apply(p.ID).Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("sets the quota of the personal spaces of the role members", func() {
+			p, err := quotaPolicies.Write(quotapolicy.Policy{SpaceType: quotapolicy.SpaceTypePersonal, RoleID: "user-role", Quota: 1000})
+			Expect(err).ToNot(HaveOccurred())
+
+			roleService.On("ListRoleAssignmentsFiltered", mock.Anything, mock.MatchedBy(func(req *settingssvc.ListRoleAssignmentsFilteredRequest) bool {
+				return req.GetFilters()[0].GetRoleId() == "user-role"
+			}), mock.Anything).Return(&settingssvc.ListRoleAssignmentsResponse{
+				Assignments: []*settingsmsg.UserRoleAssignment{{AccountUuid: "einstein", RoleId: "user-role"}},
+			}, nil)
+			gatewayClient.On("ListStorageSpaces", mock.Anything, mock.Anything).Return(&provider.ListStorageSpacesResponse{
+				Status: status.NewOK(ctx),
+				StorageSpaces: []*provider.StorageSpace{
+					{Id: &provider.StorageSpaceId{OpaqueId: "einstein-space"}, SpaceType: "personal", Owner: &userv1beta1.User{Id: &userv1beta1.UserId{OpaqueId: "einstein"}}},
+					{Id: &provider.StorageSpaceId{OpaqueId: "admin-space"}, SpaceType: "personal", Owner: &userv1beta1.User{Id: &userv1beta1.UserId{OpaqueId: "admin"}}},
+				},
+			}, nil)
+			gatewayClient.On("UpdateStorageSpace", mock.Anything, mock.Anything).Return(&provider.UpdateStorageSpaceResponse{
+				Status: status.NewOK(ctx),
+			}, nil)
+
+			rr := apply(p.ID)
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			var result service.QuotaPolicyApplyResult
+			Expect(json.NewDecoder(rr.Body).Decode(&result)).To(Succeed())
+			Expect(result.Updated).To(Equal(1))
+			Expect(result.Failed).To(BeEmpty())
+
+			gatewayClient.AssertNumberOfCalls(GinkgoT(), "UpdateStorageSpace", 1)
+			gatewayClient.AssertCalled(GinkgoT(), "UpdateStorageSpace", mock.Anything, mock.MatchedBy(func(req *provider.UpdateStorageSpaceRequest) bool {
+				return req.GetStorageSpace().GetId().GetOpaqueId() == "einstein-space" && req.GetStorageSpace().GetQuota().GetQuotaMaxBytes() == 1000
+			}))
+		})
+	})
+})
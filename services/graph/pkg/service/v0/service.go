@@ -17,9 +17,13 @@ import (
 	microstore "go-micro.dev/v4/store"
 
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+	"github.com/cs3org/reva/v2/pkg/rhttp"
 	"github.com/cs3org/reva/v2/pkg/store"
 
+	"github.com/owncloud/ocis/v2/ocis-pkg/editingsessions"
 	ocisldap "github.com/owncloud/ocis/v2/ocis-pkg/ldap"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quarantine"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quotapolicy"
 	"github.com/owncloud/ocis/v2/ocis-pkg/registry"
 	"github.com/owncloud/ocis/v2/ocis-pkg/roles"
 	"github.com/owncloud/ocis/v2/ocis-pkg/service/grpc"
@@ -154,6 +158,34 @@ func NewService(opts ...Option) (Graph, error) { //nolint:maintidx
 		historyClient:            options.EventHistoryClient,
 		traceProvider:            options.TraceProvider,
 		valueService:             options.ValueService,
+		quarantine: quarantine.NewManager(
+			options.GatewaySelector,
+			rhttp.GetHTTPClient(rhttp.Insecure(true)),
+			options.Config.ServiceAccount.ServiceAccountID,
+			options.Config.ServiceAccount.ServiceAccountSecret,
+		),
+		quotaPolicies:   options.QuotaPolicies,
+		editingSessions: options.EditingSessions,
+	}
+
+	if svc.quotaPolicies == nil {
+		svc.quotaPolicies = quotapolicy.NewManager(store.Create(
+			store.Store(options.Config.QuotaPolicies.Store),
+			microstore.Nodes(options.Config.QuotaPolicies.Nodes...),
+			microstore.Database(quotapolicy.Database),
+			microstore.Table(quotapolicy.Table),
+			store.Authentication(options.Config.QuotaPolicies.AuthUsername, options.Config.QuotaPolicies.AuthPassword),
+		))
+	}
+
+	if svc.editingSessions == nil {
+		svc.editingSessions = editingsessions.NewManager(store.Create(
+			store.Store(options.Config.EditingSessions.Store),
+			microstore.Nodes(options.Config.EditingSessions.Nodes...),
+			microstore.Database(editingsessions.Database),
+			microstore.Table(editingsessions.Table),
+			store.Authentication(options.Config.EditingSessions.AuthUsername, options.Config.EditingSessions.AuthPassword),
+		))
 	}
 
 	if err := setIdentityBackends(options, &svc); err != nil {
@@ -252,6 +284,7 @@ func NewService(opts ...Option) (Graph, error) { //nolint:maintidx
 						r.Delete("/", drivesDriveItemApi.DeleteDriveItem)
 						r.Post("/invite", driveItemPermissionsApi.Invite)
 						r.Post("/createLink", driveItemPermissionsApi.CreateLink)
+						r.Get("/sessions", svc.ListEditingSessions)
 						r.Route("/permissions", func(r chi.Router) {
 							r.Get("/", driveItemPermissionsApi.ListPermissions)
 							r.Route("/{permissionID}", func(r chi.Router) {
@@ -267,6 +300,15 @@ func NewService(opts ...Option) (Graph, error) { //nolint:maintidx
 				r.Get("/", svc.GetRoleDefinitions)
 				r.Get("/{roleID}", svc.GetRoleDefinition)
 			})
+			r.With(requireAdmin).Route("/quarantine", func(r chi.Router) {
+				r.Get("/", svc.ListQuarantine)
+				r.Route("/{itemID}", func(r chi.Router) {
+					r.Get("/", svc.GetQuarantineItem)
+					r.Delete("/", svc.PurgeQuarantineItem)
+					r.Post("/release", svc.ReleaseQuarantineItem)
+				})
+			})
+			r.With(requireAdmin).Post("/quotaPolicies/{policyID}/apply", svc.ApplyQuotaPolicy)
 		})
 		r.Route("/v1.0", func(r chi.Router) {
 			r.Route("/extensions/org.libregraph", func(r chi.Router) {
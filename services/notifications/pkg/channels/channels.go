@@ -4,6 +4,7 @@ package channels
 import (
 	"context"
 	"crypto/tls"
+	stderrors "errors"
 	"fmt"
 	"strings"
 
@@ -19,14 +20,111 @@ type Channel interface {
 	SendMessage(ctx context.Context, message *Message) error
 }
 
+// Names of the available channels as used in the configuration and the user settings.
+const (
+	NameMail    = "mail"
+	NameWebhook = "webhook"
+	NameMatrix  = "matrix"
+)
+
 // Message represent the already rendered message including the user id opaqueID
 type Message struct {
-	Sender       string
-	Recipient    []string
+	Sender string
+	// Recipient contains the email addresses of the recipients. It is empty if the recipient has no email address or
+	// opted out of email notifications.
+	Recipient []string
+	// Username is the username of the recipient
+	Username     string
 	Subject      string
 	TextBody     string
 	HTMLBody     string
 	AttachInline map[string][]byte
+	// Channels contains the names of the channels the message should be delivered on.
+	// If empty the default channels are used.
+	Channels []string
+}
+
+// NewMultiChannel instantiates a channel which dispatches messages to the named channels.
+// Messages without explicit channels are sent to the defaultChannels.
+func NewMultiChannel(channels map[string]Channel, defaultChannels []string, logger log.Logger) Channel {
+	return Multi{
+		channels:        channels,
+		defaultChannels: defaultChannels,
+		logger:          logger,
+	}
+}
+
+// Multi is a communication channel that dispatches messages to other channels.
+type Multi struct {
+	channels        map[string]Channel
+	defaultChannels []string
+	logger          log.Logger
+}
+
+// SendMessage sends the message on all channels requested by the message.
+func (m Multi) SendMessage(ctx context.Context, message *Message) error {
+	names := message.Channels
+	if len(names) == 0 {
+		names = m.defaultChannels
+	}
+
+	var errs []error
+	for _, name := range names {
+		ch, ok := m.channels[name]
+		if !ok {
+			m.logger.Debug().Str("channel", name).Msg("channel not configured, skipping message")
+			continue
+		}
+		if err := ch.SendMessage(ctx, message); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return stderrors.Join(errs...)
+}
+
+// NewChannels instantiates all channels enabled in the configuration.
+// The mail channel is always available.
+func NewChannels(cfg config.Config, logger log.Logger) (map[string]Channel, error) {
+	mc, err := NewMailChannel(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	chs := map[string]Channel{
+		NameMail: mc,
+	}
+
+	if cfg.Notifications.Webhook.URL != "" {
+		wc, err := NewWebhookChannel(cfg, logger)
+		if err != nil {
+			return nil, err
+		}
+		chs[NameWebhook] = wc
+	}
+
+	if cfg.Notifications.Matrix.Homeserver != "" {
+		mxc, err := NewMatrixChannel(cfg, logger)
+		if err != nil {
+			return nil, err
+		}
+		chs[NameMatrix] = mxc
+	}
+	return chs, nil
+}
+
+// markdownBody renders the subject and the plain text body of a message as markdown.
+func markdownBody(message *Message) string {
+	if message.Subject == "" {
+		return message.TextBody
+	}
+	return fmt.Sprintf("**%s**\n\n%s", message.Subject, message.TextBody)
+}
+
+// plainBody renders the subject and the plain text body of a message as plain text.
+func plainBody(message *Message) string {
+	if message.Subject == "" {
+		return message.TextBody
+	}
+	return fmt.Sprintf("%s\n\n%s", message.Subject, message.TextBody)
 }
 
 // NewMailChannel instantiates a new mail communication channel.
@@ -106,6 +204,9 @@ func (m Mail) SendMessage(ctx context.Context, message *Message) error {
 		m.logger.Info().Str("mail", "SendMessage").Msg("failed to send a message. SMTP host is  not set")
 		return nil
 	}
+	if len(message.Recipient) == 0 {
+		return nil
+	}
 
 	smtpClient, err := m.getMailClient()
 	if err != nil {
@@ -0,0 +1,130 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/notifications/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingChannel struct {
+	messages []*Message
+	err      error
+}
+
+func (r *recordingChannel) SendMessage(_ context.Context, m *Message) error {
+	r.messages = append(r.messages, m)
+	return r.err
+}
+
+func TestMultiChannel(t *testing.T) {
+	mail, hook := &recordingChannel{}, &recordingChannel{err: errors.New("unreachable")}
+	ch := NewMultiChannel(map[string]Channel{NameMail: mail, NameWebhook: hook}, []string{NameMail}, log.NopLogger())
+
+	require.NoError(t, ch.SendMessage(context.Background(), &Message{Subject: "default"}))
+	assert.Len(t, mail.messages, 1)
+	assert.Len(t, hook.messages, 0)
+
+	err := ch.SendMessage(context.Background(), &Message{Subject: "chosen", Channels: []string{NameWebhook, NameMatrix}})
+	assert.ErrorContains(t, err, "webhook: unreachable")
+	assert.Len(t, mail.messages, 1)
+	assert.Len(t, hook.messages, 1)
+}
+
+func TestWebhookChannel(t *testing.T) {
+	var (
+		payload   WebhookPayload
+		signature string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		signature = r.Header.Get(WebhookSignatureHeader)
+		assert.Equal(t, "sha256="+Sign("secret", b), signature)
+		_ = json.Unmarshal(b, &payload)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	cfg := config.Config{}
+	cfg.Notifications.Webhook = config.Webhook{URL: srv.URL, Secret: "secret", Format: "markdown"}
+	ch, err := NewWebhookChannel(cfg, log.NopLogger())
+	require.NoError(t, err)
+
+	err = ch.SendMessage(context.Background(), &Message{
+		Sender:    "Dr. S. Harer",
+		Username:  "eric",
+		Recipient: []string{"sharee@example.com"},
+		Subject:   "Dr. S. Harer shared 'secrets' with you",
+		TextBody:  "Hello Eric",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, signature)
+	assert.Equal(t, "eric", payload.Username)
+	assert.Equal(t, []string{"sharee@example.com"}, payload.Recipients)
+	assert.Equal(t, "markdown", payload.Format)
+	assert.Equal(t, "**Dr. S. Harer shared 'secrets' with you**\n\nHello Eric", payload.Body)
+
+	cfg.Notifications.Webhook.Format = "html"
+	_, err = NewWebhookChannel(cfg, log.NopLogger())
+	assert.Error(t, err)
+}
+
+func TestMatrixChannel(t *testing.T) {
+	var (
+		invited  []string
+		direct   = map[string][]string{"@marie:example.com": {"!marie:example.com"}}
+		messages = map[string][]matrixMessage{}
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_matrix/client/v3/account/whoami", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"user_id":"@ocis:example.com"}`))
+	})
+	mux.HandleFunc("/_matrix/client/v3/user/@ocis:example.com/account_data/m.direct", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			_ = json.NewDecoder(r.Body).Decode(&direct)
+		}
+		_ = json.NewEncoder(w).Encode(direct)
+	})
+	mux.HandleFunc("/_matrix/client/v3/createRoom", func(w http.ResponseWriter, r *http.Request) {
+		var req matrixCreateRoom
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		assert.True(t, req.IsDirect)
+		invited = append(invited, req.Invite...)
+		_, _ = w.Write([]byte(`{"room_id":"!einstein:example.com"}`))
+	})
+	mux.HandleFunc("/_matrix/client/v3/rooms/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		roomID := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/_matrix/client/v3/rooms/"), "/", 2)[0]
+		var body matrixMessage
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		messages[roomID] = append(messages[roomID], body)
+		_, _ = w.Write([]byte(`{"event_id":"$1"}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	cfg := config.Config{}
+	cfg.Notifications.Matrix = config.Matrix{Homeserver: srv.URL + "/", AccessToken: "token", ServerName: "example.com"}
+	ch, err := NewMatrixChannel(cfg, log.NopLogger())
+	require.NoError(t, err)
+
+	require.NoError(t, ch.SendMessage(context.Background(), &Message{Username: "Marie", Subject: "Subject", TextBody: "Body"}))
+	require.NoError(t, ch.SendMessage(context.Background(), &Message{Username: "einstein", Subject: "Subject", TextBody: "First"}))
+	require.NoError(t, ch.SendMessage(context.Background(), &Message{Username: "einstein", Subject: "Subject", TextBody: "Second"}))
+	assert.Error(t, ch.SendMessage(context.Background(), &Message{Subject: "Subject", TextBody: "Body"}))
+
+	assert.Equal(t, []matrixMessage{{MsgType: "m.text", Body: "Subject\n\nBody"}}, messages["!marie:example.com"])
+	assert.Len(t, messages["!einstein:example.com"], 2)
+	assert.Equal(t, []string{"@einstein:example.com"}, invited)
+	assert.Equal(t, []string{"!einstein:example.com"}, direct["@einstein:example.com"])
+}
@@ -0,0 +1,188 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/notifications/pkg/config"
+	"github.com/pkg/errors"
+)
+
+var errMatrixNotFound = errors.New("not found on matrix homeserver")
+
+// NewMatrixChannel instantiates a new matrix communication channel.
+func NewMatrixChannel(cfg config.Config, logger log.Logger) (Channel, error) {
+	if cfg.Notifications.Matrix.ServerName == "" {
+		return nil, errors.New("matrix server name must be set")
+	}
+	if cfg.Notifications.Matrix.AccessToken == "" {
+		return nil, errors.New("matrix access token must be set")
+	}
+
+	return &Matrix{
+		homeserver:  strings.TrimSuffix(cfg.Notifications.Matrix.Homeserver, "/"),
+		accessToken: cfg.Notifications.Matrix.AccessToken,
+		serverName:  cfg.Notifications.Matrix.ServerName,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					MinVersion:         tls.VersionTLS12,
+					InsecureSkipVerify: cfg.Notifications.Matrix.Insecure, //nolint:gosec
+				},
+			},
+		},
+		rooms:  map[string]string{},
+		logger: logger,
+	}, nil
+}
+
+// Matrix is the communication channel for Matrix.
+// It uses the client-server API to post every message into a direct chat with the recipient, whose Matrix user id is
+// '@<username>:<server name>'.
+type Matrix struct {
+	homeserver  string
+	accessToken string
+	serverName  string
+	client      *http.Client
+	logger      log.Logger
+
+	mu sync.Mutex
+	// sender is the Matrix user id of the access token
+	sender string
+	// rooms maps the Matrix user ids of the recipients to their direct chats
+	rooms map[string]string
+}
+
+type matrixMessage struct {
+	MsgType string `json:"msgtype"`
+	Body    string `json:"body"`
+}
+
+type matrixCreateRoom struct {
+	Invite   []string `json:"invite"`
+	IsDirect bool     `json:"is_direct"`
+	Preset   string   `json:"preset"`
+}
+
+// SendMessage posts the message as a text event to the direct chat with the recipient.
+func (m *Matrix) SendMessage(ctx context.Context, message *Message) error {
+	if message.Username == "" {
+		return errors.New("the recipient has no username")
+	}
+
+	roomID, err := m.directRoom(ctx, m.userID(message.Username))
+	if err != nil {
+		return err
+	}
+
+	// the transaction id makes the request idempotent on retries
+	endpoint := fmt.Sprintf("/rooms/%s/send/m.room.message/%s", url.PathEscape(roomID), uuid.NewString())
+	return m.do(ctx, http.MethodPut, endpoint, matrixMessage{
+		MsgType: "m.text",
+		Body:    plainBody(message),
+	}, nil)
+}
+
+// userID returns the Matrix user id of the user with the given username.
+func (m *Matrix) userID(username string) string {
+	return "@" + strings.ToLower(username) + ":" + m.serverName
+}
+
+// directRoom returns the id of the direct chat with the Matrix user. The direct chats are looked up in the 'm.direct'
+// account data of the sender like Matrix clients do, a new chat is created and added to it if there is none.
+func (m *Matrix) directRoom(ctx context.Context, userID string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if roomID, ok := m.rooms[userID]; ok {
+		return roomID, nil
+	}
+
+	if m.sender == "" {
+		var whoami struct {
+			UserID string `json:"user_id"`
+		}
+		if err := m.do(ctx, http.MethodGet, "/account/whoami", nil, &whoami); err != nil {
+			return "", err
+		}
+		m.sender = whoami.UserID
+	}
+
+	direct := map[string][]string{}
+	directEndpoint := fmt.Sprintf("/user/%s/account_data/m.direct", url.PathEscape(m.sender))
+	if err := m.do(ctx, http.MethodGet, directEndpoint, nil, &direct); err != nil && !errors.Is(err, errMatrixNotFound) {
+		return "", err
+	}
+	if rooms := direct[userID]; len(rooms) > 0 {
+		m.rooms[userID] = rooms[0]
+		return rooms[0], nil
+	}
+
+	var created struct {
+		RoomID string `json:"room_id"`
+	}
+	if err := m.do(ctx, http.MethodPost, "/createRoom", matrixCreateRoom{
+		Invite:   []string{userID},
+		IsDirect: true,
+		Preset:   "trusted_private_chat",
+	}, &created); err != nil {
+		return "", err
+	}
+
+	direct[userID] = append(direct[userID], created.RoomID)
+	if err := m.do(ctx, http.MethodPut, directEndpoint, direct, nil); err != nil {
+		return "", err
+	}
+
+	m.rooms[userID] = created.RoomID
+	return created.RoomID, nil
+}
+
+// do sends a request to the client-server API and decodes the response into res if it is not nil.
+func (m *Matrix) do(ctx context.Context, method, endpoint string, body, res interface{}) error {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, m.homeserver+"/_matrix/client/v3"+endpoint, r)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+m.accessToken)
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errMatrixNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("unexpected status code from matrix homeserver: %d", resp.StatusCode)
+	case res == nil:
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(res)
+}
@@ -0,0 +1,116 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/notifications/pkg/config"
+	"github.com/pkg/errors"
+)
+
+// WebhookSignatureHeader is the header carrying the HMAC-SHA256 signature of the webhook payload.
+const WebhookSignatureHeader = "X-OCIS-Signature"
+
+// WebhookPayload is the JSON document posted to the webhook endpoint.
+type WebhookPayload struct {
+	Sender     string   `json:"sender,omitempty"`
+	Username   string   `json:"username"`
+	Recipients []string `json:"recipients"`
+	Subject    string   `json:"subject"`
+	Body       string   `json:"body"`
+	Format     string   `json:"format"`
+}
+
+// NewWebhookChannel instantiates a new webhook communication channel.
+func NewWebhookChannel(cfg config.Config, logger log.Logger) (Channel, error) {
+	format := strings.ToLower(cfg.Notifications.Webhook.Format)
+	switch format {
+	case "":
+		format = "text"
+	case "text", "markdown":
+	default:
+		return nil, errors.New("unknown webhook format")
+	}
+
+	return Webhook{
+		url:    cfg.Notifications.Webhook.URL,
+		secret: cfg.Notifications.Webhook.Secret,
+		format: format,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					MinVersion:         tls.VersionTLS12,
+					InsecureSkipVerify: cfg.Notifications.Webhook.Insecure, //nolint:gosec
+				},
+			},
+		},
+		logger: logger,
+	}, nil
+}
+
+// Webhook is the communication channel for generic HTTP webhooks.
+type Webhook struct {
+	url    string
+	secret string
+	format string
+	client *http.Client
+	logger log.Logger
+}
+
+// SendMessage posts the message to the webhook endpoint.
+func (w Webhook) SendMessage(ctx context.Context, message *Message) error {
+	payload := WebhookPayload{
+		Sender:     message.Sender,
+		Username:   message.Username,
+		Recipients: message.Recipient,
+		Subject:    message.Subject,
+		Body:       message.TextBody,
+		Format:     w.format,
+	}
+	if w.format == "markdown" {
+		payload.Body = markdownBody(message)
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.secret != "" {
+		req.Header.Set(WebhookSignatureHeader, "sha256="+Sign(w.secret, b))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code from webhook: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the payload using the given secret.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/owncloud/ocis/v2/ocis-pkg/tracing"
 	"github.com/owncloud/ocis/v2/ocis-pkg/version"
 	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
+	"github.com/owncloud/ocis/v2/services/activitylog/pkg/event"
 	"github.com/owncloud/ocis/v2/services/notifications/pkg/channels"
 	"github.com/owncloud/ocis/v2/services/notifications/pkg/config"
 	"github.com/owncloud/ocis/v2/services/notifications/pkg/config/parser"
@@ -88,6 +89,7 @@ func Server(cfg *config.Config) *cli.Command {
 				events.SpaceShared{},
 				events.SpaceUnshared{},
 				events.SpaceMembershipExpired{},
+				event.ActivityDigest{},
 			}
 			client, err := stream.NatsFromConfig(cfg.Service.Name, false, stream.NatsConfig(cfg.Notifications.Events))
 			if err != nil {
@@ -97,10 +99,11 @@ func Server(cfg *config.Config) *cli.Command {
 			if err != nil {
 				return err
 			}
-			channel, err := channels.NewMailChannel(*cfg, logger)
+			chs, err := channels.NewChannels(*cfg, logger)
 			if err != nil {
 				return err
 			}
+			channel := channels.NewMultiChannel(chs, cfg.Notifications.DefaultChannels, logger)
 			tm, err := pool.StringToTLSMode(cfg.Notifications.GRPCClientTLS.Mode)
 			if err != nil {
 				return err
@@ -29,6 +29,9 @@ type Config struct {
 // Notifications defines the config options for the notifications service.
 type Notifications struct {
 	SMTP              SMTP                  `yaml:"SMTP"`
+	Webhook           Webhook               `yaml:"webhook"`
+	Matrix            Matrix                `yaml:"matrix"`
+	DefaultChannels   []string              `yaml:"default_channels" env:"NOTIFICATIONS_DEFAULT_CHANNELS" desc:"A list of channels used to deliver notifications to users who did not choose their channels in the settings. Supported values are 'mail', 'webhook' and 'matrix'. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
 	Events            Events                `yaml:"events"`
 	EmailTemplatePath string                `yaml:"email_template_path" env:"OCIS_EMAIL_TEMPLATE_PATH;NOTIFICATIONS_EMAIL_TEMPLATE_PATH" desc:"Path to Email notification templates overriding embedded ones." introductionVersion:"pre5.0"`
 	TranslationPath   string                `yaml:"translation_path" env:"OCIS_TRANSLATION_PATH;NOTIFICATIONS_TRANSLATION_PATH" desc:"(optional) Set this to a path with custom translations to overwrite the builtin translations. Note that file and folder naming rules apply, see the documentation for more details." introductionVersion:"pre5.0"`
@@ -49,6 +52,22 @@ type SMTP struct {
 	Encryption     string `yaml:"smtp_encryption" env:"NOTIFICATIONS_SMTP_ENCRYPTION" desc:"Encryption method for the SMTP communication. Possible values are 'starttls', 'ssl', 'ssltls', 'tls' and 'none'." introductionVersion:"pre5.0" deprecationVersion:"5.0.0" removalVersion:"7.0.0" deprecationInfo:"The NOTIFICATIONS_SMTP_ENCRYPTION values 'ssl' and 'tls' are deprecated and will be removed in the future." deprecationReplacement:"Use 'starttls' instead of 'tls' and 'ssltls' instead of 'ssl'."`
 }
 
+// Webhook combines the configuration options for the webhook channel.
+type Webhook struct {
+	URL      string `yaml:"url" env:"NOTIFICATIONS_WEBHOOK_URL" desc:"URL of an HTTP endpoint that receives notifications as JSON documents via POST. The webhook channel is disabled if not set." introductionVersion:"6.0.0"`
+	Secret   string `yaml:"secret" env:"NOTIFICATIONS_WEBHOOK_SECRET" desc:"Secret used to sign the webhook payload. The HMAC-SHA256 signature is sent in the 'X-OCIS-Signature' header. No signature is sent if not set." introductionVersion:"6.0.0"`
+	Format   string `yaml:"format" env:"NOTIFICATIONS_WEBHOOK_FORMAT" desc:"Format of the message body sent to the webhook. Possible values are 'text' and 'markdown'." introductionVersion:"6.0.0"`
+	Insecure bool   `yaml:"insecure" env:"NOTIFICATIONS_WEBHOOK_INSECURE" desc:"Allow insecure connections to the webhook endpoint." introductionVersion:"6.0.0"`
+}
+
+// Matrix combines the configuration options for the matrix channel.
+type Matrix struct {
+	Homeserver  string `yaml:"homeserver" env:"NOTIFICATIONS_MATRIX_HOMESERVER" desc:"URL of the Matrix homeserver, e.g. 'https://matrix.example.com'. The matrix channel is disabled if not set." introductionVersion:"6.0.0"`
+	AccessToken string `yaml:"access_token" env:"NOTIFICATIONS_MATRIX_ACCESS_TOKEN" desc:"Access token of the Matrix user posting the notifications." introductionVersion:"6.0.0"`
+	ServerName  string `yaml:"server_name" env:"NOTIFICATIONS_MATRIX_SERVER_NAME" desc:"Server name of the Matrix users, e.g. 'example.com'. The notifications of a user are posted to a direct chat with the Matrix user '@<username>:<server name>'." introductionVersion:"6.0.0"`
+	Insecure    bool   `yaml:"insecure" env:"NOTIFICATIONS_MATRIX_INSECURE" desc:"Allow insecure connections to the Matrix homeserver." introductionVersion:"6.0.0"`
+}
+
 // Events combines the configuration options for the event bus.
 type Events struct {
 	Endpoint             string `yaml:"endpoint" env:"OCIS_EVENTS_ENDPOINT;NOTIFICATIONS_EVENTS_ENDPOINT" desc:"The address of the event system. The event system is the message queuing service. It is used as message broker for the microservice architecture." introductionVersion:"pre5.0"`
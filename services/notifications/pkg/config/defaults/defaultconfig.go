@@ -33,6 +33,10 @@ func DefaultConfig() *config.Config {
 			SMTP: config.SMTP{
 				Encryption: "none",
 			},
+			Webhook: config.Webhook{
+				Format: "text",
+			},
+			DefaultChannels: []string{"mail"},
 			Events: config.Events{
 				Endpoint:  "127.0.0.1:9233",
 				Cluster:   "ocis-cluster",
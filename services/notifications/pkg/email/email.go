@@ -39,7 +39,7 @@ func RenderEmailTemplate(mt MessageTemplate, locale, defaultLocale string, email
 	if err != nil {
 		return nil, err
 	}
-	htmlMt, err := NewHTMLTemplate(mt, locale, defaultLocale, translationPath, escapeStringMap(vars))
+	htmlMt, err := NewHTMLTemplate(mt, locale, defaultLocale, translationPath, escapeStringMap(vars, mt.multilineVars))
 	if err != nil {
 		return nil, err
 	}
@@ -143,9 +143,15 @@ func validateMime(incipit []byte) bool {
 	return false
 }
 
-func escapeStringMap(vars map[string]string) map[string]string {
+// escapeStringMap escapes the variables for the html template, the newlines of the multiline variables become line breaks
+func escapeStringMap(vars map[string]string, multiline []string) map[string]string {
 	for k := range vars {
 		vars[k] = html.EscapeString(vars[k])
 	}
+	for _, k := range multiline {
+		if v, ok := vars[k]; ok {
+			vars[k] = newlineToBr(v)
+		}
+	}
 	return vars
 }
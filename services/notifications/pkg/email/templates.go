@@ -72,6 +72,21 @@ You might still have access through your other groups or direct membership.`),
 
 Even though this membership has expired you still might have access through other shares and/or space memberships`),
 	}
+
+	// Activity digest
+	ActivityDigest = MessageTemplate{
+		textTemplate:  "templates/text/email.text.tmpl",
+		htmlTemplate:  "templates/html/email.html.tmpl",
+		multilineVars: []string{"DigestActivities"},
+		// ActivityDigest email template, Subject field (resolves directly)
+		Subject: l10n.Template(`{ActivityCount} new activities in the folders and spaces you watch`),
+		// ActivityDigest email template, resolves via {{ .Greeting }}
+		Greeting: l10n.Template(`Hello {DigestRecipient},`),
+		// ActivityDigest email template, resolves via {{ .MessageBody }}
+		MessageBody: l10n.Template(`This happened in the folders and spaces you watch since {DigestSince}:
+
+{DigestActivities}`),
+	}
 )
 
 // holds the information to turn the raw template into a parseable go template
@@ -84,6 +99,11 @@ var _placeholders = map[string]string{
 	"{SpaceGrantee}": "{{ .SpaceGrantee }}",
 	"{SpaceSharer}":  "{{ .SpaceSharer }}",
 	"{ExpiredAt}":    "{{ .ExpiredAt }}",
+
+	"{ActivityCount}":    "{{ .ActivityCount }}",
+	"{DigestRecipient}":  "{{ .DigestRecipient }}",
+	"{DigestSince}":      "{{ .DigestSince }}",
+	"{DigestActivities}": "{{ .DigestActivities }}",
 }
 
 // MessageTemplate is the data structure for the email
@@ -92,6 +112,8 @@ type MessageTemplate struct {
 	textTemplate string
 	// htmlTemplate represent the path to html .tmpl file
 	htmlTemplate string
+	// multilineVars are the variables spanning multiple lines, their newlines become line breaks in the html template
+	multilineVars []string
 	// The fields below represent the placeholders for the translatable templates
 	Subject      string
 	Greeting     string
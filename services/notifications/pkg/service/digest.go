@@ -0,0 +1,69 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cs3org/reva/v2/pkg/utils"
+	"github.com/owncloud/ocis/v2/services/activitylog/pkg/event"
+	"github.com/owncloud/ocis/v2/services/notifications/pkg/email"
+)
+
+func (s eventsNotifier) handleActivityDigest(e event.ActivityDigest) {
+	logger := s.logger.With().
+		Str("event", "ActivityDigest").
+		Str("recipient", e.Recipient.GetOpaqueId()).
+		Logger()
+
+	gatewayClient, err := s.gatewaySelector.Next()
+	if err != nil {
+		logger.Error().Err(err).Msg("could not select next gateway client")
+		return
+	}
+
+	ctx, err := utils.GetServiceUserContext(s.serviceAccountID, gatewayClient, s.serviceAccountSecret)
+	if err != nil {
+		logger.Error().Err(err).Msg("could not handle activity digest event")
+		return
+	}
+
+	recipientList := s.ensureGranteeList(ctx, e.Recipient, e.Recipient, nil)
+	if recipientList == nil {
+		return
+	}
+
+	// the resources are listed with a link, followed by their activities
+	var (
+		activities strings.Builder
+		count      int
+	)
+	for i, r := range e.Resources {
+		link, err := urlJoinPath(s.ocisURL, "f", r.ID)
+		if err != nil {
+			logger.Error().Err(err).Msg("could not create link to the resource")
+			return
+		}
+
+		if i > 0 {
+			activities.WriteString("\n\n")
+		}
+		activities.WriteString(r.Name + ": " + link)
+		for _, a := range r.Activities {
+			activities.WriteString("\n- " + a)
+		}
+		count += len(r.Activities)
+	}
+
+	messageList, err := s.render(ctx, email.ActivityDigest,
+		"DigestRecipient",
+		map[string]string{
+			"ActivityCount":    strconv.Itoa(count),
+			"DigestSince":      e.Since.Format("2006-01-02 15:04:05"),
+			"DigestActivities": activities.String(),
+		}, recipientList, "")
+	if err != nil {
+		logger.Error().Err(err).Msg("could not render the email")
+		return
+	}
+	s.send(ctx, messageList)
+}
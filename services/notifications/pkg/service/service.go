@@ -22,6 +22,7 @@ import (
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/ocis-pkg/middleware"
 	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
+	"github.com/owncloud/ocis/v2/services/activitylog/pkg/event"
 	"github.com/owncloud/ocis/v2/services/notifications/pkg/channels"
 	"github.com/owncloud/ocis/v2/services/notifications/pkg/email"
 	"github.com/owncloud/ocis/v2/services/settings/pkg/store/defaults"
@@ -92,6 +93,8 @@ func (s eventsNotifier) Run() error {
 					s.handleShareCreated(e)
 				case events.ShareExpired:
 					s.handleShareExpired(e)
+				case event.ActivityDigest:
+					s.handleActivityDigest(e)
 				}
 			}()
 		case <-s.signals:
@@ -115,7 +118,9 @@ func (s eventsNotifier) render(ctx context.Context, template email.MessageTempla
 			return nil, err
 		}
 		rendered.Sender = sender
-		rendered.Recipient = []string{usr.GetMail()}
+		rendered.Recipient = s.mailRecipients(ctx, usr)
+		rendered.Username = usr.GetUsername()
+		rendered.Channels = s.notificationChannels(ctx, usr.GetId())
 		messageList[i] = rendered
 	}
 	return messageList, nil
@@ -144,17 +149,10 @@ func (s eventsNotifier) ensureGranteeList(ctx context.Context, executant, u *use
 func (s eventsNotifier) getGranteeList(ctx context.Context, executant, u *user.UserId, g *group.GroupId) ([]*user.User, error) {
 	switch {
 	case u != nil:
-		if s.disableEmails(ctx, u) {
-			return nil, nil
-		}
 		usr, err := s.getUser(ctx, u)
 		if err != nil {
 			return nil, err
 		}
-		if strings.TrimSpace(usr.GetMail()) == "" {
-			s.logger.Debug().Str("event", "getGranteeList").Msgf("User %s has no email, skipped", usr.GetUsername())
-			return nil, nil
-		}
 		return []*user.User{usr}, nil
 	case g != nil:
 		gatewayClient, err := s.gatewaySelector.Next()
@@ -176,18 +174,10 @@ func (s eventsNotifier) getGranteeList(ctx context.Context, executant, u *user.U
 			if userID.GetOpaqueId() == executant.GetOpaqueId() {
 				continue
 			}
-			// don't add users who opted out
-			if s.disableEmails(ctx, userID) {
-				continue
-			}
 			usr, err := s.getUser(ctx, userID)
 			if err != nil {
 				return nil, err
 			}
-			if strings.TrimSpace(usr.GetMail()) == "" {
-				s.logger.Debug().Str("event", "getGranteeList").Msgf("User %s has no email, skipped", usr.GetUsername())
-				continue
-			}
 			userList = append(userList, usr)
 		}
 		return userList, nil
@@ -214,6 +204,19 @@ func (s eventsNotifier) getUser(ctx context.Context, u *user.UserId) (*user.User
 	return r.GetUser(), nil
 }
 
+// mailRecipients returns the email address of the user or nothing if the user has no email address or opted out of
+// email notifications. The other channels are not affected by the opt-out.
+func (s eventsNotifier) mailRecipients(ctx context.Context, usr *user.User) []string {
+	if strings.TrimSpace(usr.GetMail()) == "" {
+		s.logger.Debug().Str("event", "mailRecipients").Msgf("User %s has no email, skipped", usr.GetUsername())
+		return nil
+	}
+	if s.disableEmails(ctx, usr.GetId()) {
+		return nil
+	}
+	return []string{usr.GetMail()}
+}
+
 func (s eventsNotifier) disableEmails(ctx context.Context, u *user.UserId) bool {
 	granteeCtx := metadata.Set(ctx, middleware.AccountID, u.OpaqueId)
 	if resp, err := s.valueService.GetValueByUniqueIdentifiers(granteeCtx,
@@ -228,6 +231,30 @@ func (s eventsNotifier) disableEmails(ctx context.Context, u *user.UserId) bool
 	return false
 }
 
+// notificationChannels returns the channels the user chose to receive notifications on.
+// An empty list means that the default channels should be used.
+func (s eventsNotifier) notificationChannels(ctx context.Context, u *user.UserId) []string {
+	granteeCtx := metadata.Set(ctx, middleware.AccountID, u.GetOpaqueId())
+	resp, err := s.valueService.GetValueByUniqueIdentifiers(granteeCtx,
+		&settingssvc.GetValueByUniqueIdentifiersRequest{
+			AccountUuid: u.GetOpaqueId(),
+			SettingId:   defaults.SettingUUIDProfileNotificationChannels,
+		},
+	)
+	if err != nil {
+		return nil
+	}
+
+	values := resp.GetValue().GetValue().GetListValue().GetValues()
+	names := make([]string, 0, len(values))
+	for _, v := range values {
+		if name := v.GetStringValue(); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 func (s eventsNotifier) getResourceInfo(ctx context.Context, resourceID *provider.ResourceId, fieldmask *fieldmaskpb.FieldMask) (*provider.ResourceInfo, error) {
 	// TODO: maybe cache this stat to reduce storage iops
 	gatewayClient, err := s.gatewaySelector.Next()
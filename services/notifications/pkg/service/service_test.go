@@ -16,10 +16,13 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
+	settingsmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/settings/v0"
 	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
+	"github.com/owncloud/ocis/v2/services/activitylog/pkg/event"
 	"github.com/owncloud/ocis/v2/services/graph/pkg/config/defaults"
 	"github.com/owncloud/ocis/v2/services/notifications/pkg/channels"
 	"github.com/owncloud/ocis/v2/services/notifications/pkg/service"
+	settingsdefaults "github.com/owncloud/ocis/v2/services/settings/pkg/store/defaults"
 	"github.com/stretchr/testify/mock"
 	"go-micro.dev/v4/client"
 	"google.golang.org/grpc"
@@ -215,7 +218,78 @@ https://owncloud.com
 				ExpiredAt:     time.Date(2023, 4, 17, 16, 42, 0, 0, time.UTC),
 			},
 		}),
+
+		Entry("Activity Digest", testChannel{
+			expectedReceipients: []string{sharer.GetMail()},
+			expectedSubject:     "3 new activities in the folders and spaces you watch",
+			expectedTextBody: `Hello Dr. S. Harer,
+
+This happened in the folders and spaces you watch since 2023-04-17 16:42:00:
+
+secret space: f/storageid$spaceid%21spaceid
+- Eric Expireling added report.pdf to secret space
+- Eric Expireling deleted draft.pdf from secret space
+
+board: f/storageid$spaceid%21itemid
+- Eric Expireling shared board via link
+
+
+---
+ownCloud - Store. Share. Work.
+https://owncloud.com
+`,
+			done: make(chan struct{}),
+		}, events.Event{
+			Event: event.ActivityDigest{
+				Recipient: sharer.GetId(),
+				Interval:  "daily",
+				Since:     time.Date(2023, 4, 17, 16, 42, 0, 0, time.UTC),
+				Resources: []event.DigestResource{
+					{
+						ID:         "storageid$spaceid!spaceid",
+						Name:       "secret space",
+						Activities: []string{"Eric Expireling added report.pdf to secret space", "Eric Expireling deleted draft.pdf from secret space"},
+					},
+					{
+						ID:         "storageid$spaceid!itemid",
+						Name:       "board",
+						Activities: []string{"Eric Expireling shared board via link"},
+					},
+				},
+			},
+		}),
 	)
+
+	It("notifies users who opted out of emails on the other channels", func() {
+		vs.GetValueByUniqueIdentifiersFunc = func(ctx context.Context, req *settingssvc.GetValueByUniqueIdentifiersRequest, opts ...client.CallOption) (*settingssvc.GetValueResponse, error) {
+			if req.GetSettingId() != settingsdefaults.SettingUUIDProfileDisableNotifications {
+				return nil, nil
+			}
+			return &settingssvc.GetValueResponse{Value: &settingsmsg.ValueWithIdentifier{Value: &settingsmsg.Value{Value: &settingsmsg.Value_BoolValue{BoolValue: true}}}}, nil
+		}
+
+		messages := make(chan *channels.Message, 1)
+		ch := make(chan events.Event)
+		evts := service.NewEventsNotifier(ch, channelFunc(func(_ context.Context, m *channels.Message) error {
+			messages <- m
+			return nil
+		}), log.NewLogger(), gatewaySelector, vs, "", "", "", "", "")
+		go evts.Run()
+
+		ch <- events.Event{
+			Event: events.ShareCreated{
+				Sharer:        sharer.GetId(),
+				GranteeUserID: sharee.GetId(),
+				CTime:         utils.TimeToTS(time.Date(2023, 4, 17, 16, 42, 0, 0, time.UTC)),
+				ItemID:        resourceid,
+			},
+		}
+
+		var m *channels.Message
+		Eventually(messages, 3*time.Second).Should(Receive(&m))
+		Expect(m.Subject).To(Equal("Dr. S. Harer shared 'secrets of the board' with you"))
+		Expect(m.Recipient).To(BeEmpty())
+	})
 })
 
 var _ = Describe("Notifications X-Site Scripting", func() {
@@ -421,6 +495,78 @@ https://owncloud.com
 				ID:            &provider.StorageSpaceId{OpaqueId: "spaceid"},
 			},
 		}),
+
+		Entry("Activity Digest", testChannel{
+			expectedReceipients: []string{sharer.GetMail()},
+			expectedSubject:     "1 new activities in the folders and spaces you watch",
+			expectedTextBody: `Hello Dr. O'reilly,
+
+This happened in the folders and spaces you watch since 2023-04-17 16:42:00:
+
+<script>alert('secret space');</script>: f/storageid$spaceid%21spaceid
+- <script>alert('Eric Expireling');</script> added report.pdf to <script>alert('secret space');</script>
+
+
+---
+ownCloud - Store. Share. Work.
+https://owncloud.com
+`,
+			expectedHTMLBody: `<!DOCTYPE html>
+<html>
+<body>
+<table cellspacing="0" cellpadding="0" border="0" width="100%">
+    <tr>
+        <td>
+            <table cellspacing="0" cellpadding="0" border="0" width="600px">
+                <tr>
+                    <td width="20px">&nbsp;</td>
+                    <td style="font-weight:normal; font-size:0.8em; line-height:1.2em; font-family:verdana,'arial',sans;">
+                        Hello Dr. O&#39;reilly,
+                        <br><br>
+                        This happened in the folders and spaces you watch since 2023-04-17 16:42:00:<br><br>&lt;script&gt;alert(&#39;secret space&#39;);&lt;/script&gt;: f/storageid$spaceid%21spaceid<br>- &lt;script&gt;alert(&#39;Eric Expireling&#39;);&lt;/script&gt; added report.pdf to &lt;script&gt;alert(&#39;secret space&#39;);&lt;/script&gt;
+                        
+                    </td>
+                </tr>
+                <tr>
+                    <td colspan="2">&nbsp;</td>
+                </tr>
+                <tr>
+                    <td width="20px">&nbsp;</td>
+                    <td style="font-weight:normal; font-size:0.8em; line-height:1.2em; font-family:verdana,'arial',sans;">
+                        <footer>
+                            <br>
+                            <br>
+                            --- <br>
+                            ownCloud - Store. Share. Work.<br>
+                            <a href="https://owncloud.com">https://owncloud.com</a>
+                        </footer>
+                    </td>
+                </tr>
+                <tr>
+                    <td colspan="2">&nbsp;</td>
+                </tr>
+            </table>
+        </td>
+    </tr>
+</table>
+</body>
+</html>
+`,
+			done: make(chan struct{}),
+		}, events.Event{
+			Event: event.ActivityDigest{
+				Recipient: sharer.GetId(),
+				Interval:  "weekly",
+				Since:     time.Date(2023, 4, 17, 16, 42, 0, 0, time.UTC),
+				Resources: []event.DigestResource{
+					{
+						ID:         "storageid$spaceid!spaceid",
+						Name:       "<script>alert('secret space');</script>",
+						Activities: []string{"<script>alert('Eric Expireling');</script> added report.pdf to <script>alert('secret space');</script>"},
+					},
+				},
+			},
+		}),
 	)
 })
 
@@ -447,3 +593,9 @@ func (tc testChannel) SendMessage(ctx context.Context, m *channels.Message) erro
 	tc.done <- struct{}{}
 	return nil
 }
+
+type channelFunc func(ctx context.Context, m *channels.Message) error
+
+func (f channelFunc) SendMessage(ctx context.Context, m *channels.Message) error {
+	return f(ctx, m)
+}
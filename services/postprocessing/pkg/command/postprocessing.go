@@ -2,14 +2,24 @@ package command
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cs3org/reva/v2/pkg/events"
 	"github.com/cs3org/reva/v2/pkg/events/stream"
+	"github.com/cs3org/reva/v2/pkg/store"
 	"github.com/cs3org/reva/v2/pkg/utils"
+	tw "github.com/olekukonko/tablewriter"
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config/parser"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/service"
 	"github.com/urfave/cli/v2"
+	microstore "go-micro.dev/v4/store"
 )
 
 // RestartPostprocessing cli command to restart postprocessing
@@ -54,3 +64,191 @@ func RestartPostprocessing(cfg *config.Config) *cli.Command {
 		},
 	}
 }
+
+// Uploads cli command to inspect and manage uploads in postprocessing
+func Uploads(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "uploads",
+		Usage: "inspect and manage uploads in postprocessing",
+		Subcommands: []*cli.Command{
+			ListUploads(cfg),
+			RetryUpload(cfg),
+			SkipStep(cfg),
+			AbortUpload(cfg),
+		},
+		Before: func(c *cli.Context) error {
+			return configlog.ReturnFatal(parser.ParseConfig(cfg))
+		},
+	}
+}
+
+// ListUploads prints the uploads in postprocessing
+func ListUploads(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "list",
+		Usage: "print a list of uploads in postprocessing",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "step",
+				Aliases: []string{"s"},
+				Usage:   "only list uploads currently processing the given step",
+			},
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "output as json",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			admin := service.NewAdmin(newStore(cfg), nil, cfg.Postprocessing)
+			uploads, err := admin.List(events.Postprocessingstep(c.String("step")))
+			if err != nil {
+				return err
+			}
+
+			if c.Bool("json") {
+				j, err := json.Marshal(uploads)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(j))
+				return nil
+			}
+
+			table := tw.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Upload Id", "Name", "Size", "User", "Steps", "Pending", "Outcome", "Retries", "Last Failure", "Finished"})
+			table.SetAutoFormatHeaders(false)
+			for _, u := range uploads {
+				pending := string(u.CurrentStep)
+				if len(u.PendingSteps) > 0 {
+					pending = joinSteps(u.PendingSteps)
+				}
+
+				var failure string
+				if u.LastFailure != nil {
+					failure = fmt.Sprintf("%s: %s (%s)", u.LastFailure.Step, u.LastFailure.Outcome, u.LastFailure.Time.Format(time.RFC3339))
+					if u.LastFailure.Message != "" {
+						failure += " " + u.LastFailure.Message
+					}
+				}
+
+				table.Append([]string{
+					u.ID,
+					u.Filename,
+					strconv.FormatUint(u.Filesize, 10),
+					u.User,
+					joinSteps(u.Steps),
+					pending,
+					string(u.Outcome),
+					strconv.Itoa(u.Retries),
+					failure,
+					strconv.FormatBool(u.Finished),
+				})
+			}
+			table.Render()
+			return nil
+		},
+	}
+}
+
+// RetryUpload restarts the pending steps of an upload
+func RetryUpload(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "retry",
+		Usage: "restart the pending postprocessing steps of an upload",
+		Flags: []cli.Flag{uploadIDFlag()},
+		Action: func(c *cli.Context) error {
+			admin, err := newAdmin(cfg)
+			if err != nil {
+				return err
+			}
+			return admin.Retry(c.Context, c.String("upload-id"))
+		},
+	}
+}
+
+// SkipStep marks a pending step of an upload as finished
+func SkipStep(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "skip",
+		Usage: "skip a pending postprocessing step of an upload",
+		Flags: []cli.Flag{
+			uploadIDFlag(),
+			&cli.StringFlag{
+				Name:    "step",
+				Aliases: []string{"s"},
+				Usage:   "the step to skip. Defaults to the current step.",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			admin, err := newAdmin(cfg)
+			if err != nil {
+				return err
+			}
+			return admin.Skip(c.Context, c.String("upload-id"), events.Postprocessingstep(c.String("step")))
+		},
+	}
+}
+
+// AbortUpload finishes the postprocessing of an upload
+func AbortUpload(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "abort",
+		Usage: "abort the postprocessing of an upload",
+		Flags: []cli.Flag{
+			uploadIDFlag(),
+			&cli.BoolFlag{
+				Name:  "delete",
+				Usage: "delete the upload instead of keeping it",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			admin, err := newAdmin(cfg)
+			if err != nil {
+				return err
+			}
+
+			outcome := events.PPOutcomeAbort
+			if c.Bool("delete") {
+				outcome = events.PPOutcomeDelete
+			}
+			return admin.Abort(c.Context, c.String("upload-id"), outcome)
+		},
+	}
+}
+
+func uploadIDFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:     "upload-id",
+		Aliases:  []string{"u"},
+		Usage:    "the id of the upload",
+		Required: true,
+	}
+}
+
+func joinSteps(steps []events.Postprocessingstep) string {
+	s := make([]string, 0, len(steps))
+	for _, step := range steps {
+		s = append(s, string(step))
+	}
+	return strings.Join(s, ",")
+}
+
+func newAdmin(cfg *config.Config) (service.Admin, error) {
+	stream, err := stream.NatsFromConfig(cfg.Service.Name, false, stream.NatsConfig(cfg.Postprocessing.Events))
+	if err != nil {
+		return service.Admin{}, err
+	}
+	return service.NewAdmin(newStore(cfg), stream, cfg.Postprocessing), nil
+}
+
+func newStore(cfg *config.Config) microstore.Store {
+	return store.Create(
+		store.Store(cfg.Store.Store),
+		store.TTL(cfg.Store.TTL),
+		store.Size(cfg.Store.Size),
+		microstore.Nodes(cfg.Store.Nodes...),
+		microstore.Database(cfg.Store.Database),
+		microstore.Table(cfg.Store.Table),
+		store.Authentication(cfg.Store.AuthUsername, cfg.Store.AuthPassword),
+	)
+}
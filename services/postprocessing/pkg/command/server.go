@@ -6,18 +6,19 @@ import (
 	"os"
 
 	"github.com/cs3org/reva/v2/pkg/events/stream"
-	"github.com/cs3org/reva/v2/pkg/store"
 	"github.com/oklog/run"
 	"github.com/owncloud/ocis/v2/ocis-pkg/handlers"
 	"github.com/owncloud/ocis/v2/ocis-pkg/service/debug"
+	ogrpc "github.com/owncloud/ocis/v2/ocis-pkg/service/grpc"
 	"github.com/owncloud/ocis/v2/ocis-pkg/tracing"
 	"github.com/owncloud/ocis/v2/ocis-pkg/version"
+	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config/parser"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/logging"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/server/http"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/service"
 	"github.com/urfave/cli/v2"
-	microstore "go-micro.dev/v4/store"
 )
 
 // Server is the entrypoint for the server command.
@@ -28,6 +29,9 @@ func Server(cfg *config.Config) *cli.Command {
 		Category: "server",
 		Before: func(c *cli.Context) error {
 			err := parser.ParseConfig(cfg)
+			if err == nil && cfg.HTTP.Enabled {
+				err = parser.ValidateHTTP(cfg)
+			}
 			if err != nil {
 				fmt.Printf("%v", err)
 				os.Exit(1)
@@ -53,22 +57,21 @@ func Server(cfg *config.Config) *cli.Command {
 				return err
 			}
 
-			{
-				bus, err := stream.NatsFromConfig(cfg.Service.Name, false, stream.NatsConfig(cfg.Postprocessing.Events))
-				if err != nil {
-					return err
-				}
+			grpcClient, err := ogrpc.NewClient(
+				append(ogrpc.GetClientOptions(cfg.GRPCClientTLS), ogrpc.WithTraceProvider(traceProvider))...,
+			)
+			if err != nil {
+				return err
+			}
 
-				st := store.Create(
-					store.Store(cfg.Store.Store),
-					store.TTL(cfg.Store.TTL),
-					store.Size(cfg.Store.Size),
-					microstore.Nodes(cfg.Store.Nodes...),
-					microstore.Database(cfg.Store.Database),
-					microstore.Table(cfg.Store.Table),
-					store.Authentication(cfg.Store.AuthUsername, cfg.Store.AuthPassword),
-				)
+			bus, err := stream.NatsFromConfig(cfg.Service.Name, false, stream.NatsConfig(cfg.Postprocessing.Events))
+			if err != nil {
+				return err
+			}
+
+			st := newStore(cfg)
 
+			{
 				svc, err := service.NewPostprocessingService(ctx, bus, logger, st, traceProvider, cfg.Postprocessing)
 				if err != nil {
 					return err
@@ -92,6 +95,34 @@ func Server(cfg *config.Config) *cli.Command {
 				})
 			}
 
+			if cfg.HTTP.Enabled {
+				server, err := http.Server(
+					http.Logger(logger),
+					http.Context(ctx),
+					http.Config(cfg),
+					http.Store(st),
+					http.Stream(bus),
+					http.Role(settingssvc.NewRoleService("com.owncloud.api.settings", grpcClient)),
+					http.TracerProvider(traceProvider),
+				)
+				if err != nil {
+					logger.Info().Err(err).Str("transport", "http").Msg("Failed to initialize server")
+					return err
+				}
+
+				gr.Add(func() error {
+					return server.Run()
+				}, func(err error) {
+					logger.Error().
+						Err(err).
+						Str("server", "http").
+						Msg("Shutting down server")
+
+					cancel()
+					os.Exit(1)
+				})
+			}
+
 			{
 				server := debug.NewService(
 					debug.Logger(logger),
@@ -17,6 +17,10 @@ type Config struct {
 	Log     *Log     `yaml:"log"`
 	Debug   Debug    `yaml:"debug"`
 
+	HTTP          HTTP                  `yaml:"http"`
+	GRPCClientTLS *shared.GRPCClientTLS `yaml:"grpc_client_tls"`
+	TokenManager  *TokenManager         `yaml:"token_manager"`
+
 	Store          Store          `yaml:"store"`
 	Postprocessing Postprocessing `yaml:"postprocessing"`
 
@@ -26,13 +30,30 @@ type Config struct {
 // Postprocessing defines the config options for the postprocessing service.
 type Postprocessing struct {
 	Events          Events        `yaml:"events"`
-	Steps           []string      `yaml:"steps" env:"POSTPROCESSING_STEPS" desc:"A list of postprocessing steps processed in order of their appearance. Currently supported values by the system are: 'virusscan', 'policies' and 'delay'. Custom steps are allowed. See the documentation for instructions. See the Environment Variable Types description for more details." introductionVersion:"pre5.0"`
+	Steps           []string      `yaml:"steps" env:"POSTPROCESSING_STEPS" desc:"A list of postprocessing steps processed in order of their appearance. Currently supported values by the system are: 'virusscan', 'policies' and 'delay'. Custom steps are allowed. Steps of one list entry separated by a '|' are processed in parallel. Ignored if a pipeline is configured. See the documentation for instructions. See the Environment Variable Types description for more details." introductionVersion:"pre5.0"`
+	Pipeline        []Stage       `yaml:"pipeline"`
 	Delayprocessing time.Duration `yaml:"delayprocessing" env:"POSTPROCESSING_DELAY" desc:"After uploading a file but before making it available for download, a delay step can be added. Intended for developing purposes only. If a duration is set but the keyword 'delay' is not explicitely added to 'POSTPROCESSING_STEPS', the delay step will be processed as last step. In such a case, a log entry will be written on service startup to remind the admin about that situation. See the Environment Variable Types description for more details." introductionVersion:"pre5.0"`
 
 	RetryBackoffDuration time.Duration `yaml:"retry_backoff_duration" env:"POSTPROCESSING_RETRY_BACKOFF_DURATION" desc:"The base for the exponential backoff duration before retrying a failed postprocessing step. See the Environment Variable Types description for more details." introductionVersion:"5.0"`
 	MaxRetries           int           `yaml:"max_retries" env:"POSTPROCESSING_MAX_RETRIES" desc:"The maximum number of retries for a failed postprocessing step." introductionVersion:"5.0"`
 }
 
+// Stage is a group of postprocessing steps that are processed in parallel.
+// A stage is started once all steps of the previous stage have finished.
+type Stage struct {
+	Steps []Step `yaml:"steps"`
+}
+
+// Step is a postprocessing step with optional conditions.
+// A step is only processed for uploads matching all of its conditions.
+type Step struct {
+	Name      string   `yaml:"name"`
+	MimeTypes []string `yaml:"mimetypes"` // patterns like 'image/*'
+	MinSize   uint64   `yaml:"min_size"`
+	MaxSize   uint64   `yaml:"max_size"`
+	SpaceIDs  []string `yaml:"space_ids"`
+}
+
 // Events combines the configuration options for the event bus.
 type Events struct {
 	Endpoint string `yaml:"endpoint" env:"OCIS_EVENTS_ENDPOINT;POSTPROCESSING_EVENTS_ENDPOINT" desc:"The address of the event system. The event system is the message queuing service. It is used as message broker for the microservice architecture." introductionVersion:"pre5.0"`
@@ -45,6 +66,20 @@ type Events struct {
 	AuthPassword         string `yaml:"password" env:"OCIS_EVENTS_AUTH_PASSWORD;POSTPROCESSING_EVENTS_AUTH_PASSWORD" desc:"The password to authenticate with the events broker. The events broker is the ocis service which receives and delivers events between the services." introductionVersion:"5.0"`
 }
 
+// HTTP defines the available http configuration of the admin API.
+type HTTP struct {
+	Enabled   bool                  `yaml:"enabled" env:"POSTPROCESSING_HTTP_ENABLED" desc:"Enable the HTTP admin API to inspect and manage uploads in postprocessing. Requires a JWT secret." introductionVersion:"6.0.0"`
+	Addr      string                `yaml:"addr" env:"POSTPROCESSING_HTTP_ADDR" desc:"The bind address of the HTTP service." introductionVersion:"6.0.0"`
+	Namespace string                `yaml:"-"`
+	Root      string                `yaml:"root" env:"POSTPROCESSING_HTTP_ROOT" desc:"Subdirectory that serves as the root for this HTTP service." introductionVersion:"6.0.0"`
+	TLS       shared.HTTPServiceTLS `yaml:"tls"`
+}
+
+// TokenManager is the config for using the reva token manager
+type TokenManager struct {
+	JWTSecret string `yaml:"jwt_secret" env:"OCIS_JWT_SECRET;POSTPROCESSING_JWT_SECRET" desc:"The secret to mint and validate jwt tokens." introductionVersion:"6.0.0"`
+}
+
 // Debug defines the available debug configuration.
 type Debug struct {
 	Addr   string `yaml:"addr" env:"POSTPROCESSING_DEBUG_ADDR" desc:"Bind address of the debug server, where metrics, health, config and debug endpoints will be exposed." introductionVersion:"pre5.0"`
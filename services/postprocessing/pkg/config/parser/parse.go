@@ -7,6 +7,7 @@ import (
 
 	"github.com/cs3org/reva/v2/pkg/events"
 	ociscfg "github.com/owncloud/ocis/v2/ocis-pkg/config"
+	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
 	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config/defaults"
 
@@ -37,7 +38,14 @@ func ParseConfig(cfg *config.Config) error {
 
 // Validate validates the config
 func Validate(cfg *config.Config) error {
-	if cfg.Postprocessing.Delayprocessing != 0 {
+	if cfg.Postprocessing.Delayprocessing != 0 && len(cfg.Postprocessing.Pipeline) > 0 {
+		if !pipelineContains(cfg.Postprocessing.Pipeline, events.PPStepDelay) {
+			fmt.Println("Added delay stage to the end of the postprocessing pipeline. NOTE: Add a `delay` step to the pipeline to suppress this message and choose the position of the delay step.")
+			cfg.Postprocessing.Pipeline = append(cfg.Postprocessing.Pipeline, config.Stage{
+				Steps: []config.Step{{Name: string(events.PPStepDelay)}},
+			})
+		}
+	} else if cfg.Postprocessing.Delayprocessing != 0 {
 		if !contains(cfg.Postprocessing.Steps, events.PPStepDelay) {
 			if len(cfg.Postprocessing.Steps) > 0 {
 				s := strings.Join(append(cfg.Postprocessing.Steps, string(events.PPStepDelay)), ",")
@@ -47,13 +55,47 @@ func Validate(cfg *config.Config) error {
 			cfg.Postprocessing.Steps = append(cfg.Postprocessing.Steps, string(events.PPStepDelay))
 		}
 	}
+
+	for _, stage := range cfg.Postprocessing.Pipeline {
+		for _, step := range stage.Steps {
+			if step.Name == "" {
+				return errors.New("postprocessing pipeline steps need a name")
+			}
+			if step.MaxSize != 0 && step.MinSize > step.MaxSize {
+				return fmt.Errorf("postprocessing step '%s': min_size must not be greater than max_size", step.Name)
+			}
+		}
+	}
 	return nil
 }
 
+// ValidateHTTP validates the config of the admin API. It is only needed by the server command when the API is enabled,
+// the CLI commands work without it.
+func ValidateHTTP(cfg *config.Config) error {
+	if cfg.TokenManager.JWTSecret == "" {
+		return shared.MissingJWTTokenError(cfg.Service.Name)
+	}
+	return nil
+}
+
+func pipelineContains(pipeline []config.Stage, candidate events.Postprocessingstep) bool {
+	for _, stage := range pipeline {
+		for _, step := range stage.Steps {
+			if step.Name == string(candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func contains(all []string, candidate events.Postprocessingstep) bool {
 	for _, s := range all {
-		if s == string(candidate) {
-			return true
+		// steps separated by '|' are processed in parallel
+		for _, step := range strings.Split(s, "|") {
+			if strings.TrimSpace(step) == string(candidate) {
+				return true
+			}
 		}
 	}
 	return false
@@ -0,0 +1,95 @@
+package postprocessing
+
+import (
+	"path"
+	"strings"
+
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/mime"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
+)
+
+// Pipeline describes which postprocessing steps apply to an upload and in which order they are processed
+type Pipeline struct {
+	stages []config.Stage
+}
+
+// File contains the information about an upload the step conditions are evaluated against
+type File struct {
+	Filename string
+	Filesize uint64
+	SpaceID  string
+}
+
+// NewPipeline returns the pipeline configured in c. If no pipeline is configured
+// it is built from the list of steps where each entry is a stage of its own.
+func NewPipeline(c config.Postprocessing) Pipeline {
+	if len(c.Pipeline) > 0 {
+		return Pipeline{stages: c.Pipeline}
+	}
+
+	stages := make([]config.Stage, 0, len(c.Steps))
+	for _, s := range c.Steps {
+		var stage config.Stage
+		for _, name := range strings.Split(s, "|") {
+			if name = strings.TrimSpace(name); name != "" {
+				stage.Steps = append(stage.Steps, config.Step{Name: name})
+			}
+		}
+		if len(stage.Steps) > 0 {
+			stages = append(stages, stage)
+		}
+	}
+	return Pipeline{stages: stages}
+}
+
+// Resolve returns the stages of steps that apply to the given file. Stages without matching steps are omitted.
+func (p Pipeline) Resolve(f File) [][]events.Postprocessingstep {
+	var mimetype string
+	stages := make([][]events.Postprocessingstep, 0, len(p.stages))
+	for _, stage := range p.stages {
+		var steps []events.Postprocessingstep
+		for _, step := range stage.Steps {
+			if len(step.MimeTypes) > 0 && mimetype == "" {
+				mimetype = mime.Detect(false, f.Filename)
+			}
+			if matches(step, f, mimetype) {
+				steps = append(steps, events.Postprocessingstep(step.Name))
+			}
+		}
+		if len(steps) > 0 {
+			stages = append(stages, steps)
+		}
+	}
+	return stages
+}
+
+func matches(step config.Step, f File, mimetype string) bool {
+	if step.MinSize != 0 && f.Filesize < step.MinSize {
+		return false
+	}
+	if step.MaxSize != 0 && f.Filesize > step.MaxSize {
+		return false
+	}
+	if len(step.SpaceIDs) > 0 && !containsString(step.SpaceIDs, f.SpaceID) {
+		return false
+	}
+	if len(step.MimeTypes) > 0 {
+		for _, pattern := range step.MimeTypes {
+			if ok, _ := path.Match(pattern, mimetype); ok {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func containsString(all []string, candidate string) bool {
+	for _, s := range all {
+		if s == candidate {
+			return true
+		}
+	}
+	return false
+}
@@ -12,15 +12,18 @@ import (
 
 // Postprocessing handles postprocessing of a file
 type Postprocessing struct {
-	ID          string
-	URL         string
-	User        *user.User
-	Filename    string
-	Filesize    uint64
-	ResourceID  *provider.ResourceId
-	Steps       []events.Postprocessingstep
+	ID         string
+	URL        string
+	User       *user.User
+	Filename   string
+	Filesize   uint64
+	ResourceID *provider.ResourceId
+	Steps      []events.Postprocessingstep
+	// Stages groups the steps. Steps of the same stage are processed in parallel.
+	Stages      [][]events.Postprocessingstep
 	Status      Status
 	Failures    int
+	LastFailure *Failure
 	InitiatorID string
 	Finished    bool
 
@@ -30,7 +33,19 @@ type Postprocessing struct {
 // Status is helper struct to show current postprocessing status
 type Status struct {
 	CurrentStep events.Postprocessingstep
-	Outcome     events.PostprocessingOutcome
+	// Stage is the index of the stage currently processed
+	Stage int
+	// PendingSteps are the steps of the current stage which have not finished yet
+	PendingSteps []events.Postprocessingstep
+	Outcome      events.PostprocessingOutcome
+}
+
+// Failure records a step which did not succeed
+type Failure struct {
+	Step    events.Postprocessingstep
+	Outcome events.PostprocessingOutcome
+	Message string
+	Time    time.Time
 }
 
 // New returns a new postprocessing instance
@@ -41,41 +56,81 @@ func New(config config.Postprocessing) *Postprocessing {
 }
 
 // Init is the first step of the postprocessing
-func (pp *Postprocessing) Init(_ events.BytesReceived) interface{} {
-	if len(pp.Steps) == 0 {
-		return pp.finished(events.PPOutcomeContinue)
+func (pp *Postprocessing) Init(_ events.BytesReceived) []interface{} {
+	stages := pp.stages()
+	if len(stages) == 0 {
+		return []interface{}{pp.finished(events.PPOutcomeContinue)}
 	}
 
-	return pp.step(pp.Steps[0])
+	return pp.startStage(0)
 }
 
-// NextStep returns the next postprocessing step
-func (pp *Postprocessing) NextStep(ev events.PostprocessingStepFinished) interface{} {
+// NextStep returns the next postprocessing steps. The result is empty when other steps of the current stage are still pending.
+func (pp *Postprocessing) NextStep(ev events.PostprocessingStepFinished) []interface{} {
+	if pp.Status.CurrentStep == events.PPStepFinished {
+		// postprocessing was already finished by a step running in parallel
+		return nil
+	}
+
+	if ev.Outcome != events.PPOutcomeContinue {
+		pp.LastFailure = &Failure{
+			Step:    ev.FinishedStep,
+			Outcome: ev.Outcome,
+			Message: failureMessage(ev),
+			Time:    time.Now(),
+		}
+	}
+
 	switch ev.Outcome {
 	case events.PPOutcomeContinue:
 		return pp.next(ev.FinishedStep)
 	case events.PPOutcomeRetry:
 		pp.Failures++
 		if pp.Failures > pp.config.MaxRetries {
-			return pp.finished(events.PPOutcomeAbort)
+			return []interface{}{pp.finished(events.PPOutcomeAbort)}
 		}
-		return pp.retry()
+		return []interface{}{pp.retry(ev.FinishedStep)}
 	default:
-		return pp.finished(ev.Outcome)
+		return []interface{}{pp.finished(ev.Outcome)}
 	}
 }
 
-// CurrentStep returns the current postprocessing step
-func (pp *Postprocessing) CurrentStep() interface{} {
+// CurrentStep returns the events needed to resume the postprocessing
+func (pp *Postprocessing) CurrentStep() []interface{} {
 	if pp.Status.CurrentStep == events.PPStepFinished {
-		return pp.finished(pp.Status.Outcome)
+		return []interface{}{pp.finished(pp.Status.Outcome)}
 	}
-	return pp.step(pp.Status.CurrentStep)
+
+	if len(pp.Status.PendingSteps) == 0 {
+		return []interface{}{pp.step(pp.Status.CurrentStep)}
+	}
+
+	next := make([]interface{}, 0, len(pp.Status.PendingSteps))
+	for _, s := range pp.Status.PendingSteps {
+		next = append(next, pp.step(s))
+	}
+	return next
+}
+
+// IsPending returns true if the given step is currently processed
+func (pp *Postprocessing) IsPending(step events.Postprocessingstep) bool {
+	if pp.Status.CurrentStep == step {
+		return true
+	}
+	for _, s := range pp.Status.PendingSteps {
+		if s == step {
+			return true
+		}
+	}
+	return false
 }
 
 // Delay will sleep the configured time then continue
-func (pp *Postprocessing) Delay() interface{} {
+func (pp *Postprocessing) Delay() []interface{} {
 	time.Sleep(pp.config.Delayprocessing)
+	if pp.Status.CurrentStep == events.PPStepFinished {
+		return nil
+	}
 	return pp.next(events.PPStepDelay)
 }
 
@@ -84,14 +139,65 @@ func (pp *Postprocessing) BackoffDuration() time.Duration {
 	return pp.config.RetryBackoffDuration * time.Duration(math.Pow(2, float64(pp.Failures-1)))
 }
 
-func (pp *Postprocessing) next(current events.Postprocessingstep) interface{} {
-	l := len(pp.Steps)
-	for i, s := range pp.Steps {
-		if s == current && i+1 < l {
-			return pp.step(pp.Steps[i+1])
+// stages returns the stages of the postprocessing. Uploads stored by older versions
+// only know the list of steps which are processed one after another.
+func (pp *Postprocessing) stages() [][]events.Postprocessingstep {
+	if len(pp.Stages) > 0 {
+		return pp.Stages
+	}
+
+	stages := make([][]events.Postprocessingstep, 0, len(pp.Steps))
+	for _, s := range pp.Steps {
+		stages = append(stages, []events.Postprocessingstep{s})
+	}
+	return stages
+}
+
+func (pp *Postprocessing) next(current events.Postprocessingstep) []interface{} {
+	stages := pp.stages()
+	if len(pp.Stages) == 0 {
+		// legacy state - find the stage of the finished step
+		for i, stage := range stages {
+			for _, s := range stage {
+				if s == current {
+					pp.Status.Stage = i
+				}
+			}
+		}
+	}
+
+	pending := make([]events.Postprocessingstep, 0, len(pp.Status.PendingSteps))
+	for _, s := range pp.Status.PendingSteps {
+		if s != current {
+			pending = append(pending, s)
 		}
 	}
-	return pp.finished(events.PPOutcomeContinue)
+	pp.Status.PendingSteps = pending
+	pp.Status.Outcome = events.PPOutcomeContinue
+
+	if len(pending) > 0 {
+		// wait for the other steps of this stage
+		pp.Status.CurrentStep = pending[0]
+		return nil
+	}
+
+	if pp.Status.Stage+1 < len(stages) {
+		return pp.startStage(pp.Status.Stage + 1)
+	}
+	return []interface{}{pp.finished(events.PPOutcomeContinue)}
+}
+
+func (pp *Postprocessing) startStage(i int) []interface{} {
+	stage := pp.stages()[i]
+	pp.Status.Stage = i
+	pp.Status.PendingSteps = append([]events.Postprocessingstep{}, stage...)
+
+	next := make([]interface{}, 0, len(stage))
+	for _, s := range stage {
+		next = append(next, pp.step(s))
+	}
+	pp.Status.CurrentStep = stage[0]
+	return next
 }
 
 func (pp *Postprocessing) step(next events.Postprocessingstep) events.StartPostprocessingStep {
@@ -109,6 +215,7 @@ func (pp *Postprocessing) step(next events.Postprocessingstep) events.StartPostp
 
 func (pp *Postprocessing) finished(outcome events.PostprocessingOutcome) events.PostprocessingFinished {
 	pp.Status.CurrentStep = events.PPStepFinished
+	pp.Status.PendingSteps = nil
 	pp.Status.Outcome = outcome
 	return events.PostprocessingFinished{
 		UploadID:      pp.ID,
@@ -118,7 +225,10 @@ func (pp *Postprocessing) finished(outcome events.PostprocessingOutcome) events.
 	}
 }
 
-func (pp *Postprocessing) retry() events.PostprocessingRetry {
+func (pp *Postprocessing) retry(step events.Postprocessingstep) events.PostprocessingRetry {
+	if step != "" {
+		pp.Status.CurrentStep = step
+	}
 	pp.Status.Outcome = events.PPOutcomeRetry
 	return events.PostprocessingRetry{
 		UploadID:        pp.ID,
@@ -128,3 +238,19 @@ func (pp *Postprocessing) retry() events.PostprocessingRetry {
 		BackoffDuration: pp.BackoffDuration(),
 	}
 }
+
+func failureMessage(ev events.PostprocessingStepFinished) string {
+	switch r := ev.Result.(type) {
+	case events.VirusscanResult:
+		if r.ErrorMsg != "" {
+			return r.ErrorMsg
+		}
+		return r.Description
+	case string:
+		return r
+	}
+	if ev.Error != nil {
+		return ev.Error.Error()
+	}
+	return ""
+}
@@ -0,0 +1,95 @@
+package postprocessing
+
+import (
+	"testing"
+
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startedSteps(evs []interface{}) []events.Postprocessingstep {
+	var steps []events.Postprocessingstep
+	for _, ev := range evs {
+		if s, ok := ev.(events.StartPostprocessingStep); ok {
+			steps = append(steps, s.StepToStart)
+		}
+	}
+	return steps
+}
+
+func TestNewPipelineFromSteps(t *testing.T) {
+	p := NewPipeline(config.Postprocessing{Steps: []string{"virusscan | ocr", "policies"}})
+	assert.Equal(t, [][]events.Postprocessingstep{{"virusscan", "ocr"}, {"policies"}}, p.Resolve(File{Filename: "a.txt"}))
+}
+
+func TestPipelineConditions(t *testing.T) {
+	p := NewPipeline(config.Postprocessing{Pipeline: []config.Stage{
+		{Steps: []config.Step{
+			{Name: "virusscan", MaxSize: 1024},
+			{Name: "ocr", MimeTypes: []string{"image/*", "application/pdf"}},
+		}},
+		{Steps: []config.Step{
+			{Name: "policies", SpaceIDs: []string{"project"}},
+		}},
+	}})
+
+	assert.Equal(t, [][]events.Postprocessingstep{{"virusscan", "ocr"}, {"policies"}},
+		p.Resolve(File{Filename: "scan.pdf", Filesize: 10, SpaceID: "project"}))
+	assert.Equal(t, [][]events.Postprocessingstep{{"ocr"}},
+		p.Resolve(File{Filename: "photo.png", Filesize: 2048, SpaceID: "personal"}))
+	assert.Empty(t, p.Resolve(File{Filename: "video.mp4", Filesize: 2048}))
+}
+
+func TestParallelStages(t *testing.T) {
+	pp := New(config.Postprocessing{MaxRetries: 1})
+	pp.Stages = [][]events.Postprocessingstep{{"virusscan", "ocr"}, {"policies"}}
+
+	assert.Equal(t, []events.Postprocessingstep{"virusscan", "ocr"}, startedSteps(pp.Init(events.BytesReceived{})))
+	assert.True(t, pp.IsPending("ocr"))
+
+	// ocr finished, virusscan still pending
+	assert.Empty(t, pp.NextStep(events.PostprocessingStepFinished{FinishedStep: "ocr", Outcome: events.PPOutcomeContinue}))
+	assert.False(t, pp.IsPending("ocr"))
+
+	// a duplicate event does not advance the pipeline
+	assert.Empty(t, pp.NextStep(events.PostprocessingStepFinished{FinishedStep: "ocr", Outcome: events.PPOutcomeContinue}))
+
+	// virusscan needs a retry
+	next := pp.NextStep(events.PostprocessingStepFinished{FinishedStep: "virusscan", Outcome: events.PPOutcomeRetry})
+	require.Len(t, next, 1)
+	assert.IsType(t, events.PostprocessingRetry{}, next[0])
+	assert.Equal(t, events.Postprocessingstep("virusscan"), pp.Status.CurrentStep)
+
+	assert.Equal(t, []events.Postprocessingstep{"policies"},
+		startedSteps(pp.NextStep(events.PostprocessingStepFinished{FinishedStep: "virusscan", Outcome: events.PPOutcomeContinue})))
+
+	next = pp.NextStep(events.PostprocessingStepFinished{FinishedStep: "policies", Outcome: events.PPOutcomeContinue})
+	require.Len(t, next, 1)
+	assert.Equal(t, events.PPOutcomeContinue, next[0].(events.PostprocessingFinished).Outcome)
+}
+
+func TestParallelAbort(t *testing.T) {
+	pp := New(config.Postprocessing{})
+	pp.Stages = [][]events.Postprocessingstep{{"virusscan", "ocr"}}
+	pp.Init(events.BytesReceived{})
+
+	next := pp.NextStep(events.PostprocessingStepFinished{FinishedStep: "virusscan", Outcome: events.PPOutcomeDelete})
+	require.Len(t, next, 1)
+	assert.Equal(t, events.PPOutcomeDelete, next[0].(events.PostprocessingFinished).Outcome)
+
+	// late results of parallel steps are ignored
+	assert.Empty(t, pp.NextStep(events.PostprocessingStepFinished{FinishedStep: "ocr", Outcome: events.PPOutcomeContinue}))
+}
+
+func TestLegacySteps(t *testing.T) {
+	// uploads stored by older versions only know the list of steps
+	pp := New(config.Postprocessing{})
+	pp.Steps = []events.Postprocessingstep{"virusscan", "policies"}
+	pp.Status.CurrentStep = "virusscan"
+
+	assert.Equal(t, []events.Postprocessingstep{"virusscan"}, startedSteps(pp.CurrentStep()))
+	assert.Equal(t, []events.Postprocessingstep{"policies"},
+		startedSteps(pp.NextStep(events.PostprocessingStepFinished{FinishedStep: "virusscan", Outcome: events.PPOutcomeContinue})))
+}
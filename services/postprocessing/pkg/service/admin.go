@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/postprocessing"
+	"go-micro.dev/v4/store"
+)
+
+var (
+	// ErrFinished is returned when an operation is not possible because the postprocessing is already finished.
+	ErrFinished = errors.New("postprocessing already finished")
+	// ErrInvalidStep is returned when a step is not part of the current stage of the postprocessing.
+	ErrInvalidStep = errors.New("step is not pending")
+	// ErrInvalidOutcome is returned when an upload should be aborted with an unsupported outcome.
+	ErrInvalidOutcome = errors.New("invalid outcome")
+)
+
+// Upload is the administrative view on an upload in postprocessing
+type Upload struct {
+	ID           string                       `json:"id"`
+	Filename     string                       `json:"filename"`
+	Filesize     uint64                       `json:"filesize"`
+	ResourceID   string                       `json:"resourceId,omitempty"`
+	User         string                       `json:"user,omitempty"`
+	Steps        []events.Postprocessingstep  `json:"steps"`
+	CurrentStep  events.Postprocessingstep    `json:"currentStep"`
+	PendingSteps []events.Postprocessingstep  `json:"pendingSteps,omitempty"`
+	Outcome      events.PostprocessingOutcome `json:"outcome,omitempty"`
+	Retries      int                          `json:"retries"`
+	LastFailure  *postprocessing.Failure      `json:"lastFailure,omitempty"`
+	Finished     bool                         `json:"finished"`
+}
+
+// NewUpload converts a postprocessing into its administrative view
+func NewUpload(pp *postprocessing.Postprocessing) Upload {
+	u := Upload{
+		ID:           pp.ID,
+		Filename:     pp.Filename,
+		Filesize:     pp.Filesize,
+		User:         pp.User.GetUsername(),
+		Steps:        pp.Steps,
+		CurrentStep:  pp.Status.CurrentStep,
+		PendingSteps: pp.Status.PendingSteps,
+		Outcome:      pp.Status.Outcome,
+		Retries:      pp.Failures,
+		LastFailure:  pp.LastFailure,
+		Finished:     pp.Finished,
+	}
+	if pp.ResourceID != nil {
+		u.ResourceID = storagespace.FormatResourceID(*pp.ResourceID)
+	}
+	return u
+}
+
+// Admin provides administrative access to the uploads in postprocessing.
+// Changes are not applied directly but published as events which are handled by the postprocessing service.
+type Admin struct {
+	store store.Store
+	pub   events.Publisher
+	c     config.Postprocessing
+}
+
+// NewAdmin returns a new Admin
+func NewAdmin(sto store.Store, pub events.Publisher, c config.Postprocessing) Admin {
+	return Admin{
+		store: sto,
+		pub:   pub,
+		c:     c,
+	}
+}
+
+// List returns all uploads in postprocessing. If step is not empty only uploads currently processing this step are returned.
+func (a Admin) List(step events.Postprocessingstep) ([]Upload, error) {
+	keys, err := a.store.List()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list uploads: %w", err)
+	}
+
+	uploads := make([]Upload, 0, len(keys))
+	for _, k := range keys {
+		recs, err := a.store.Read(k)
+		if err != nil || len(recs) != 1 {
+			continue
+		}
+
+		pp := postprocessing.New(a.c)
+		if err := json.Unmarshal(recs[0].Value, pp); err != nil {
+			continue
+		}
+
+		if step != "" && !pp.IsPending(step) {
+			continue
+		}
+		uploads = append(uploads, NewUpload(pp))
+	}
+
+	sort.Slice(uploads, func(i, j int) bool { return uploads[i].ID < uploads[j].ID })
+	return uploads, nil
+}
+
+// Get returns a single upload
+func (a Admin) Get(uploadID string) (Upload, error) {
+	pp, err := getPP(a.store, a.c, uploadID)
+	if err != nil {
+		return Upload{}, err
+	}
+	return NewUpload(pp), nil
+}
+
+// Retry restarts the pending steps of an upload
+func (a Admin) Retry(ctx context.Context, uploadID string) error {
+	if _, err := getPP(a.store, a.c, uploadID); err != nil {
+		return err
+	}
+
+	return events.Publish(ctx, a.pub, events.ResumePostprocessing{
+		UploadID:  uploadID,
+		Timestamp: utils.TSNow(),
+	})
+}
+
+// Skip marks a pending step as successfully finished. If step is empty the current step is skipped.
+func (a Admin) Skip(ctx context.Context, uploadID string, step events.Postprocessingstep) error {
+	pp, err := getPP(a.store, a.c, uploadID)
+	if err != nil {
+		return err
+	}
+
+	if pp.Status.CurrentStep == events.PPStepFinished {
+		return ErrFinished
+	}
+
+	if step == "" {
+		step = pp.Status.CurrentStep
+	}
+	if !pp.IsPending(step) {
+		return ErrInvalidStep
+	}
+
+	return events.Publish(ctx, a.pub, events.PostprocessingStepFinished{
+		UploadID:      pp.ID,
+		ExecutingUser: pp.User,
+		Filename:      pp.Filename,
+		FinishedStep:  step,
+		Outcome:       events.PPOutcomeContinue,
+		Timestamp:     utils.TSNow(),
+	})
+}
+
+// Abort finishes the postprocessing of an upload with the given outcome which must be either 'abort' or 'delete'
+func (a Admin) Abort(ctx context.Context, uploadID string, outcome events.PostprocessingOutcome) error {
+	switch outcome {
+	case "":
+		outcome = events.PPOutcomeAbort
+	case events.PPOutcomeAbort, events.PPOutcomeDelete:
+	default:
+		return ErrInvalidOutcome
+	}
+
+	pp, err := getPP(a.store, a.c, uploadID)
+	if err != nil {
+		return err
+	}
+
+	if pp.Status.CurrentStep == events.PPStepFinished {
+		return ErrFinished
+	}
+
+	return events.Publish(ctx, a.pub, events.PostprocessingStepFinished{
+		UploadID:      pp.ID,
+		ExecutingUser: pp.User,
+		Filename:      pp.Filename,
+		FinishedStep:  pp.Status.CurrentStep,
+		Outcome:       outcome,
+		Timestamp:     utils.TSNow(),
+	})
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/config"
+	"github.com/owncloud/ocis/v2/services/postprocessing/pkg/postprocessing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	microevents "go-micro.dev/v4/events"
+	"go-micro.dev/v4/store"
+)
+
+type publisher struct {
+	published []interface{}
+}
+
+func (p *publisher) Publish(_ string, ev interface{}, _ ...microevents.PublishOption) error {
+	p.published = append(p.published, ev)
+	return nil
+}
+
+func newTestAdmin(t *testing.T) (Admin, *publisher) {
+	sto := store.NewMemoryStore()
+	pub := &publisher{}
+
+	running := postprocessing.New(config.Postprocessing{})
+	running.ID = "running"
+	running.Stages = [][]events.Postprocessingstep{{"virusscan", "ocr"}}
+	running.Init(events.BytesReceived{})
+	require.NoError(t, storePP(sto, running))
+
+	done := postprocessing.New(config.Postprocessing{})
+	done.ID = "done"
+	done.Init(events.BytesReceived{})
+	require.NoError(t, storePP(sto, done))
+
+	return NewAdmin(sto, pub, config.Postprocessing{}), pub
+}
+
+func TestAdminList(t *testing.T) {
+	admin, _ := newTestAdmin(t)
+
+	uploads, err := admin.List("")
+	require.NoError(t, err)
+	require.Len(t, uploads, 2)
+	assert.Equal(t, "done", uploads[0].ID)
+	assert.Equal(t, "running", uploads[1].ID)
+	assert.Equal(t, []events.Postprocessingstep{"virusscan", "ocr"}, uploads[1].PendingSteps)
+
+	uploads, err = admin.List("ocr")
+	require.NoError(t, err)
+	require.Len(t, uploads, 1)
+	assert.Equal(t, "running", uploads[0].ID)
+
+	_, err = admin.Get("unknown")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestAdminSkip(t *testing.T) {
+	admin, pub := newTestAdmin(t)
+
+	require.NoError(t, admin.Skip(context.Background(), "running", "ocr"))
+	require.Len(t, pub.published, 1)
+	ev := pub.published[0].(events.PostprocessingStepFinished)
+	assert.Equal(t, events.Postprocessingstep("ocr"), ev.FinishedStep)
+	assert.Equal(t, events.PPOutcomeContinue, ev.Outcome)
+
+	assert.ErrorIs(t, admin.Skip(context.Background(), "running", "policies"), ErrInvalidStep)
+	assert.ErrorIs(t, admin.Skip(context.Background(), "done", ""), ErrFinished)
+}
+
+func TestAdminAbort(t *testing.T) {
+	admin, pub := newTestAdmin(t)
+
+	assert.ErrorIs(t, admin.Abort(context.Background(), "running", events.PPOutcomeContinue), ErrInvalidOutcome)
+	require.NoError(t, admin.Abort(context.Background(), "running", events.PPOutcomeDelete))
+	require.Len(t, pub.published, 1)
+	assert.Equal(t, events.PPOutcomeDelete, pub.published[0].(events.PostprocessingStepFinished).Outcome)
+
+	require.NoError(t, admin.Retry(context.Background(), "running"))
+	assert.Equal(t, "running", pub.published[1].(events.ResumePostprocessing).UploadID)
+}
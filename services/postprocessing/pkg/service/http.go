@@ -0,0 +1,156 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/go-chi/chi/v5"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/ocis-pkg/roles"
+	settings "github.com/owncloud/ocis/v2/services/settings/pkg/service/v0"
+)
+
+// AdminHandler serves the admin API to inspect and manage uploads in postprocessing
+type AdminHandler struct {
+	admin Admin
+	mux   *chi.Mux
+	rm    *roles.Manager
+	log   log.Logger
+}
+
+// NewAdminHandler registers the admin API on the given mux
+func NewAdminHandler(admin Admin, mux *chi.Mux, rm *roles.Manager, root string, logger log.Logger) *AdminHandler {
+	h := &AdminHandler{
+		admin: admin,
+		mux:   mux,
+		rm:    rm,
+		log:   logger,
+	}
+
+	mux.Route(path.Join(root, "/api/v0/postprocessing/uploads"), func(r chi.Router) {
+		r.Use(h.requireAdmin)
+		r.Get("/", h.HandleList)
+		r.Get("/{uploadid}", h.HandleGet)
+		r.Post("/{uploadid}/retry", h.HandleRetry)
+		r.Post("/{uploadid}/skip", h.HandleSkip)
+		r.Post("/{uploadid}/abort", h.HandleAbort)
+	})
+
+	return h
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *AdminHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// HandleList lists all uploads in postprocessing. The optional query parameter 'step' filters by pending step.
+func (h *AdminHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	uploads, err := h.admin.List(events.Postprocessingstep(r.URL.Query().Get("step")))
+	if err != nil {
+		h.log.Error().Err(err).Msg("cannot list uploads")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSON(w, uploads)
+}
+
+// HandleGet returns a single upload
+func (h *AdminHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
+	upload, err := h.admin.Get(chi.URLParam(r, "uploadid"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, upload)
+}
+
+// HandleRetry restarts the pending steps of an upload
+func (h *AdminHandler) HandleRetry(w http.ResponseWriter, r *http.Request) {
+	if err := h.admin.Retry(r.Context(), chi.URLParam(r, "uploadid")); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// HandleSkip marks a pending step as finished. The optional query parameter 'step' defaults to the current step.
+func (h *AdminHandler) HandleSkip(w http.ResponseWriter, r *http.Request) {
+	step := events.Postprocessingstep(r.URL.Query().Get("step"))
+	if err := h.admin.Skip(r.Context(), chi.URLParam(r, "uploadid"), step); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// HandleAbort finishes the postprocessing. The optional query parameter 'outcome' can be 'abort' (default) or 'delete'.
+func (h *AdminHandler) HandleAbort(w http.ResponseWriter, r *http.Request) {
+	outcome := events.PostprocessingOutcome(r.URL.Query().Get("outcome"))
+	if err := h.admin.Abort(r.Context(), chi.URLParam(r, "uploadid"), outcome); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *AdminHandler) writeJSON(w http.ResponseWriter, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		h.log.Error().Err(err).Msg("cannot marshal response")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
+
+func (h *AdminHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, ErrFinished):
+		w.WriteHeader(http.StatusConflict)
+	case errors.Is(err, ErrInvalidStep), errors.Is(err, ErrInvalidOutcome):
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		h.log.Error().Err(err).Msg("postprocessing admin request failed")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_, _ = w.Write([]byte(err.Error()))
+}
+
+// requireAdmin allows only requests of users with the account management permission
+func (h *AdminHandler) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, ok := revactx.ContextGetUser(r.Context())
+		if !ok || u.GetId().GetOpaqueId() == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		roleIDs, ok := roles.ReadRoleIDsFromContext(r.Context())
+		if !ok {
+			var err error
+			roleIDs, err = h.rm.FindRoleIDsForUser(r.Context(), u.GetId().GetOpaqueId())
+			if err != nil {
+				h.log.Error().Err(err).Str("userid", u.GetId().GetOpaqueId()).Msg("failed to get roles for user")
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		if h.rm.FindPermissionByID(r.Context(), roleIDs, settings.AccountManagementPermissionID) == nil {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -23,7 +23,7 @@ type PostprocessingService struct {
 	log    log.Logger
 	events <-chan events.Event
 	pub    events.Publisher
-	steps  []events.Postprocessingstep
+	steps  postprocessing.Pipeline
 	store  store.Store
 	c      config.Postprocessing
 	tp     trace.TracerProvider
@@ -56,7 +56,7 @@ func NewPostprocessingService(ctx context.Context, stream events.Stream, logger
 		log:    logger,
 		events: evs,
 		pub:    stream,
-		steps:  getSteps(c),
+		steps:  postprocessing.NewPipeline(c),
 		store:  sto,
 		c:      c,
 		tp:     tp,
@@ -83,7 +83,7 @@ func (pps *PostprocessingService) Run() error {
 
 func (pps *PostprocessingService) processEvent(e events.Event) error {
 	var (
-		next interface{}
+		next []interface{}
 		pp   *postprocessing.Postprocessing
 		err  error
 	)
@@ -94,6 +94,11 @@ func (pps *PostprocessingService) processEvent(e events.Event) error {
 
 	switch ev := e.Event.(type) {
 	case events.BytesReceived:
+		stages := pps.steps.Resolve(postprocessing.File{
+			Filename: ev.Filename,
+			Filesize: ev.Filesize,
+			SpaceID:  ev.ResourceID.GetSpaceId(),
+		})
 		pp = &postprocessing.Postprocessing{
 			ID:          ev.UploadID,
 			URL:         ev.URL,
@@ -101,7 +106,8 @@ func (pps *PostprocessingService) processEvent(e events.Event) error {
 			Filename:    ev.Filename,
 			Filesize:    ev.Filesize,
 			ResourceID:  ev.ResourceID,
-			Steps:       pps.steps,
+			Steps:       flatten(stages),
+			Stages:      stages,
 			InitiatorID: e.InitiatorID,
 		}
 		next = pp.Init(ev)
@@ -178,8 +184,8 @@ func (pps *PostprocessingService) processEvent(e events.Event) error {
 		}
 	}
 
-	if next != nil {
-		if err := events.Publish(ctx, pps.pub, next); err != nil {
+	for _, n := range next {
+		if err := events.Publish(ctx, pps.pub, n); err != nil {
 			pps.log.Error().Err(err).Msg("unable to publish event")
 			return fmt.Errorf("%w: unable to publish event", ErrFatal) // we can't publish -> we are screwed
 		}
@@ -188,6 +194,10 @@ func (pps *PostprocessingService) processEvent(e events.Event) error {
 }
 
 func (pps *PostprocessingService) getPP(sto store.Store, uploadID string) (*postprocessing.Postprocessing, error) {
+	return getPP(sto, pps.c, uploadID)
+}
+
+func getPP(sto store.Store, c config.Postprocessing, uploadID string) (*postprocessing.Postprocessing, error) {
 	recs, err := sto.Read(uploadID)
 	if err != nil {
 		if err == store.ErrNotFound {
@@ -204,7 +214,7 @@ func (pps *PostprocessingService) getPP(sto store.Store, uploadID string) (*post
 		return nil, fmt.Errorf("expected only one result for '%s', got %d", uploadID, len(recs))
 	}
 
-	pp := postprocessing.New(pps.c)
+	pp := postprocessing.New(c)
 	err = json.Unmarshal(recs[0].Value, pp)
 	if err != nil {
 		return nil, err
@@ -213,15 +223,11 @@ func (pps *PostprocessingService) getPP(sto store.Store, uploadID string) (*post
 	return pp, nil
 }
 
-func getSteps(c config.Postprocessing) []events.Postprocessingstep {
-	// NOTE: improved version only allows configuring order of postprocessing steps
-	// But we aim for a system where postprocessing steps can be configured per space, ideally by the spaceadmin itself
-	// We need to iterate over configuring PP service when we see fit
-	steps := make([]events.Postprocessingstep, 0, len(c.Steps))
-	for _, s := range c.Steps {
-		steps = append(steps, events.Postprocessingstep(s))
+func flatten(stages [][]events.Postprocessingstep) []events.Postprocessingstep {
+	var steps []events.Postprocessingstep
+	for _, stage := range stages {
+		steps = append(steps, stage...)
 	}
-
 	return steps
 }
 
@@ -272,7 +278,12 @@ func (pps *PostprocessingService) resumePP(ctx context.Context, uploadID string)
 		return nil
 	}
 
-	return events.Publish(ctx, pps.pub, pp.CurrentStep())
+	for _, ev := range pp.CurrentStep() {
+		if err := events.Publish(ctx, pps.pub, ev); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (pps *PostprocessingService) findUploadsByStep(step events.Postprocessingstep) []string {
@@ -302,7 +313,7 @@ func (pps *PostprocessingService) findUploadsByStep(step events.Postprocessingst
 			continue
 		}
 
-		if pp.Status.CurrentStep == step {
+		if pp.IsPending(step) {
 			ids = append(ids, pp.ID)
 		}
 	}
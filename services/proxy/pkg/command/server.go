@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path"
 	"time"
 
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
@@ -19,9 +20,11 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
+	"github.com/owncloud/ocis/v2/ocis-pkg/config/defaults"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	pkgmiddleware "github.com/owncloud/ocis/v2/ocis-pkg/middleware"
 	"github.com/owncloud/ocis/v2/ocis-pkg/oidc"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quotapolicy"
 	"github.com/owncloud/ocis/v2/ocis-pkg/registry"
 	"github.com/owncloud/ocis/v2/ocis-pkg/service/grpc"
 	"github.com/owncloud/ocis/v2/ocis-pkg/tracing"
@@ -29,11 +32,13 @@ import (
 	policiessvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/policies/v0"
 	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
 	"github.com/owncloud/ocis/v2/services/proxy/pkg/config"
+	proxydefaults "github.com/owncloud/ocis/v2/services/proxy/pkg/config/defaults"
 	"github.com/owncloud/ocis/v2/services/proxy/pkg/config/parser"
 	"github.com/owncloud/ocis/v2/services/proxy/pkg/logging"
 	"github.com/owncloud/ocis/v2/services/proxy/pkg/metrics"
 	"github.com/owncloud/ocis/v2/services/proxy/pkg/middleware"
 	"github.com/owncloud/ocis/v2/services/proxy/pkg/proxy"
+	"github.com/owncloud/ocis/v2/services/proxy/pkg/reload"
 	"github.com/owncloud/ocis/v2/services/proxy/pkg/router"
 	"github.com/owncloud/ocis/v2/services/proxy/pkg/server/debug"
 	proxyHTTP "github.com/owncloud/ocis/v2/services/proxy/pkg/server/http"
@@ -131,6 +136,7 @@ func Server(cfg *config.Config) *cli.Command {
 			rp, err := proxy.NewMultiHostReverseProxy(
 				proxy.Logger(logger),
 				proxy.Config(cfg),
+				proxy.Metrics(m),
 			)
 
 			lh := staticroutes.StaticRouteHandler{
@@ -146,8 +152,32 @@ func Server(cfg *config.Config) *cli.Command {
 				return fmt.Errorf("failed to initialize reverse proxy: %w", err)
 			}
 
+			healthCheckClient := router.HealthCheckClient(&http.Client{Transport: rp.Transport})
+			routes := router.NewReloadable(router.New(cfg.PolicySelector, cfg.Policies, logger, healthCheckClient))
+			cspConfig, err := middleware.LoadCSPConfig(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to load CSP configuration: %w", err)
+			}
+			securityHeaders, err := middleware.NewSecurityHeaders(cspConfig)
+			if err != nil {
+				return fmt.Errorf("invalid CSP configuration: %w", err)
+			}
+
+			files := []string{path.Join(defaults.BaseConfigPath(), cfg.Service.Name+".yaml")}
+			if cfg.CSPConfigFileLocation != "" {
+				files = append(files, cfg.CSPConfigFileLocation)
+			}
+			watcher := reload.NewWatcher(files, cfg.ConfigReloadInterval, reloadPolicies(cfg, logger, routes, securityHeaders, healthCheckClient), logger)
+			if cfg.ConfigReloadInterval > 0 {
+				gr.Add(func() error {
+					return watcher.Run(ctx)
+				}, func(err error) {
+					cancel()
+				})
+			}
+
 			{
-				middlewares := loadMiddlewares(ctx, logger, cfg, userInfoCache, signingKeyStore, traceProvider, *m)
+				middlewares := loadMiddlewares(ctx, logger, cfg, userInfoCache, signingKeyStore, traceProvider, *m, routes, securityHeaders)
 				server, err := proxyHTTP.Server(
 					proxyHTTP.Handler(lh.Handler()),
 					proxyHTTP.Logger(logger),
@@ -183,6 +213,7 @@ func Server(cfg *config.Config) *cli.Command {
 					debug.Logger(logger),
 					debug.Context(ctx),
 					debug.Config(cfg),
+					debug.Reload(watcher),
 				)
 				if err != nil {
 					logger.Error().Err(err).Str("server", "debug").Msg("Failed to initialize server")
@@ -200,7 +231,37 @@ func Server(cfg *config.Config) *cli.Command {
 	}
 }
 
-func loadMiddlewares(ctx context.Context, logger log.Logger, cfg *config.Config, userInfoCache, signingKeyStore microstore.Store, traceProvider trace.TracerProvider, metrics metrics.Metrics) alice.Chain {
+// reloadPolicies returns a function which loads the routing policies and the CSP configuration again. The new
+// configuration is only applied when it is valid.
+func reloadPolicies(cfg *config.Config, logger log.Logger, routes *router.Reloadable, securityHeaders *middleware.SecurityHeaders, routerOpts ...router.Option) func() error {
+	return func() error {
+		newCfg := proxydefaults.DefaultConfig()
+		newCfg.Commons = cfg.Commons
+		if err := parser.ParseConfig(newCfg); err != nil {
+			return err
+		}
+
+		if err := router.CheckSelectedPolicies(newCfg.PolicySelector, newCfg.Policies); err != nil {
+			return err
+		}
+		cspConfig, err := middleware.LoadCSPConfig(newCfg)
+		if err != nil {
+			return fmt.Errorf("failed to load CSP configuration: %w", err)
+		}
+		r, err := router.Load(newCfg.PolicySelector, newCfg.Policies, logger, routerOpts...)
+		if err != nil {
+			return err
+		}
+		if err := securityHeaders.Update(cspConfig); err != nil {
+			r.Close()
+			return fmt.Errorf("invalid CSP configuration: %w", err)
+		}
+		routes.Swap(r)
+		return nil
+	}
+}
+
+func loadMiddlewares(ctx context.Context, logger log.Logger, cfg *config.Config, userInfoCache, signingKeyStore microstore.Store, traceProvider trace.TracerProvider, metrics metrics.Metrics, routes *router.Reloadable, securityHeaders *middleware.SecurityHeaders) alice.Chain {
 	rolesClient := settingssvc.NewRoleService("com.owncloud.api.settings", cfg.GrpcClient)
 	policiesProviderClient := policiessvc.NewPoliciesProviderService("com.owncloud.api.policies", cfg.GrpcClient)
 	gatewaySelector, err := pool.GatewaySelector(
@@ -300,11 +361,25 @@ func loadMiddlewares(ctx context.Context, logger log.Logger, cfg *config.Config,
 		Now:                time.Now,
 	})
 
-	cspConfig, err := middleware.LoadCSPConfig(cfg)
-	if err != nil {
-		logger.Fatal().Err(err).Msg("Failed to load CSP configuration.")
+	var rateLimitStore microstore.Store
+	if cfg.RateLimit.Enabled {
+		rateLimitStore = store.Create(
+			store.Store(cfg.RateLimit.Store.Store),
+			microstore.Nodes(cfg.RateLimit.Store.Nodes...),
+			microstore.Database("proxy"),
+			microstore.Table("rate-limits"),
+			store.Authentication(cfg.RateLimit.Store.AuthUsername, cfg.RateLimit.Store.AuthPassword),
+		)
 	}
 
+	quotaPolicies := quotapolicy.NewManager(store.Create(
+		store.Store(cfg.QuotaPolicies.Store),
+		microstore.Nodes(cfg.QuotaPolicies.Nodes...),
+		microstore.Database(quotapolicy.Database),
+		microstore.Table(quotapolicy.Table),
+		store.Authentication(cfg.QuotaPolicies.AuthUsername, cfg.QuotaPolicies.AuthPassword),
+	))
+
 	return alice.New(
 		// first make sure we log all requests and redirect to https if necessary
 		otelhttp.NewMiddleware("proxy",
@@ -316,12 +391,18 @@ func loadMiddlewares(ctx context.Context, logger log.Logger, cfg *config.Config,
 		middleware.Tracer(traceProvider),
 		pkgmiddleware.TraceContext,
 		middleware.Instrumenter(metrics),
+		middleware.PeerAddr,
 		chimiddleware.RealIP,
 		chimiddleware.RequestID,
 		middleware.AccessLog(logger),
 		middleware.HTTPSRedirect,
-		middleware.Security(cspConfig),
-		router.Middleware(cfg.PolicySelector, cfg.Policies, logger),
+		securityHeaders.Handler,
+		router.ReloadableMiddleware(routes),
+		middleware.IPRateLimit(
+			middleware.Logger(logger),
+			middleware.RateLimitConfig(cfg.RateLimit),
+			middleware.RateLimitStore(rateLimitStore),
+		),
 		middleware.Authentication(
 			authenticators,
 			middleware.CredentialsByUserAgent(cfg.AuthMiddleware.CredentialsByUserAgent),
@@ -339,9 +420,14 @@ func loadMiddlewares(ctx context.Context, logger log.Logger, cfg *config.Config,
 			middleware.UserCS3Claim(cfg.UserCS3Claim),
 			middleware.AutoprovisionAccounts(cfg.AutoprovisionAccounts),
 		),
+		middleware.UserRateLimit(
+			middleware.Logger(logger),
+			middleware.RateLimitConfig(cfg.RateLimit),
+			middleware.RateLimitStore(rateLimitStore),
+		),
 		middleware.SelectorCookie(
 			middleware.Logger(logger),
-			middleware.PolicySelectorConfig(*cfg.PolicySelector),
+			middleware.Router(routes),
 		),
 		middleware.Policies(
 			cfg.PoliciesMiddleware.Query,
@@ -354,6 +440,7 @@ func loadMiddlewares(ctx context.Context, logger log.Logger, cfg *config.Config,
 			middleware.Logger(logger),
 			middleware.WithRevaGatewaySelector(gatewaySelector),
 			middleware.RoleQuotas(cfg.RoleQuotas),
+			middleware.QuotaPolicies(quotaPolicies),
 		),
 	)
 }
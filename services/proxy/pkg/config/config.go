@@ -32,6 +32,8 @@ type Config struct {
 	RoleAssignment        RoleAssignment      `yaml:"role_assignment"`
 	PolicySelector        *PolicySelector     `yaml:"policy_selector"`
 	PreSignedURL          PreSignedURL        `yaml:"pre_signed_url"`
+	RateLimit             RateLimit           `yaml:"rate_limit"`
+	QuotaPolicies         QuotaPolicies       `yaml:"quota_policies"`
 	AccountBackend        string              `yaml:"account_backend" env:"PROXY_ACCOUNT_BACKEND_TYPE" desc:"Account backend the PROXY service should use. Currently only 'cs3' is possible here." introductionVersion:"pre5.0"`
 	UserOIDCClaim         string              `yaml:"user_oidc_claim" env:"PROXY_USER_OIDC_CLAIM" desc:"The name of an OpenID Connect claim that is used for resolving users with the account backend. The value of the claim must hold a per user unique, stable and non re-assignable identifier. The availability of claims depends on your Identity Provider. There are common claims available for most Identity providers like 'email' or 'preferred_username' but you can also add your own claim." introductionVersion:"pre5.0"`
 	UserCS3Claim          string              `yaml:"user_cs3_claim" env:"PROXY_USER_CS3_CLAIM" desc:"The name of a CS3 user attribute (claim) that should be mapped to the 'user_oidc_claim'. Supported values are 'username', 'mail' and 'userid'." introductionVersion:"pre5.0"`
@@ -44,6 +46,7 @@ type Config struct {
 	AuthMiddleware        AuthMiddleware      `yaml:"auth_middleware"`
 	PoliciesMiddleware    PoliciesMiddleware  `yaml:"policies_middleware"`
 	CSPConfigFileLocation string              `yaml:"csp_config_file_location" env:"PROXY_CSP_CONFIG_FILE_LOCATION" desc:"The location of the CSP configuration file." introductionVersion:"6.0.0"`
+	ConfigReloadInterval  time.Duration       `yaml:"config_reload_interval" env:"PROXY_CONFIG_RELOAD_INTERVAL" desc:"The interval in which the proxy checks its configuration file and the CSP configuration file for changes and reloads the routing policies and the CSP. Set to '0' to disable reloading. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
 
 	Context context.Context `yaml:"-" json:"-"`
 }
@@ -62,10 +65,54 @@ type Route struct {
 	Endpoint string `yaml:"endpoint,omitempty"`
 	// Backend is a static URL to forward the request to
 	Backend string `yaml:"backend,omitempty"`
+	// Backends are static URLs to forward the requests to according to their weight, used instead of Backend
+	Backends []WeightedBackend `yaml:"backends,omitempty"`
+	// HealthCheck optionally checks the Backends actively and stops forwarding requests to unhealthy ones
+	HealthCheck *HealthCheck `yaml:"health_check,omitempty"`
+	// OutlierDetection optionally ejects Backends which fail to respond to requests
+	OutlierDetection *OutlierDetection `yaml:"outlier_detection,omitempty"`
 	// Service name to look up in the registry
 	Service     string `yaml:"service,omitempty"`
 	ApacheVHost bool   `yaml:"apache_vhost,omitempty"`
 	Unprotected bool   `yaml:"unprotected,omitempty"`
+	// RateLimit optionally limits the requests per user to this route
+	RateLimit *RouteRateLimit `yaml:"rate_limit,omitempty"`
+}
+
+// RouteRateLimit defines the token bucket of a route. Requests of unauthenticated users are limited per IP.
+type RouteRateLimit struct {
+	// Rate is the number of requests per second
+	Rate float64 `yaml:"rate"`
+	// Burst is the number of requests that can be made at once
+	Burst int `yaml:"burst"`
+}
+
+// WeightedBackend is a backend of a route which receives a share of the requests according to its weight.
+type WeightedBackend struct {
+	URL string `yaml:"url"`
+	// Weight of the backend, defaults to 1
+	Weight int `yaml:"weight,omitempty"`
+}
+
+// HealthCheck defines the active health checks of the backends of a route.
+type HealthCheck struct {
+	// Path is requested on every backend, it is healthy if it responds with a status code below 400
+	Path     string        `yaml:"path"`
+	Interval time.Duration `yaml:"interval,omitempty"`
+	Timeout  time.Duration `yaml:"timeout,omitempty"`
+	// UnhealthyThreshold is the number of failed checks after which a backend is considered to be unhealthy
+	UnhealthyThreshold int `yaml:"unhealthy_threshold,omitempty"`
+	// HealthyThreshold is the number of successful checks after which an unhealthy backend is considered to be healthy again
+	HealthyThreshold int `yaml:"healthy_threshold,omitempty"`
+}
+
+// OutlierDetection defines the passive failure detection of the backends of a route.
+type OutlierDetection struct {
+	// ConsecutiveFailures is the number of failed requests after which a backend is ejected. Requests fail when the backend
+	// is not reachable or responds with a 5xx status code.
+	ConsecutiveFailures int `yaml:"consecutive_failures,omitempty"`
+	// EjectionTime is the duration for which an ejected backend doesn't receive requests
+	EjectionTime time.Duration `yaml:"ejection_time,omitempty"`
 }
 
 // RouteType defines the type of route
@@ -166,6 +213,8 @@ type PolicySelector struct {
 	Static *StaticSelectorConf `yaml:"static"`
 	Claims *ClaimsSelectorConf `yaml:"claims"`
 	Regex  *RegexSelectorConf  `yaml:"regex"`
+	// Attributes selects the policy by group membership, user type and claims of the user
+	Attributes *AttributesSelectorConf `yaml:"attributes"`
 }
 
 // StaticSelectorConf is the config for the static-policy-selector
@@ -180,6 +229,33 @@ type PreSignedURL struct {
 	SigningKeys        *SigningKeys `yaml:"signing_keys"`
 }
 
+// QuotaPolicies configures the store of the quota policies managed by the settings service
+type QuotaPolicies struct {
+	Store        string   `yaml:"store" env:"OCIS_PERSISTENT_STORE;PROXY_QUOTA_POLICIES_STORE" desc:"The type of the store for the quota policies. Supported values are: 'memory', 'redis-sentinel' and 'nats-js-kv'. The store must be shared with the settings service. See the text description for details." introductionVersion:"6.0.0"`
+	Nodes        []string `yaml:"nodes" env:"OCIS_PERSISTENT_STORE_NODES;PROXY_QUOTA_POLICIES_STORE_NODES" desc:"A list of nodes to access the configured store. This has no effect when 'memory' store is configured. Note that the behaviour how nodes are used is dependent on the library of the configured store. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+	AuthUsername string   `yaml:"username" env:"OCIS_PERSISTENT_STORE_AUTH_USERNAME;PROXY_QUOTA_POLICIES_STORE_AUTH_USERNAME" desc:"The username to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"6.0.0"`
+	AuthPassword string   `yaml:"password" env:"OCIS_PERSISTENT_STORE_AUTH_PASSWORD;PROXY_QUOTA_POLICIES_STORE_AUTH_PASSWORD" desc:"The password to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"6.0.0"`
+}
+
+// RateLimit is the config for the rate limiting middleware
+type RateLimit struct {
+	Enabled        bool            `yaml:"enabled" env:"PROXY_RATE_LIMIT_ENABLED" desc:"Enables rate limiting of requests. See the text description for more details." introductionVersion:"6.0.0"`
+	UserRate       float64         `yaml:"user_rate" env:"PROXY_RATE_LIMIT_USER_RATE" desc:"The number of requests per second an authenticated user can make. Set to '0' to not limit requests per user." introductionVersion:"6.0.0"`
+	UserBurst      int             `yaml:"user_burst" env:"PROXY_RATE_LIMIT_USER_BURST" desc:"The number of requests an authenticated user can make at once before being limited to PROXY_RATE_LIMIT_USER_RATE." introductionVersion:"6.0.0"`
+	IPRate         float64         `yaml:"ip_rate" env:"PROXY_RATE_LIMIT_IP_RATE" desc:"The number of requests per second a client IP address can make. Set to '0' to not limit requests per IP address." introductionVersion:"6.0.0"`
+	IPBurst        int             `yaml:"ip_burst" env:"PROXY_RATE_LIMIT_IP_BURST" desc:"The number of requests a client IP address can make at once before being limited to PROXY_RATE_LIMIT_IP_RATE." introductionVersion:"6.0.0"`
+	TrustedProxies []string        `yaml:"trusted_proxies" env:"PROXY_RATE_LIMIT_TRUSTED_PROXIES" desc:"A list of IP addresses or CIDR ranges of reverse proxies in front of the proxy. The client IP address is only taken from the 'X-Forwarded-For' and 'X-Real-IP' headers of requests from these addresses. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+	Store          *RateLimitStore `yaml:"store"`
+}
+
+// RateLimitStore is the store configuration for the rate limits.
+type RateLimitStore struct {
+	Store        string   `yaml:"store" env:"OCIS_CACHE_STORE;PROXY_RATE_LIMIT_STORE" desc:"The type of the store for the rate limits. Supported values are: 'memory', 'redis-sentinel' and 'nats-js-kv'. Use a shared store to share the rate limits between proxy instances. See the text description for details." introductionVersion:"6.0.0"`
+	Nodes        []string `yaml:"addresses" env:"OCIS_CACHE_STORE_NODES;PROXY_RATE_LIMIT_STORE_NODES" desc:"A list of nodes to access the configured store. This has no effect when 'memory' store is configured. Note that the behaviour how nodes are used is dependent on the library of the configured store. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+	AuthUsername string   `yaml:"username" env:"OCIS_CACHE_AUTH_USERNAME;PROXY_RATE_LIMIT_STORE_AUTH_USERNAME" desc:"The username to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"6.0.0"`
+	AuthPassword string   `yaml:"password" env:"OCIS_CACHE_AUTH_PASSWORD;PROXY_RATE_LIMIT_STORE_AUTH_PASSWORD" desc:"The password to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"6.0.0"`
+}
+
 // SigningKeys is a store configuration.
 type SigningKeys struct {
 	Store              string        `yaml:"store" env:"OCIS_CACHE_STORE;PROXY_PRESIGNEDURL_SIGNING_KEYS_STORE" desc:"The type of the signing key store. Supported values are: 'redis-sentinel', 'nats-js-kv' and 'ocisstoreservice' (deprecated). See the text description for details." introductionVersion:"5.0"`
@@ -197,6 +273,38 @@ type ClaimsSelectorConf struct {
 	SelectorCookieName    string `yaml:"selector_cookie_name"`
 }
 
+// AttributesSelectorConf is the config for the attributes-selector
+type AttributesSelectorConf struct {
+	DefaultPolicy         string              `yaml:"default_policy"`
+	Rules                 []AttributeRuleConf `yaml:"rules"`
+	UnauthenticatedPolicy string              `yaml:"unauthenticated_policy"`
+	SelectorCookieName    string              `yaml:"selector_cookie_name"`
+}
+
+// AttributeRuleConf selects a policy when the condition matches the user. Rules are evaluated by priority.
+type AttributeRuleConf struct {
+	Priority  int                `yaml:"priority"`
+	Condition AttributeCondition `yaml:"condition"`
+	Policy    string             `yaml:"policy"`
+}
+
+// AttributeCondition is a condition on the attributes of a user. Exactly one of the fields has to be set,
+// conditions can be combined with All, Any and Not.
+type AttributeCondition struct {
+	All []AttributeCondition `yaml:"all,omitempty"`
+	Any []AttributeCondition `yaml:"any,omitempty"`
+	Not *AttributeCondition  `yaml:"not,omitempty"`
+	// GroupID matches users which are member of the group with this id. The group memberships are taken from
+	// the access token, they are not available when OCIS_SKIP_USER_GROUPS_IN_TOKEN is set.
+	GroupID string `yaml:"group_id,omitempty"`
+	// UserType matches users of this type, e.g. 'primary', 'guest' or 'federated'
+	UserType string `yaml:"user_type,omitempty"`
+	// Claim matches users whose OIDC claim matches the regular expression in Match. Nested claims are separated by '.'.
+	Claim string `yaml:"claim,omitempty"`
+	// Match is the regular expression for the claim. If it is empty, the claim only has to be set.
+	Match string `yaml:"match,omitempty"`
+}
+
 // RegexSelectorConf is the config for the regex-selector
 type RegexSelectorConf struct {
 	DefaultPolicy         string          `yaml:"default_policy"`
@@ -80,6 +80,20 @@ func DefaultConfig() *config.Config {
 				DisablePersistence: true,
 			},
 		},
+		RateLimit: config.RateLimit{
+			UserRate:  20,
+			UserBurst: 100,
+			IPRate:    50,
+			IPBurst:   200,
+			Store: &config.RateLimitStore{
+				Store: "memory",
+				Nodes: []string{"127.0.0.1:9233"},
+			},
+		},
+		QuotaPolicies: config.QuotaPolicies{
+			Store: "nats-js-kv",
+			Nodes: []string{"127.0.0.1:9233"},
+		},
 		AccountBackend:        "cs3",
 		UserOIDCClaim:         "preferred_username",
 		UserCS3Claim:          "username",
@@ -252,6 +266,10 @@ func DefaultPolicies() []config.Policy {
 					Endpoint: "/api/v0/settings",
 					Service:  "com.owncloud.web.settings",
 				},
+				{
+					Endpoint: "/api/v0/postprocessing",
+					Service:  "com.owncloud.web.postprocessing",
+				},
 			},
 		},
 	}
@@ -3,6 +3,7 @@ package parser
 import (
 	"errors"
 	"fmt"
+	"net"
 
 	ociscfg "github.com/owncloud/ocis/v2/ocis-pkg/config"
 	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
@@ -53,6 +54,10 @@ func Validate(cfg *config.Config) error {
 		)
 	}
 
+	if err := validateRateLimits(cfg); err != nil {
+		return err
+	}
+
 	if cfg.ServiceAccount.ServiceAccountID == "" {
 		return shared.MissingServiceAccountID(cfg.Service.Name)
 	}
@@ -62,3 +67,26 @@ func Validate(cfg *config.Config) error {
 
 	return nil
 }
+
+func validateRateLimits(cfg *config.Config) error {
+	rl := cfg.RateLimit
+	if !rl.Enabled {
+		return nil
+	}
+	if rl.UserRate < 0 || rl.IPRate < 0 || rl.UserBurst < 0 || rl.IPBurst < 0 {
+		return fmt.Errorf("the rate limits of service %s must not be negative", cfg.Service.Name)
+	}
+	for _, p := range rl.TrustedProxies {
+		if _, _, err := net.ParseCIDR(p); err != nil && net.ParseIP(p) == nil {
+			return fmt.Errorf("the trusted proxy '%s' of service %s is neither an IP address nor a CIDR range", p, cfg.Service.Name)
+		}
+	}
+	for _, policy := range append(cfg.Policies, cfg.AdditionalPolicies...) {
+		for _, route := range policy.Routes {
+			if route.RateLimit != nil && (route.RateLimit.Rate < 0 || route.RateLimit.Burst < 0) {
+				return fmt.Errorf("the rate limit of route '%s' in policy '%s' must not be negative", route.Endpoint, policy.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -18,6 +18,12 @@ type Metrics struct {
 	Errors    *prometheus.CounterVec
 	Duration  *prometheus.HistogramVec
 	BuildInfo *prometheus.GaugeVec
+	// BackendRequests counts the requests forwarded to a backend by response status code
+	BackendRequests *prometheus.CounterVec
+	// BackendErrors counts the requests which could not be forwarded to a backend
+	BackendErrors *prometheus.CounterVec
+	// BackendEjections counts how often a backend was ejected by the outlier detection
+	BackendEjections *prometheus.CounterVec
 }
 
 // New initializes the available metrics.
@@ -47,11 +53,32 @@ func New() *Metrics {
 			Name:      "build_info",
 			Help:      "Build Information",
 		}, []string{"versions"}),
+		BackendRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "backend_requests_total",
+			Help:      "How many requests were forwarded to a backend",
+		}, []string{"backend", "code"}),
+		BackendErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "backend_errors_total",
+			Help:      "How many requests could not be forwarded to a backend",
+		}, []string{"backend"}),
+		BackendEjections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "backend_ejections_total",
+			Help:      "How often a backend was ejected because of failing requests",
+		}, []string{"backend"}),
 	}
 
 	_ = prometheus.Register(m.Requests)
 	_ = prometheus.Register(m.Errors)
 	_ = prometheus.Register(m.Duration)
 	_ = prometheus.Register(m.BuildInfo)
+	_ = prometheus.Register(m.BackendRequests)
+	_ = prometheus.Register(m.BackendErrors)
+	_ = prometheus.Register(m.BackendEjections)
 	return m
 }
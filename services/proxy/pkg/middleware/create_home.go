@@ -13,6 +13,7 @@ import (
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
 	"github.com/cs3org/reva/v2/pkg/utils"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quotapolicy"
 	"github.com/owncloud/ocis/v2/services/graph/pkg/errorcode"
 	"google.golang.org/grpc/metadata"
 )
@@ -28,6 +29,7 @@ func CreateHome(optionSetters ...Option) func(next http.Handler) http.Handler {
 			logger:              logger,
 			revaGatewaySelector: options.RevaGatewaySelector,
 			roleQuotas:          options.RoleQuotas,
+			quotaPolicies:       options.QuotaPolicies,
 		}
 	}
 }
@@ -37,6 +39,7 @@ type createHome struct {
 	logger              log.Logger
 	revaGatewaySelector pool.Selectable[gateway.GatewayAPIClient]
 	roleQuotas          map[string]uint64
+	quotaPolicies       *quotapolicy.Manager
 }
 
 func (m createHome) ServeHTTP(w http.ResponseWriter, req *http.Request) {
@@ -107,6 +110,15 @@ func (m createHome) getUserRoles(user *userv1beta1.User) ([]string, error) {
 
 func (m createHome) checkRoleQuotaLimit(roleIDs []string) (uint64, bool) {
 	id := roleIDs[0] // At the moment a user can only have one role.
+	if m.quotaPolicies != nil {
+		p, err := m.quotaPolicies.SpacePolicy(quotapolicy.SpaceTypePersonal, id, "")
+		switch {
+		case err != nil:
+			m.logger.Error().Err(err).Msg("could not read the quota policies, falling back to the role quotas")
+		case p != nil:
+			return p.Quota, true
+		}
+	}
 	quota, ok := m.roleQuotas[id]
 	return quota, ok
 }
@@ -8,9 +8,11 @@ import (
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/ocis-pkg/oidc"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quotapolicy"
 	policiessvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/policies/v0"
 	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
 	"github.com/owncloud/ocis/v2/services/proxy/pkg/config"
+	"github.com/owncloud/ocis/v2/services/proxy/pkg/router"
 	"github.com/owncloud/ocis/v2/services/proxy/pkg/user/backend"
 	"github.com/owncloud/ocis/v2/services/proxy/pkg/userroles"
 	"go-micro.dev/v4/store"
@@ -26,6 +28,8 @@ type Options struct {
 	Logger log.Logger
 	// PolicySelectorConfig for using the policy selector
 	PolicySelector config.PolicySelector
+	// Router provides the policy selector of the current routing policies, it takes precedence over the PolicySelector
+	Router *router.Reloadable
 	// HTTPClient to use for communication with the oidcAuth provider
 	HTTPClient *http.Client
 	// UserProvider backend to use for resolving User
@@ -56,6 +60,10 @@ type Options struct {
 	DefaultAccessTokenTTL time.Duration
 	// UserInfoCache sets the access token cache store
 	UserInfoCache store.Store
+	// RateLimitConfig to configure the rate limits
+	RateLimitConfig config.RateLimit
+	// RateLimitStore persists the state of the rate limits
+	RateLimitStore store.Store
 	// CredentialsByUserAgent sets the auth challenges on a per user-agent basis
 	CredentialsByUserAgent map[string]string
 	// AccessTokenVerifyMethod configures how access_tokens should be verified but the oidc_auth middleware.
@@ -66,6 +74,8 @@ type Options struct {
 	// RoleQuotas hold userid:quota mappings. These will be used when provisioning new users.
 	// The users will get as much quota as is set for their role.
 	RoleQuotas map[string]uint64
+	// QuotaPolicies are the quota policies managed by the settings service. They take precedence over the RoleQuotas.
+	QuotaPolicies *quotapolicy.Manager
 	// TraceProvider sets the tracing provider.
 	TraceProvider trace.TracerProvider
 	// SkipUserInfo prevents the oidc middleware from querying the userinfo endpoint and read any claims directly from the access token instead
@@ -97,6 +107,13 @@ func PolicySelectorConfig(cfg config.PolicySelector) Option {
 	}
 }
 
+// Router provides a function to set the Router option
+func Router(val *router.Reloadable) Option {
+	return func(o *Options) {
+		o.Router = val
+	}
+}
+
 // HTTPClient provides a function to set the http client config option.
 func HTTPClient(c *http.Client) Option {
 	return func(o *Options) {
@@ -195,6 +212,20 @@ func UserInfoCache(val store.Store) Option {
 	}
 }
 
+// RateLimitConfig provides a function to set the RateLimitConfig
+func RateLimitConfig(cfg config.RateLimit) Option {
+	return func(o *Options) {
+		o.RateLimitConfig = cfg
+	}
+}
+
+// RateLimitStore provides a function to set the RateLimitStore
+func RateLimitStore(val store.Store) Option {
+	return func(o *Options) {
+		o.RateLimitStore = val
+	}
+}
+
 // UserProvider sets the accounts user provider
 func UserProvider(up backend.UserBackend) Option {
 	return func(o *Options) {
@@ -223,6 +254,13 @@ func RoleQuotas(roleQuotas map[string]uint64) Option {
 	}
 }
 
+// QuotaPolicies sets the quota policies
+func QuotaPolicies(m *quotapolicy.Manager) Option {
+	return func(o *Options) {
+		o.QuotaPolicies = m
+	}
+}
+
 // TraceProvider sets the tracing provider.
 func TraceProvider(tp trace.TracerProvider) Option {
 	return func(o *Options) {
@@ -0,0 +1,276 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/graph/pkg/errorcode"
+	"github.com/owncloud/ocis/v2/services/proxy/pkg/router"
+	"go-micro.dev/v4/store"
+)
+
+// IPRateLimit provides a middleware which limits the requests per client IP address.
+// It is added before the authentication to also throttle clients with invalid credentials.
+func IPRateLimit(optionSetters ...Option) func(next http.Handler) http.Handler {
+	options := newOptions(optionSetters...)
+	limiter := newTokenBuckets(options.RateLimitStore)
+	proxies := newTrustedProxies(options.RateLimitConfig.TrustedProxies)
+
+	return func(next http.Handler) http.Handler {
+		if !options.RateLimitConfig.Enabled {
+			return next
+		}
+		return &rateLimit{
+			next:    next,
+			logger:  options.Logger,
+			limiter: limiter,
+			limits: func(r *http.Request) []limit {
+				if options.RateLimitConfig.IPRate <= 0 {
+					return nil
+				}
+				return []limit{{
+					key:   "ip/" + proxies.clientIP(r),
+					rate:  options.RateLimitConfig.IPRate,
+					burst: options.RateLimitConfig.IPBurst,
+				}}
+			},
+		}
+	}
+}
+
+// UserRateLimit provides a middleware which limits the requests per user and the requests per user to routes
+// with a rate limit. Requests to routes of unauthenticated users are limited per client IP address.
+func UserRateLimit(optionSetters ...Option) func(next http.Handler) http.Handler {
+	options := newOptions(optionSetters...)
+	limiter := newTokenBuckets(options.RateLimitStore)
+	proxies := newTrustedProxies(options.RateLimitConfig.TrustedProxies)
+
+	return func(next http.Handler) http.Handler {
+		if !options.RateLimitConfig.Enabled {
+			return next
+		}
+		return &rateLimit{
+			next:    next,
+			logger:  options.Logger,
+			limiter: limiter,
+			limits: func(r *http.Request) []limit {
+				var limits []limit
+
+				client := "ip/" + proxies.clientIP(r)
+				if u, ok := revactx.ContextGetUser(r.Context()); ok {
+					client = "user/" + u.GetId().GetOpaqueId()
+					if options.RateLimitConfig.UserRate > 0 {
+						limits = append(limits, limit{
+							key:   client,
+							rate:  options.RateLimitConfig.UserRate,
+							burst: options.RateLimitConfig.UserBurst,
+						})
+					}
+				}
+
+				ri := router.ContextRoutingInfo(r.Context())
+				if rl := ri.RateLimit(); rl != nil && rl.Rate > 0 {
+					limits = append(limits, limit{
+						key:   "route/" + ri.ID() + "/" + client,
+						rate:  rl.Rate,
+						burst: rl.Burst,
+					})
+				}
+				return limits
+			},
+		}
+	}
+}
+
+// limit is a token bucket which is refilled with `rate` tokens per second up to `burst` tokens
+type limit struct {
+	key   string
+	rate  float64
+	burst int
+}
+
+type rateLimit struct {
+	next    http.Handler
+	logger  log.Logger
+	limiter *tokenBuckets
+	limits  func(r *http.Request) []limit
+}
+
+func (m rateLimit) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	for _, l := range m.limits(r) {
+		retryAfter, err := m.limiter.take(l)
+		if err != nil {
+			// don't block clients because the store is not available
+			m.logger.Error().Err(err).Str("key", l.key).Msg("could not check rate limit")
+			continue
+		}
+		if retryAfter > 0 {
+			m.logger.Debug().Str("key", l.key).Dur("retry_after", retryAfter).Msg("request throttled")
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			errorcode.ActivityLimitReached.Render(w, r, http.StatusTooManyRequests, "too many requests")
+			return
+		}
+	}
+	m.next.ServeHTTP(w, r)
+}
+
+// bucket is the state of a token bucket as persisted in the store
+type bucket struct {
+	Tokens  float64   `json:"tokens"`
+	Updated time.Time `json:"updated"`
+}
+
+// bucketLocks is the number of locks the buckets are distributed over
+const bucketLocks = 256
+
+// tokenBuckets persists token buckets in a store, so that the limits are shared between proxy instances.
+// The buckets are updated without a distributed lock, concurrent requests to different instances might
+// exceed a limit slightly. Within an instance the buckets are locked by key, so that requests of different
+// clients don't wait for each others round-trips to the store.
+type tokenBuckets struct {
+	store store.Store
+	now   func() time.Time
+	locks [bucketLocks]sync.Mutex
+}
+
+func newTokenBuckets(s store.Store) *tokenBuckets {
+	return &tokenBuckets{
+		store: s,
+		now:   time.Now,
+	}
+}
+
+// take takes a token from the bucket. It returns the time until the next token is available if the bucket is empty.
+func (tb *tokenBuckets) take(l limit) (time.Duration, error) {
+	burst := float64(l.burst)
+	if burst < 1 {
+		burst = 1
+	}
+
+	mu := tb.lock(l.key)
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := tb.now()
+	b := bucket{Tokens: burst, Updated: now}
+	recs, err := tb.store.Read(l.key)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+	case err != nil:
+		return 0, err
+	case len(recs) > 0:
+		if err := json.Unmarshal(recs[0].Value, &b); err != nil {
+			return 0, err
+		}
+		b.Tokens = math.Min(burst, b.Tokens+now.Sub(b.Updated).Seconds()*l.rate)
+		b.Updated = now
+	}
+
+	if b.Tokens < 1 {
+		return time.Duration((1 - b.Tokens) / l.rate * float64(time.Second)), nil
+	}
+	b.Tokens--
+
+	v, err := json.Marshal(b)
+	if err != nil {
+		return 0, err
+	}
+	// the bucket is full again after this time and doesn't need to be stored any longer
+	expiry := time.Duration((burst - b.Tokens) / l.rate * float64(time.Second))
+	return 0, tb.store.Write(&store.Record{Key: l.key, Value: v, Expiry: expiry})
+}
+
+// lock returns the lock of the bucket with the given key
+func (tb *tokenBuckets) lock(key string) *sync.Mutex {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return &tb.locks[h.Sum32()%bucketLocks]
+}
+
+type peerAddrKey struct{}
+
+// PeerAddr keeps the address of the peer of a request in the context. It has to be added before the RealIP
+// middleware, which replaces the remote address with the unverified address from the proxy headers.
+func PeerAddr(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), peerAddrKey{}, r.RemoteAddr)))
+	})
+}
+
+// trustedProxies are the reverse proxies whose X-Forwarded-For and X-Real-IP headers are used to determine the
+// client IP address.
+type trustedProxies []*net.IPNet
+
+func newTrustedProxies(proxies []string) trustedProxies {
+	var tp trustedProxies
+	for _, p := range proxies {
+		if _, n, err := net.ParseCIDR(p); err == nil {
+			tp = append(tp, n)
+			continue
+		}
+		// the config parser already rejected invalid addresses
+		if ip := net.ParseIP(p); ip != nil {
+			tp = append(tp, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+		}
+	}
+	return tp
+}
+
+func (tp trustedProxies) contains(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, n := range tp {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP returns the IP address of the client. The proxy headers are only used if the request comes from a
+// trusted proxy. The X-Forwarded-For header is read from the right, because clients can send their own values
+// which are kept by the proxies.
+func (tp trustedProxies) clientIP(r *http.Request) string {
+	peer, ok := r.Context().Value(peerAddrKey{}).(string)
+	if !ok {
+		peer = r.RemoteAddr
+	}
+	if host, _, err := net.SplitHostPort(peer); err == nil {
+		peer = host
+	}
+	if !tp.contains(peer) {
+		return peer
+	}
+
+	if xff := r.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+		addrs := strings.Split(strings.Join(xff, ","), ",")
+		for i := len(addrs) - 1; i >= 0; i-- {
+			addr := strings.TrimSpace(addrs[i])
+			if net.ParseIP(addr) == nil {
+				break
+			}
+			if !tp.contains(addr) {
+				return addr
+			}
+			peer = addr
+		}
+		return peer
+	}
+	if xrip := strings.TrimSpace(r.Header.Get("X-Real-IP")); net.ParseIP(xrip) != nil {
+		return xrip
+	}
+	return peer
+}
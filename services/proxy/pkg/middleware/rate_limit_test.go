@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go-micro.dev/v4/store"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/proxy/pkg/config"
+	"github.com/owncloud/ocis/v2/services/proxy/pkg/router"
+)
+
+var _ = Describe("Rate limiting requests", Label("RateLimit"), func() {
+	var (
+		cfg     config.RateLimit
+		handler http.Handler
+	)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	request := func(remoteAddr string, user string) int {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com/dav/files", http.NoBody)
+		req.RemoteAddr = remoteAddr
+		req = req.WithContext(router.SetRoutingInfo(req.Context(), router.RoutingInfo{}))
+		if user != "" {
+			req = req.WithContext(revactx.ContextSetUser(req.Context(), &userv1beta1.User{Id: &userv1beta1.UserId{OpaqueId: user}}))
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		if rr.Code == http.StatusTooManyRequests {
+			Expect(rr.Header().Get("Retry-After")).To(Equal("1"))
+		}
+		return rr.Code
+	}
+
+	BeforeEach(func() {
+		cfg = config.RateLimit{
+			Enabled:   true,
+			UserRate:  1,
+			UserBurst: 2,
+			IPRate:    1,
+			IPBurst:   3,
+		}
+	})
+
+	When("limiting per IP address", func() {
+		BeforeEach(func() {
+			handler = IPRateLimit(Logger(log.NewLogger()), RateLimitConfig(cfg), RateLimitStore(store.NewMemoryStore()))(next)
+		})
+
+		It("throttles a client after its burst", func() {
+			for i := 0; i < 3; i++ {
+				Expect(request("192.0.2.1:1234", "")).To(Equal(http.StatusOK))
+			}
+			Expect(request("192.0.2.1:5678", "")).To(Equal(http.StatusTooManyRequests))
+			Expect(request("192.0.2.2:1234", "")).To(Equal(http.StatusOK))
+		})
+	})
+
+	When("the client is behind a reverse proxy", func() {
+		forwarded := func(remoteAddr string, xff string) int {
+			req := httptest.NewRequest(http.MethodGet, "http://example.com/dav/files", http.NoBody)
+			req.RemoteAddr = remoteAddr
+			req.Header.Set("X-Forwarded-For", xff)
+			rr := httptest.NewRecorder()
+			// the peer address is kept before RealIP rewrites it, like in the middleware chain of the proxy
+			PeerAddr(chimiddleware.RealIP(handler)).ServeHTTP(rr, req)
+			return rr.Code
+		}
+
+		BeforeEach(func() {
+			cfg.IPBurst = 1
+			cfg.TrustedProxies = []string{"10.0.0.0/8", "192.0.2.10"}
+			handler = IPRateLimit(Logger(log.NewLogger()), RateLimitConfig(cfg), RateLimitStore(store.NewMemoryStore()))(next)
+		})
+
+		It("limits the forwarded client addresses of trusted proxies", func() {
+			Expect(forwarded("192.0.2.10:1234", "198.51.100.1")).To(Equal(http.StatusOK))
+			Expect(forwarded("10.1.2.3:1234", "198.51.100.2, 10.0.0.1")).To(Equal(http.StatusOK))
+			Expect(forwarded("192.0.2.10:1234", "198.51.100.2")).To(Equal(http.StatusTooManyRequests))
+			// addresses added by the client in front of the proxy are ignored
+			Expect(forwarded("192.0.2.10:1234", "203.0.113.1, 198.51.100.1")).To(Equal(http.StatusTooManyRequests))
+		})
+
+		It("ignores the headers of untrusted clients", func() {
+			Expect(forwarded("192.0.2.1:1234", "198.51.100.1")).To(Equal(http.StatusOK))
+			Expect(forwarded("192.0.2.1:1234", "198.51.100.2")).To(Equal(http.StatusTooManyRequests))
+		})
+	})
+
+	When("limiting per user", func() {
+		BeforeEach(func() {
+			handler = UserRateLimit(Logger(log.NewLogger()), RateLimitConfig(cfg), RateLimitStore(store.NewMemoryStore()))(next)
+		})
+
+		It("throttles a user regardless of the IP address", func() {
+			Expect(request("192.0.2.1:1234", "einstein")).To(Equal(http.StatusOK))
+			Expect(request("192.0.2.2:1234", "einstein")).To(Equal(http.StatusOK))
+			Expect(request("192.0.2.3:1234", "einstein")).To(Equal(http.StatusTooManyRequests))
+			Expect(request("192.0.2.3:1234", "marie")).To(Equal(http.StatusOK))
+		})
+
+		It("doesn't limit unauthenticated requests without a route limit", func() {
+			for i := 0; i < 5; i++ {
+				Expect(request("192.0.2.1:1234", "")).To(Equal(http.StatusOK))
+			}
+		})
+	})
+
+	When("the rate limit is disabled", func() {
+		It("returns the next handler", func() {
+			cfg.Enabled = false
+			handler = IPRateLimit(RateLimitConfig(cfg))(next)
+			for i := 0; i < 5; i++ {
+				Expect(request("192.0.2.1:1234", "")).To(Equal(http.StatusOK))
+			}
+		})
+	})
+
+	It("refills the bucket over time", func() {
+		now := time.Now()
+		tb := newTokenBuckets(store.NewMemoryStore())
+		tb.now = func() time.Time { return now }
+		l := limit{key: "user/einstein", rate: 2, burst: 1}
+
+		Expect(tb.take(l)).To(BeZero())
+		Expect(tb.take(l)).To(Equal(500 * time.Millisecond))
+
+		now = now.Add(250 * time.Millisecond)
+		Expect(tb.take(l)).To(Equal(250 * time.Millisecond))
+
+		now = now.Add(250 * time.Millisecond)
+		Expect(tb.take(l)).To(BeZero())
+	})
+})
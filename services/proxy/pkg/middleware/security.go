@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 	"os"
+	"sync/atomic"
 
 	gofig "github.com/gookit/config/v2"
 	"github.com/gookit/config/v2/yaml"
@@ -48,15 +49,30 @@ func loadCSPYaml(proxyCfg *config.Config) ([]byte, error) {
 	return os.ReadFile(proxyCfg.CSPConfigFileLocation)
 }
 
-// Security is a middleware to apply security relevant http headers like CSP.
-func Security(cspConfig *config.CSP) func(h http.Handler) http.Handler {
+// SecurityHeaders applies security relevant http headers like CSP. The CSP can be updated while serving requests.
+type SecurityHeaders struct {
+	secure atomic.Pointer[secure.Secure]
+}
+
+// NewSecurityHeaders creates SecurityHeaders for the given CSP configuration.
+func NewSecurityHeaders(cspConfig *config.CSP) (*SecurityHeaders, error) {
+	sh := &SecurityHeaders{}
+	return sh, sh.Update(cspConfig)
+}
+
+// Update atomically replaces the CSP. It returns an error and keeps the current CSP if the configuration is invalid.
+func (sh *SecurityHeaders) Update(cspConfig *config.CSP) error {
 	cspBuilder := cspbuilder.Builder{
 		Directives: cspConfig.Directives,
 	}
+	csp, err := cspBuilder.Build()
+	if err != nil {
+		return err
+	}
 
-	secureMiddleware := secure.New(secure.Options{
+	sh.secure.Store(secure.New(secure.Options{
 		BrowserXssFilter:             true,
-		ContentSecurityPolicy:        cspBuilder.MustBuild(),
+		ContentSecurityPolicy:        csp,
 		ContentTypeNosniff:           true,
 		CustomFrameOptionsValue:      "SAMEORIGIN",
 		FrameDeny:                    true,
@@ -65,8 +81,13 @@ func Security(cspConfig *config.CSP) func(h http.Handler) http.Handler {
 		STSPreload:                   true,
 		PermittedCrossDomainPolicies: "none",
 		RobotTag:                     "none",
+	}))
+	return nil
+}
+
+// Handler is the middleware applying the headers.
+func (sh *SecurityHeaders) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sh.secure.Load().Handler(next).ServeHTTP(w, r)
 	})
-	return func(next http.Handler) http.Handler {
-		return secureMiddleware.Handler(next)
-	}
 }
@@ -13,7 +13,11 @@ import (
 func SelectorCookie(optionSetters ...Option) func(next http.Handler) http.Handler {
 	options := newOptions(optionSetters...)
 	logger := options.Logger
-	policySelector := options.PolicySelector
+	policySelector := func() config.PolicySelector { return options.PolicySelector }
+	if options.Router != nil {
+		// the policies can be reloaded
+		policySelector = options.Router.PolicySelector
+	}
 
 	return func(next http.Handler) http.Handler {
 		return &selectorCookie{
@@ -27,27 +31,30 @@ func SelectorCookie(optionSetters ...Option) func(next http.Handler) http.Handle
 type selectorCookie struct {
 	next           http.Handler
 	logger         log.Logger
-	policySelector config.PolicySelector
+	policySelector func() config.PolicySelector
 }
 
 func (m selectorCookie) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	if m.policySelector.Regex == nil && m.policySelector.Claims == nil {
-		// only set selector cookie for regex and claim selectors
+	policySelector := m.policySelector()
+	if policySelector.Regex == nil && policySelector.Claims == nil && policySelector.Attributes == nil {
+		// only set selector cookie for regex, claim and attributes selectors
 		m.next.ServeHTTP(w, req)
 		return
 	}
 
 	selectorCookieName := ""
-	if m.policySelector.Regex != nil {
-		selectorCookieName = m.policySelector.Regex.SelectorCookieName
-	} else if m.policySelector.Claims != nil {
-		selectorCookieName = m.policySelector.Claims.SelectorCookieName
+	if policySelector.Regex != nil {
+		selectorCookieName = policySelector.Regex.SelectorCookieName
+	} else if policySelector.Claims != nil {
+		selectorCookieName = policySelector.Claims.SelectorCookieName
+	} else if policySelector.Attributes != nil {
+		selectorCookieName = policySelector.Attributes.SelectorCookieName
 	}
 
 	// update cookie
 	if oidc.FromContext(req.Context()) != nil {
 
-		selectorFunc, err := policy.LoadSelector(&m.policySelector)
+		selectorFunc, err := policy.LoadSelector(&policySelector)
 		if err != nil {
 			m.logger.Err(err)
 		}
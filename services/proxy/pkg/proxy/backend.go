@@ -0,0 +1,46 @@
+package proxy
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/proxy/pkg/metrics"
+	"github.com/owncloud/ocis/v2/services/proxy/pkg/router"
+)
+
+// backendTransport reports failed requests to the outlier detection of the backend and counts the requests per backend
+// if metrics are set.
+type backendTransport struct {
+	next    http.RoundTripper
+	metrics *metrics.Metrics
+	logger  log.Logger
+}
+
+func (t backendTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	backend, ok := router.ContextBackend(req.Context())
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	res, err := t.next.RoundTrip(req)
+	switch {
+	case t.metrics == nil:
+	case err != nil:
+		t.metrics.BackendErrors.WithLabelValues(backend.Name()).Inc()
+	default:
+		t.metrics.BackendRequests.WithLabelValues(backend.Name(), strconv.Itoa(res.StatusCode)).Inc()
+	}
+
+	// the request is canceled by the client, that's not the fault of the backend
+	if req.Context().Err() != nil {
+		return res, err
+	}
+	if backend.Report(err != nil || res.StatusCode >= http.StatusInternalServerError) {
+		t.logger.Warn().Str("backend", backend.Name()).Msg("ejected backend after consecutive failures")
+		if t.metrics != nil {
+			t.metrics.BackendEjections.WithLabelValues(backend.Name()).Inc()
+		}
+	}
+	return res, err
+}
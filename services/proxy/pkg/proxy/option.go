@@ -3,6 +3,7 @@ package proxy
 import (
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/services/proxy/pkg/config"
+	"github.com/owncloud/ocis/v2/services/proxy/pkg/metrics"
 )
 
 // Option defines a single option function.
@@ -10,8 +11,9 @@ type Option func(o *Options)
 
 // Options defines the available options for this package.
 type Options struct {
-	Logger log.Logger
-	Config *config.Config
+	Logger  log.Logger
+	Config  *config.Config
+	Metrics *metrics.Metrics
 }
 
 // newOptions initializes the available default options.
@@ -38,3 +40,10 @@ func Config(val *config.Config) Option {
 		o.Config = val
 	}
 }
+
+// Metrics provides a function to set the metrics option.
+func Metrics(val *metrics.Metrics) Option {
+	return func(o *Options) {
+		o.Metrics = val
+	}
+}
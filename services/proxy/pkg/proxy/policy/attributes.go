@@ -0,0 +1,185 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	revactx "github.com/cs3org/reva/v2/pkg/ctx"
+	"github.com/owncloud/ocis/v2/ocis-pkg/oidc"
+	"github.com/owncloud/ocis/v2/services/proxy/pkg/config"
+)
+
+// condition evaluates the attributes of a user. The claims are nil for users which didn't authenticate with OIDC.
+type condition func(u *userv1beta1.User, claims map[string]interface{}) bool
+
+type attributeRule struct {
+	condition condition
+	policy    string
+}
+
+// NewAttributesSelector selects the policy based on group membership, user type and claims of the user.
+// Groups are matched by their id, group names are available in the groups claim of most identity providers.
+// The conditions of the rules can be combined with "all", "any" and "not":
+//
+//	"policy_selector": {
+//	   "attributes": {
+//	     "rules": [
+//	       {"priority": 10, "policy": "pilot", "condition": {"all": [
+//	         {"group_id": "2ab4b1a0-1c8e-4c5a-9b2c-3b3a6f6c1d11"},
+//	         {"not": {"user_type": "guest"}}
+//	       ]}},
+//	       {"priority": 20, "policy": "pilot", "condition": {"claim": "roles", "match": "^beta-tester$"}}
+//	     ],
+//	     "default_policy": "ocis",
+//	     "unauthenticated_policy": "ocis"
+//	   }
+//	 },
+//
+// Unauthenticated requests are routed by the selector cookie, which is set for authenticated users.
+func NewAttributesSelector(cfg *config.AttributesSelectorConf) (Selector, error) {
+	rules := make([]config.AttributeRuleConf, len(cfg.Rules))
+	copy(rules, cfg.Rules)
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Priority < rules[j].Priority
+	})
+
+	attributeRules := make([]attributeRule, 0, len(rules))
+	for _, rule := range rules {
+		c, err := newCondition(rule.Condition)
+		if err != nil {
+			return nil, fmt.Errorf("invalid condition for policy '%s': %w", rule.Policy, err)
+		}
+		attributeRules = append(attributeRules, attributeRule{condition: c, policy: rule.Policy})
+	}
+
+	return func(r *http.Request) (string, error) {
+		if u, ok := revactx.ContextGetUser(r.Context()); ok {
+			claims := oidc.FromContext(r.Context())
+			for _, rule := range attributeRules {
+				if rule.condition(u, claims) {
+					return rule.policy, nil
+				}
+			}
+			return cfg.DefaultPolicy, nil
+		}
+
+		if selectorCookie, err := r.Cookie(cfg.SelectorCookieName); err == nil {
+			return selectorCookie.Value, nil
+		}
+		return cfg.UnauthenticatedPolicy, nil
+	}, nil
+}
+
+func newCondition(cfg config.AttributeCondition) (condition, error) {
+	set := 0
+	for _, isSet := range []bool{len(cfg.All) > 0, len(cfg.Any) > 0, cfg.Not != nil, cfg.GroupID != "", cfg.UserType != "", cfg.Claim != ""} {
+		if isSet {
+			set++
+		}
+	}
+	switch {
+	case set == 0:
+		return nil, errors.New("empty condition")
+	case set > 1:
+		return nil, errors.New("only one of all, any, not, group_id, user_type or claim can be set per condition")
+	case cfg.Match != "" && cfg.Claim == "":
+		return nil, errors.New("match is only supported for claims")
+	}
+
+	switch {
+	case len(cfg.All) > 0 || len(cfg.Any) > 0:
+		list, all := cfg.Any, false
+		if len(cfg.All) > 0 {
+			list, all = cfg.All, true
+		}
+		conditions := make([]condition, 0, len(list))
+		for _, c := range list {
+			cond, err := newCondition(c)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, cond)
+		}
+		return func(u *userv1beta1.User, claims map[string]interface{}) bool {
+			for _, cond := range conditions {
+				if cond(u, claims) != all {
+					return !all
+				}
+			}
+			return all
+		}, nil
+	case cfg.Not != nil:
+		cond, err := newCondition(*cfg.Not)
+		if err != nil {
+			return nil, err
+		}
+		return func(u *userv1beta1.User, claims map[string]interface{}) bool {
+			return !cond(u, claims)
+		}, nil
+	case cfg.GroupID != "":
+		// the user provider fills the groups of the user with the ids of the groups, not their names
+		return func(u *userv1beta1.User, _ map[string]interface{}) bool {
+			for _, g := range u.GetGroups() {
+				if g == cfg.GroupID {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case cfg.UserType != "":
+		userType, ok := userv1beta1.UserType_value["USER_TYPE_"+strings.ToUpper(cfg.UserType)]
+		if !ok {
+			return nil, fmt.Errorf("unknown user type '%s'", cfg.UserType)
+		}
+		return func(u *userv1beta1.User, _ map[string]interface{}) bool {
+			return int32(u.GetId().GetType()) == userType
+		}, nil
+	default:
+		match, err := regexp.Compile(cfg.Match)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex '%s' for claim '%s': %w", cfg.Match, cfg.Claim, err)
+		}
+		return func(_ *userv1beta1.User, claims map[string]interface{}) bool {
+			for _, v := range claimValues(cfg.Claim, claims) {
+				if match.MatchString(v) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	}
+}
+
+// claimValues returns the values of a claim, multi-valued claims like groups return all values.
+func claimValues(path string, claims map[string]interface{}) []string {
+	if claims == nil {
+		return nil
+	}
+	claim, ok := claims[path]
+	if !ok {
+		var err error
+		if claim, err = oidc.WalkSegments(oidc.SplitWithEscaping(path, ".", "\\"), claims); err != nil {
+			return nil
+		}
+	}
+
+	switch v := claim.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			values = append(values, fmt.Sprint(e))
+		}
+		return values
+	case []string:
+		return v
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
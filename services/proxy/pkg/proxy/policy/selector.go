@@ -13,9 +13,9 @@ import (
 
 var (
 	// ErrMultipleSelectors in case there is more then one selector configured.
-	ErrMultipleSelectors = fmt.Errorf("only one type of policy-selector (static, migration, claim or regex) can be configured")
+	ErrMultipleSelectors = fmt.Errorf("only one type of policy-selector (static, migration, claim, regex or attributes) can be configured")
 	// ErrSelectorConfigIncomplete if policy_selector conf is missing
-	ErrSelectorConfigIncomplete = fmt.Errorf("missing either \"static\", \"migration\", \"claim\" or \"regex\" configuration in policy_selector config ")
+	ErrSelectorConfigIncomplete = fmt.Errorf("missing either \"static\", \"migration\", \"claim\", \"regex\" or \"attributes\" configuration in policy_selector config ")
 	// ErrUnexpectedConfigError unexpected config error
 	ErrUnexpectedConfigError = fmt.Errorf("could not initialize policy-selector for given config")
 )
@@ -65,11 +65,14 @@ func LoadSelector(cfg *config.PolicySelector) (Selector, error) {
 	if cfg.Regex != nil {
 		selCount++
 	}
+	if cfg.Attributes != nil {
+		selCount++
+	}
 	if selCount > 1 {
 		return nil, ErrMultipleSelectors
 	}
 
-	if cfg.Static == nil && cfg.Claims == nil && cfg.Regex == nil {
+	if selCount == 0 {
 		return nil, ErrSelectorConfigIncomplete
 	}
 
@@ -85,12 +88,24 @@ func LoadSelector(cfg *config.PolicySelector) (Selector, error) {
 	}
 
 	if cfg.Regex != nil {
+		for _, rule := range cfg.Regex.MatchesPolicies {
+			if _, err := regexp.Compile(rule.Match); err != nil {
+				return nil, fmt.Errorf("invalid regex '%s' for policy '%s': %w", rule.Match, rule.Policy, err)
+			}
+		}
 		if cfg.Regex.SelectorCookieName == "" {
 			cfg.Regex.SelectorCookieName = SelectorCookieName
 		}
 		return NewRegexSelector(cfg.Regex), nil
 	}
 
+	if cfg.Attributes != nil {
+		if cfg.Attributes.SelectorCookieName == "" {
+			cfg.Attributes.SelectorCookieName = SelectorCookieName
+		}
+		return NewAttributesSelector(cfg.Attributes)
+	}
+
 	return nil, ErrUnexpectedConfigError
 }
 
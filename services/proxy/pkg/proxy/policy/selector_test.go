@@ -27,6 +27,8 @@ func TestLoadSelector(t *testing.T) {
 		{cfg: &config.PolicySelector{Static: sCfg}, expectedErr: nil},
 		{cfg: &config.PolicySelector{Claims: ccfg}, expectedErr: nil},
 		{cfg: &config.PolicySelector{Regex: rcfg}, expectedErr: nil},
+		{cfg: &config.PolicySelector{Attributes: &config.AttributesSelectorConf{}}, expectedErr: nil},
+		{cfg: &config.PolicySelector{Regex: rcfg, Attributes: &config.AttributesSelectorConf{}}, expectedErr: ErrMultipleSelectors},
 	}
 
 	for _, test := range table {
@@ -144,3 +146,104 @@ func TestRegexSelector(t *testing.T) {
 		})
 	}
 }
+
+func TestAttributesSelector(t *testing.T) {
+	const (
+		pilotUsersID = "2ab4b1a0-1c8e-4c5a-9b2c-3b3a6f6c1d11"
+		physicsID    = "262982c1-2362-4afa-bfdf-8cbfef64a06e"
+	)
+
+	sel, err := NewAttributesSelector(&config.AttributesSelectorConf{
+		DefaultPolicy: "default",
+		Rules: []config.AttributeRuleConf{
+			{Priority: 20, Policy: "beta", Condition: config.AttributeCondition{Claim: "roles", Match: "^beta-tester$"}},
+			{Priority: 10, Policy: "pilot", Condition: config.AttributeCondition{All: []config.AttributeCondition{
+				{GroupID: pilotUsersID},
+				{Not: &config.AttributeCondition{UserType: "guest"}},
+			}}},
+			{Priority: 25, Policy: "pilot-claim", Condition: config.AttributeCondition{Claim: "groups", Match: "^pilot-users$"}},
+			{Priority: 30, Policy: "external", Condition: config.AttributeCondition{Any: []config.AttributeCondition{
+				{UserType: "federated"},
+				{Claim: "org.name", Match: "partner"},
+			}}},
+		},
+		UnauthenticatedPolicy: "unauthenticated",
+		SelectorCookieName:    SelectorCookieName,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// the user provider fills the groups with the ids of the groups, like a user of the LDAP backend
+	user := func(groups []string, userType userv1beta1.UserType) context.Context {
+		return revactx.ContextSetUser(context.Background(), &userv1beta1.User{
+			Id: &userv1beta1.UserId{
+				Idp:      "https://localhost:9200",
+				OpaqueId: "4c510ada-c86b-4815-8820-42cdf82c3d51",
+				Type:     userType,
+			},
+			Username:    "einstein",
+			DisplayName: "Albert Einstein",
+			Mail:        "einstein@example.org",
+			Groups:      groups,
+		})
+	}
+	primary := userv1beta1.UserType_USER_TYPE_PRIMARY
+
+	var tests = []testCase{
+		{"unauthenticated", context.Background(), nil, "unauthenticated"},
+		{"cookie", context.Background(), &http.Cookie{Name: SelectorCookieName, Value: "pilot"}, "pilot"},
+		{"default", user(nil, primary), nil, "default"},
+		{"group", user([]string{physicsID, pilotUsersID}, primary), nil, "pilot"},
+		{"group-name", user([]string{"pilot-users"}, primary), nil, "default"},
+		{"groups-skipped-in-token", user(nil, primary), nil, "default"},
+		{"group-guest", user([]string{pilotUsersID}, userv1beta1.UserType_USER_TYPE_GUEST), nil, "default"},
+		{"groups-claim", oidc.NewContext(user(nil, primary), map[string]interface{}{"groups": []interface{}{"physics", "pilot-users"}}), nil, "pilot-claim"},
+		{"user-type", user(nil, userv1beta1.UserType_USER_TYPE_FEDERATED), nil, "external"},
+		{"claim-list", oidc.NewContext(user(nil, primary), map[string]interface{}{"roles": []interface{}{"admin", "beta-tester"}}), nil, "beta"},
+		{"nested-claim", oidc.NewContext(user(nil, primary), map[string]interface{}{"org": map[string]interface{}{"name": "partner"}}), nil, "external"},
+		{"priority", oidc.NewContext(user([]string{pilotUsersID}, primary), map[string]interface{}{"roles": "beta-tester"}), nil, "pilot"},
+		{"user-overrides-cookie", user(nil, primary), &http.Cookie{Name: SelectorCookieName, Value: "pilot"}, "default"},
+	}
+
+	for _, tc := range tests {
+		tc := tc // capture range variable
+		t.Run(tc.Name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "https://example.com", nil)
+			if tc.Cookie != nil {
+				r.AddCookie(tc.Cookie)
+			}
+			got, err := sel(r.WithContext(tc.Context))
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+
+			if got != tc.Expected {
+				t.Errorf("Expected Policy %v got %v", tc.Expected, got)
+			}
+		})
+	}
+}
+
+func TestAttributesSelectorRejectsInvalidConditions(t *testing.T) {
+	table := map[string]config.AttributeCondition{
+		"empty":             {},
+		"multiple":          {GroupID: "pilot-users", UserType: "guest"},
+		"unknown user type": {UserType: "robot"},
+		"invalid regex":     {Claim: "roles", Match: "("},
+		"match without claim": {
+			GroupID: "pilot-users",
+			Match:   "pilot",
+		},
+		"nested": {Not: &config.AttributeCondition{}},
+	}
+
+	for name, c := range table {
+		_, err := NewAttributesSelector(&config.AttributesSelectorConf{
+			Rules: []config.AttributeRuleConf{{Policy: "pilot", Condition: c}},
+		})
+		if err == nil {
+			t.Errorf("Accepted %s condition", name)
+		}
+	}
+}
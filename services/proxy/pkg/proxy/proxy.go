@@ -57,7 +57,7 @@ func NewMultiHostReverseProxy(opts ...Option) (*MultiHostReverseProxy, error) {
 		tlsConf.RootCAs = certs
 	}
 	// equals http.DefaultTransport except TLSClientConfig
-	rp.Transport = &http.Transport{
+	transport := &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
 		DialContext: (&net.Dialer{
 			Timeout:   30 * time.Second,
@@ -71,6 +71,7 @@ func NewMultiHostReverseProxy(opts ...Option) (*MultiHostReverseProxy, error) {
 		ExpectContinueTimeout: 1 * time.Second,
 		TLSClientConfig:       tlsConf,
 	}
+	rp.Transport = backendTransport{next: transport, metrics: options.Metrics, logger: options.Logger}
 	return rp, nil
 }
 
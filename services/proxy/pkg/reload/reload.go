@@ -0,0 +1,138 @@
+// Package reload watches configuration files and reloads the configuration when they change.
+package reload
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+)
+
+// Status is the state of the configuration reloads
+type Status struct {
+	Files []string `json:"files"`
+	// Revision is incremented with every successful reload
+	Revision   int       `json:"revision"`
+	LastReload time.Time `json:"last_reload,omitempty"`
+	// LastError is the error of the last reload, it is reset by a successful reload
+	LastError   string    `json:"last_error,omitempty"`
+	LastErrorAt time.Time `json:"last_error_at,omitempty"`
+}
+
+// Watcher polls files and calls a reload function when their content changed.
+// Polling is used instead of file system notifications to also detect files that are replaced via symlinks,
+// like mounted kubernetes config maps.
+type Watcher struct {
+	files    []string
+	interval time.Duration
+	reload   func() error
+	logger   log.Logger
+
+	checksums map[string]string
+
+	mu     sync.RWMutex
+	status Status
+}
+
+// NewWatcher creates a Watcher for the given files. The current content of the files is considered to be loaded.
+func NewWatcher(files []string, interval time.Duration, reload func() error, logger log.Logger) *Watcher {
+	w := &Watcher{
+		files:     files,
+		interval:  interval,
+		reload:    reload,
+		logger:    logger,
+		checksums: make(map[string]string, len(files)),
+		status:    Status{Files: files},
+	}
+	for _, f := range files {
+		w.checksums[f] = checksum(f)
+	}
+	return w
+}
+
+// Run checks the files in the configured interval until the context is done.
+func (w *Watcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			w.Check()
+		}
+	}
+}
+
+// Check reloads the configuration if one of the files has changed. If the reload fails, the error is kept
+// in the status until the files change again.
+func (w *Watcher) Check() {
+	changed := false
+	for _, f := range w.files {
+		sum := checksum(f)
+		if sum != w.checksums[f] {
+			w.checksums[f] = sum
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+
+	err := w.reload()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err != nil {
+		w.logger.Error().Err(err).Strs("files", w.files).Msg("rejected invalid configuration, keeping the current configuration")
+		w.status.LastError = err.Error()
+		w.status.LastErrorAt = time.Now()
+		return
+	}
+	w.logger.Info().Strs("files", w.files).Msg("reloaded configuration")
+	w.status.Revision++
+	w.status.LastReload = time.Now()
+	w.status.LastError = ""
+	w.status.LastErrorAt = time.Time{}
+}
+
+// Status returns the state of the reloads.
+func (w *Watcher) Status() Status {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.status
+}
+
+// ServeHTTP renders the status as json.
+func (w *Watcher) ServeHTTP(rw http.ResponseWriter, _ *http.Request) {
+	b, err := json.Marshal(w.Status())
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	_, _ = rw.Write(b)
+}
+
+// checksum returns the checksum of the file content. Missing files have an empty checksum.
+func checksum(path string) string {
+	b, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return ""
+	case err != nil:
+		// unreadable files are treated as changed, the reload will report the error
+		return "error: " + err.Error()
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,50 @@
+package reload
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "proxy.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("policies: []"), 0600))
+
+	var reloadErr error
+	reloads := 0
+	w := NewWatcher([]string{file}, 0, func() error {
+		reloads++
+		return reloadErr
+	}, log.NewLogger())
+
+	// unchanged files are not reloaded
+	w.Check()
+	require.Equal(t, 0, reloads)
+
+	require.NoError(t, os.WriteFile(file, []byte("policies: [{}]"), 0600))
+	w.Check()
+	require.Equal(t, 1, reloads)
+	require.Equal(t, 1, w.Status().Revision)
+	require.Empty(t, w.Status().LastError)
+
+	// invalid configurations are reported once
+	reloadErr = errors.New("invalid policy")
+	require.NoError(t, os.WriteFile(file, []byte("policies: invalid"), 0600))
+	w.Check()
+	w.Check()
+	require.Equal(t, 2, reloads)
+	require.Equal(t, 1, w.Status().Revision)
+	require.Equal(t, "invalid policy", w.Status().LastError)
+
+	// removing the file restores the defaults
+	reloadErr = nil
+	require.NoError(t, os.Remove(file))
+	w.Check()
+	require.Equal(t, 3, reloads)
+	require.Equal(t, 2, w.Status().Revision)
+	require.Empty(t, w.Status().LastError)
+}
@@ -0,0 +1,270 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/proxy/pkg/config"
+)
+
+const (
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultHealthCheckTimeout  = 2 * time.Second
+	defaultUnhealthyThreshold  = 3
+	defaultHealthyThreshold    = 2
+	defaultConsecutiveFailures = 5
+	defaultEjectionTime        = 30 * time.Second
+)
+
+type backendCtxKey struct{}
+
+// Backend is a target requests are forwarded to.
+type Backend struct {
+	name    string
+	url     *url.URL
+	weight  int
+	outlier *config.OutlierDetection
+	now     func() time.Time
+
+	mu sync.Mutex
+	// unhealthy is set by the active health checks
+	unhealthy      bool
+	checkFailures  int
+	checkSuccesses int
+	// failures are the consecutive failed requests
+	failures     int
+	ejectedUntil time.Time
+}
+
+// Name returns the url of the backend or the name of the service.
+func (b *Backend) Name() string {
+	return b.name
+}
+
+// Report records the result of a request which was forwarded to the backend. It returns true if the backend
+// was ejected because of too many consecutive failures.
+func (b *Backend) Report(failed bool) bool {
+	if b.outlier == nil {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !failed {
+		b.failures = 0
+		return false
+	}
+
+	b.failures++
+	if b.failures < b.outlier.ConsecutiveFailures {
+		return false
+	}
+	b.failures = 0
+	b.ejectedUntil = b.now().Add(b.outlier.EjectionTime)
+	return true
+}
+
+// available returns true if the backend is healthy and not ejected
+func (b *Backend) available() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !b.unhealthy && !b.now().Before(b.ejectedUntil)
+}
+
+// checked records the result of a health check. It returns true if the health of the backend changed.
+func (b *Backend) checked(healthy bool, hc *config.HealthCheck) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if healthy {
+		b.checkFailures = 0
+		b.checkSuccesses++
+		if b.unhealthy && b.checkSuccesses >= hc.HealthyThreshold {
+			b.unhealthy = false
+			return true
+		}
+		return false
+	}
+	b.checkSuccesses = 0
+	b.checkFailures++
+	if !b.unhealthy && b.checkFailures >= hc.UnhealthyThreshold {
+		b.unhealthy = true
+		return true
+	}
+	return false
+}
+
+// withBackend puts the backend a request is forwarded to in the context.
+func withBackend(parent context.Context, b *Backend) context.Context {
+	return context.WithValue(parent, backendCtxKey{}, b)
+}
+
+// ContextBackend gets the backend a request is forwarded to from the context.
+func ContextBackend(ctx context.Context) (*Backend, bool) {
+	b, ok := ctx.Value(backendCtxKey{}).(*Backend)
+	return b, ok
+}
+
+// backendPool distributes the requests of a route to its backends according to their weight.
+type backendPool struct {
+	backends    []*Backend
+	healthCheck *config.HealthCheck
+	logger      log.Logger
+	intn        func(n int) int
+}
+
+func newBackendPool(route config.Route, logger log.Logger) (*backendPool, error) {
+	backends := route.Backends
+	if len(backends) == 0 {
+		backends = []config.WeightedBackend{{URL: route.Backend}}
+	}
+
+	var outlier *config.OutlierDetection
+	if route.OutlierDetection != nil {
+		o := *route.OutlierDetection
+		if o.ConsecutiveFailures <= 0 {
+			o.ConsecutiveFailures = defaultConsecutiveFailures
+		}
+		if o.EjectionTime <= 0 {
+			o.EjectionTime = defaultEjectionTime
+		}
+		outlier = &o
+	}
+
+	p := &backendPool{
+		logger: logger,
+		intn:   rand.Intn,
+	}
+	for _, wb := range backends {
+		uri, err := url.Parse(wb.URL)
+		if err != nil {
+			return nil, fmt.Errorf("malformed backend url '%s': %w", wb.URL, err)
+		}
+		weight := wb.Weight
+		switch {
+		case weight < 0:
+			return nil, fmt.Errorf("negative weight of backend '%s'", wb.URL)
+		case weight == 0:
+			weight = 1
+		}
+		p.backends = append(p.backends, &Backend{
+			name:    wb.URL,
+			url:     uri,
+			weight:  weight,
+			outlier: outlier,
+			now:     time.Now,
+		})
+	}
+
+	if route.HealthCheck != nil {
+		hc := *route.HealthCheck
+		if hc.Interval <= 0 {
+			hc.Interval = defaultHealthCheckInterval
+		}
+		if hc.Timeout <= 0 {
+			hc.Timeout = defaultHealthCheckTimeout
+		}
+		if hc.UnhealthyThreshold <= 0 {
+			hc.UnhealthyThreshold = defaultUnhealthyThreshold
+		}
+		if hc.HealthyThreshold <= 0 {
+			hc.HealthyThreshold = defaultHealthyThreshold
+		}
+		p.healthCheck = &hc
+	}
+	return p, nil
+}
+
+// pick selects a backend by weight. If none of the backends is available, all of them are considered,
+// because forwarding the request is better than failing it for sure.
+func (p *backendPool) pick() *Backend {
+	if len(p.backends) == 1 {
+		return p.backends[0]
+	}
+
+	candidates := make([]*Backend, 0, len(p.backends))
+	for _, b := range p.backends {
+		if b.available() {
+			candidates = append(candidates, b)
+		}
+	}
+	if len(candidates) == 0 {
+		p.logger.Warn().Msg("no backend of the route is available, falling back to all backends")
+		candidates = p.backends
+	}
+
+	total := 0
+	for _, b := range candidates {
+		total += b.weight
+	}
+	n := p.intn(total)
+	for _, b := range candidates {
+		n -= b.weight
+		if n < 0 {
+			return b
+		}
+	}
+	return candidates[len(candidates)-1]
+}
+
+// runHealthChecks checks the backends in the configured interval until the context is done.
+func (p *backendPool) runHealthChecks(ctx context.Context, client *http.Client) {
+	if p.healthCheck == nil {
+		return
+	}
+	ticker := time.NewTicker(p.healthCheck.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.checkBackends(ctx, client)
+		}
+	}
+}
+
+func (p *backendPool) checkBackends(ctx context.Context, client *http.Client) {
+	var wg sync.WaitGroup
+	for _, b := range p.backends {
+		wg.Add(1)
+		go func(b *Backend) {
+			defer wg.Done()
+			err := p.check(ctx, client, b)
+			if b.checked(err == nil, p.healthCheck) {
+				if err != nil {
+					p.logger.Warn().Err(err).Str("backend", b.name).Msg("backend is unhealthy")
+				} else {
+					p.logger.Info().Str("backend", b.name).Msg("backend is healthy again")
+				}
+			}
+		}(b)
+	}
+	wg.Wait()
+}
+
+func (p *backendPool) check(ctx context.Context, client *http.Client, b *Backend) error {
+	ctx, cancel := context.WithTimeout(ctx, p.healthCheck.Timeout)
+	defer cancel()
+
+	u := *b.url
+	u.Path = singleJoiningSlash(u.Path, p.healthCheck.Path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	if err != nil {
+		return err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/proxy/pkg/config"
+)
+
+func TestBackendPoolPicksByWeight(t *testing.T) {
+	pool, err := newBackendPool(config.Route{Backends: []config.WeightedBackend{
+		{URL: "http://stable", Weight: 9},
+		{URL: "http://canary", Weight: 1},
+	}}, log.NewLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	picked := map[string]int{}
+	for n := 0; n < 10; n++ {
+		pool.intn = func(int) int { return n }
+		picked[pool.pick().Name()]++
+	}
+	if picked["http://stable"] != 9 || picked["http://canary"] != 1 {
+		t.Errorf("unexpected distribution %v", picked)
+	}
+}
+
+func TestBackendPoolEjectsOutliers(t *testing.T) {
+	pool, err := newBackendPool(config.Route{
+		Backends: []config.WeightedBackend{
+			{URL: "http://stable"},
+			{URL: "http://canary"},
+		},
+		OutlierDetection: &config.OutlierDetection{ConsecutiveFailures: 2, EjectionTime: time.Minute},
+	}, log.NewLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for _, b := range pool.backends {
+		b.now = func() time.Time { return now }
+	}
+	pool.intn = func(int) int { return 0 }
+	stable := pool.backends[0]
+
+	if stable.Report(true) {
+		t.Error("backend was ejected after the first failure")
+	}
+	if !stable.Report(true) {
+		t.Error("backend was not ejected after consecutive failures")
+	}
+	if b := pool.pick(); b.Name() != "http://canary" {
+		t.Errorf("picked the ejected backend %s", b.Name())
+	}
+
+	// all backends are ejected, requests are still forwarded
+	pool.backends[1].Report(true)
+	pool.backends[1].Report(true)
+	if b := pool.pick(); b.Name() != "http://stable" {
+		t.Errorf("expected to fall back to all backends, picked %s", b.Name())
+	}
+
+	now = now.Add(time.Minute)
+	if !stable.available() {
+		t.Error("backend is still ejected after the ejection time")
+	}
+}
+
+func TestBackendPoolHealthChecks(t *testing.T) {
+	var healthy atomic.Bool
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/app/status" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer svr.Close()
+
+	pool, err := newBackendPool(config.Route{
+		Backends:    []config.WeightedBackend{{URL: svr.URL + "/app"}},
+		HealthCheck: &config.HealthCheck{Path: "/status", UnhealthyThreshold: 2, HealthyThreshold: 1},
+	}, log.NewLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := pool.backends[0]
+	ctx := context.Background()
+
+	pool.checkBackends(ctx, svr.Client())
+	if !backend.available() {
+		t.Error("backend is unavailable after the first failed check")
+	}
+	pool.checkBackends(ctx, svr.Client())
+	if backend.available() {
+		t.Error("backend is available after consecutive failed checks")
+	}
+
+	healthy.Store(true)
+	pool.checkBackends(ctx, svr.Client())
+	if !backend.available() {
+		t.Error("backend is unavailable after a successful check")
+	}
+}
+
+func TestLoadRejectsInvalidBackends(t *testing.T) {
+	table := map[string]config.Route{
+		"backend and backends": {Endpoint: "/", Backend: "http://web", Backends: []config.WeightedBackend{{URL: "http://web"}}},
+		"negative weight":      {Endpoint: "/", Backends: []config.WeightedBackend{{URL: "http://web", Weight: -1}}},
+		"service health check": {Endpoint: "/", Service: "com.owncloud.web.web", HealthCheck: &config.HealthCheck{Path: "/"}},
+	}
+
+	for name, route := range table {
+		policies := []config.Policy{{Name: "ocis", Routes: []config.Route{route}}}
+		if _, err := Load(nil, policies, log.NewLogger()); err == nil {
+			t.Errorf("Load accepted %s", name)
+		}
+	}
+}
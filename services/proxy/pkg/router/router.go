@@ -2,11 +2,14 @@ package router
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"regexp"
 	"strings"
+	"sync/atomic"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/ocis-pkg/registry"
@@ -21,7 +24,11 @@ var noInfo = RoutingInfo{}
 
 // Middleware returns a HTTP middleware containing the router.
 func Middleware(policySelector *config.PolicySelector, policies []config.Policy, logger log.Logger) func(http.Handler) http.Handler {
-	router := New(policySelector, policies, logger)
+	return ReloadableMiddleware(NewReloadable(New(policySelector, policies, logger)))
+}
+
+// ReloadableMiddleware returns a HTTP middleware containing the current router of the Reloadable.
+func ReloadableMiddleware(router *Reloadable) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ri, ok := router.Route(r)
@@ -34,9 +41,61 @@ func Middleware(policySelector *config.PolicySelector, policies []config.Policy,
 	}
 }
 
+// Reloadable holds a router which can be replaced while requests are being routed.
+type Reloadable struct {
+	current atomic.Pointer[Router]
+}
+
+// NewReloadable creates a Reloadable routing with the given router.
+func NewReloadable(router Router) *Reloadable {
+	r := &Reloadable{}
+	r.Swap(router)
+	return r
+}
+
+// Swap atomically replaces the router and closes the previous one. Requests that are already routed are not affected.
+func (r *Reloadable) Swap(router Router) {
+	if old := r.current.Swap(&router); old != nil {
+		old.Close()
+	}
+}
+
+// PolicySelector returns the configuration of the policy-selector used by the current router.
+func (r *Reloadable) PolicySelector() config.PolicySelector {
+	return r.current.Load().PolicySelector()
+}
+
+// Route routes the request with the current router.
+func (r *Reloadable) Route(req *http.Request) (RoutingInfo, bool) {
+	return r.current.Load().Route(req)
+}
+
+// Option defines a single option function.
+type Option func(r *Router)
+
+// HealthCheckClient sets the http client used for the health checks of the backends.
+func HealthCheckClient(client *http.Client) Option {
+	return func(r *Router) {
+		r.healthCheckClient = client
+	}
+}
+
 // New creates a new request router.
 // It initializes the routes before returning the router.
-func New(policySelector *config.PolicySelector, policies []config.Policy, logger log.Logger) Router {
+func New(policySelector *config.PolicySelector, policies []config.Policy, logger log.Logger, opts ...Option) Router {
+	r, err := Load(policySelector, policies, logger, opts...)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Could not load the routing policies")
+	}
+	return r
+}
+
+// Load creates a new request router and returns an error if the policies are invalid.
+// The health checks of the backends are started, they are stopped by closing the router.
+func Load(policySelector *config.PolicySelector, policies []config.Policy, logger log.Logger, opts ...Option) (Router, error) {
+	if len(policies) == 0 {
+		return Router{}, errors.New("no policies configured")
+	}
 	if policySelector == nil {
 		firstPolicy := policies[0].Name
 		logger.Warn().Str("policy", firstPolicy).Msg("policy-selector not configured. Will always use first policy")
@@ -53,35 +112,96 @@ func New(policySelector *config.PolicySelector, policies []config.Policy, logger
 
 	selector, err := policy.LoadSelector(policySelector)
 	if err != nil {
-		logger.Fatal().Err(err).Msg("Could not load policy-selector")
+		return Router{}, fmt.Errorf("could not load policy-selector: %w", err)
 	}
 
 	r := Router{
-		logger:         logger,
-		rewriters:      make(map[string]map[config.RouteType]map[string][]RoutingInfo),
-		policySelector: selector,
+		logger:            logger,
+		rewriters:         make(map[string]map[config.RouteType]map[string][]RoutingInfo),
+		policySelector:    selector,
+		selectorConfig:    *policySelector,
+		healthCheckClient: http.DefaultClient,
 	}
+	for _, o := range opts {
+		o(&r)
+	}
+
+	var pools []*backendPool
 	for _, pol := range policies {
 		for _, route := range pol.Routes {
 			logger.Debug().Str("fwd: ", route.Endpoint)
 
-			if route.Backend == "" && route.Service == "" {
-				logger.Fatal().Interface("route", route).Msg("neither Backend nor Service is set")
+			switch {
+			case route.Backend == "" && route.Service == "" && len(route.Backends) == 0:
+				return Router{}, fmt.Errorf("neither backend nor service is set for route '%s' of policy '%s'", route.Endpoint, pol.Name)
+			case route.Backend != "" && len(route.Backends) > 0:
+				return Router{}, fmt.Errorf("backend and backends are both set for route '%s' of policy '%s'", route.Endpoint, pol.Name)
+			case route.Service != "" && (route.HealthCheck != nil || route.OutlierDetection != nil):
+				return Router{}, fmt.Errorf("health checks and outlier detection are not supported for the service of route '%s' of policy '%s'", route.Endpoint, pol.Name)
 			}
-			uri, err2 := url.Parse(route.Backend)
-			if err2 != nil {
-				logger.
-					Fatal(). // fail early on misconfiguration
-					Err(err2).
-					Str("backend", route.Backend).
-					Msg("malformed url")
+			if route.Type == config.RegexRoute {
+				if _, err := regexp.Compile(route.Endpoint); err != nil {
+					return Router{}, fmt.Errorf("invalid regex route '%s' of policy '%s': %w", route.Endpoint, pol.Name, err)
+				}
+			}
+			pool, err := newBackendPool(route, logger)
+			if err != nil {
+				return Router{}, err
 			}
+			pools = append(pools, pool)
 
-			// here the backend is used as a uri
-			r.addHost(pol.Name, uri, route)
+			r.addHost(pol.Name, pool, route)
 		}
 	}
-	return r
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	for _, pool := range pools {
+		go pool.runHealthChecks(ctx, r.healthCheckClient)
+	}
+	return r, nil
+}
+
+// CheckSelectedPolicies makes sure that the policy-selector only selects configured policies.
+func CheckSelectedPolicies(policySelector *config.PolicySelector, policies []config.Policy) error {
+	if policySelector == nil {
+		return nil
+	}
+
+	var selected []string
+	switch {
+	case policySelector.Static != nil:
+		selected = append(selected, policySelector.Static.Policy)
+	case policySelector.Claims != nil:
+		selected = append(selected, policySelector.Claims.DefaultPolicy, policySelector.Claims.UnauthenticatedPolicy)
+	case policySelector.Regex != nil:
+		selected = append(selected, policySelector.Regex.DefaultPolicy, policySelector.Regex.UnauthenticatedPolicy)
+		for _, rule := range policySelector.Regex.MatchesPolicies {
+			selected = append(selected, rule.Policy)
+		}
+	case policySelector.Attributes != nil:
+		selected = append(selected, policySelector.Attributes.DefaultPolicy, policySelector.Attributes.UnauthenticatedPolicy)
+		for _, rule := range policySelector.Attributes.Rules {
+			selected = append(selected, rule.Policy)
+		}
+	}
+
+	for _, name := range selected {
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, pol := range policies {
+			if pol.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("policy '%s' is selected but not configured", name)
+		}
+	}
+	return nil
 }
 
 // RoutingInfo contains the proxy rewrite hook and some information about the route.
@@ -89,6 +209,8 @@ type RoutingInfo struct {
 	rewrite     func(*httputil.ProxyRequest)
 	endpoint    string
 	unprotected bool
+	id          string
+	rateLimit   *config.RouteRateLimit
 }
 
 // Rewrite returns the proxy rewrite hook.
@@ -101,15 +223,40 @@ func (r RoutingInfo) IsRouteUnprotected() bool {
 	return r.unprotected
 }
 
+// ID returns an identifier of the route which is unique across all policies.
+func (r RoutingInfo) ID() string {
+	return r.id
+}
+
+// RateLimit returns the rate limit of the route, nil if the route is not limited.
+func (r RoutingInfo) RateLimit() *config.RouteRateLimit {
+	return r.rateLimit
+}
+
 // Router handles the routing of HTTP requests according to the given policies.
 type Router struct {
 	logger         log.Logger
 	rewriters      map[string]map[config.RouteType]map[string][]RoutingInfo
 	policySelector policy.Selector
+	selectorConfig config.PolicySelector
+
+	healthCheckClient *http.Client
+	cancel            context.CancelFunc
+}
+
+// Close stops the health checks of the backends.
+func (rt Router) Close() {
+	if rt.cancel != nil {
+		rt.cancel()
+	}
 }
 
-func (rt Router) addHost(policy string, target *url.URL, route config.Route) {
-	targetQuery := target.RawQuery
+// PolicySelector returns the configuration of the policy-selector used by the router.
+func (rt Router) PolicySelector() config.PolicySelector {
+	return rt.selectorConfig
+}
+
+func (rt Router) addHost(policy string, pool *backendPool, route config.Route) {
 	if rt.rewriters[policy] == nil {
 		rt.rewriters[policy] = make(map[config.RouteType]map[string][]RoutingInfo)
 	}
@@ -126,12 +273,18 @@ func (rt Router) addHost(policy string, target *url.URL, route config.Route) {
 
 	reg := registry.GetRegistry()
 	sel := selector.NewSelector(selector.Registry(reg))
+	// requests to services are forwarded to the path of the backend url
+	service := &Backend{name: route.Service, url: pool.backends[0].url}
 
 	rt.rewriters[policy][routeType][route.Method] = append(rt.rewriters[policy][routeType][route.Method], RoutingInfo{
 		endpoint:    route.Endpoint,
 		unprotected: route.Unprotected,
+		id:          strings.Join([]string{policy, string(routeType), route.Method, route.Endpoint}, " "),
+		rateLimit:   route.RateLimit,
 		rewrite: func(req *httputil.ProxyRequest) {
+			var backend *Backend
 			if route.Service != "" {
+				backend = service
 				// select next node
 				next, err := sel.Select(route.Service)
 				if err != nil {
@@ -153,9 +306,14 @@ func (rt Router) addHost(policy string, target *url.URL, route config.Route) {
 					req.Out.URL.Scheme = "https"
 				}
 			} else {
-				req.Out.URL.Host = target.Host
-				req.Out.URL.Scheme = target.Scheme
+				backend = pool.pick()
+				req.Out.URL.Host = backend.url.Host
+				req.Out.URL.Scheme = backend.url.Scheme
 			}
+			req.Out = req.Out.WithContext(withBackend(req.Out.Context(), backend))
+
+			target := backend.url
+			targetQuery := target.RawQuery
 
 			// Apache deployments host addresses need to match on req.Out.Host and req.Out.URL.Host
 			// see https://stackoverflow.com/questions/34745654/golang-reverseproxy-with-apache2-sni-hostname-error
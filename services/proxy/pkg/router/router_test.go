@@ -159,3 +159,74 @@ func TestRouter(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadRejectsInvalidPolicies(t *testing.T) {
+	table := []struct {
+		name     string
+		selector *config.PolicySelector
+		policies []config.Policy
+	}{
+		{name: "no policies"},
+		{
+			name:     "route without backend",
+			policies: []config.Policy{{Name: "ocis", Routes: []config.Route{{Endpoint: "/"}}}},
+		},
+		{
+			name:     "invalid regex route",
+			policies: []config.Policy{{Name: "ocis", Routes: []config.Route{{Type: config.RegexRoute, Endpoint: "/(", Backend: "http://backend"}}}},
+		},
+		{
+			name: "invalid regex selector",
+			selector: &config.PolicySelector{Regex: &config.RegexSelectorConf{
+				MatchesPolicies: []config.RegexRuleConf{{Property: "username", Match: "(", Policy: "ocis"}},
+			}},
+			policies: []config.Policy{{Name: "ocis", Routes: []config.Route{{Endpoint: "/", Backend: "http://backend"}}}},
+		},
+	}
+
+	for _, test := range table {
+		if _, err := Load(test.selector, test.policies, log.NewLogger()); err == nil {
+			t.Errorf("Load accepted %s", test.name)
+		}
+	}
+
+	err := CheckSelectedPolicies(
+		&config.PolicySelector{Static: &config.StaticSelectorConf{Policy: "missing"}},
+		[]config.Policy{{Name: "ocis"}},
+	)
+	if err == nil {
+		t.Error("CheckSelectedPolicies accepted a missing policy")
+	}
+}
+
+func TestReloadableSwapsRouter(t *testing.T) {
+	policies := func(backend string) []config.Policy {
+		return []config.Policy{{Name: "ocis", Routes: []config.Route{{Endpoint: "/", Backend: backend}}}}
+	}
+	selector := &config.PolicySelector{Static: &config.StaticSelectorConf{Policy: "ocis"}}
+
+	rt := NewReloadable(New(selector, policies("http://old"), log.NewLogger()))
+	route := func() string {
+		req := httptest.NewRequest(http.MethodGet, "/app", nil)
+		ri, ok := rt.Route(req)
+		if !ok {
+			t.Fatal("request was not routed")
+		}
+		pr := &httputil.ProxyRequest{In: req, Out: req.Clone(context.Background())}
+		ri.Rewrite()(pr)
+		return pr.Out.URL.Host
+	}
+
+	if host := route(); host != "old" {
+		t.Errorf("expected the old backend, got %s", host)
+	}
+
+	r, err := Load(selector, policies("http://new"), log.NewLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rt.Swap(r)
+	if host := route(); host != "new" {
+		t.Errorf("expected the new backend, got %s", host)
+	}
+}
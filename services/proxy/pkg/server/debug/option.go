@@ -5,6 +5,7 @@ import (
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/services/proxy/pkg/config"
+	"github.com/owncloud/ocis/v2/services/proxy/pkg/reload"
 )
 
 // Option defines a single option function.
@@ -15,6 +16,7 @@ type Options struct {
 	Logger  log.Logger
 	Context context.Context
 	Config  *config.Config
+	Reload  *reload.Watcher
 }
 
 // newOptions initializes the available default options.
@@ -48,3 +50,10 @@ func Config(val *config.Config) Option {
 		o.Config = val
 	}
 }
+
+// Reload provides a function to set the reload option.
+func Reload(val *reload.Watcher) Option {
+	return func(o *Options) {
+		o.Reload = val
+	}
+}
@@ -15,7 +15,7 @@ import (
 func Server(opts ...Option) (*http.Server, error) {
 	options := newOptions(opts...)
 
-	return debug.NewService(
+	dopts := []debug.Option{
 		debug.Logger(options.Logger),
 		debug.Name(options.Config.Service.Name),
 		debug.Version(version.GetString()),
@@ -26,7 +26,12 @@ func Server(opts ...Option) (*http.Server, error) {
 		debug.Health(health(options.Config)),
 		debug.Ready(ready(options.Config)),
 		debug.ConfigDump(configDump(options.Config)),
-	), nil
+	}
+	if options.Reload != nil {
+		dopts = append(dopts, debug.Handler("/reload", options.Reload))
+	}
+
+	return debug.NewService(dopts...), nil
 }
 
 // health implements the health check.
@@ -36,6 +36,11 @@ func Index(cfg *config.Config) *cli.Command {
 				Required: true,
 				Usage:    "the username of the user that shall be used to access the files",
 			},
+			&cli.BoolFlag{
+				Name:    "background",
+				Aliases: []string{"b"},
+				Usage:   "start the indexing in the background and return immediately, use 'index-status' to follow the progress",
+			},
 		},
 		Before: func(c *cli.Context) error {
 			return configlog.ReturnFatal(parser.ParseConfig(cfg))
@@ -55,14 +60,18 @@ func Index(cfg *config.Config) *cli.Command {
 			}
 
 			c := searchsvc.NewSearchProviderService("com.owncloud.api.search", grpcClient)
-			_, err = c.IndexSpace(context.Background(), &searchsvc.IndexSpaceRequest{
-				SpaceId: ctx.String("space"),
-				UserId:  ctx.String("user"),
+			res, err := c.IndexSpace(context.Background(), &searchsvc.IndexSpaceRequest{
+				SpaceId:    ctx.String("space"),
+				UserId:     ctx.String("user"),
+				Background: ctx.Bool("background"),
 			}, func(opts *client.CallOptions) { opts.RequestTimeout = 10 * time.Minute })
 			if err != nil {
 				fmt.Println("failed to index space: " + err.Error())
 				return err
 			}
+			if res.GetJob() != nil {
+				printIndexJobs(res.GetJob())
+			}
 			return nil
 		},
 	}
@@ -0,0 +1,99 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	tw "github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
+	"github.com/owncloud/ocis/v2/ocis-pkg/service/grpc"
+	"github.com/owncloud/ocis/v2/ocis-pkg/tracing"
+	searchmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
+	searchsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
+	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+	"github.com/owncloud/ocis/v2/services/search/pkg/config/parser"
+)
+
+// IndexStatus is the entrypoint for the index-status command.
+func IndexStatus(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:     "index-status",
+		Usage:    "show the progress of the index jobs",
+		Category: "index management",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "space",
+				Aliases: []string{"s"},
+				Usage:   "the id of the space to show the index job of, all spaces if omitted",
+			},
+		},
+		Before: func(c *cli.Context) error {
+			return configlog.ReturnFatal(parser.ParseConfig(cfg))
+		},
+		Action: func(ctx *cli.Context) error {
+			traceProvider, err := tracing.GetServiceTraceProvider(cfg.Tracing, cfg.Service.Name)
+			if err != nil {
+				return err
+			}
+			grpcClient, err := grpc.NewClient(
+				append(grpc.GetClientOptions(cfg.GRPCClientTLS),
+					grpc.WithTraceProvider(traceProvider),
+				)...,
+			)
+			if err != nil {
+				return err
+			}
+
+			c := searchsvc.NewSearchProviderService("com.owncloud.api.search", grpcClient)
+			res, err := c.GetIndexStatus(context.Background(), &searchsvc.GetIndexStatusRequest{
+				SpaceId: ctx.String("space"),
+			})
+			if err != nil {
+				fmt.Println("failed to get the index status: " + err.Error())
+				return err
+			}
+
+			if len(res.GetJobs()) == 0 {
+				fmt.Println("No index jobs found.")
+				return nil
+			}
+			printIndexJobs(res.GetJobs()...)
+			return nil
+		},
+	}
+}
+
+func printIndexJobs(jobs ...*searchmsg.IndexJob) {
+	table := tw.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Space", "State", "Processed", "Indexed", "Skipped", "Failed", "Pending folders", "Current path", "Started", "Updated", "Error"})
+	table.SetAutoFormatHeaders(false)
+	for _, j := range jobs {
+		table.Append([]string{
+			j.GetSpaceId(),
+			j.GetState(),
+			strconv.FormatUint(j.GetProcessed(), 10),
+			strconv.FormatUint(j.GetIndexed(), 10),
+			strconv.FormatUint(j.GetSkipped(), 10),
+			strconv.FormatUint(j.GetFailed(), 10),
+			strconv.FormatUint(j.GetPending(), 10),
+			j.GetCurrentPath(),
+			formatTimestamp(j.GetStartedAt()),
+			formatTimestamp(j.GetUpdatedAt()),
+			j.GetError(),
+		})
+	}
+	table.Render()
+}
+
+func formatTimestamp(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Local().Format(time.RFC3339)
+}
@@ -27,6 +27,7 @@ type Config struct {
 	Events                     Events                `yaml:"events"`
 	Engine                     Engine                `yaml:"engine"`
 	Extractor                  Extractor             `yaml:"extractor"`
+	IndexJobs                  IndexJobs             `yaml:"index_jobs"`
 	ContentExtractionSizeLimit uint64                `yaml:"content_extraction_size_limit" env:"SEARCH_CONTENT_EXTRACTION_SIZE_LIMIT" desc:"Maximum file size in bytes that is allowed for content extraction." introductionVersion:"pre5.0"`
 
 	ServiceAccount ServiceAccount `yaml:"service_account"`
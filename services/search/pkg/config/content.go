@@ -1,10 +1,13 @@
 package config
 
+import "time"
+
 // Extractor defines which extractor to use
 type Extractor struct {
 	Type             string        `yaml:"type" env:"SEARCH_EXTRACTOR_TYPE" desc:"Defines the content extraction engine. Defaults to 'basic'. Supported values are: 'basic' and 'tika'." introductionVersion:"pre5.0"`
 	CS3AllowInsecure bool          `yaml:"cs3_allow_insecure" env:"OCIS_INSECURE;SEARCH_EXTRACTOR_CS3SOURCE_INSECURE" desc:"Ignore untrusted SSL certificates when connecting to the CS3 source." introductionVersion:"pre5.0"`
 	Tika             ExtractorTika `yaml:"tika"`
+	OCR              ExtractorOCR  `yaml:"ocr"`
 }
 
 // ExtractorTika configures the Tika extractor
@@ -12,3 +15,14 @@ type ExtractorTika struct {
 	TikaURL        string `yaml:"tika_url" env:"SEARCH_EXTRACTOR_TIKA_TIKA_URL" desc:"URL of the tika server." introductionVersion:"pre5.0"`
 	CleanStopWords bool   `yaml:"clean_stop_words" env:"SEARCH_EXTRACTOR_TIKA_CLEAN_STOP_WORDS" desc:"Defines if stop words should be cleaned or not. See the documentation for more details." introductionVersion:"5.0"`
 }
+
+// ExtractorOCR configures the OCR extractor
+type ExtractorOCR struct {
+	Enabled    bool          `yaml:"enabled" env:"SEARCH_EXTRACTOR_OCR_ENABLED" desc:"Recognize the text of images and scanned documents after the configured extractor. The text is only recognized if the extractor did not find any content." introductionVersion:"6.0.0"`
+	URL        string        `yaml:"url" env:"SEARCH_EXTRACTOR_OCR_URL" desc:"URL of an HTTP OCR service. The file is sent as the body of a POST request and the service must respond with the recognized plain text. Takes precedence over the command." introductionVersion:"6.0.0"`
+	Command    []string      `yaml:"command" env:"SEARCH_EXTRACTOR_OCR_COMMAND" desc:"The OCR command and its arguments. The file is passed on stdin and the recognized text is read from stdout. Defaults to 'tesseract,stdin,stdout'. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+	PDFCommand []string      `yaml:"pdf_command" env:"SEARCH_EXTRACTOR_OCR_PDF_COMMAND" desc:"The command converting the pages of scanned PDF files to images before their text is recognized with the OCR command. The path of the PDF file and a prefix for the images are appended as arguments, one image per page must be written with this prefix. Defaults to 'pdftoppm,-r,300,-png'. PDF files are skipped if the command can not be found. Not used with an OCR url. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+	MimeTypes  []string      `yaml:"mime_types" env:"SEARCH_EXTRACTOR_OCR_MIME_TYPES" desc:"A list of mime types to recognize the text of. A trailing '*' matches all subtypes, for example 'image/*'. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+	SizeLimit  uint64        `yaml:"size_limit" env:"SEARCH_EXTRACTOR_OCR_SIZE_LIMIT" desc:"Maximum file size in bytes that is allowed for text recognition. Defaults to 10MB." introductionVersion:"6.0.0"`
+	Timeout    time.Duration `yaml:"timeout" env:"SEARCH_EXTRACTOR_OCR_TIMEOUT" desc:"Timeout for the recognition of a single file. Defaults to '1m'. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+}
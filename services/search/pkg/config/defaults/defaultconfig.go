@@ -2,6 +2,7 @@ package defaults
 
 import (
 	"path/filepath"
+	"time"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/defaults"
 	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
@@ -38,6 +39,17 @@ func DefaultConfig() *config.Config {
 			Bleve: config.EngineBleve{
 				Datapath: filepath.Join(defaults.BaseDataPath(), "search"),
 			},
+			OpenSearch: config.EngineOpenSearch{
+				URL:   "http://127.0.0.1:9200",
+				Index: "ocis-resources",
+			},
+			Embedding: config.EngineEmbedding{
+				URL:        "http://127.0.0.1:11434/v1/embeddings",
+				Model:      "nomic-embed-text",
+				Timeout:    30 * time.Second,
+				MaxLength:  8000,
+				Candidates: 50,
+			},
 		},
 		Extractor: config.Extractor{
 			Type:             "basic",
@@ -46,6 +58,13 @@ func DefaultConfig() *config.Config {
 				TikaURL:        "http://127.0.0.1:9998",
 				CleanStopWords: true,
 			},
+			OCR: config.ExtractorOCR{
+				Command:    []string{"tesseract", "stdin", "stdout"},
+				PDFCommand: []string{"pdftoppm", "-r", "300", "-png"},
+				MimeTypes:  []string{"image/png", "image/jpeg", "image/tiff", "image/bmp", "image/webp", "image/gif", "application/pdf"},
+				SizeLimit:  10 * 1024 * 1024,
+				Timeout:    time.Minute,
+			},
 		},
 		Events: config.Events{
 			Endpoint:         "127.0.0.1:9233",
@@ -55,6 +74,10 @@ func DefaultConfig() *config.Config {
 			EnableTLS:        false,
 		},
 		ContentExtractionSizeLimit: 20 * 1024 * 1024, // Limit content extraction to <20MB files by default
+		IndexJobs: config.IndexJobs{
+			DataPath:      filepath.Join(defaults.BaseDataPath(), "search", "jobs"),
+			YieldToSearch: true,
+		},
 	}
 }
 
@@ -1,12 +1,36 @@
 package config
 
+import "time"
+
 // Engine defines which search engine to use
 type Engine struct {
-	Type  string      `yaml:"type" env:"SEARCH_ENGINE_TYPE" desc:"Defines which search engine to use. Defaults to 'bleve'. Supported values are: 'bleve'." introductionVersion:"pre5.0"`
-	Bleve EngineBleve `yaml:"bleve"`
+	Type       string           `yaml:"type" env:"SEARCH_ENGINE_TYPE" desc:"Defines which search engine to use. Defaults to 'bleve'. Supported values are: 'bleve' and 'opensearch'." introductionVersion:"pre5.0"`
+	Bleve      EngineBleve      `yaml:"bleve"`
+	OpenSearch EngineOpenSearch `yaml:"opensearch"`
+	Embedding  EngineEmbedding  `yaml:"embedding"`
 }
 
 // EngineBleve configures the bleve engine
 type EngineBleve struct {
 	Datapath string `yaml:"data_path" env:"SEARCH_ENGINE_BLEVE_DATA_PATH" desc:"The directory where the filesystem will store search data. If not defined, the root directory derives from $OCIS_BASE_DATA_PATH:/search." introductionVersion:"pre5.0"`
 }
+
+// EngineOpenSearch configures the OpenSearch engine
+type EngineOpenSearch struct {
+	URL      string `yaml:"url" env:"SEARCH_ENGINE_OPENSEARCH_URL" desc:"The URL of the OpenSearch compatible server." introductionVersion:"6.0.0"`
+	Index    string `yaml:"index" env:"SEARCH_ENGINE_OPENSEARCH_INDEX" desc:"The name of the index holding the resources. All search service replicas must use the same index." introductionVersion:"6.0.0"`
+	Username string `yaml:"username" env:"SEARCH_ENGINE_OPENSEARCH_USERNAME" desc:"The username to authenticate with the OpenSearch server." introductionVersion:"6.0.0"`
+	Password string `yaml:"password" env:"SEARCH_ENGINE_OPENSEARCH_PASSWORD" desc:"The password to authenticate with the OpenSearch server." introductionVersion:"6.0.0"`
+	Insecure bool   `yaml:"insecure" env:"OCIS_INSECURE;SEARCH_ENGINE_OPENSEARCH_INSECURE" desc:"Ignore untrusted SSL certificates when connecting to the OpenSearch server." introductionVersion:"6.0.0"`
+}
+
+// EngineEmbedding configures the embedding of the resources for similarity searches
+type EngineEmbedding struct {
+	Enabled    bool          `yaml:"enabled" env:"SEARCH_ENGINE_EMBEDDING_ENABLED" desc:"Embed the extracted content of the resources to find resources with the 'similar:' operator. Only supported with the bleve engine, the vectors are stored next to the bleve index in the directory of SEARCH_ENGINE_BLEVE_DATA_PATH." introductionVersion:"6.0.0"`
+	URL        string        `yaml:"url" env:"SEARCH_ENGINE_EMBEDDING_URL" desc:"The URL of an embeddings endpoint compatible with the OpenAI embeddings API, for example of a local Ollama or LocalAI server." introductionVersion:"6.0.0"`
+	Model      string        `yaml:"model" env:"SEARCH_ENGINE_EMBEDDING_MODEL" desc:"The name of the embedding model. The resources must be reindexed after changing the model." introductionVersion:"6.0.0"`
+	APIKey     string        `yaml:"api_key" env:"SEARCH_ENGINE_EMBEDDING_API_KEY" desc:"An optional API key sent as bearer token to the embeddings endpoint." introductionVersion:"6.0.0"`
+	Timeout    time.Duration `yaml:"timeout" env:"SEARCH_ENGINE_EMBEDDING_TIMEOUT" desc:"Timeout for embedding a single resource or query. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+	MaxLength  int           `yaml:"max_length" env:"SEARCH_ENGINE_EMBEDDING_MAX_LENGTH" desc:"The maximum number of characters of the content which are embedded. Longer content is truncated." introductionVersion:"6.0.0"`
+	Candidates int           `yaml:"candidates" env:"SEARCH_ENGINE_EMBEDDING_CANDIDATES" desc:"The number of most similar resources which are combined with the results of the KQL query." introductionVersion:"6.0.0"`
+}
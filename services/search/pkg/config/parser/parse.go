@@ -2,6 +2,7 @@ package parser
 
 import (
 	"errors"
+	"fmt"
 
 	ociscfg "github.com/owncloud/ocis/v2/ocis-pkg/config"
 	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
@@ -45,5 +46,10 @@ func Validate(cfg *config.Config) error {
 		return shared.MissingServiceAccountSecret(cfg.Service.Name)
 	}
 
+	// the vectors are stored on the local disk of each replica, only the bleve engine has the same limitation
+	if cfg.Engine.Embedding.Enabled && cfg.Engine.Type != "bleve" {
+		return fmt.Errorf("the similarity search can only be enabled with the bleve engine, the %s engine is configured", cfg.Engine.Type)
+	}
+
 	return nil
 }
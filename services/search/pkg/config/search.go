@@ -14,3 +14,10 @@ type Events struct {
 	AuthUsername         string `yaml:"username" env:"OCIS_EVENTS_AUTH_USERNAME;SEARCH_EVENTS_AUTH_USERNAME" desc:"The username to authenticate with the events broker. The events broker is the ocis service which receives and delivers events between the services." introductionVersion:"5.0"`
 	AuthPassword         string `yaml:"password" env:"OCIS_EVENTS_AUTH_PASSWORD;SEARCH_EVENTS_AUTH_PASSWORD" desc:"The password to authenticate with the events broker. The events broker is the ocis service which receives and delivers events between the services." introductionVersion:"5.0"`
 }
+
+// IndexJobs configures the jobs which index whole spaces.
+type IndexJobs struct {
+	DataPath      string `yaml:"data_path" env:"SEARCH_INDEX_JOBS_DATA_PATH" desc:"The directory where the checkpoints of the indexing jobs are stored. Interrupted jobs resume from their last checkpoint. If not defined, the root directory derives from $OCIS_BASE_DATA_PATH:/search/jobs." introductionVersion:"6.0.0"`
+	RateLimit     int    `yaml:"rate_limit" env:"SEARCH_INDEX_JOBS_RATE_LIMIT" desc:"The maximum number of resources per second an indexing job extracts and indexes, unchanged resources are not counted. Defaults to '0' which does not limit the rate." introductionVersion:"6.0.0"`
+	YieldToSearch bool   `yaml:"yield_to_search" env:"SEARCH_INDEX_JOBS_YIELD_TO_SEARCH" desc:"Pause the indexing jobs while searches are running, so that indexing does not slow down interactive searches. A job waits at most one second per resource." introductionVersion:"6.0.0"`
+}
@@ -0,0 +1,236 @@
+package content
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+)
+
+// OCR is used to recognize the text of images and scanned documents,
+// it runs after another extractor and adds the recognized text to the content of its Document.
+type OCR struct {
+	Extractor
+	Retriever
+	logger     log.Logger
+	httpClient http.Client
+	url        string
+	command    []string
+	pdfCommand []string
+	mimeTypes  []string
+	sizeLimit  uint64
+	timeout    time.Duration
+}
+
+// NewOCRExtractor creates a new OCR instance which recognizes the text after the given extractor.
+func NewOCRExtractor(extractor Extractor, gatewaySelector pool.Selectable[gateway.GatewayAPIClient], logger log.Logger, cfg *config.Config) (*OCR, error) {
+	ocrCfg := cfg.Extractor.OCR
+	if ocrCfg.URL == "" {
+		if len(ocrCfg.Command) == 0 {
+			return nil, errors.New("neither an OCR url nor an OCR command is configured")
+		}
+		if _, err := exec.LookPath(ocrCfg.Command[0]); err != nil {
+			return nil, fmt.Errorf("could not find the OCR command: %w", err)
+		}
+	}
+
+	// OCR commands like tesseract can't read PDF files, their pages are converted to images first
+	pdfCommand := ocrCfg.PDFCommand
+	if ocrCfg.URL == "" && len(pdfCommand) > 0 {
+		if _, err := exec.LookPath(pdfCommand[0]); err != nil {
+			logger.Warn().Err(err).Str("command", pdfCommand[0]).Msg("could not find the PDF command, the text of scanned PDF files will not be recognized")
+			pdfCommand = nil
+		}
+	}
+
+	return &OCR{
+		Extractor:  extractor,
+		Retriever:  newCS3Retriever(gatewaySelector, logger, cfg.Extractor.CS3AllowInsecure),
+		logger:     logger,
+		httpClient: http.Client{},
+		url:        ocrCfg.URL,
+		command:    ocrCfg.Command,
+		pdfCommand: pdfCommand,
+		mimeTypes:  ocrCfg.MimeTypes,
+		sizeLimit:  ocrCfg.SizeLimit,
+		timeout:    ocrCfg.Timeout,
+	}, nil
+}
+
+// Extract runs the wrapped extractor and recognizes the text of the resource if the extractor did not find any content.
+func (o OCR) Extract(ctx context.Context, ri *provider.ResourceInfo) (Document, error) {
+	doc, err := o.Extractor.Extract(ctx, ri)
+	if err != nil {
+		return doc, err
+	}
+
+	if ri.Type != provider.ResourceType_RESOURCE_TYPE_FILE || ri.Size == 0 || strings.TrimSpace(doc.Content) != "" {
+		return doc, nil
+	}
+
+	if !o.supports(ri.MimeType) {
+		return doc, nil
+	}
+
+	if ri.Size > o.sizeLimit {
+		o.logger.Info().Interface("ResourceID", ri.Id).Str("Name", ri.Name).Msg("file exceeds ocr size limit. skipping.")
+		return doc, nil
+	}
+
+	isPDF := ri.MimeType == "application/pdf" && o.url == ""
+	if isPDF && len(o.pdfCommand) == 0 {
+		return doc, nil
+	}
+
+	data, err := o.Retrieve(ctx, ri.Id)
+	if err != nil {
+		o.logger.Error().Err(err).Interface("ResourceID", ri.Id).Str("Name", ri.Name).Msg("could not retrieve the file for ocr. indexing without content.")
+		return doc, nil
+	}
+	defer data.Close()
+
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	var text string
+	switch {
+	case o.url != "":
+		text, err = o.recognizeHTTP(ctx, ri.MimeType, data)
+	case isPDF:
+		text, err = o.recognizePDF(ctx, data)
+	default:
+		text, err = o.recognizeCommand(ctx, data)
+	}
+	if err != nil {
+		// the file is still indexed by its name and metadata
+		o.logger.Error().Err(err).Interface("ResourceID", ri.Id).Str("Name", ri.Name).Msg("ocr failed. indexing without content.")
+		return doc, nil
+	}
+
+	doc.Content = strings.TrimSpace(text)
+	return doc, nil
+}
+
+func (o OCR) supports(mimeType string) bool {
+	for _, m := range o.mimeTypes {
+		if prefix, ok := strings.CutSuffix(m, "*"); ok {
+			if strings.HasPrefix(mimeType, prefix) {
+				return true
+			}
+			continue
+		}
+		if m == mimeType {
+			return true
+		}
+	}
+	return false
+}
+
+func (o OCR) recognizeHTTP(ctx context.Context, mimeType string, data io.Reader) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.url, data)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", mimeType)
+	req.Header.Set("Accept", "text/plain")
+
+	res, err := o.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("ocr service responded with status: %s", res.Status)
+	}
+
+	text, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(text), nil
+}
+
+// recognizePDF converts the pages of the PDF file to images with the PDF command and recognizes their text
+// with the OCR command. The PDF command gets the path of the file and the prefix of the images as arguments.
+func (o OCR) recognizePDF(ctx context.Context, data io.Reader) (string, error) {
+	dir, err := os.MkdirTemp("", "ocis-search-ocr-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	pdf := filepath.Join(dir, "document.pdf")
+	f, err := os.Create(pdf)
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(f, data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var stderr bytes.Buffer
+	args := append(append([]string{}, o.pdfCommand[1:]...), pdf, filepath.Join(dir, "page"))
+	cmd := exec.CommandContext(ctx, o.pdfCommand[0], args...) //nolint:gosec
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("pdf command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	// the page numbers are zero padded, the lexical order is the page order
+	pages, err := filepath.Glob(filepath.Join(dir, "page*"))
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(pages)
+
+	texts := make([]string, 0, len(pages))
+	for _, page := range pages {
+		img, err := os.Open(page)
+		if err != nil {
+			return "", err
+		}
+		text, err := o.recognizeCommand(ctx, img)
+		img.Close()
+		if err != nil {
+			return "", err
+		}
+		texts = append(texts, strings.TrimSpace(text))
+	}
+	return strings.Join(texts, "\n"), nil
+}
+
+func (o OCR) recognizeCommand(ctx context.Context, data io.Reader) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, o.command[0], o.command[1:]...) //nolint:gosec
+	cmd.Stdin = data
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("ocr command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
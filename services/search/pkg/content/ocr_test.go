@@ -0,0 +1,179 @@
+package content_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+	conf "github.com/owncloud/ocis/v2/services/search/pkg/config/defaults"
+	"github.com/owncloud/ocis/v2/services/search/pkg/content"
+	contentMocks "github.com/owncloud/ocis/v2/services/search/pkg/content/mocks"
+)
+
+var _ = Describe("OCR", func() {
+	var (
+		body      string
+		cfg       *config.Config
+		retriever *contentMocks.Retriever
+		image     *provider.ResourceInfo
+
+		newOCR = func(extractor content.Extractor) *content.OCR {
+			ocr, err := content.NewOCRExtractor(extractor, nil, log.NewLogger(), cfg)
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+			ocr.Retriever = retriever
+			return ocr
+		}
+	)
+
+	BeforeEach(func() {
+		body = "whiteboard notes"
+		cfg = conf.DefaultConfig()
+		// cat echoes the file which makes it a predictable stand-in for the OCR command
+		cfg.Extractor.OCR.Command = []string{"cat"}
+
+		retriever = &contentMocks.Retriever{}
+		retriever.On("Retrieve", mock.Anything, mock.Anything).Return(func(context.Context, *provider.ResourceId) io.ReadCloser {
+			return io.NopCloser(strings.NewReader(body))
+		}, nil)
+
+		image = &provider.ResourceInfo{
+			Type:     provider.ResourceType_RESOURCE_TYPE_FILE,
+			Name:     "whiteboard.png",
+			MimeType: "image/png",
+			Size:     uint64(len(body)),
+		}
+	})
+
+	It("fails if the command can not be found", func() {
+		cfg.Extractor.OCR.Command = []string{"does-not-exist-ocr"}
+		_, err := content.NewOCRExtractor(&content.Basic{}, nil, log.NewLogger(), cfg)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("recognizes the text with the command", func() {
+		doc, err := newOCR(&content.Basic{}).Extract(context.TODO(), image)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(doc.Name).To(Equal("whiteboard.png"))
+		Expect(doc.Content).To(Equal(body))
+	})
+
+	It("recognizes the text with the http service", func() {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			Expect(req.Method).To(Equal(http.MethodPost))
+			Expect(req.Header.Get("Content-Type")).To(Equal("image/png"))
+			b, _ := io.ReadAll(req.Body)
+			_, _ = w.Write([]byte(" recognized " + string(b) + "\n"))
+		}))
+		defer srv.Close()
+		cfg.Extractor.OCR.URL = srv.URL
+
+		doc, err := newOCR(&content.Basic{}).Extract(context.TODO(), image)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(doc.Content).To(Equal("recognized " + body))
+	})
+
+	It("indexes the file without content if the http service fails", func() {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+		cfg.Extractor.OCR.URL = srv.URL
+
+		doc, err := newOCR(&content.Basic{}).Extract(context.TODO(), image)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(doc.Name).To(Equal("whiteboard.png"))
+		Expect(doc.Content).To(BeEmpty())
+	})
+
+	It("indexes the file without content if the command fails", func() {
+		cfg.Extractor.OCR.Command = []string{"false"}
+
+		doc, err := newOCR(&content.Basic{}).Extract(context.TODO(), image)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(doc.Name).To(Equal("whiteboard.png"))
+		Expect(doc.Content).To(BeEmpty())
+	})
+
+	Describe("scanned PDF files", func() {
+		var pdf *provider.ResourceInfo
+
+		BeforeEach(func() {
+			pdf = &provider.ResourceInfo{
+				Type:     provider.ResourceType_RESOURCE_TYPE_FILE,
+				Name:     "scan.pdf",
+				MimeType: "application/pdf",
+				Size:     uint64(len(body)),
+			}
+
+			// the fake PDF command writes the file as two pages, like pdftoppm does for a two page document
+			dir := GinkgoT().TempDir()
+			command := filepath.Join(dir, "topages")
+			script := "#!/bin/sh\nsed 's/^/page one: /' \"$1\" > \"$2-1.png\"\nsed 's/^/page two: /' \"$1\" > \"$2-2.png\"\n"
+			Expect(os.WriteFile(command, []byte(script), 0o700)).To(Succeed())
+			cfg.Extractor.OCR.PDFCommand = []string{command}
+		})
+
+		It("recognizes the text of all pages", func() {
+			doc, err := newOCR(&content.Basic{}).Extract(context.TODO(), pdf)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(doc.Content).To(Equal("page one: " + body + "\npage two: " + body))
+		})
+
+		It("skips PDF files if the PDF command can not be found", func() {
+			cfg.Extractor.OCR.PDFCommand = []string{"does-not-exist-pdf"}
+
+			doc, err := newOCR(&content.Basic{}).Extract(context.TODO(), pdf)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(doc.Content).To(BeEmpty())
+			retriever.AssertNotCalled(GinkgoT(), "Retrieve", mock.Anything, mock.Anything)
+		})
+	})
+
+	It("matches the mime types by prefix", func() {
+		cfg.Extractor.OCR.MimeTypes = []string{"image/*"}
+		image.MimeType = "image/x-custom"
+
+		doc, err := newOCR(&content.Basic{}).Extract(context.TODO(), image)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(doc.Content).To(Equal(body))
+	})
+
+	It("skips unsupported mime types", func() {
+		image.MimeType = "application/zip"
+
+		doc, err := newOCR(&content.Basic{}).Extract(context.TODO(), image)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(doc.Content).To(BeEmpty())
+		retriever.AssertNotCalled(GinkgoT(), "Retrieve", mock.Anything, mock.Anything)
+	})
+
+	It("skips files exceeding the size limit", func() {
+		cfg.Extractor.OCR.SizeLimit = 1
+
+		doc, err := newOCR(&content.Basic{}).Extract(context.TODO(), image)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(doc.Content).To(BeEmpty())
+		retriever.AssertNotCalled(GinkgoT(), "Retrieve", mock.Anything, mock.Anything)
+	})
+
+	It("keeps the content of the previous extractor", func() {
+		extractor := &contentMocks.Extractor{}
+		extractor.On("Extract", mock.Anything, mock.Anything).Return(content.Document{Content: "extracted"}, nil)
+
+		doc, err := newOCR(extractor).Extract(context.TODO(), image)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(doc.Content).To(Equal("extracted"))
+		retriever.AssertNotCalled(GinkgoT(), "Retrieve", mock.Anything, mock.Anything)
+	})
+})
@@ -0,0 +1,83 @@
+// Package embedding provides the vector embeddings of texts used for similarity searches.
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+)
+
+// Provider is the interface that wraps the basic Embed method.
+// It returns the vector embedding of the text, vectors of similar texts have a small cosine distance.
+type Provider interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
+
+// HTTP is a Provider which requests the embeddings from an endpoint compatible with the OpenAI embeddings API.
+type HTTP struct {
+	client http.Client
+	url    string
+	model  string
+	apiKey string
+}
+
+// NewHTTPProvider creates a new HTTP provider.
+func NewHTTPProvider(cfg config.EngineEmbedding) *HTTP {
+	return &HTTP{
+		client: http.Client{Timeout: cfg.Timeout},
+		url:    cfg.URL,
+		model:  cfg.Model,
+		apiKey: cfg.APIKey,
+	}
+}
+
+// Embed implements the Provider interface.
+func (h *HTTP) Embed(ctx context.Context, text string) ([]float32, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"model": h.model,
+		"input": text,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+h.apiKey)
+	}
+
+	res, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return nil, fmt.Errorf("embedding request failed: %s: %s", res.Status, bytes.TrimSpace(b))
+	}
+
+	var result struct {
+		Data []struct {
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if len(result.Data) == 0 || len(result.Data[0].Embedding) == 0 {
+		return nil, errors.New("the embedding response contains no embedding")
+	}
+
+	return result.Data[0].Embedding, nil
+}
@@ -0,0 +1,45 @@
+package embedding_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	tAssert "github.com/stretchr/testify/assert"
+
+	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+	"github.com/owncloud/ocis/v2/services/search/pkg/embedding"
+)
+
+func TestHTTP_Embed(t *testing.T) {
+	assert := tAssert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch {
+		case r.Header.Get("Authorization") != "Bearer secret":
+			w.WriteHeader(http.StatusUnauthorized)
+		case body["input"] == "":
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		default:
+			assert.Equal("test-model", body["model"])
+			_, _ = w.Write([]byte(`{"data":[{"embedding":[0.5,-1,2]}]}`))
+		}
+	}))
+	defer srv.Close()
+
+	p := embedding.NewHTTPProvider(config.EngineEmbedding{URL: srv.URL, Model: "test-model", APIKey: "secret"})
+
+	vector, err := p.Embed(context.Background(), "some text")
+	assert.NoError(err)
+	assert.Equal([]float32{0.5, -1, 2}, vector)
+
+	_, err = p.Embed(context.Background(), "")
+	assert.Error(err)
+
+	_, err = embedding.NewHTTPProvider(config.EngineEmbedding{URL: srv.URL}).Embed(context.Background(), "some text")
+	assert.Error(err)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/blevesearch/bleve/v2/analysis/tokenizer/single"
 	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
 	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search"
 	"github.com/blevesearch/bleve/v2/search/query"
 	storageProvider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -154,11 +155,32 @@ func (b *Bleve) Search(ctx context.Context, sir *searchService.SearchIndexReques
 				),
 			},
 		)
+
+		// restrict the query to the path to get the facets of the matches in the path only
+		if requestedPath := utils.MakeRelativePath(sir.Ref.Path); requestedPath != "." {
+			pathQuery := bleve.NewTermQuery(requestedPath)
+			pathQuery.SetField("Path")
+			descendantsQuery := bleve.NewPrefixQuery(requestedPath + "/")
+			descendantsQuery.SetField("Path")
+			q.Conjuncts = append(q.Conjuncts, bleve.NewDisjunctionQuery(pathQuery, descendantsQuery))
+		}
 	}
 
 	bleveReq := bleve.NewSearchRequest(q)
 	bleveReq.Highlight = bleve.NewHighlight()
 
+	if sir.Facets {
+		bleveReq.AddFacet(FacetMediaType, bleve.NewFacetRequest("MimeType", _facetSize))
+		bleveReq.AddFacet(FacetTags, bleve.NewFacetRequest("Tags", _facetSize))
+		bleveReq.AddFacet(FacetSpace, bleve.NewFacetRequest("RootID", _facetSize))
+		ranges := mtimeRanges(time.Now())
+		mtimeFacet := bleve.NewFacetRequest("Mtime", len(ranges))
+		for _, r := range ranges {
+			mtimeFacet.AddDateTimeRange(r.name, r.start, r.end)
+		}
+		bleveReq.AddFacet(FacetMtime, mtimeFacet)
+	}
+
 	switch {
 	case sir.PageSize == -1:
 		bleveReq.Size = math.MaxInt
@@ -188,53 +210,53 @@ func (b *Bleve) Search(ctx context.Context, sir *searchService.SearchIndexReques
 			}
 		}
 
-		rootID, err := storagespace.ParseID(getFieldValue[string](hit.Fields, "RootID"))
+		match, err := newMatch(hit.Fields, hit.Score, getFragmentValue(hit.Fragments, "Content", 0))
 		if err != nil {
 			return nil, err
 		}
 
-		rID, err := storagespace.ParseID(getFieldValue[string](hit.Fields, "ID"))
-		if err != nil {
-			return nil, err
-		}
-
-		pID, _ := storagespace.ParseID(getFieldValue[string](hit.Fields, "ParentID"))
-		match := &searchMessage.Match{
-			Score: float32(hit.Score),
-			Entity: &searchMessage.Entity{
-				Ref: &searchMessage.Reference{
-					ResourceId: resourceIDtoSearchID(rootID),
-					Path:       getFieldValue[string](hit.Fields, "Path"),
-				},
-				Id:         resourceIDtoSearchID(rID),
-				Name:       getFieldValue[string](hit.Fields, "Name"),
-				ParentId:   resourceIDtoSearchID(pID),
-				Size:       uint64(getFieldValue[float64](hit.Fields, "Size")),
-				Type:       uint64(getFieldValue[float64](hit.Fields, "Type")),
-				MimeType:   getFieldValue[string](hit.Fields, "MimeType"),
-				Deleted:    getFieldValue[bool](hit.Fields, "Deleted"),
-				Tags:       getFieldSliceValue[string](hit.Fields, "Tags"),
-				Highlights: getFragmentValue(hit.Fragments, "Content", 0),
-				Audio:      getAudioValue[searchMessage.Audio](hit.Fields),
-				Image:      getImageValue[searchMessage.Image](hit.Fields),
-				Location:   getLocationValue[searchMessage.GeoCoordinates](hit.Fields),
-				Photo:      getPhotoValue[searchMessage.Photo](hit.Fields),
-			},
-		}
-
-		if mtime, err := time.Parse(time.RFC3339, getFieldValue[string](hit.Fields, "Mtime")); err == nil {
-			match.Entity.LastModifiedTime = &timestamppb.Timestamp{Seconds: mtime.Unix(), Nanos: int32(mtime.Nanosecond())}
-		}
-
 		matches = append(matches, match)
 	}
 
 	return &searchService.SearchIndexResponse{
 		Matches:      matches,
 		TotalMatches: int32(totalMatches),
+		Facets:       bleveFacets(res.Facets),
 	}, nil
 }
 
+// bleveFacets converts the facet results, the facets are returned in the order of the engine.Facet* constants
+func bleveFacets(results search.FacetResults) []*searchMessage.Facet {
+	if len(results) == 0 {
+		return nil
+	}
+
+	facets := make([]*searchMessage.Facet, 0, len(results))
+	for _, name := range []string{FacetMediaType, FacetTags, FacetMtime, FacetSpace} {
+		result, ok := results[name]
+		if !ok {
+			continue
+		}
+
+		facet := &searchMessage.Facet{Name: name}
+		for _, t := range result.Terms.Terms() {
+			facet.Values = append(facet.Values, &searchMessage.FacetValue{Value: t.Term, Count: uint64(t.Count)})
+		}
+		if name == FacetMtime {
+			counts := make(map[string]int, len(result.DateRanges))
+			for _, r := range result.DateRanges {
+				counts[r.Name] = r.Count
+			}
+			// keep the order of the ranges, bleve sorts them by count
+			for _, r := range mtimeRanges(time.Now()) {
+				facet.Values = append(facet.Values, &searchMessage.FacetValue{Value: r.name, Count: uint64(counts[r.name])})
+			}
+		}
+		facets = append(facets, facet)
+	}
+	return facets
+}
+
 // Upsert indexes or stores Resource data fields.
 func (b *Bleve) Upsert(id string, r Resource) error {
 	return b.index.Index(id, r)
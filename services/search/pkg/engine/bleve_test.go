@@ -3,9 +3,11 @@ package engine_test
 import (
 	"context"
 	"fmt"
+	"time"
 
 	bleveSearch "github.com/blevesearch/bleve/v2"
 	sprovider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/errtypes"
 	"github.com/cs3org/reva/v2/pkg/storagespace"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -164,6 +166,14 @@ var _ = Describe("Bleve", func() {
 				})
 			})
 
+			It("rejects the similar operator without the similarity search", func() {
+				err := eng.Upsert(parentResource.ID, parentResource)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = doSearch(rootResource.ID, `similar:"parent folder"`, "")
+				Expect(err).To(MatchError(errtypes.BadRequest("the similarity search is not enabled, the query can't contain 'similar:parent folder'")))
+			})
+
 			It("limits the search to the specified fields", func() {
 				parentResource.Document.Name = "bar.pdf"
 				err := eng.Upsert(parentResource.ID, parentResource)
@@ -354,6 +364,45 @@ var _ = Describe("Bleve", func() {
 			})
 		})
 
+		Context("Facets", func() {
+			It("returns the facet counts of the matches", func() {
+				parentResource.Document.Tags = []string{"foo", "bar"}
+				parentResource.MimeType = "httpd/unix-directory"
+				parentResource.Document.Mtime = time.Now().UTC().Format(time.RFC3339Nano)
+				childResource.Document.Tags = []string{"foo"}
+				childResource.MimeType = "application/pdf"
+				childResource.Document.Mtime = time.Now().AddDate(-3, 0, 0).UTC().Format(time.RFC3339Nano)
+				Expect(eng.Upsert(parentResource.ID, parentResource)).To(Succeed())
+				Expect(eng.Upsert(childResource.ID, childResource)).To(Succeed())
+
+				res, err := eng.Search(context.Background(), &searchsvc.SearchIndexRequest{
+					Query:  "Name:*",
+					Ref:    &searchmsg.Reference{ResourceId: &searchmsg.ResourceID{StorageId: "1", SpaceId: "2", OpaqueId: "2"}},
+					Facets: true,
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res.Facets).To(HaveLen(4))
+				Expect(res.Facets[0].Name).To(Equal(engine.FacetMediaType))
+				Expect(res.Facets[0].Values).To(HaveLen(2))
+				Expect(res.Facets[1].Name).To(Equal(engine.FacetTags))
+				Expect(res.Facets[1].Values[0].Value).To(Equal("foo"))
+				Expect(res.Facets[1].Values[0].Count).To(Equal(uint64(2)))
+				Expect(res.Facets[2].Name).To(Equal(engine.FacetMtime))
+				Expect(res.Facets[2].Values[0].Value).To(Equal("today"))
+				Expect(res.Facets[2].Values[0].Count).To(Equal(uint64(1)))
+				Expect(res.Facets[3].Name).To(Equal(engine.FacetSpace))
+				Expect(res.Facets[3].Values[0].Value).To(Equal(rootResource.ID))
+				Expect(res.Facets[3].Values[0].Count).To(Equal(uint64(2)))
+			})
+
+			It("omits the facets unless requested", func() {
+				Expect(eng.Upsert(parentResource.ID, parentResource)).To(Succeed())
+
+				res, err := doSearch(rootResource.ID, "Name:*", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res.Facets).To(BeEmpty())
+			})
+		})
 	})
 
 	Describe("Upsert", func() {
@@ -3,15 +3,35 @@ package engine
 import (
 	"context"
 	"regexp"
+	"sort"
+	"time"
 
 	"github.com/blevesearch/bleve/v2/search"
 	storageProvider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+	"github.com/jinzhu/now"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	searchMessage "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
 	searchService "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
 	"github.com/owncloud/ocis/v2/services/search/pkg/content"
 )
 
+// The facets returned for searches requesting them
+const (
+	// FacetMediaType counts the matches by their mime type
+	FacetMediaType = "mediatype"
+	// FacetTags counts the matches by their tags
+	FacetTags = "tags"
+	// FacetMtime counts the matches by modification date ranges, the ranges are named like the KQL date ranges
+	FacetMtime = "mtime"
+	// FacetSpace counts the matches by their space
+	FacetSpace = "space"
+)
+
+// _facetSize is the maximum number of values of the term facets
+const _facetSize = 10
+
 var queryEscape = regexp.MustCompile(`([` + regexp.QuoteMeta(`+=&|><!(){}[]^\"~*?:\/`) + `\-\s])`)
 
 // Engine is the interface to the search engine
@@ -45,6 +65,109 @@ func resourceIDtoSearchID(id storageProvider.ResourceId) *searchMessage.Resource
 		OpaqueId:  id.GetOpaqueId()}
 }
 
+// newMatch returns the search match for the fields of an indexed resource
+func newMatch(fields map[string]interface{}, score float64, highlights string) (*searchMessage.Match, error) {
+	rootID, err := storagespace.ParseID(getFieldValue[string](fields, "RootID"))
+	if err != nil {
+		return nil, err
+	}
+
+	rID, err := storagespace.ParseID(getFieldValue[string](fields, "ID"))
+	if err != nil {
+		return nil, err
+	}
+
+	pID, _ := storagespace.ParseID(getFieldValue[string](fields, "ParentID"))
+	match := &searchMessage.Match{
+		Score: float32(score),
+		Entity: &searchMessage.Entity{
+			Ref: &searchMessage.Reference{
+				ResourceId: resourceIDtoSearchID(rootID),
+				Path:       getFieldValue[string](fields, "Path"),
+			},
+			Id:         resourceIDtoSearchID(rID),
+			Name:       getFieldValue[string](fields, "Name"),
+			ParentId:   resourceIDtoSearchID(pID),
+			Size:       uint64(getFieldValue[float64](fields, "Size")),
+			Type:       uint64(getFieldValue[float64](fields, "Type")),
+			MimeType:   getFieldValue[string](fields, "MimeType"),
+			Deleted:    getFieldValue[bool](fields, "Deleted"),
+			Tags:       getFieldSliceValue[string](fields, "Tags"),
+			Highlights: highlights,
+			Audio:      getAudioValue[searchMessage.Audio](fields),
+			Image:      getImageValue[searchMessage.Image](fields),
+			Location:   getLocationValue[searchMessage.GeoCoordinates](fields),
+			Photo:      getPhotoValue[searchMessage.Photo](fields),
+		},
+	}
+
+	if mtime, err := time.Parse(time.RFC3339, getFieldValue[string](fields, "Mtime")); err == nil {
+		match.Entity.LastModifiedTime = &timestamppb.Timestamp{Seconds: mtime.Unix(), Nanos: int32(mtime.Nanosecond())}
+	}
+
+	return match, nil
+}
+
+// dateRange is a named range of the mtime facet
+type dateRange struct {
+	name       string
+	start, end time.Time
+}
+
+// MergeFacets sums up the facet counts of several searches. The values are sorted by their count,
+// except the mtime ranges which keep their order.
+func MergeFacets(facets [][]*searchMessage.Facet) []*searchMessage.Facet {
+	merged := []*searchMessage.Facet{}
+	byName := map[string]*searchMessage.Facet{}
+	values := map[string]map[string]*searchMessage.FacetValue{}
+	for _, spaceFacets := range facets {
+		for _, f := range spaceFacets {
+			facet, ok := byName[f.GetName()]
+			if !ok {
+				facet = &searchMessage.Facet{Name: f.GetName()}
+				byName[f.GetName()] = facet
+				values[f.GetName()] = map[string]*searchMessage.FacetValue{}
+				merged = append(merged, facet)
+			}
+			for _, v := range f.GetValues() {
+				if value, ok := values[f.GetName()][v.GetValue()]; ok {
+					value.Count += v.GetCount()
+					continue
+				}
+				value := &searchMessage.FacetValue{Value: v.GetValue(), Name: v.GetName(), Count: v.GetCount()}
+				values[f.GetName()][v.GetValue()] = value
+				facet.Values = append(facet.Values, value)
+			}
+		}
+	}
+
+	for _, facet := range merged {
+		if facet.Name == FacetMtime {
+			continue
+		}
+		sort.SliceStable(facet.Values, func(i, j int) bool {
+			if facet.Values[i].Count != facet.Values[j].Count {
+				return facet.Values[i].Count > facet.Values[j].Count
+			}
+			return facet.Values[i].Value < facet.Values[j].Value
+		})
+	}
+	return merged
+}
+
+// mtimeRanges returns the ranges of the mtime facet, they match the KQL date ranges with the same name
+func mtimeRanges(t time.Time) []dateRange {
+	n := (&now.Config{WeekStartDay: time.Monday}).With(t)
+	lastYear := n.With(n.AddDate(-1, 0, 0))
+	return []dateRange{
+		{name: "today", start: n.BeginningOfDay(), end: n.EndOfDay()},
+		{name: "last 7 days", start: n.With(n.AddDate(0, 0, -6)).BeginningOfDay(), end: n.EndOfDay()},
+		{name: "last 30 days", start: n.With(n.AddDate(0, 0, -29)).BeginningOfDay(), end: n.EndOfDay()},
+		{name: "this year", start: n.BeginningOfYear(), end: n.EndOfYear()},
+		{name: "last year", start: lastYear.BeginningOfYear(), end: lastYear.EndOfYear()},
+	}
+}
+
 func escapeQuery(s string) string {
 	return queryEscape.ReplaceAllString(s, "\\$1")
 }
@@ -0,0 +1,231 @@
+package engine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	storageProvider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/ast"
+	"github.com/owncloud/ocis/v2/ocis-pkg/kql"
+	searchMessage "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
+	searchService "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
+	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+	"github.com/owncloud/ocis/v2/services/search/pkg/embedding"
+)
+
+// _rrfK dampens the influence of the top ranks in the reciprocal rank fusion
+const _rrfK = 60
+
+// _noResource replaces the similarity operators without similar resources, it matches no resource id
+const _noResource = `ID:"none"`
+
+// Hybrid represents a search engine which combines the lexical search of another engine with a similarity search.
+// It embeds the resources into the vector index when they are upserted and resolves the `similar:` operators of the queries.
+type Hybrid struct {
+	Engine
+	vectors    *VectorIndex
+	embedder   embedding.Provider
+	model      string
+	maxLength  int
+	candidates int
+}
+
+// NewHybridEngine creates a new Hybrid instance which wraps the lexical engine.
+func NewHybridEngine(lexical Engine, vectors *VectorIndex, embedder embedding.Provider, cfg config.EngineEmbedding) *Hybrid {
+	return &Hybrid{
+		Engine:     lexical,
+		vectors:    vectors,
+		embedder:   embedder,
+		model:      cfg.Model,
+		maxLength:  cfg.MaxLength,
+		candidates: cfg.Candidates,
+	}
+}
+
+// Search executes the query with the lexical engine. The `similar:` operators of the query are replaced by the ids
+// of the most similar resources, the matches are ranked by the reciprocal rank fusion of their lexical and similarity ranks.
+func (h *Hybrid) Search(ctx context.Context, sir *searchService.SearchIndexRequest) (*searchService.SearchIndexResponse, error) {
+	a, err := kql.Builder{}.Build(sir.Query)
+	if err != nil {
+		// the lexical engine reports the invalid query
+		return h.Engine.Search(ctx, sir)
+	}
+	nodes := similarNodes(a.Nodes)
+	if len(nodes) == 0 {
+		return h.Engine.Search(ctx, sir)
+	}
+
+	rootID := storagespace.FormatResourceID(storageProvider.ResourceId{
+		StorageId: sir.GetRef().GetResourceId().GetStorageId(),
+		SpaceId:   sir.GetRef().GetResourceId().GetSpaceId(),
+		OpaqueId:  sir.GetRef().GetResourceId().GetOpaqueId(),
+	})
+
+	// the similarity rank of a resource is its best rank for any of the operators
+	similarRanks := map[string]int{}
+	q := []rune(sir.Query)
+	var rewritten strings.Builder
+	last := 0
+	for _, n := range nodes {
+		// a resource id finds the resources similar to the resource, any other value the resources similar to the text
+		vector, isResource, err := h.similarVector(ctx, n.Value)
+		if err != nil {
+			return nil, err
+		}
+		hits, err := h.vectors.nearest(rootID, vector, h.candidates+1)
+		if err != nil {
+			return nil, err
+		}
+
+		var ids []string
+		for _, hit := range hits {
+			// the resource is not similar to itself
+			if (isResource && hit.id == n.Value) || len(ids) == h.candidates {
+				continue
+			}
+			if rank, ok := similarRanks[hit.id]; !ok || len(ids) < rank {
+				similarRanks[hit.id] = len(ids)
+			}
+			ids = append(ids, "ID:"+strconv.Quote(hit.id))
+		}
+
+		start := n.Loc.Start.Column - 1
+		rewritten.WriteString(string(q[last:start]))
+		switch len(ids) {
+		case 0:
+			rewritten.WriteString(_noResource)
+		default:
+			rewritten.WriteString("(" + strings.Join(ids, " OR ") + ")")
+		}
+		last = start + utf8.RuneCountInString(*n.Loc.Source)
+	}
+	rewritten.WriteString(string(q[last:]))
+
+	// the lexical ranks of all candidates are needed to fuse the ranks
+	size := sir.PageSize
+	if size > 0 && size < int32(h.candidates) {
+		size = int32(h.candidates)
+	}
+	res, err := h.Engine.Search(ctx, &searchService.SearchIndexRequest{Query: rewritten.String(), Ref: sir.Ref, PageSize: size, Facets: sir.Facets})
+	if err != nil {
+		return nil, err
+	}
+
+	// matches with the same lexical score share their rank, the ids of the similar resources all score the same
+	rank, lexicalScore := 0, float32(0)
+	for i, match := range res.Matches {
+		if i == 0 || match.Score != lexicalScore {
+			rank, lexicalScore = i, match.Score
+		}
+		match.Score = 1 / float32(_rrfK+rank+1)
+		if similarRank, ok := similarRanks[matchID(match)]; ok {
+			match.Score += 1 / float32(_rrfK+similarRank+1)
+		}
+	}
+	sort.SliceStable(res.Matches, func(i, j int) bool {
+		return res.Matches[i].Score > res.Matches[j].Score
+	})
+	if sir.PageSize > 0 && len(res.Matches) > int(sir.PageSize) {
+		res.Matches = res.Matches[:sir.PageSize]
+	}
+	return res, nil
+}
+
+// similarNodes returns the similarity operators of the query in the order of their position.
+func similarNodes(nodes []ast.Node) []*ast.SimilarNode {
+	var similar []*ast.SimilarNode
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *ast.SimilarNode:
+			similar = append(similar, n)
+		case *ast.GroupNode:
+			similar = append(similar, similarNodes(n.Nodes)...)
+		}
+	}
+	return similar
+}
+
+// Upsert indexes the resource with the lexical engine and stores the embedding of its content.
+// The resource is only embedded again if its name, title or content changed.
+func (h *Hybrid) Upsert(id string, r Resource) error {
+	if err := h.Engine.Upsert(id, r); err != nil {
+		return err
+	}
+
+	text := h.embeddingText(r)
+	if text == "" {
+		return h.vectors.delete(id)
+	}
+
+	sum := sha256.Sum256([]byte(h.model + "\n" + text))
+	hash := hex.EncodeToString(sum[:])
+	e, err := h.vectors.get(id)
+	if err != nil {
+		return err
+	}
+	if e != nil && e.hash == hash && e.rootID == r.RootID {
+		return nil
+	}
+
+	vector, err := h.embedder.Embed(context.Background(), text)
+	if err != nil {
+		return fmt.Errorf("could not embed the resource: %w", err)
+	}
+	return h.vectors.upsert(id, vectorEntry{rootID: r.RootID, hash: hash, vector: vector})
+}
+
+// Purge removes the resource from the lexical engine and its embedding from the vector index.
+func (h *Hybrid) Purge(id string) error {
+	if err := h.Engine.Purge(id); err != nil {
+		return err
+	}
+	return h.vectors.delete(id)
+}
+
+func (h *Hybrid) similarVector(ctx context.Context, similar string) ([]float32, bool, error) {
+	e, err := h.vectors.get(similar)
+	if err != nil {
+		return nil, false, err
+	}
+	if e != nil {
+		return e.vector, true, nil
+	}
+
+	vector, err := h.embedder.Embed(ctx, similar)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not embed the query: %w", err)
+	}
+	return vector, false, nil
+}
+
+// embeddingText returns the text representing the resource, the content is truncated to the configured length.
+func (h *Hybrid) embeddingText(r Resource) string {
+	content := []rune(r.Content)
+	if h.maxLength > 0 && len(content) > h.maxLength {
+		content = content[:h.maxLength]
+	}
+
+	var parts []string
+	for _, p := range []string{r.Name, r.Title, string(content)} {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+func matchID(m *searchMessage.Match) string {
+	return storagespace.FormatResourceID(storageProvider.ResourceId{
+		StorageId: m.GetEntity().GetId().GetStorageId(),
+		SpaceId:   m.GetEntity().GetId().GetSpaceId(),
+		OpaqueId:  m.GetEntity().GetId().GetOpaqueId(),
+	})
+}
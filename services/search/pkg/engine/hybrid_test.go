@@ -0,0 +1,156 @@
+package engine_test
+
+import (
+	"context"
+	"strings"
+
+	bleveSearch "github.com/blevesearch/bleve/v2"
+	sprovider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	searchmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
+	searchsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
+	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+	"github.com/owncloud/ocis/v2/services/search/pkg/config/defaults"
+	"github.com/owncloud/ocis/v2/services/search/pkg/content"
+	"github.com/owncloud/ocis/v2/services/search/pkg/engine"
+	"github.com/owncloud/ocis/v2/services/search/pkg/query/bleve"
+)
+
+// fakeEmbedder embeds the texts by counting the words of a small vocabulary
+type fakeEmbedder struct {
+	calls int
+}
+
+func (f *fakeEmbedder) Embed(_ context.Context, text string) ([]float32, error) {
+	f.calls++
+	vector := make([]float32, 3)
+	for _, w := range strings.Fields(strings.ToLower(text)) {
+		switch strings.Trim(w, ".,") {
+		case "budget", "finance", "costs":
+			vector[0]++
+		case "holiday", "beach", "vacation":
+			vector[1]++
+		case "recipe", "cooking":
+			vector[2]++
+		}
+	}
+	return vector, nil
+}
+
+var _ = Describe("Hybrid", func() {
+	var (
+		eng          *engine.Hybrid
+		embedder     *fakeEmbedder
+		embeddingCfg config.EngineEmbedding
+
+		doSearch = func(query string) []string {
+			res, err := eng.Search(context.Background(), &searchsvc.SearchIndexRequest{
+				Query: query,
+				Ref: &searchmsg.Reference{
+					ResourceId: &searchmsg.ResourceID{StorageId: "1", SpaceId: "2", OpaqueId: "2"},
+				},
+			})
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+
+			names := make([]string, 0, len(res.Matches))
+			for _, m := range res.Matches {
+				names = append(names, m.Entity.Name)
+			}
+			return names
+		}
+
+		upsert = func(opaqueID, name, text string) {
+			id := "1$2!" + opaqueID
+			ExpectWithOffset(1, eng.Upsert(id, engine.Resource{
+				ID:       id,
+				RootID:   "1$2!2",
+				ParentID: "1$2!2",
+				Path:     "./" + name,
+				Type:     uint64(sprovider.ResourceType_RESOURCE_TYPE_FILE),
+				Document: content.Document{Name: name, Content: text},
+			})).To(Succeed())
+		}
+	)
+
+	BeforeEach(func() {
+		embeddingCfg = defaults.DefaultConfig().Engine.Embedding
+	})
+
+	JustBeforeEach(func() {
+		mapping, err := engine.BuildBleveMapping()
+		Expect(err).ToNot(HaveOccurred())
+		idx, err := bleveSearch.NewMemOnly(mapping)
+		Expect(err).ToNot(HaveOccurred())
+
+		vectors, err := engine.NewVectorIndex(GinkgoT().TempDir())
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(vectors.Close)
+
+		embedder = &fakeEmbedder{}
+		eng = engine.NewHybridEngine(engine.NewBleveEngine(idx, bleve.DefaultCreator), vectors, embedder, embeddingCfg)
+
+		upsert("3", "report.pdf", "The budget and the finance costs of next year.")
+		upsert("4", "trip.txt", "Holiday at the beach.")
+		upsert("5", "pasta.txt", "A cooking recipe.")
+		upsert("6", "budget 2025.xlsx", "")
+	})
+
+	It("passes queries without the similar operator to the lexical engine", func() {
+		Expect(doSearch("Name:trip.txt")).To(Equal([]string{"trip.txt"}))
+	})
+
+	It("finds the resources similar to a text", func() {
+		names := doSearch(`similar:"planning the vacation"`)
+		Expect(names[0]).To(Equal("trip.txt"))
+	})
+
+	It("finds the resources similar to a resource", func() {
+		names := doSearch(`similar:1$2!3`)
+		Expect(names[0]).To(Equal("budget 2025.xlsx"))
+		Expect(names).ToNot(ContainElement("report.pdf"))
+	})
+
+	It("ranks the resources matching the query and similar to the text first", func() {
+		upsert("7", "costs.txt", "Holiday costs.")
+
+		names := doSearch(`Name:*.txt similar:"vacation"`)
+		Expect(names[0]).To(Equal("trip.txt"))
+		Expect(names).To(ContainElements("costs.txt", "pasta.txt"))
+	})
+
+	When("the operator is combined with other restrictions", func() {
+		BeforeEach(func() {
+			embeddingCfg.Candidates = 1
+		})
+
+		It("matches the most similar resources in groups", func() {
+			Expect(doSearch(`(similar:"vacation" OR similar:"cooking") OR Name:"report.pdf"`)).To(ConsistOf("trip.txt", "pasta.txt", "report.pdf"))
+		})
+
+		It("excludes the most similar resources", func() {
+			Expect(doSearch(`Name:*.txt NOT similar:"vacation"`)).To(Equal([]string{"pasta.txt"}))
+		})
+	})
+
+	It("does not embed unchanged resources again", func() {
+		calls := embedder.calls
+		upsert("4", "trip.txt", "Holiday at the beach.")
+		Expect(embedder.calls).To(Equal(calls))
+
+		upsert("4", "trip.txt", "Holiday at the lake.")
+		Expect(embedder.calls).To(Equal(calls + 1))
+	})
+
+	It("skips deleted and purged resources", func() {
+		Expect(eng.Delete("1$2!4")).To(Succeed())
+		Expect(doSearch(`similar:"vacation"`)).ToNot(ContainElement("trip.txt"))
+
+		Expect(eng.Restore("1$2!4")).To(Succeed())
+		Expect(doSearch(`similar:"vacation"`)).To(ContainElement("trip.txt"))
+
+		Expect(eng.Purge("1$2!4")).To(Succeed())
+		Expect(doSearch(`similar:"vacation"`)).ToNot(ContainElement("trip.txt"))
+	})
+})
@@ -0,0 +1,424 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	storageProvider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/errtypes"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+	"github.com/cs3org/reva/v2/pkg/utils"
+
+	searchMessage "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
+	searchService "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
+	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+	searchQuery "github.com/owncloud/ocis/v2/services/search/pkg/query"
+	"github.com/owncloud/ocis/v2/services/search/pkg/query/opensearch"
+)
+
+// _openSearchMaxResults is the default max_result_window of OpenSearch indices,
+// it limits the number of results of unlimited searches
+const _openSearchMaxResults = 10000
+
+// painless scripts used to update the descendants of a container
+const (
+	_openSearchMoveScript    = "ctx._source.Path = params.next + ctx._source.Path.substring(params.current.length())"
+	_openSearchDeletedScript = "ctx._source.Deleted = params.deleted"
+)
+
+// _openSearchIndex defines the settings and mappings of the index, they match the bleve mapping
+var _openSearchIndex = map[string]interface{}{
+	"settings": map[string]interface{}{
+		"analysis": map[string]interface{}{
+			"analyzer": map[string]interface{}{
+				"fulltext": map[string]interface{}{
+					"type":      "custom",
+					"tokenizer": "standard",
+					"filter":    []string{"lowercase", "porter_stem"},
+				},
+			},
+		},
+	},
+	"mappings": map[string]interface{}{
+		"dynamic_templates": []interface{}{
+			map[string]interface{}{
+				"strings": map[string]interface{}{
+					"match_mapping_type": "string",
+					"mapping":            map[string]interface{}{"type": "keyword", "ignore_above": 256},
+				},
+			},
+		},
+		"properties": map[string]interface{}{
+			"ID":       map[string]interface{}{"type": "keyword"},
+			"RootID":   map[string]interface{}{"type": "keyword"},
+			"ParentID": map[string]interface{}{"type": "keyword"},
+			"Path":     map[string]interface{}{"type": "keyword"},
+			"Name":     map[string]interface{}{"type": "keyword"},
+			"Tags":     map[string]interface{}{"type": "keyword"},
+			"MimeType": map[string]interface{}{"type": "keyword"},
+			"Content":  map[string]interface{}{"type": "text", "analyzer": "fulltext"},
+			"Size":     map[string]interface{}{"type": "long"},
+			"Type":     map[string]interface{}{"type": "long"},
+			"Mtime":    map[string]interface{}{"type": "date", "ignore_malformed": true},
+			"Deleted":  map[string]interface{}{"type": "boolean"},
+			"Hidden":   map[string]interface{}{"type": "boolean"},
+		},
+	},
+}
+
+// OpenSearch represents a search engine which stores the resources in an index of an OpenSearch compatible server.
+// Unlike the bleve index, the index can be shared by several search service replicas.
+type OpenSearch struct {
+	client       *http.Client
+	url          string
+	username     string
+	password     string
+	queryCreator searchQuery.Creator[opensearch.Query]
+}
+
+// openSearchError is returned if the server does not accept a request
+type openSearchError struct {
+	status int
+	body   string
+}
+
+func (e openSearchError) Error() string {
+	return fmt.Sprintf("opensearch responded with status %d: %s", e.status, e.body)
+}
+
+func isOpenSearchNotFound(err error) bool {
+	var osErr openSearchError
+	return errors.As(err, &osErr) && osErr.status == http.StatusNotFound
+}
+
+// NewOpenSearchEngine creates a new OpenSearch instance, the index is created if it does not exist yet.
+func NewOpenSearchEngine(cfg config.EngineOpenSearch, queryCreator searchQuery.Creator[opensearch.Query]) (*OpenSearch, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: cfg.Insecure, //nolint:gosec
+	}
+
+	o := &OpenSearch{
+		client:       &http.Client{Transport: transport},
+		url:          strings.TrimSuffix(cfg.URL, "/") + "/" + url.PathEscape(cfg.Index),
+		username:     cfg.Username,
+		password:     cfg.Password,
+		queryCreator: queryCreator,
+	}
+
+	return o, o.createIndex()
+}
+
+func (o *OpenSearch) createIndex() error {
+	err := o.do(context.Background(), http.MethodHead, "", nil, nil)
+	if !isOpenSearchNotFound(err) {
+		return err
+	}
+
+	err = o.do(context.Background(), http.MethodPut, "", _openSearchIndex, nil)
+	var osErr openSearchError
+	if errors.As(err, &osErr) && strings.Contains(osErr.body, "resource_already_exists_exception") {
+		// created by another replica in the meantime
+		return nil
+	}
+	return err
+}
+
+// Search executes a search request operation within the index.
+// Returns a SearchIndexResponse object or an error.
+func (o *OpenSearch) Search(ctx context.Context, sir *searchService.SearchIndexRequest) (*searchService.SearchIndexResponse, error) {
+	createdQuery, err := o.queryCreator.Create(sir.Query)
+	if err != nil {
+		if searchQuery.IsValidationError(err) {
+			return nil, errtypes.BadRequest(err.Error())
+		}
+		return nil, err
+	}
+
+	// Skip documents that have been marked as deleted
+	filters := []opensearch.Query{opensearch.NewTermQuery("Deleted", false)}
+	if sir.Ref != nil {
+		filters = append(filters, opensearch.NewTermQuery("RootID", storagespace.FormatResourceID(
+			storageProvider.ResourceId{
+				StorageId: sir.Ref.GetResourceId().GetStorageId(),
+				SpaceId:   sir.Ref.GetResourceId().GetSpaceId(),
+				OpaqueId:  sir.Ref.GetResourceId().GetOpaqueId(),
+			},
+		)))
+
+		if requestedPath := utils.MakeRelativePath(sir.Ref.Path); requestedPath != "." {
+			filters = append(filters, opensearch.NewDisjunctionQuery(
+				opensearch.NewTermQuery("Path", requestedPath),
+				opensearch.NewPrefixQuery("Path", requestedPath+"/"),
+			))
+		}
+	}
+
+	size := int(sir.PageSize)
+	switch {
+	case sir.PageSize == -1:
+		size = _openSearchMaxResults
+	case sir.PageSize == 0:
+		size = 200
+	}
+
+	req := map[string]interface{}{
+		"query": opensearch.Query{"bool": map[string]interface{}{
+			"must":   []opensearch.Query{createdQuery},
+			"filter": filters,
+		}},
+		"size":             size,
+		"track_total_hits": true,
+		"highlight": map[string]interface{}{
+			"pre_tags":  []string{"<mark>"},
+			"post_tags": []string{"</mark>"},
+			"fields":    map[string]interface{}{"Content": map[string]interface{}{}},
+		},
+	}
+
+	if sir.Facets {
+		ranges := make([]interface{}, 0, 5)
+		for _, r := range mtimeRanges(time.Now()) {
+			ranges = append(ranges, map[string]interface{}{
+				"key":  r.name,
+				"from": r.start.Format(time.RFC3339Nano),
+				"to":   r.end.Format(time.RFC3339Nano),
+			})
+		}
+		req["aggs"] = map[string]interface{}{
+			FacetMediaType: map[string]interface{}{"terms": map[string]interface{}{"field": "MimeType", "size": _facetSize}},
+			FacetTags:      map[string]interface{}{"terms": map[string]interface{}{"field": "Tags", "size": _facetSize}},
+			FacetSpace:     map[string]interface{}{"terms": map[string]interface{}{"field": "RootID", "size": _facetSize}},
+			FacetMtime:     map[string]interface{}{"date_range": map[string]interface{}{"field": "Mtime", "ranges": ranges}},
+		}
+	}
+
+	var res struct {
+		Aggregations map[string]struct {
+			Buckets []struct {
+				Key      string `json:"key"`
+				DocCount uint64 `json:"doc_count"`
+			} `json:"buckets"`
+		} `json:"aggregations"`
+		Hits struct {
+			Total struct {
+				Value int32 `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				Score     float64                `json:"_score"`
+				Source    map[string]interface{} `json:"_source"`
+				Highlight map[string][]string    `json:"highlight"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := o.do(ctx, http.MethodPost, "/_search", req, &res); err != nil {
+		return nil, err
+	}
+
+	matches := make([]*searchMessage.Match, 0, len(res.Hits.Hits))
+	for _, hit := range res.Hits.Hits {
+		fields := map[string]interface{}{}
+		flattenFields(fields, "", hit.Source)
+
+		match, err := newMatch(fields, hit.Score, getFragmentValue(hit.Highlight, "Content", 0))
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, match)
+	}
+
+	var facets []*searchMessage.Facet
+	for _, name := range []string{FacetMediaType, FacetTags, FacetMtime, FacetSpace} {
+		agg, ok := res.Aggregations[name]
+		if !ok {
+			continue
+		}
+		facet := &searchMessage.Facet{Name: name}
+		for _, b := range agg.Buckets {
+			facet.Values = append(facet.Values, &searchMessage.FacetValue{Value: b.Key, Count: b.DocCount})
+		}
+		facets = append(facets, facet)
+	}
+
+	return &searchService.SearchIndexResponse{
+		Matches:      matches,
+		TotalMatches: res.Hits.Total.Value,
+		Facets:       facets,
+	}, nil
+}
+
+// Upsert indexes or stores Resource data fields.
+// It returns once the resource is visible to searches but leaves the refresh of the index to OpenSearch, forcing it
+// for every resource is too expensive when indexing whole spaces.
+func (o *OpenSearch) Upsert(id string, r Resource) error {
+	return o.do(context.Background(), http.MethodPut, "/_doc/"+url.PathEscape(id)+"?refresh=wait_for", r, nil)
+}
+
+// Move updates the resource location and all of its necessary fields.
+func (o *OpenSearch) Move(id string, parentid string, target string) error {
+	r, err := o.getResource(id)
+	if err != nil {
+		return err
+	}
+	currentPath := r.Path
+	nextPath := utils.MakeRelativePath(target)
+
+	r.Path = nextPath
+	r.Name = path.Base(nextPath)
+	r.ParentID = parentid
+	if err := o.Upsert(id, *r); err != nil {
+		return err
+	}
+
+	if r.Type == uint64(storageProvider.ResourceType_RESOURCE_TYPE_CONTAINER) {
+		return o.updateDescendants(r.RootID, currentPath, _openSearchMoveScript, map[string]interface{}{
+			"current": currentPath,
+			"next":    nextPath,
+		})
+	}
+
+	return nil
+}
+
+// Delete marks the resource as deleted.
+// The resource object will stay in the index,
+// instead of removing the resource it just marks it as deleted!
+// can be undone
+func (o *OpenSearch) Delete(id string) error {
+	return o.setDeleted(id, true)
+}
+
+// Restore is the counterpart to Delete.
+// It restores the resource which makes it available again.
+func (o *OpenSearch) Restore(id string) error {
+	return o.setDeleted(id, false)
+}
+
+// Purge removes a resource from the index, irreversible operation.
+func (o *OpenSearch) Purge(id string) error {
+	err := o.do(context.Background(), http.MethodDelete, "/_doc/"+url.PathEscape(id)+"?refresh=wait_for", nil, nil)
+	if isOpenSearchNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// DocCount returns the number of resources in the index.
+func (o *OpenSearch) DocCount() (uint64, error) {
+	var res struct {
+		Count uint64 `json:"count"`
+	}
+	if err := o.do(context.Background(), http.MethodGet, "/_count", nil, &res); err != nil {
+		return 0, err
+	}
+	return res.Count, nil
+}
+
+func (o *OpenSearch) getResource(id string) (*Resource, error) {
+	var res struct {
+		Source Resource `json:"_source"`
+	}
+	err := o.do(context.Background(), http.MethodGet, "/_doc/"+url.PathEscape(id), nil, &res)
+	switch {
+	case isOpenSearchNotFound(err):
+		return nil, errors.New("entity not found")
+	case err != nil:
+		return nil, err
+	}
+	return &res.Source, nil
+}
+
+func (o *OpenSearch) setDeleted(id string, deleted bool) error {
+	r, err := o.getResource(id)
+	if err != nil {
+		return err
+	}
+
+	r.Deleted = deleted
+	if err := o.Upsert(id, *r); err != nil {
+		return err
+	}
+
+	if r.Type == uint64(storageProvider.ResourceType_RESOURCE_TYPE_CONTAINER) {
+		return o.updateDescendants(r.RootID, r.Path, _openSearchDeletedScript, map[string]interface{}{
+			"deleted": deleted,
+		})
+	}
+
+	return nil
+}
+
+// updateDescendants runs the script on all resources below the container path
+func (o *OpenSearch) updateDescendants(rootID, containerPath, script string, params map[string]interface{}) error {
+	// update by query does not support waiting for the next refresh
+	return o.do(context.Background(), http.MethodPost, "/_update_by_query?refresh=true&conflicts=proceed", map[string]interface{}{
+		"query": opensearch.NewConjunctionQuery(
+			opensearch.NewTermQuery("RootID", rootID),
+			opensearch.NewPrefixQuery("Path", containerPath+"/"),
+		),
+		"script": map[string]interface{}{
+			"lang":   "painless",
+			"source": script,
+			"params": params,
+		},
+	}, nil)
+}
+
+// do sends the request to the index, the body is encoded and the response is decoded as json
+func (o *OpenSearch) do(ctx context.Context, method, p string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, o.url+p, reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if o.username != "" {
+		req.SetBasicAuth(o.username, o.password)
+	}
+
+	res, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		b, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+		return openSearchError{status: res.StatusCode, body: string(b)}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// flattenFields flattens the nested objects of the document source to dotted keys like the bleve fields
+func flattenFields(fields map[string]interface{}, prefix string, source map[string]interface{}) {
+	for k, v := range source {
+		if nested, ok := v.(map[string]interface{}); ok {
+			flattenFields(fields, prefix+k+".", nested)
+			continue
+		}
+		fields[prefix+k] = v
+	}
+}
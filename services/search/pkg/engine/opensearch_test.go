@@ -0,0 +1,467 @@
+package engine_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	sprovider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	searchmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
+	searchsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
+	"github.com/owncloud/ocis/v2/services/search/pkg/config"
+	"github.com/owncloud/ocis/v2/services/search/pkg/content"
+	"github.com/owncloud/ocis/v2/services/search/pkg/engine"
+	"github.com/owncloud/ocis/v2/services/search/pkg/query/opensearch"
+)
+
+// fakeOpenSearch is a local stand-in for an OpenSearch server, it supports the requests and queries used by the engine
+type fakeOpenSearch struct {
+	mu       sync.Mutex
+	index    string
+	mappings map[string]interface{}
+	docs     map[string]map[string]interface{}
+}
+
+func (f *fakeOpenSearch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, ok := strings.CutPrefix(r.URL.Path, "/"+f.index)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var body map[string]interface{}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	switch {
+	case p == "" && r.Method == http.MethodHead:
+		if f.mappings == nil {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case p == "" && r.Method == http.MethodPut:
+		f.mappings = body
+	case f.mappings == nil:
+		w.WriteHeader(http.StatusNotFound)
+	case strings.HasPrefix(p, "/_doc/"):
+		id := strings.TrimPrefix(p, "/_doc/")
+		switch r.Method {
+		case http.MethodPut:
+			f.docs[id] = body
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			doc, ok := f.docs[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"_id": id, "found": true, "_source": doc})
+		case http.MethodDelete:
+			if _, ok := f.docs[id]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(f.docs, id)
+		}
+	case p == "/_count":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": len(f.docs)})
+	case p == "/_search":
+		var hits []interface{}
+		var found []map[string]interface{}
+		for id, doc := range f.docs {
+			if matchesQuery(body["query"].(map[string]interface{}), doc) {
+				hits = append(hits, map[string]interface{}{"_id": id, "_score": 1, "_source": doc})
+				found = append(found, doc)
+			}
+		}
+		aggs := map[string]interface{}{}
+		if a, ok := body["aggs"].(map[string]interface{}); ok {
+			for name, agg := range a {
+				aggs[name] = map[string]interface{}{"buckets": aggregate(agg.(map[string]interface{}), found)}
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"hits":         map[string]interface{}{"total": map[string]interface{}{"value": len(hits)}, "hits": hits},
+			"aggregations": aggs,
+		})
+	case p == "/_update_by_query":
+		params := body["script"].(map[string]interface{})["params"].(map[string]interface{})
+		for _, doc := range f.docs {
+			if !matchesQuery(body["query"].(map[string]interface{}), doc) {
+				continue
+			}
+			if deleted, ok := params["deleted"]; ok {
+				doc["Deleted"] = deleted
+			}
+			if current, ok := params["current"].(string); ok {
+				doc["Path"] = params["next"].(string) + strings.TrimPrefix(doc["Path"].(string), current)
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+// aggregate computes the buckets of terms and date_range aggregations
+func aggregate(agg map[string]interface{}, docs []map[string]interface{}) []interface{} {
+	var buckets []interface{}
+	if terms, ok := agg["terms"].(map[string]interface{}); ok {
+		counts := map[string]int{}
+		for _, doc := range docs {
+			for _, v := range fieldValues(doc, terms["field"].(string)) {
+				if s, ok := v.(string); ok && s != "" {
+					counts[s]++
+				}
+			}
+		}
+		keys := make([]string, 0, len(counts))
+		for k := range counts {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if counts[keys[i]] != counts[keys[j]] {
+				return counts[keys[i]] > counts[keys[j]]
+			}
+			return keys[i] < keys[j]
+		})
+		for _, k := range keys {
+			buckets = append(buckets, map[string]interface{}{"key": k, "doc_count": counts[k]})
+		}
+	}
+	if dr, ok := agg["date_range"].(map[string]interface{}); ok {
+		for _, r := range dr["ranges"].([]interface{}) {
+			r := r.(map[string]interface{})
+			from, _ := time.Parse(time.RFC3339Nano, r["from"].(string))
+			to, _ := time.Parse(time.RFC3339Nano, r["to"].(string))
+			count := 0
+			for _, doc := range docs {
+				for _, v := range fieldValues(doc, dr["field"].(string)) {
+					s, _ := v.(string)
+					if t, err := time.Parse(time.RFC3339Nano, s); err == nil && !t.Before(from) && t.Before(to) {
+						count++
+					}
+				}
+			}
+			buckets = append(buckets, map[string]interface{}{"key": r["key"], "doc_count": count})
+		}
+	}
+	return buckets
+}
+
+func matchesQuery(q map[string]interface{}, doc map[string]interface{}) bool {
+	for kind, v := range q {
+		args := v.(map[string]interface{})
+		if kind == "bool" {
+			return matchesBool(args, doc)
+		}
+
+		for field, fv := range args {
+			spec := fv.(map[string]interface{})
+			caseInsensitive, _ := spec["case_insensitive"].(bool)
+			for _, value := range fieldValues(doc, field) {
+				if matchesValue(kind, spec, value, caseInsensitive) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func matchesBool(args map[string]interface{}, doc map[string]interface{}) bool {
+	clauses := func(kind string) []interface{} {
+		c, _ := args[kind].([]interface{})
+		return c
+	}
+	for _, c := range append(clauses("must"), clauses("filter")...) {
+		if !matchesQuery(c.(map[string]interface{}), doc) {
+			return false
+		}
+	}
+	for _, c := range clauses("must_not") {
+		if matchesQuery(c.(map[string]interface{}), doc) {
+			return false
+		}
+	}
+	should := clauses("should")
+	for _, c := range should {
+		if matchesQuery(c.(map[string]interface{}), doc) {
+			return true
+		}
+	}
+	return len(should) == 0
+}
+
+func matchesValue(kind string, spec map[string]interface{}, value interface{}, caseInsensitive bool) bool {
+	v := fmt.Sprint(value)
+	switch kind {
+	case "term":
+		if caseInsensitive {
+			return strings.EqualFold(v, fmt.Sprint(spec["value"]))
+		}
+		return v == fmt.Sprint(spec["value"])
+	case "prefix":
+		return strings.HasPrefix(v, spec["value"].(string))
+	case "wildcard":
+		pattern := regexp.QuoteMeta(spec["value"].(string))
+		pattern = strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(pattern)
+		if caseInsensitive {
+			pattern = "(?i)" + pattern
+		}
+		return regexp.MustCompile("^" + pattern + "$").MatchString(v)
+	case "match":
+		for _, word := range strings.Fields(spec["query"].(string)) {
+			if !strings.Contains(strings.ToLower(v), word) {
+				return false
+			}
+		}
+		return true
+	case "range":
+		n, ok := value.(float64)
+		for op, limit := range spec {
+			l, _ := limit.(float64)
+			switch {
+			case !ok:
+				return false
+			case op == "gt" && !(n > l), op == "gte" && !(n >= l), op == "lt" && !(n < l), op == "lte" && !(n <= l):
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func fieldValues(doc map[string]interface{}, field string) []interface{} {
+	switch v := doc[field].(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return v
+	default:
+		return []interface{}{v}
+	}
+}
+
+var _ = Describe("OpenSearch", func() {
+	var (
+		eng    *engine.OpenSearch
+		server *fakeOpenSearch
+
+		doSearch = func(id string, query, path string) (*searchsvc.SearchIndexResponse, error) {
+			rID, err := storagespace.ParseID(id)
+			if err != nil {
+				return nil, err
+			}
+
+			return eng.Search(context.Background(), &searchsvc.SearchIndexRequest{
+				Query: query,
+				Ref: &searchmsg.Reference{
+					ResourceId: &searchmsg.ResourceID{
+						StorageId: rID.StorageId,
+						SpaceId:   rID.SpaceId,
+						OpaqueId:  rID.OpaqueId,
+					},
+					Path: path,
+				},
+			})
+		}
+
+		assertDocCount = func(id string, query string, expectedCount int) []*searchmsg.Match {
+			res, err := doSearch(id, query, "")
+
+			ExpectWithOffset(1, err).ToNot(HaveOccurred())
+			ExpectWithOffset(1, len(res.Matches)).To(Equal(expectedCount), "query returned unexpected number of results: "+query)
+			return res.Matches
+		}
+
+		rootResource   engine.Resource
+		parentResource engine.Resource
+		childResource  engine.Resource
+	)
+
+	BeforeEach(func() {
+		server = &fakeOpenSearch{index: "ocis-resources", docs: map[string]map[string]interface{}{}}
+		ts := httptest.NewServer(server)
+		DeferCleanup(ts.Close)
+
+		var err error
+		eng, err = engine.NewOpenSearchEngine(config.EngineOpenSearch{URL: ts.URL, Index: "ocis-resources"}, opensearch.DefaultCreator)
+		Expect(err).ToNot(HaveOccurred())
+
+		rootResource = engine.Resource{
+			ID:       "1$2!2",
+			RootID:   "1$2!2",
+			Path:     ".",
+			Document: content.Document{},
+		}
+
+		parentResource = engine.Resource{
+			ID:       "1$2!3",
+			ParentID: rootResource.ID,
+			RootID:   rootResource.ID,
+			Path:     "./parent d!r",
+			Type:     uint64(sprovider.ResourceType_RESOURCE_TYPE_CONTAINER),
+			Document: content.Document{Name: "parent d!r"},
+		}
+
+		childResource = engine.Resource{
+			ID:       "1$2!4",
+			ParentID: parentResource.ID,
+			RootID:   rootResource.ID,
+			Path:     "./parent d!r/child.pdf",
+			Type:     uint64(sprovider.ResourceType_RESOURCE_TYPE_FILE),
+			Document: content.Document{Name: "child.pdf"},
+		}
+	})
+
+	Describe("New", func() {
+		It("creates the index", func() {
+			Expect(server.mappings).To(HaveKey("mappings"))
+		})
+	})
+
+	Describe("Search", func() {
+		It("returns all desired fields", func() {
+			parentResource.Document.Name = "bar.pdf"
+			parentResource.Document.Size = 12345
+			parentResource.Document.Tags = []string{"foo", "bar"}
+			parentResource.MimeType = "application/pdf"
+			Expect(eng.Upsert(parentResource.ID, parentResource)).To(Succeed())
+
+			matches := assertDocCount(rootResource.ID, "Name:BAR.pdf", 1)
+			Expect(matches[0].Entity.Ref.Path).To(Equal(parentResource.Path))
+			Expect(matches[0].Entity.Name).To(Equal(parentResource.Name))
+			Expect(matches[0].Entity.Size).To(Equal(parentResource.Size))
+			Expect(matches[0].Entity.MimeType).To(Equal(parentResource.MimeType))
+			Expect(matches[0].Entity.Tags).To(Equal(parentResource.Tags))
+			Expect(matches[0].Entity.Id.OpaqueId).To(Equal("3"))
+		})
+
+		It("finds files by wildcards, tags and size", func() {
+			parentResource.Document.Name = "Foo oo.pdf"
+			parentResource.Document.Size = 12345
+			parentResource.Document.Tags = []string{"foo", "bar"}
+			Expect(eng.Upsert(parentResource.ID, parentResource)).To(Succeed())
+
+			assertDocCount(rootResource.ID, `name:"foo o*"`, 1)
+			assertDocCount(rootResource.ID, "Tags:foo Tags:bar", 1)
+			assertDocCount(rootResource.ID, "Tags:baz", 0)
+			assertDocCount(rootResource.ID, "Size:>1000", 1)
+			assertDocCount(rootResource.ID, "Size:<1000", 0)
+		})
+
+		It("scopes the search to the specified space and path", func() {
+			Expect(eng.Upsert(parentResource.ID, parentResource)).To(Succeed())
+			Expect(eng.Upsert(childResource.ID, childResource)).To(Succeed())
+
+			assertDocCount(rootResource.ID, "Name:child.pdf", 1)
+			assertDocCount("9$8!7", "Name:child.pdf", 0)
+
+			res, err := doSearch(rootResource.ID, "Name:*", "./parent d!r")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.TotalMatches).To(Equal(int32(2)))
+
+			res, err = doSearch(rootResource.ID, "Name:*", "./other")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.TotalMatches).To(Equal(int32(0)))
+		})
+		It("returns the facet counts of the matches", func() {
+			parentResource.Document.Tags = []string{"foo", "bar"}
+			parentResource.MimeType = "httpd/unix-directory"
+			parentResource.Document.Mtime = time.Now().Format(time.RFC3339Nano)
+			childResource.Document.Tags = []string{"foo"}
+			childResource.MimeType = "application/pdf"
+			childResource.Document.Mtime = time.Now().AddDate(-3, 0, 0).Format(time.RFC3339Nano)
+			Expect(eng.Upsert(parentResource.ID, parentResource)).To(Succeed())
+			Expect(eng.Upsert(childResource.ID, childResource)).To(Succeed())
+
+			res, err := eng.Search(context.Background(), &searchsvc.SearchIndexRequest{
+				Query:  "Name:*",
+				Ref:    &searchmsg.Reference{ResourceId: &searchmsg.ResourceID{StorageId: "1", SpaceId: "2", OpaqueId: "2"}},
+				Facets: true,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Facets).To(HaveLen(4))
+			Expect(res.Facets[0].Name).To(Equal(engine.FacetMediaType))
+			Expect(res.Facets[0].Values).To(HaveLen(2))
+			Expect(res.Facets[1].Name).To(Equal(engine.FacetTags))
+			Expect(res.Facets[1].Values[0].Value).To(Equal("foo"))
+			Expect(res.Facets[1].Values[0].Count).To(Equal(uint64(2)))
+			Expect(res.Facets[2].Name).To(Equal(engine.FacetMtime))
+			Expect(res.Facets[2].Values[0].Value).To(Equal("today"))
+			Expect(res.Facets[2].Values[0].Count).To(Equal(uint64(1)))
+			Expect(res.Facets[3].Name).To(Equal(engine.FacetSpace))
+			Expect(res.Facets[3].Values[0].Value).To(Equal(rootResource.ID))
+			Expect(res.Facets[3].Values[0].Count).To(Equal(uint64(2)))
+		})
+	})
+
+	Describe("Move", func() {
+		It("renames the resource and the paths of its children", func() {
+			Expect(eng.Upsert(parentResource.ID, parentResource)).To(Succeed())
+			Expect(eng.Upsert(childResource.ID, childResource)).To(Succeed())
+
+			Expect(eng.Move(parentResource.ID, rootResource.ID, "./new parent")).To(Succeed())
+
+			assertDocCount(rootResource.ID, `Name:"parent d!r"`, 0)
+			matches := assertDocCount(rootResource.ID, `Name:"new parent"`, 1)
+			Expect(matches[0].Entity.Ref.Path).To(Equal("./new parent"))
+
+			matches = assertDocCount(rootResource.ID, "Name:child.pdf", 1)
+			Expect(matches[0].Entity.Ref.Path).To(Equal("./new parent/child.pdf"))
+		})
+	})
+
+	Describe("Delete and Restore", func() {
+		It("marks the resource and its children as deleted and restores them", func() {
+			Expect(eng.Upsert(parentResource.ID, parentResource)).To(Succeed())
+			Expect(eng.Upsert(childResource.ID, childResource)).To(Succeed())
+
+			Expect(eng.Delete(parentResource.ID)).To(Succeed())
+			assertDocCount(rootResource.ID, `Name:"parent d!r"`, 0)
+			assertDocCount(rootResource.ID, "Name:child.pdf", 0)
+
+			Expect(eng.Restore(parentResource.ID)).To(Succeed())
+			assertDocCount(rootResource.ID, `Name:"parent d!r"`, 1)
+			assertDocCount(rootResource.ID, "Name:child.pdf", 1)
+		})
+
+		It("fails for unknown resources", func() {
+			Expect(eng.Delete("1$2!99")).ToNot(Succeed())
+		})
+	})
+
+	Describe("Purge and DocCount", func() {
+		It("removes the resource from the index", func() {
+			Expect(eng.Upsert(parentResource.ID, parentResource)).To(Succeed())
+			Expect(eng.Upsert(childResource.ID, childResource)).To(Succeed())
+
+			count, err := eng.DocCount()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(uint64(2)))
+
+			Expect(eng.Purge(childResource.ID)).To(Succeed())
+			Expect(eng.Purge(childResource.ID)).To(Succeed())
+
+			count, err = eng.DocCount()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(uint64(1)))
+		})
+	})
+})
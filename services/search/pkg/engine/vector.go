@@ -0,0 +1,219 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// _vectorBucket holds the entries under the root id of their space followed by their id,
+	// so that the entries of a space can be read with a prefix scan
+	_vectorBucket = []byte("space-vectors")
+	// _rootBucket maps the ids of the resources to the root id of their space
+	_rootBucket = []byte("roots")
+)
+
+// VectorIndex stores the embeddings of the resources next to the bleve index,
+// it finds the nearest neighbours of a vector by their cosine similarity.
+type VectorIndex struct {
+	db *bolt.DB
+}
+
+type vectorEntry struct {
+	rootID string
+	hash   string
+	vector []float32
+}
+
+type vectorHit struct {
+	id         string
+	similarity float32
+}
+
+// NewVectorIndex opens or creates the vector index in the given directory.
+func NewVectorIndex(root string) (*VectorIndex, error) {
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, err
+	}
+
+	db, err := bolt.Open(filepath.Join(root, "vectors.db"), 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, b := range [][]byte{_vectorBucket, _rootBucket} {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &VectorIndex{db: db}, nil
+}
+
+// Close closes the underlying database.
+func (v *VectorIndex) Close() error {
+	return v.db.Close()
+}
+
+func (v *VectorIndex) upsert(id string, e vectorEntry) error {
+	e.vector = normalize(e.vector)
+	return v.db.Update(func(tx *bolt.Tx) error {
+		// the resource might have been moved to another space
+		if err := deleteVectorEntry(tx, id); err != nil {
+			return err
+		}
+		if err := tx.Bucket(_rootBucket).Put([]byte(id), []byte(e.rootID)); err != nil {
+			return err
+		}
+		return tx.Bucket(_vectorBucket).Put(vectorKey(e.rootID, id), e.encode())
+	})
+}
+
+func (v *VectorIndex) get(id string) (*vectorEntry, error) {
+	var e *vectorEntry
+	err := v.db.View(func(tx *bolt.Tx) error {
+		rootID := tx.Bucket(_rootBucket).Get([]byte(id))
+		if rootID == nil {
+			return nil
+		}
+		b := tx.Bucket(_vectorBucket).Get(vectorKey(string(rootID), id))
+		if b == nil {
+			return nil
+		}
+		var err error
+		e, err = decodeVectorEntry(b)
+		return err
+	})
+	return e, err
+}
+
+func (v *VectorIndex) delete(id string) error {
+	return v.db.Update(func(tx *bolt.Tx) error {
+		return deleteVectorEntry(tx, id)
+	})
+}
+
+func deleteVectorEntry(tx *bolt.Tx, id string) error {
+	rootID := tx.Bucket(_rootBucket).Get([]byte(id))
+	if rootID == nil {
+		return nil
+	}
+	if err := tx.Bucket(_vectorBucket).Delete(vectorKey(string(rootID), id)); err != nil {
+		return err
+	}
+	return tx.Bucket(_rootBucket).Delete([]byte(id))
+}
+
+// vectorKey returns the key of an entry, the ids can't contain the NUL separator
+func vectorKey(rootID, id string) []byte {
+	return []byte(rootID + "\x00" + id)
+}
+
+// nearest returns the k vectors of the space with the highest cosine similarity to the given vector, the most similar first.
+func (v *VectorIndex) nearest(rootID string, vector []float32, k int) ([]vectorHit, error) {
+	vector = normalize(vector)
+
+	var hits []vectorHit
+	prefix := vectorKey(rootID, "")
+	err := v.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(_vectorBucket).Cursor()
+		for key, value := c.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, value = c.Next() {
+			e, err := decodeVectorEntry(value)
+			if err != nil {
+				return err
+			}
+			// vectors of another model can't be compared
+			if len(e.vector) != len(vector) {
+				continue
+			}
+
+			var similarity float32
+			for i := range vector {
+				similarity += vector[i] * e.vector[i]
+			}
+			hits = append(hits, vectorHit{id: string(key[len(prefix):]), similarity: similarity})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		return hits[i].similarity > hits[j].similarity
+	})
+	if len(hits) > k {
+		hits = hits[:k]
+	}
+	return hits, nil
+}
+
+// normalize scales the vector to the length 1, the cosine similarity of normalized vectors is their dot product.
+func normalize(vector []float32) []float32 {
+	var sum float64
+	for _, f := range vector {
+		sum += float64(f) * float64(f)
+	}
+	if sum == 0 {
+		return vector
+	}
+
+	norm := float32(math.Sqrt(sum))
+	normalized := make([]float32, len(vector))
+	for i, f := range vector {
+		normalized[i] = f / norm
+	}
+	return normalized
+}
+
+// encode encodes the entry as length prefixed root id and hash followed by the little endian vector components.
+func (e vectorEntry) encode() []byte {
+	b := make([]byte, 0, 4+len(e.rootID)+len(e.hash)+4*len(e.vector))
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(e.rootID)))
+	b = append(b, e.rootID...)
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(e.hash)))
+	b = append(b, e.hash...)
+	for _, f := range e.vector {
+		b = binary.LittleEndian.AppendUint32(b, math.Float32bits(f))
+	}
+	return b
+}
+
+func decodeVectorEntry(b []byte) (*vectorEntry, error) {
+	errCorrupt := errors.New("corrupt vector entry")
+	e := &vectorEntry{}
+	for _, s := range []*string{&e.rootID, &e.hash} {
+		if len(b) < 2 {
+			return nil, errCorrupt
+		}
+		l := int(binary.LittleEndian.Uint16(b))
+		if len(b) < 2+l {
+			return nil, errCorrupt
+		}
+		*s = string(b[2 : 2+l])
+		b = b[2+l:]
+	}
+	if len(b)%4 != 0 {
+		return nil, errCorrupt
+	}
+
+	e.vector = make([]float32, len(b)/4)
+	for i := range e.vector {
+		e.vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+	return e, nil
+}
@@ -0,0 +1,252 @@
+// Package compiler provides the walk over the KQL ast which is shared by the compilers of the different search engines.
+package compiler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/ast"
+	"github.com/owncloud/ocis/v2/ocis-pkg/kql"
+	"github.com/owncloud/ocis/v2/services/search/pkg/query"
+)
+
+var _fields = map[string]string{
+	"rootid":    "RootID",
+	"path":      "Path",
+	"id":        "ID",
+	"name":      "Name",
+	"size":      "Size",
+	"mtime":     "Mtime",
+	"mediatype": "MimeType",
+	"type":      "Type",
+	"tag":       "Tags",
+	"tags":      "Tags",
+	"content":   "Content",
+	"hidden":    "Hidden",
+}
+
+// Mapper maps the nodes of the ast to the queries of a search engine.
+type Mapper[T any] interface {
+	// Field returns the query matching the value of the field, the value may contain the wildcards * and ?
+	Field(k, v string) T
+	// MimeType returns the query matching one of the mime types of a media type, like 'image/*'
+	MimeType(k, v string) T
+	// DateTime returns the query matching the time range given by the operator, one of '>', '>=', '<' and '<='
+	DateTime(k, operator string, v time.Time) T
+	Boolean(k string, v bool) T
+	Not(q T) T
+	Conjunction(queries ...T) T
+	Disjunction(queries ...T) T
+	// Conjuncts returns the queries of a conjunction, false if the query is no conjunction
+	Conjuncts(q T) ([]T, bool)
+	// Disjuncts returns the queries of a disjunction, false if the query is no disjunction
+	Disjuncts(q T) ([]T, bool)
+}
+
+// Compile walks the ast and builds the query with the mapper. The query is always a conjunction or a disjunction.
+func Compile[T any](m Mapper[T], a *ast.Ast) (T, error) {
+	w := walker[T]{m: m}
+	q, _, err := w.walk(0, a.Nodes)
+	if err != nil {
+		return q, err
+	}
+	if _, ok := m.Conjuncts(q); ok {
+		return q, nil
+	}
+	if _, ok := m.Disjuncts(q); ok {
+		return q, nil
+	}
+	return m.Conjunction(q), nil
+}
+
+type walker[T any] struct {
+	m Mapper[T]
+}
+
+func (w walker[T]) walk(offset int, nodes []ast.Node) (T, int, error) {
+	var prev, next *T
+	var operator *ast.OperatorNode
+	var isGroup bool
+	set := func(q T) {
+		if prev == nil {
+			prev = &q
+		} else {
+			next = &q
+		}
+	}
+	for i := offset; i < len(nodes); i++ {
+		var q T
+		switch n := nodes[i].(type) {
+		case *ast.StringNode:
+			k := getField(n.Key)
+			v := n.Value
+			if k != "Hidden" {
+				v = strings.ToLower(v)
+			}
+
+			switch k {
+			case "MimeType":
+				var group bool
+				q, group = w.mimeType(k, v)
+				if prev == nil {
+					isGroup = group
+				}
+			default:
+				q = w.m.Field(k, v)
+			}
+			set(q)
+		case *ast.DateTimeNode:
+			if n.Operator == nil {
+				continue
+			}
+
+			switch n.Operator.Value {
+			case ">", ">=", "<", "<=":
+				set(w.m.DateTime(getField(n.Key), n.Operator.Value, n.Value))
+			default:
+				continue
+			}
+		case *ast.BooleanNode:
+			set(w.m.Boolean(getField(n.Key), n.Value))
+		case *ast.SimilarNode:
+			// the similarity operator is resolved by the search engine before the query is compiled
+			return q, 0, &query.SimilarityDisabledError{Node: n}
+		case *ast.GroupNode:
+			if n.Key != "" {
+				n = normalizeGroupingProperty(n)
+			}
+			var err error
+			q, _, err = w.walk(0, n.Nodes)
+			if err != nil {
+				return q, 0, err
+			}
+			if prev == nil {
+				isGroup = true
+			}
+			set(q)
+		case *ast.OperatorNode:
+			switch n.Value {
+			case kql.BoolAND, kql.BoolOR:
+				operator = n
+			case kql.BoolNOT:
+				var err error
+				q, offset, err = w.nextNode(i+1, nodes)
+				if err != nil {
+					return q, 0, err
+				}
+				// unary in the beginning or the right operand
+				set(w.m.Not(q))
+			}
+		}
+
+		if prev != nil && next != nil && operator != nil {
+			q := w.mapBinary(operator, *prev, *next, isGroup)
+			prev = &q
+			isGroup = false
+			operator = nil
+			next = nil
+		}
+		if i < offset {
+			i = offset
+		}
+	}
+	if prev == nil {
+		var q T
+		return q, 0, fmt.Errorf("can not compile the query")
+	}
+	return *prev, offset, nil
+}
+
+func (w walker[T]) nextNode(offset int, nodes []ast.Node) (T, int, error) {
+	if n, ok := nodes[offset].(*ast.GroupNode); ok {
+		gq, _, err := w.walk(0, n.Nodes)
+		if err != nil {
+			return gq, 0, err
+		}
+		return gq, offset + 1, nil
+	}
+	if n, ok := nodes[offset].(*ast.OperatorNode); ok {
+		if n.Value == kql.BoolNOT {
+			return w.walk(offset, nodes)
+		}
+	}
+	one := nodes[:offset+1]
+	return w.walk(offset, one)
+}
+
+func (w walker[T]) mapBinary(operator *ast.OperatorNode, ln, rn T, leftIsGroup bool) T {
+	if operator.Value == kql.BoolOR {
+		right, rightIsDisjunction := w.m.Disjuncts(rn)
+		if left, ok := w.m.Disjuncts(ln); ok {
+			if rightIsDisjunction {
+				return w.m.Disjunction(concat(left, right...)...)
+			}
+			return w.m.Disjunction(concat(left, rn)...)
+		}
+		if _, ok := w.m.Conjuncts(ln); ok {
+			return w.m.Disjunction(ln, rn)
+		}
+		if rightIsDisjunction {
+			return w.m.Disjunction(concat([]T{ln}, right...)...)
+		}
+		return w.m.Disjunction(ln, rn)
+	}
+	if operator.Value == kql.BoolAND {
+		if left, ok := w.m.Conjuncts(ln); ok {
+			return w.m.Conjunction(concat(left, rn)...)
+		}
+		if left, ok := w.m.Disjuncts(ln); ok && !leftIsGroup {
+			last := left[len(left)-1]
+			return w.m.Disjunction(concat(left[:len(left)-1], w.m.Conjunction(last, rn))...)
+		}
+	}
+	return w.m.Conjunction(ln, rn)
+}
+
+func (w walker[T]) mimeType(k, v string) (T, bool) {
+	switch v {
+	case "file":
+		return w.m.Not(w.m.MimeType(k, _folderMimeType)), false
+	case "folder":
+		return w.m.MimeType(k, _folderMimeType), false
+	}
+
+	mimeTypes, ok := _mediaTypes[v]
+	switch {
+	case !ok:
+		return w.m.Field(k, v), false
+	case len(mimeTypes) == 1:
+		return w.m.MimeType(k, mimeTypes[0]), false
+	}
+
+	queries := make([]T, len(mimeTypes))
+	for i, mt := range mimeTypes {
+		queries[i] = w.m.MimeType(k, mt)
+	}
+	return w.m.Disjunction(queries...), true
+}
+
+// concat returns a new slice, the queries of the compiled bool queries must not be modified
+func concat[T any](s []T, v ...T) []T {
+	return append(append(make([]T, 0, len(s)+len(v)), s...), v...)
+}
+
+func getField(name string) string {
+	if name == "" {
+		return "Name"
+	}
+	if _, ok := _fields[strings.ToLower(name)]; ok {
+		return _fields[strings.ToLower(name)]
+	}
+	return name
+}
+
+func normalizeGroupingProperty(group *ast.GroupNode) *ast.GroupNode {
+	for _, n := range group.Nodes {
+		if onode, ok := n.(*ast.StringNode); ok {
+			onode.Key = group.Key
+		}
+	}
+	return group
+}
@@ -0,0 +1,47 @@
+package compiler
+
+const _folderMimeType = "httpd/unix-directory"
+
+// _mediaTypes maps the values of the mediatype property to the matching mime types. The values 'file' and 'folder'
+// are handled separately, unknown values are matched with the mime type itself.
+var _mediaTypes = map[string][]string{
+	"document": {
+		"application/msword",
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.form",
+		"application/vnd.oasis.opendocument.text",
+		"text/plain",
+		"text/markdown",
+		"application/rtf",
+		"application/vnd.apple.pages",
+	},
+	"spreadsheet": {
+		"application/vnd.ms-excel",
+		"application/vnd.oasis.opendocument.spreadsheet",
+		"text/csv",
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		"application/vnd.oasis.opendocument.spreadsheet",
+		"application/vnd.apple.numbers",
+	},
+	"presentation": {
+		"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+		"application/vnd.oasis.opendocument.presentation",
+		"application/vnd.ms-powerpoint",
+		"application/vnd.apple.keynote",
+	},
+	"pdf":   {"application/pdf"},
+	"image": {"image/*"},
+	"video": {"video/*"},
+	"audio": {"audio/*"},
+	"archive": {
+		"application/zip",
+		"application/gzip",
+		"application/x-gzip",
+		"application/x-7z-compressed",
+		"application/x-rar-compressed",
+		"application/x-tar",
+		"application/x-bzip2",
+		"application/x-bzip",
+		"application/x-tgz",
+	},
+}
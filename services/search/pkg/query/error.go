@@ -38,9 +38,28 @@ func (e UnsupportedTimeRangeError) Error() string {
 	return fmt.Sprintf("unable to convert '%v' to a time range", e.Value)
 }
 
+// SimilarGroupError records an error and the group that caused it, the similarity operator can't be used with groups.
+type SimilarGroupError struct {
+	Node *ast.GroupNode
+}
+
+func (e SimilarGroupError) Error() string {
+	return "the similar operator can't be used with a group: '" + e.Node.Key + ":(...)'"
+}
+
+// SimilarityDisabledError records an error and the node that caused it, it is returned if a query uses the
+// similarity operator but the similarity search is not enabled.
+type SimilarityDisabledError struct {
+	Node *ast.SimilarNode
+}
+
+func (e SimilarityDisabledError) Error() string {
+	return "the similarity search is not enabled, the query can't contain 'similar:" + e.Node.Value + "'"
+}
+
 func IsValidationError(err error) bool {
 	switch err.(type) {
-	case *StartsWithBinaryOperatorError, *NamedGroupInvalidNodesError, *UnsupportedTimeRangeError:
+	case *StartsWithBinaryOperatorError, *NamedGroupInvalidNodesError, *UnsupportedTimeRangeError, *SimilarGroupError, *SimilarityDisabledError:
 		return true
 	}
 	return false
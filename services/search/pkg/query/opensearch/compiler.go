@@ -0,0 +1,139 @@
+package opensearch
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/ast"
+	"github.com/owncloud/ocis/v2/services/search/pkg/query/compiler"
+)
+
+// Compiler represents a KQL query search string to the OpenSearch query DSL formatter.
+type Compiler struct{}
+
+// Compile implements the query formatter which converts the KQL query search string to the OpenSearch query DSL.
+func (c Compiler) Compile(givenAst *ast.Ast) (Query, error) {
+	return compiler.Compile[Query](mapper{}, givenAst)
+}
+
+// NewTermQuery returns a query matching the exact value of the field.
+func NewTermQuery(field string, value interface{}) Query {
+	return Query{"term": map[string]interface{}{field: map[string]interface{}{"value": value}}}
+}
+
+// NewPrefixQuery returns a query matching the values of the field starting with the prefix.
+func NewPrefixQuery(field, prefix string) Query {
+	return Query{"prefix": map[string]interface{}{field: map[string]interface{}{"value": prefix}}}
+}
+
+// NewConjunctionQuery returns a query matching if all queries match.
+func NewConjunctionQuery(queries ...Query) Query {
+	return Query{"bool": map[string]interface{}{"must": queries}}
+}
+
+// NewDisjunctionQuery returns a query matching if any of the queries matches.
+func NewDisjunctionQuery(queries ...Query) Query {
+	return Query{"bool": map[string]interface{}{"should": queries, "minimum_should_match": 1}}
+}
+
+// NewNegationQuery returns a query matching if the query does not match.
+func NewNegationQuery(q Query) Query {
+	return Query{"bool": map[string]interface{}{"must_not": []Query{q}}}
+}
+
+var _rangeOperators = map[string]string{">": "gt", ">=": "gte", "<": "lt", "<=": "lte"}
+
+// mapper maps the nodes of the ast to OpenSearch queries
+type mapper struct{}
+
+func (mapper) Field(k, v string) Query {
+	return fieldQuery(k, v)
+}
+
+func (mapper) MimeType(k, v string) Query {
+	return fieldQuery(k, v)
+}
+
+func (mapper) DateTime(k, operator string, v time.Time) Query {
+	return rangeQuery(k, _rangeOperators[operator], v.Format(time.RFC3339Nano))
+}
+
+func (mapper) Boolean(k string, v bool) Query {
+	return NewTermQuery(k, v)
+}
+
+func (mapper) Not(q Query) Query {
+	return NewNegationQuery(q)
+}
+
+func (mapper) Conjunction(queries ...Query) Query {
+	return NewConjunctionQuery(queries...)
+}
+
+func (mapper) Disjunction(queries ...Query) Query {
+	return NewDisjunctionQuery(queries...)
+}
+
+func (mapper) Conjuncts(q Query) ([]Query, bool) {
+	c := clauses(q, "must")
+	return c, c != nil
+}
+
+func (mapper) Disjuncts(q Query) ([]Query, bool) {
+	c := clauses(q, "should")
+	return c, c != nil
+}
+
+// clauses returns the clauses of the bool query, nil if the query is no bool query with only this kind of clauses
+func clauses(q Query, kind string) []Query {
+	b, ok := q["bool"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	for k := range b {
+		if k != kind && k != "minimum_should_match" {
+			return nil
+		}
+	}
+	c, _ := b[kind].([]Query)
+	return c
+}
+
+// fieldQuery returns the query for a value of a field, the values may contain the wildcards * and ?
+func fieldQuery(k, v string) Query {
+	switch k {
+	case "Size":
+		for _, op := range []struct{ prefix, name string }{{">=", "gte"}, {"<=", "lte"}, {">", "gt"}, {"<", "lt"}} {
+			if s, ok := strings.CutPrefix(v, op.prefix); ok {
+				if size, err := strconv.ParseUint(s, 10, 64); err == nil {
+					return rangeQuery(k, op.name, size)
+				}
+			}
+		}
+		if size, err := strconv.ParseUint(v, 10, 64); err == nil {
+			return NewTermQuery(k, size)
+		}
+		return NewTermQuery(k, v)
+	case "Hidden":
+		if hidden, err := strconv.ParseBool(v); err == nil {
+			return NewTermQuery(k, hidden)
+		}
+		return NewTermQuery(k, v)
+	case "Content":
+		if strings.ContainsAny(v, "*?") {
+			return Query{"wildcard": map[string]interface{}{k: map[string]interface{}{"value": v}}}
+		}
+		return Query{"match": map[string]interface{}{k: map[string]interface{}{"query": v, "operator": "and"}}}
+	}
+
+	kind := "term"
+	if strings.ContainsAny(v, "*?") {
+		kind = "wildcard"
+	}
+	return Query{kind: map[string]interface{}{k: map[string]interface{}{"value": v, "case_insensitive": true}}}
+}
+
+func rangeQuery(k, op string, v interface{}) Query {
+	return Query{"range": map[string]interface{}{k: map[string]interface{}{op: v}}}
+}
@@ -0,0 +1,180 @@
+package opensearch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/ast"
+	tAssert "github.com/stretchr/testify/assert"
+)
+
+func term(k, v string) Query {
+	return Query{"term": map[string]interface{}{k: map[string]interface{}{"value": v, "case_insensitive": true}}}
+}
+
+func wildcard(k, v string) Query {
+	return Query{"wildcard": map[string]interface{}{k: map[string]interface{}{"value": v, "case_insensitive": true}}}
+}
+
+func Test_compile(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    *ast.Ast
+		want    Query
+		wantErr bool
+	}{
+		{
+			name: `federated`,
+			args: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.StringNode{Value: "federated"},
+				},
+			},
+			want: NewConjunctionQuery(term("Name", "federated")),
+		},
+		{
+			name: `"John Smith" Jane`,
+			args: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.StringNode{Key: "name", Value: "John Smith"},
+					&ast.OperatorNode{Value: "AND"},
+					&ast.StringNode{Key: "name", Value: "Jane"},
+				},
+			},
+			want: NewConjunctionQuery(term("Name", "john smith"), term("Name", "jane")),
+		},
+		{
+			name: `name:"moby di*" OR tag:bestseller AND tag:book`,
+			args: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.StringNode{Key: "name", Value: "moby di*"},
+					&ast.OperatorNode{Value: "OR"},
+					&ast.StringNode{Key: "tag", Value: "bestseller"},
+					&ast.OperatorNode{Value: "AND"},
+					&ast.StringNode{Key: "tag", Value: "book"},
+				},
+			},
+			want: NewDisjunctionQuery(
+				wildcard("Name", "moby di*"),
+				NewConjunctionQuery(term("Tags", "bestseller"), term("Tags", "book")),
+			),
+		},
+		{
+			name: `a AND b OR c`,
+			args: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.StringNode{Value: "a"},
+					&ast.OperatorNode{Value: "AND"},
+					&ast.StringNode{Value: "b"},
+					&ast.OperatorNode{Value: "OR"},
+					&ast.StringNode{Value: "c"},
+				},
+			},
+			want: NewDisjunctionQuery(
+				NewConjunctionQuery(term("Name", "a"), term("Name", "b")),
+				term("Name", "c"),
+			),
+		},
+		{
+			name: `(a OR b OR c) AND d`,
+			args: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.GroupNode{Nodes: []ast.Node{
+						&ast.StringNode{Value: "a"},
+						&ast.OperatorNode{Value: "OR"},
+						&ast.StringNode{Value: "b"},
+						&ast.OperatorNode{Value: "OR"},
+						&ast.StringNode{Value: "c"},
+					}},
+					&ast.OperatorNode{Value: "AND"},
+					&ast.StringNode{Value: "d"},
+				},
+			},
+			want: NewConjunctionQuery(
+				NewDisjunctionQuery(term("Name", "a"), term("Name", "b"), term("Name", "c")),
+				term("Name", "d"),
+			),
+		},
+		{
+			name: `tag:book AND NOT tag:read`,
+			args: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.StringNode{Key: "tag", Value: "book"},
+					&ast.OperatorNode{Value: "AND"},
+					&ast.OperatorNode{Value: "NOT"},
+					&ast.StringNode{Key: "tag", Value: "read"},
+				},
+			},
+			want: NewConjunctionQuery(term("Tags", "book"), NewNegationQuery(term("Tags", "read"))),
+		},
+		{
+			name: `mediatype:image OR mediatype:folder`,
+			args: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.StringNode{Key: "mediatype", Value: "image"},
+					&ast.OperatorNode{Value: "OR"},
+					&ast.StringNode{Key: "mediatype", Value: "folder"},
+				},
+			},
+			want: NewDisjunctionQuery(wildcard("MimeType", "image/*"), term("MimeType", "httpd/unix-directory")),
+		},
+		{
+			name: `content:"ownCloud Infinite" size>=1024`,
+			args: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.StringNode{Key: "content", Value: "ownCloud Infinite"},
+					&ast.OperatorNode{Value: "AND"},
+					&ast.StringNode{Key: "size", Value: ">=1024"},
+				},
+			},
+			want: NewConjunctionQuery(
+				Query{"match": map[string]interface{}{"Content": map[string]interface{}{"query": "owncloud infinite", "operator": "and"}}},
+				Query{"range": map[string]interface{}{"Size": map[string]interface{}{"gte": uint64(1024)}}},
+			),
+		},
+		{
+			name: `mtime>=2023-09-05T08:42:11.23554+02:00 AND hidden:true`,
+			args: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.DateTimeNode{Key: "Mtime", Operator: &ast.OperatorNode{Value: ">="}, Value: time.Date(2023, 9, 5, 6, 42, 11, 0, time.UTC)},
+					&ast.OperatorNode{Value: "AND"},
+					&ast.BooleanNode{Key: "hidden", Value: true},
+				},
+			},
+			want: NewConjunctionQuery(
+				Query{"range": map[string]interface{}{"Mtime": map[string]interface{}{"gte": "2023-09-05T06:42:11Z"}}},
+				NewTermQuery("Hidden", true),
+			),
+		},
+		{
+			name:    `empty`,
+			args:    &ast.Ast{},
+			wantErr: true,
+		},
+		{
+			name: `similar:"sunset"`,
+			args: &ast.Ast{
+				Nodes: []ast.Node{
+					&ast.SimilarNode{Value: "sunset"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	assert := tAssert.New(t)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Compiler{}.Compile(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Compile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			assert.Equal(tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// Package opensearch provides the ability to work with OpenSearch queries.
+package opensearch
+
+import (
+	"github.com/owncloud/ocis/v2/ocis-pkg/kql"
+	"github.com/owncloud/ocis/v2/services/search/pkg/query"
+)
+
+// Query is a node of the OpenSearch query DSL, it marshals to the JSON representation of the query.
+type Query map[string]interface{}
+
+// Creator is combines a Builder and a Compiler which is used to Create the query.
+type Creator struct {
+	builder  query.Builder
+	compiler query.Compiler[Query]
+}
+
+// Create implements the Creator interface
+func (c Creator) Create(qs string) (Query, error) {
+	builderAst, err := c.builder.Build(qs)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.compiler.Compile(builderAst)
+}
+
+// DefaultCreator exposes a kql to OpenSearch query creator.
+var DefaultCreator = Creator{kql.Builder{}, Compiler{}}
@@ -0,0 +1,296 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	searchmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
+)
+
+// The states of an index job
+const (
+	IndexJobRunning     = "running"
+	IndexJobCompleted   = "completed"
+	IndexJobFailed      = "failed"
+	IndexJobInterrupted = "interrupted"
+)
+
+var errIndexJobRunning = errors.New("the space is already being indexed")
+
+// indexJob is the state of the indexing of a space. The pending folders are the checkpoint
+// of the job, an interrupted or failed job continues with them when the space is indexed again.
+type indexJob struct {
+	SpaceID   string          `json:"space_id"`
+	UserID    string          `json:"user_id"`
+	State     string          `json:"state"`
+	Processed uint64          `json:"processed"`
+	Indexed   uint64          `json:"indexed"`
+	Skipped   uint64          `json:"skipped"`
+	Failed    uint64          `json:"failed"`
+	Pending   []pendingFolder `json:"pending,omitempty"`
+	// Rerun is set when the space was changed or reindexed while the job was running, the job walks the
+	// space again when it is done, otherwise the changes in the folders it already walked would be lost
+	Rerun       bool      `json:"rerun,omitempty"`
+	CurrentPath string    `json:"current_path,omitempty"`
+	StartedAt   time.Time `json:"started_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	FinishedAt  time.Time `json:"finished_at,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// pendingFolder is a folder the job still has to walk. The folder itself is indexed
+// when the job leaves it after all of its children, an unfinished folder is never considered unchanged.
+type pendingFolder struct {
+	Path  string `json:"path"`
+	Leave bool   `json:"leave,omitempty"`
+}
+
+// indexJobs keeps track of the index jobs of all spaces and persists them in the data path.
+// An empty data path keeps the jobs in memory only.
+type indexJobs struct {
+	dataPath string
+	mu       sync.Mutex
+	jobs     map[string]*indexJob
+}
+
+func newIndexJobs(dataPath string) (*indexJobs, error) {
+	j := &indexJobs{
+		dataPath: dataPath,
+		jobs:     map[string]*indexJob{},
+	}
+	if dataPath == "" {
+		return j, nil
+	}
+
+	if err := os.MkdirAll(dataPath, 0700); err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(dataPath, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		job := &indexJob{}
+		if err := json.Unmarshal(b, job); err != nil {
+			return nil, err
+		}
+		// the service stopped while the job was running
+		if job.State == IndexJobRunning {
+			job.State = IndexJobInterrupted
+		}
+		j.jobs[job.SpaceID] = job
+	}
+	return j, nil
+}
+
+// start starts a job for the space. An interrupted or failed job of the space is resumed from its checkpoint.
+// If a job of the space is already running, it is marked to walk the space again when it is done.
+func (j *indexJobs) start(spaceID, userID string) (*indexJob, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	job, ok := j.jobs[spaceID]
+	switch {
+	case ok && job.State == IndexJobRunning:
+		if !job.Rerun {
+			job.Rerun = true
+			if err := j.save(job); err != nil {
+				return nil, err
+			}
+		}
+		return nil, errIndexJobRunning
+	case ok && len(job.Pending) > 0:
+		job.State = IndexJobRunning
+		job.Error = ""
+		job.FinishedAt = time.Time{}
+	default:
+		job = &indexJob{
+			SpaceID:   spaceID,
+			State:     IndexJobRunning,
+			Pending:   []pendingFolder{{Path: "."}},
+			StartedAt: now,
+		}
+		j.jobs[spaceID] = job
+	}
+	job.UserID = userID
+	job.UpdatedAt = now
+
+	return job, j.save(job)
+}
+
+// resumable returns the jobs which were interrupted by a restart of the service.
+func (j *indexJobs) resumable() []indexJob {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var jobs []indexJob
+	for _, job := range j.jobs {
+		if job.State == IndexJobInterrupted && len(job.Pending) > 0 {
+			jobs = append(jobs, *job)
+		}
+	}
+	return jobs
+}
+
+// update changes the job while holding the lock, the status of the job is read concurrently.
+func (j *indexJobs) update(job *indexJob, fn func(job *indexJob)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	fn(job)
+	job.UpdatedAt = time.Now()
+}
+
+// checkpoint persists the progress of the job.
+func (j *indexJobs) checkpoint(job *indexJob) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.save(job)
+}
+
+// complete marks the job as completed and persists it. A job marked to run again is restarted at the
+// root of the space instead and true is returned.
+func (j *indexJobs) complete(job *indexJob) (bool, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if job.Rerun {
+		job.Rerun = false
+		job.Pending = []pendingFolder{{Path: "."}}
+		job.UpdatedAt = time.Now()
+		return true, j.save(job)
+	}
+	return false, j.finishLocked(job, nil)
+}
+
+// finish marks the job as completed or failed and persists it.
+func (j *indexJobs) finish(job *indexJob, err error) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.finishLocked(job, err)
+}
+
+func (j *indexJobs) finishLocked(job *indexJob, err error) error {
+	now := time.Now()
+	job.UpdatedAt = now
+	job.FinishedAt = now
+	job.CurrentPath = ""
+	if err != nil {
+		job.State = IndexJobFailed
+		job.Error = err.Error()
+	} else {
+		job.State = IndexJobCompleted
+		job.Pending = nil
+	}
+	return j.save(job)
+}
+
+// status returns the jobs of the space or of all spaces if the space id is empty.
+func (j *indexJobs) status(spaceID string) []*searchmsg.IndexJob {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var jobs []*searchmsg.IndexJob
+	for id, job := range j.jobs {
+		if spaceID != "" && id != spaceID {
+			continue
+		}
+		jobs = append(jobs, job.toProto())
+	}
+	sort.Slice(jobs, func(a, b int) bool {
+		return jobs[a].SpaceId < jobs[b].SpaceId
+	})
+	return jobs
+}
+
+// save writes the job to a temporary file first, so a crash never leaves a partially written checkpoint behind.
+func (j *indexJobs) save(job *indexJob) error {
+	if j.dataPath == "" {
+		return nil
+	}
+
+	b, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	name := filepath.Join(j.dataPath, url.PathEscape(job.SpaceID)+".json")
+	if err := os.WriteFile(name+".tmp", b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(name+".tmp", name)
+}
+
+func (job *indexJob) toProto() *searchmsg.IndexJob {
+	var pending uint64
+	for _, p := range job.Pending {
+		if !p.Leave {
+			pending++
+		}
+	}
+
+	return &searchmsg.IndexJob{
+		SpaceId:     job.SpaceID,
+		UserId:      job.UserID,
+		State:       job.State,
+		Processed:   job.Processed,
+		Indexed:     job.Indexed,
+		Skipped:     job.Skipped,
+		Failed:      job.Failed,
+		Pending:     pending,
+		CurrentPath: job.CurrentPath,
+		StartedAt:   toTimestamp(job.StartedAt),
+		UpdatedAt:   toTimestamp(job.UpdatedAt),
+		FinishedAt:  toTimestamp(job.FinishedAt),
+		Error:       job.Error,
+	}
+}
+
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// _maxYield limits how long a job waits for searches, so a steady stream of searches doesn't stall it
+const _maxYield = time.Second
+
+// throttle paces an index job to the configured rate and lets it yield to interactive searches.
+type throttle struct {
+	interval time.Duration
+	searches *atomic.Int32
+	last     time.Time
+}
+
+func (t *throttle) wait() {
+	if t.interval > 0 {
+		if d := time.Until(t.last.Add(t.interval)); d > 0 {
+			time.Sleep(d)
+		}
+		t.last = time.Now()
+	}
+
+	if t.searches == nil {
+		return
+	}
+	deadline := time.Now().Add(_maxYield)
+	for t.searches.Load() > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -10,6 +10,8 @@ import (
 
 	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
 
+	searchv0 "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
+
 	v0 "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
 )
 
@@ -73,6 +75,54 @@ func (_c *Searcher_IndexSpace_Call) RunAndReturn(run func(*providerv1beta1.Stora
 	return _c
 }
 
+// IndexStatus provides a mock function with given fields: spaceID
+func (_m *Searcher) IndexStatus(spaceID string) []*searchv0.IndexJob {
+	ret := _m.Called(spaceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IndexStatus")
+	}
+
+	var r0 []*searchv0.IndexJob
+	if rf, ok := ret.Get(0).(func(string) []*searchv0.IndexJob); ok {
+		r0 = rf(spaceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*searchv0.IndexJob)
+		}
+	}
+
+	return r0
+}
+
+// Searcher_IndexStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IndexStatus'
+type Searcher_IndexStatus_Call struct {
+	*mock.Call
+}
+
+// IndexStatus is a helper method to define mock.On call
+//   - spaceID string
+func (_e *Searcher_Expecter) IndexStatus(spaceID interface{}) *Searcher_IndexStatus_Call {
+	return &Searcher_IndexStatus_Call{Call: _e.mock.On("IndexStatus", spaceID)}
+}
+
+func (_c *Searcher_IndexStatus_Call) Run(run func(spaceID string)) *Searcher_IndexStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *Searcher_IndexStatus_Call) Return(_a0 []*searchv0.IndexJob) *Searcher_IndexStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Searcher_IndexStatus_Call) RunAndReturn(run func(string) []*searchv0.IndexJob) *Searcher_IndexStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // MoveItem provides a mock function with given fields: ref, uID
 func (_m *Searcher) MoveItem(ref *providerv1beta1.Reference, uID *userv1beta1.UserId) {
 	_m.Called(ref, uID)
@@ -200,6 +250,65 @@ func (_c *Searcher_Search_Call) RunAndReturn(run func(context.Context, *v0.Searc
 	return _c
 }
 
+// StartIndexSpace provides a mock function with given fields: rID, uID
+func (_m *Searcher) StartIndexSpace(rID *providerv1beta1.StorageSpaceId, uID *userv1beta1.UserId) (*searchv0.IndexJob, error) {
+	ret := _m.Called(rID, uID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartIndexSpace")
+	}
+
+	var r0 *searchv0.IndexJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*providerv1beta1.StorageSpaceId, *userv1beta1.UserId) (*searchv0.IndexJob, error)); ok {
+		return rf(rID, uID)
+	}
+	if rf, ok := ret.Get(0).(func(*providerv1beta1.StorageSpaceId, *userv1beta1.UserId) *searchv0.IndexJob); ok {
+		r0 = rf(rID, uID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*searchv0.IndexJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*providerv1beta1.StorageSpaceId, *userv1beta1.UserId) error); ok {
+		r1 = rf(rID, uID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Searcher_StartIndexSpace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartIndexSpace'
+type Searcher_StartIndexSpace_Call struct {
+	*mock.Call
+}
+
+// StartIndexSpace is a helper method to define mock.On call
+//   - rID *providerv1beta1.StorageSpaceId
+//   - uID *userv1beta1.UserId
+func (_e *Searcher_Expecter) StartIndexSpace(rID interface{}, uID interface{}) *Searcher_StartIndexSpace_Call {
+	return &Searcher_StartIndexSpace_Call{Call: _e.mock.On("StartIndexSpace", rID, uID)}
+}
+
+func (_c *Searcher_StartIndexSpace_Call) Run(run func(rID *providerv1beta1.StorageSpaceId, uID *userv1beta1.UserId)) *Searcher_StartIndexSpace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*providerv1beta1.StorageSpaceId), args[1].(*userv1beta1.UserId))
+	})
+	return _c
+}
+
+func (_c *Searcher_StartIndexSpace_Call) Return(_a0 *searchv0.IndexJob, _a1 error) *Searcher_StartIndexSpace_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Searcher_StartIndexSpace_Call) RunAndReturn(run func(*providerv1beta1.StorageSpaceId, *userv1beta1.UserId) (*searchv0.IndexJob, error)) *Searcher_StartIndexSpace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TrashItem provides a mock function with given fields: rID
 func (_m *Searcher) TrashItem(rID *providerv1beta1.ResourceId) {
 	_m.Called(rID)
@@ -2,11 +2,13 @@ package search
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
@@ -19,7 +21,6 @@ import (
 	"github.com/cs3org/reva/v2/pkg/errtypes"
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
 	sdk "github.com/cs3org/reva/v2/pkg/sdk/common"
-	"github.com/cs3org/reva/v2/pkg/storage/utils/walker"
 	"github.com/cs3org/reva/v2/pkg/storagespace"
 	"github.com/cs3org/reva/v2/pkg/utils"
 	"golang.org/x/sync/errgroup"
@@ -47,6 +48,8 @@ const (
 type Searcher interface {
 	Search(ctx context.Context, req *searchsvc.SearchRequest) (*searchsvc.SearchResponse, error)
 	IndexSpace(rID *provider.StorageSpaceId, uID *user.UserId) error
+	StartIndexSpace(rID *provider.StorageSpaceId, uID *user.UserId) (*searchmsg.IndexJob, error)
+	IndexStatus(spaceID string) []*searchmsg.IndexJob
 	TrashItem(rID *provider.ResourceId)
 	UpsertItem(ref *provider.Reference, uID *user.UserId)
 	RestoreItem(ref *provider.Reference, uID *user.UserId)
@@ -60,6 +63,12 @@ type Service struct {
 	gatewaySelector pool.Selectable[gateway.GatewayAPIClient]
 	engine          engine.Engine
 	extractor       content.Extractor
+	indexJobs       *indexJobs
+
+	// the index jobs are paced to the interval and yield while searches are running
+	indexInterval time.Duration
+	yieldToSearch bool
+	searches      atomic.Int32
 
 	serviceAccountID     string
 	serviceAccountSecret string
@@ -74,11 +83,23 @@ func NewService(gatewaySelector pool.Selectable[gateway.GatewayAPIClient], eng e
 		engine:          eng,
 		logger:          logger,
 		extractor:       extractor,
+		yieldToSearch:   cfg.IndexJobs.YieldToSearch,
 
 		serviceAccountID:     cfg.ServiceAccount.ServiceAccountID,
 		serviceAccountSecret: cfg.ServiceAccount.ServiceAccountSecret,
 	}
 
+	if cfg.IndexJobs.RateLimit > 0 {
+		s.indexInterval = time.Second / time.Duration(cfg.IndexJobs.RateLimit)
+	}
+
+	jobs, err := newIndexJobs(cfg.IndexJobs.DataPath)
+	if err != nil {
+		logger.Error().Err(err).Str("path", cfg.IndexJobs.DataPath).Msg("could not load the index jobs, the jobs are not persisted")
+		jobs, _ = newIndexJobs("")
+	}
+	s.indexJobs = jobs
+
 	return s
 }
 
@@ -86,6 +107,9 @@ func NewService(gatewaySelector pool.Selectable[gateway.GatewayAPIClient], eng e
 func (s *Service) Search(ctx context.Context, req *searchsvc.SearchRequest) (*searchsvc.SearchResponse, error) {
 	s.logger.Debug().Str("query", req.Query).Msg("performing a search")
 
+	s.searches.Add(1)
+	defer s.searches.Add(-1)
+
 	gatewayClient, err := s.gatewaySelector.Next()
 	if err != nil {
 		return nil, err
@@ -178,6 +202,7 @@ func (s *Service) Search(ctx context.Context, req *searchsvc.SearchRequest) (*se
 
 	matches := matchArray{}
 	total := int32(0)
+	var facets [][]*searchmsg.Facet
 
 	errg, ctx := errgroup.WithContext(ctx)
 	work := make(chan *provider.StorageSpace, len(spaces))
@@ -241,6 +266,7 @@ func (s *Service) Search(ctx context.Context, req *searchsvc.SearchRequest) (*se
 			continue
 		}
 		total += res.TotalMatches
+		facets = append(facets, res.Facets)
 		for _, match := range res.Matches {
 			matches = append(matches, match)
 		}
@@ -256,10 +282,14 @@ func (s *Service) Search(ctx context.Context, req *searchsvc.SearchRequest) (*se
 		matches = matches[0:limit]
 	}
 
-	return &searchsvc.SearchResponse{
+	res := &searchsvc.SearchResponse{
 		Matches:      matches,
 		TotalMatches: total,
-	}, nil
+	}
+	if req.Facets {
+		res.Facets = engine.MergeFacets(facets)
+	}
+	return res, nil
 }
 
 func (s *Service) searchIndex(ctx context.Context, req *searchsvc.SearchRequest, space *provider.StorageSpace, mountpointID string) (*searchsvc.SearchIndexResponse, error) {
@@ -365,6 +395,7 @@ func (s *Service) searchIndex(ctx context.Context, req *searchsvc.SearchRequest,
 			Path:       searchPathPrefix,
 		},
 		PageSize: req.PageSize,
+		Facets:   req.Facets,
 	}
 	start := time.Now()
 	res, err := s.engine.Search(ctx, searchRequest)
@@ -405,69 +436,273 @@ func (s *Service) searchIndex(ctx context.Context, req *searchsvc.SearchRequest,
 
 	res.Matches = matches
 
+	// count the matches of shares by their mountpoint like the match references
+	spaceID, spaceName := searchRootID, space.GetName()
+	if mountpointRootID != nil {
+		spaceID, spaceName = mountpointRootID, rootName
+	}
+	for _, facet := range res.Facets {
+		if facet.Name != engine.FacetSpace {
+			continue
+		}
+		for _, v := range facet.Values {
+			v.Value = storagespace.FormatResourceID(provider.ResourceId{
+				StorageId: spaceID.GetStorageId(),
+				SpaceId:   spaceID.GetSpaceId(),
+				OpaqueId:  spaceID.GetOpaqueId(),
+			})
+			v.Name = spaceName
+		}
+	}
+
 	return res, nil
 }
 
-// IndexSpace (re)indexes all resources of a given space.
+// IndexSpace (re)indexes all resources of a given space. The progress of the job is checkpointed,
+// an interrupted or failed job of the space continues where it stopped.
 func (s *Service) IndexSpace(spaceID *provider.StorageSpaceId, uID *user.UserId) error {
-	ownerCtx, err := getAuthContext(s.serviceAccountID, s.gatewaySelector, s.serviceAccountSecret, s.logger)
-	if err != nil {
+	job, rootID, err := s.startIndexJob(spaceID, uID)
+	switch {
+	case errors.Is(err, errIndexJobRunning):
+		// the running job walks the space again when it is done
+		s.logger.Debug().Str("spaceID", spaceID.GetOpaqueId()).Msg("the space is already being indexed, queued a rerun")
+		return nil
+	case err != nil:
 		return err
 	}
 
-	rootID, err := storagespace.ParseID(spaceID.OpaqueId)
+	return s.runIndexJob(job, rootID, uID)
+}
+
+// StartIndexSpace starts to (re)index all resources of a given space in the background and returns the job.
+// If the space is already being indexed, the running job is returned.
+func (s *Service) StartIndexSpace(spaceID *provider.StorageSpaceId, uID *user.UserId) (*searchmsg.IndexJob, error) {
+	job, rootID, err := s.startIndexJob(spaceID, uID)
+	switch {
+	case errors.Is(err, errIndexJobRunning):
+		return s.IndexStatus(spaceID.GetOpaqueId())[0], nil
+	case err != nil:
+		return nil, err
+	}
+
+	go func() {
+		if err := s.runIndexJob(job, rootID, uID); err != nil {
+			s.logger.Error().Err(err).Str("spaceID", job.SpaceID).Msg("error while indexing a space")
+		}
+	}()
+
+	return s.IndexStatus(job.SpaceID)[0], nil
+}
+
+// IndexStatus returns the index job of the space or the jobs of all spaces if the space id is empty.
+func (s *Service) IndexStatus(spaceID string) []*searchmsg.IndexJob {
+	if spaceID != "" {
+		if rootID, err := parseSpaceRoot(spaceID); err == nil {
+			spaceID = storagespace.FormatResourceID(rootID)
+		}
+	}
+	return s.indexJobs.status(spaceID)
+}
+
+// ResumeIndexJobs resumes the index jobs which were interrupted by a restart of the service in the background.
+func (s *Service) ResumeIndexJobs() {
+	jobs := s.indexJobs.resumable()
+	if len(jobs) == 0 {
+		return
+	}
+
+	go func() {
+		for _, job := range jobs {
+			s.logger.Info().Str("spaceID", job.SpaceID).Msg("resuming an interrupted index job")
+			if err := s.IndexSpace(&provider.StorageSpaceId{OpaqueId: job.SpaceID}, &user.UserId{OpaqueId: job.UserID}); err != nil {
+				s.logger.Error().Err(err).Str("spaceID", job.SpaceID).Msg("error while indexing a space")
+			}
+		}
+	}()
+}
+
+func (s *Service) startIndexJob(spaceID *provider.StorageSpaceId, uID *user.UserId) (*indexJob, provider.ResourceId, error) {
+	rootID, err := parseSpaceRoot(spaceID.GetOpaqueId())
 	if err != nil {
 		s.logger.Error().Err(err).Msg("invalid space id")
+		return nil, rootID, err
+	}
+
+	job, err := s.indexJobs.start(storagespace.FormatResourceID(rootID), uID.GetOpaqueId())
+	return job, rootID, err
+}
+
+// runIndexJob walks the pending folders of the job depth first. The files of a folder are indexed when the folder
+// is entered, the folder itself when it is left, so an unchanged folder in the index always has an indexed subtree.
+func (s *Service) runIndexJob(job *indexJob, rootID provider.ResourceId, uID *user.UserId) error {
+	ownerCtx, err := getAuthContext(s.serviceAccountID, s.gatewaySelector, s.serviceAccountSecret, s.logger)
+	if err != nil {
+		_ = s.indexJobs.finish(job, err)
 		return err
 	}
-	if rootID.StorageId == "" || rootID.SpaceId == "" {
-		s.logger.Error().Err(err).Msg("invalid space id")
-		return fmt.Errorf("invalid space id")
+
+	t := &throttle{interval: s.indexInterval}
+	if s.yieldToSearch {
+		t.searches = &s.searches
 	}
-	rootID.OpaqueId = rootID.SpaceId
 
-	w := walker.NewWalker(s.gatewaySelector)
-	err = w.Walk(ownerCtx, &rootID, func(wd string, info *provider.ResourceInfo, err error) error {
-		if err != nil {
-			s.logger.Error().Err(err).Msg("error walking the tree")
-			return err
+	for {
+		var folder pendingFolder
+		var done bool
+		s.indexJobs.update(job, func(job *indexJob) {
+			if done = len(job.Pending) == 0; !done {
+				folder = job.Pending[len(job.Pending)-1]
+				job.CurrentPath = folder.Path
+			}
+		})
+		if done {
+			rerun, err := s.indexJobs.complete(job)
+			if err != nil {
+				s.logger.Error().Err(err).Msg("could not persist the index job")
+			}
+			if rerun {
+				s.logger.Debug().Str("spaceID", job.SpaceID).Msg("the space changed while it was indexed, walking it again")
+				continue
+			}
+			break
 		}
 
-		if info == nil {
-			return nil
+		if folder.Leave {
+			s.indexJobs.update(job, popFolder)
+			s.indexItem(job, t, &provider.Reference{ResourceId: &rootID, Path: folder.Path}, uID)
+		} else if err := s.walkFolder(ownerCtx, job, t, rootID, folder, uID); err != nil {
+			s.logger.Error().Err(err).Str("path", folder.Path).Msg("error walking the tree")
+			_ = s.indexJobs.finish(job, err)
+			return err
 		}
 
-		ref := &provider.Reference{
-			Path:       utils.MakeRelativePath(filepath.Join(wd, info.Path)),
-			ResourceId: &rootID,
+		if err := s.indexJobs.checkpoint(job); err != nil {
+			s.logger.Error().Err(err).Msg("could not persist the index job")
 		}
-		s.logger.Debug().Str("path", ref.Path).Msg("Walking tree")
+	}
+
+	logDocCount(s.engine, s.logger)
 
-		searchRes, err := s.engine.Search(ownerCtx, &searchsvc.SearchIndexRequest{
-			Query: "id:" + storagespace.FormatResourceID(*info.Id) + ` mtime>=` + utils.TSToTime(info.Mtime).Format(time.RFC3339Nano),
+	return nil
+}
+
+// walkFolder indexes the changed files of the folder and replaces it with its subfolders on the pending stack.
+func (s *Service) walkFolder(ctx context.Context, job *indexJob, t *throttle, rootID provider.ResourceId, folder pendingFolder, uID *user.UserId) error {
+	gatewayClient, err := s.gatewaySelector.Next()
+	if err != nil {
+		return err
+	}
+
+	ref := &provider.Reference{ResourceId: &rootID, Path: folder.Path}
+	s.logger.Debug().Str("path", ref.Path).Msg("Walking tree")
+
+	statRes, err := gatewayClient.Stat(ctx, &provider.StatRequest{Ref: ref})
+	switch {
+	case err != nil:
+		return err
+	case statRes.GetStatus().GetCode() == rpc.Code_CODE_NOT_FOUND:
+		// the folder was deleted in the meantime
+		s.indexJobs.update(job, popFolder)
+		return nil
+	case statRes.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return errtypes.NewErrtypeFromStatus(statRes.GetStatus())
+	}
+
+	info := statRes.GetInfo()
+	if s.unchanged(ctx, info) {
+		s.logger.Debug().Str("path", ref.Path).Msg("subtree hasn't changed. Skipping.")
+		s.indexJobs.update(job, func(job *indexJob) {
+			popFolder(job)
+			job.Processed++
+			job.Skipped++
 		})
+		return nil
+	}
 
-		if err == nil && len(searchRes.Matches) >= 1 {
-			if info.Type == provider.ResourceType_RESOURCE_TYPE_CONTAINER {
-				s.logger.Debug().Str("path", ref.Path).Msg("subtree hasn't changed. Skipping.")
-				return filepath.SkipDir
-			}
-			s.logger.Debug().Str("path", ref.Path).Msg("element hasn't changed. Skipping.")
-			return nil
+	if info.GetType() != provider.ResourceType_RESOURCE_TYPE_CONTAINER {
+		s.indexJobs.update(job, popFolder)
+		s.indexItem(job, t, ref, uID)
+		return nil
+	}
+
+	lcRes, err := gatewayClient.ListContainer(ctx, &provider.ListContainerRequest{Ref: &provider.Reference{ResourceId: info.GetId()}})
+	switch {
+	case err != nil:
+		return err
+	case lcRes.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return errtypes.NewErrtypeFromStatus(lcRes.GetStatus())
+	}
+
+	var folders []pendingFolder
+	for _, child := range lcRes.GetInfos() {
+		childRef := &provider.Reference{
+			ResourceId: &rootID,
+			Path:       utils.MakeRelativePath(filepath.Join(folder.Path, child.GetPath())),
+		}
+		if child.GetType() == provider.ResourceType_RESOURCE_TYPE_CONTAINER {
+			folders = append(folders, pendingFolder{Path: childRef.Path})
+			continue
 		}
 
-		s.UpsertItem(ref, uID)
+		if s.unchanged(ctx, child) {
+			s.logger.Debug().Str("path", childRef.Path).Msg("element hasn't changed. Skipping.")
+			s.indexJobs.update(job, func(job *indexJob) {
+				job.Processed++
+				job.Skipped++
+			})
+			continue
+		}
+		s.indexItem(job, t, childRef, uID)
+	}
 
-		return nil
+	s.indexJobs.update(job, func(job *indexJob) {
+		job.Pending[len(job.Pending)-1].Leave = true
+		job.Pending = append(job.Pending, folders...)
 	})
+	return nil
+}
 
+// indexItem upserts the resource into the index and counts the result in the job.
+func (s *Service) indexItem(job *indexJob, t *throttle, ref *provider.Reference, uID *user.UserId) {
+	t.wait()
+
+	err := s.upsertItem(ref, uID)
 	if err != nil {
-		return err
+		s.logger.Error().Err(err).Str("path", ref.GetPath()).Msg("failed to index the resource")
 	}
 
-	logDocCount(s.engine, s.logger)
+	s.indexJobs.update(job, func(job *indexJob) {
+		job.Processed++
+		if err != nil {
+			job.Failed++
+		} else {
+			job.Indexed++
+		}
+	})
+}
 
-	return nil
+// unchanged checks if the resource is in the index with the same or a newer mtime.
+func (s *Service) unchanged(ctx context.Context, info *provider.ResourceInfo) bool {
+	searchRes, err := s.engine.Search(ctx, &searchsvc.SearchIndexRequest{
+		Query: "id:" + storagespace.FormatResourceID(*info.GetId()) + ` mtime>=` + utils.TSToTime(info.GetMtime()).Format(time.RFC3339Nano),
+	})
+	return err == nil && len(searchRes.GetMatches()) >= 1
+}
+
+func popFolder(job *indexJob) {
+	job.Pending = job.Pending[:len(job.Pending)-1]
+}
+
+func parseSpaceRoot(spaceID string) (provider.ResourceId, error) {
+	rootID, err := storagespace.ParseID(spaceID)
+	if err != nil {
+		return rootID, err
+	}
+	if rootID.StorageId == "" || rootID.SpaceId == "" {
+		return rootID, fmt.Errorf("invalid space id")
+	}
+	rootID.OpaqueId = rootID.SpaceId
+	return rootID, nil
 }
 
 // TrashItem marks the item as deleted.
@@ -480,15 +715,20 @@ func (s *Service) TrashItem(rID *provider.ResourceId) {
 
 // UpsertItem indexes or stores Resource data fields.
 func (s *Service) UpsertItem(ref *provider.Reference, uID *user.UserId) {
+	if err := s.upsertItem(ref, uID); err != nil {
+		s.logger.Error().Err(err).Msg("error adding updating the resource in the index")
+	}
+}
+
+func (s *Service) upsertItem(ref *provider.Reference, uID *user.UserId) error {
 	ctx, stat, path := s.resInfo(uID, ref)
 	if ctx == nil || stat == nil || path == "" {
-		return
+		return errors.New("could not resolve the resource")
 	}
 
 	doc, err := s.extractor.Extract(ctx, stat.Info)
 	if err != nil {
-		s.logger.Error().Err(err).Msg("failed to extract resource content")
-		return
+		return fmt.Errorf("failed to extract resource content: %w", err)
 	}
 
 	r := engine.Resource{
@@ -509,10 +749,9 @@ func (s *Service) UpsertItem(ref *provider.Reference, uID *user.UserId) {
 	}
 
 	if err = s.engine.Upsert(r.ID, r); err != nil {
-		s.logger.Error().Err(err).Msg("error adding updating the resource in the index")
-	} else {
-		logDocCount(s.engine, s.logger)
+		return err
 	}
+	logDocCount(s.engine, s.logger)
 
 	// determine if metadata needs to be stored in storage as well
 	metadata := map[string]string{}
@@ -521,7 +760,7 @@ func (s *Service) UpsertItem(ref *provider.Reference, uID *user.UserId) {
 	addLocationMetadata(metadata, doc.Location)
 	addPhotoMetadata(metadata, doc.Photo)
 	if len(metadata) == 0 {
-		return
+		return nil
 	}
 
 	s.logger.Trace().Str("name", doc.Name).Interface("metadata", metadata).Msg("Storing metadata")
@@ -529,7 +768,7 @@ func (s *Service) UpsertItem(ref *provider.Reference, uID *user.UserId) {
 	gatewayClient, err := s.gatewaySelector.Next()
 	if err != nil {
 		s.logger.Error().Err(err).Msg("could not retrieve client to store metadata")
-		return
+		return nil
 	}
 
 	resp, err := gatewayClient.SetArbitraryMetadata(ctx, &provider.SetArbitraryMetadataRequest{
@@ -540,8 +779,8 @@ func (s *Service) UpsertItem(ref *provider.Reference, uID *user.UserId) {
 	})
 	if err != nil || resp.GetStatus().GetCode() != rpc.Code_CODE_OK {
 		s.logger.Error().Err(err).Int32("status", int32(resp.GetStatus().GetCode())).Msg("error storing metadata")
-		return
 	}
+	return nil
 }
 
 func addAudioMetadata(metadata map[string]string, audio *libregraph.Audio) {
@@ -2,6 +2,8 @@ package search_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 
 	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
 	userv1beta1 "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
@@ -131,6 +133,121 @@ var _ = Describe("Searchprovider", func() {
 			err := s.IndexSpace(&sprovider.StorageSpaceId{OpaqueId: "storageid$spaceid!spaceid"}, user.Id)
 			Expect(err).ShouldNot(HaveOccurred())
 		})
+
+		Context("with a tree", func() {
+			var (
+				cfg      *config.Config
+				upserted []string
+				stated   []string
+				onUpsert func(id string)
+
+				info = func(id string, t sprovider.ResourceType, path string) *sprovider.ResourceInfo {
+					return &sprovider.ResourceInfo{
+						Id:    &sprovider.ResourceId{StorageId: "storageid", SpaceId: "spaceid", OpaqueId: id},
+						Type:  t,
+						Path:  path,
+						Mtime: &typesv1beta1.Timestamp{Seconds: 4000},
+					}
+				}
+				tree = map[string]*sprovider.ResourceInfo{
+					".":           info("spaceid", sprovider.ResourceType_RESOURCE_TYPE_CONTAINER, "."),
+					"./a.txt":     info("a", sprovider.ResourceType_RESOURCE_TYPE_FILE, "a.txt"),
+					"./sub":       info("sub", sprovider.ResourceType_RESOURCE_TYPE_CONTAINER, "sub"),
+					"./sub/b.txt": info("b", sprovider.ResourceType_RESOURCE_TYPE_FILE, "b.txt"),
+				}
+			)
+
+			BeforeEach(func() {
+				upserted, stated, onUpsert = nil, nil, nil
+				cfg = &config.Config{IndexJobs: config.IndexJobs{DataPath: GinkgoT().TempDir()}}
+
+				gatewayClient.On("GetUserByClaim", mock.Anything, mock.Anything).Return(&userv1beta1.GetUserByClaimResponse{
+					Status: status.NewOK(context.Background()),
+					User:   user,
+				}, nil)
+				gatewayClient.On("Stat", mock.Anything, mock.Anything).Return(func(_ context.Context, req *sprovider.StatRequest, _ ...grpc.CallOption) (*sprovider.StatResponse, error) {
+					stated = append(stated, req.GetRef().GetPath())
+					return &sprovider.StatResponse{Status: status.NewOK(context.Background()), Info: tree[req.GetRef().GetPath()]}, nil
+				})
+				gatewayClient.On("ListContainer", mock.Anything, mock.Anything).Return(func(_ context.Context, req *sprovider.ListContainerRequest, _ ...grpc.CallOption) (*sprovider.ListContainerResponse, error) {
+					res := &sprovider.ListContainerResponse{Status: status.NewOK(context.Background())}
+					switch req.GetRef().GetResourceId().GetOpaqueId() {
+					case "spaceid":
+						res.Infos = []*sprovider.ResourceInfo{tree["./a.txt"], tree["./sub"]}
+					case "sub":
+						res.Infos = []*sprovider.ResourceInfo{tree["./sub/b.txt"]}
+					}
+					return res, nil
+				})
+				extractor.On("Extract", mock.Anything, mock.Anything, mock.Anything).Return(content.Document{}, nil)
+				indexClient.On("Search", mock.Anything, mock.Anything).Return(&searchsvc.SearchIndexResponse{}, nil)
+				indexClient.On("Upsert", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					upserted = append(upserted, args.String(0))
+					if onUpsert != nil {
+						onUpsert(args.String(0))
+					}
+				}).Return(nil)
+			})
+
+			It("indexes the folders after their children and reports the progress", func() {
+				s := search.NewService(gatewaySelector, indexClient, extractor, logger, cfg)
+				Expect(s.IndexSpace(&sprovider.StorageSpaceId{OpaqueId: "storageid$spaceid"}, user.Id)).To(Succeed())
+
+				Expect(upserted).To(Equal([]string{"storageid$spaceid!a", "storageid$spaceid!b", "storageid$spaceid!sub", "storageid$spaceid!spaceid"}))
+
+				jobs := s.IndexStatus("storageid$spaceid")
+				Expect(jobs).To(HaveLen(1))
+				Expect(jobs[0].SpaceId).To(Equal("storageid$spaceid!spaceid"))
+				Expect(jobs[0].State).To(Equal(search.IndexJobCompleted))
+				Expect(jobs[0].Processed).To(Equal(uint64(4)))
+				Expect(jobs[0].Indexed).To(Equal(uint64(4)))
+				Expect(jobs[0].Pending).To(BeZero())
+				Expect(jobs[0].FinishedAt).ToNot(BeNil())
+			})
+
+			It("walks the space again if it changed while it was indexed", func() {
+				s := search.NewService(gatewaySelector, indexClient, extractor, logger, cfg)
+				onUpsert = func(id string) {
+					if id == "storageid$spaceid!a" && len(upserted) == 1 {
+						// an upload event arrives while the job is running
+						Expect(s.IndexSpace(&sprovider.StorageSpaceId{OpaqueId: "storageid$spaceid"}, user.Id)).To(Succeed())
+					}
+				}
+				Expect(s.IndexSpace(&sprovider.StorageSpaceId{OpaqueId: "storageid$spaceid"}, user.Id)).To(Succeed())
+
+				Expect(upserted).To(Equal([]string{
+					"storageid$spaceid!a", "storageid$spaceid!b", "storageid$spaceid!sub", "storageid$spaceid!spaceid",
+					"storageid$spaceid!a", "storageid$spaceid!b", "storageid$spaceid!sub", "storageid$spaceid!spaceid",
+				}))
+				jobs := s.IndexStatus("storageid$spaceid")
+				Expect(jobs[0].State).To(Equal(search.IndexJobCompleted))
+				Expect(jobs[0].Pending).To(BeZero())
+			})
+
+			It("resumes an interrupted job from its checkpoint", func() {
+				checkpoint := `{"space_id":"storageid$spaceid!spaceid","user_id":"user","state":"running","processed":1,"indexed":1,"pending":[{"path":".","leave":true},{"path":"./sub"}]}`
+				Expect(os.WriteFile(filepath.Join(cfg.IndexJobs.DataPath, "storageid$spaceid!spaceid.json"), []byte(checkpoint), 0600)).To(Succeed())
+
+				s := search.NewService(gatewaySelector, indexClient, extractor, logger, cfg)
+				jobs := s.IndexStatus("")
+				Expect(jobs).To(HaveLen(1))
+				Expect(jobs[0].State).To(Equal(search.IndexJobInterrupted))
+				Expect(jobs[0].Pending).To(Equal(uint64(1)))
+
+				Expect(s.IndexSpace(&sprovider.StorageSpaceId{OpaqueId: "storageid$spaceid!spaceid"}, user.Id)).To(Succeed())
+
+				Expect(stated).ToNot(ContainElement("./a.txt"))
+				Expect(upserted).To(Equal([]string{"storageid$spaceid!b", "storageid$spaceid!sub", "storageid$spaceid!spaceid"}))
+
+				jobs = s.IndexStatus("storageid$spaceid!spaceid")
+				Expect(jobs[0].State).To(Equal(search.IndexJobCompleted))
+				Expect(jobs[0].Processed).To(Equal(uint64(4)))
+
+				// the completed job is persisted and starts over the next time
+				s = search.NewService(gatewaySelector, indexClient, extractor, logger, cfg)
+				Expect(s.IndexStatus("")[0].State).To(Equal(search.IndexJobCompleted))
+			})
+		})
 	})
 
 	Describe("Search", func() {
@@ -374,6 +491,10 @@ var _ = Describe("Searchprovider", func() {
 							req.Ref.ResourceId.SpaceId == grantSpace.Root.SpaceId
 					})).Return(&searchsvc.SearchIndexResponse{
 						TotalMatches: 2,
+						Facets: []*searchmsg.Facet{
+							{Name: "mediatype", Values: []*searchmsg.FacetValue{{Value: "application/pdf", Count: 2}}},
+							{Name: "space", Values: []*searchmsg.FacetValue{{Value: grantSpace.Root.OpaqueId, Count: 2}}},
+						},
 						Matches: []*searchmsg.Match{
 							{
 								Score: 2,
@@ -418,6 +539,10 @@ var _ = Describe("Searchprovider", func() {
 							req.Ref.ResourceId.SpaceId == personalSpace.Root.SpaceId
 					})).Return(&searchsvc.SearchIndexResponse{
 						TotalMatches: 1,
+						Facets: []*searchmsg.Facet{
+							{Name: "mediatype", Values: []*searchmsg.FacetValue{{Value: "text/plain", Count: 1}, {Value: "application/pdf", Count: 1}}},
+							{Name: "space", Values: []*searchmsg.FacetValue{{Value: personalSpace.Root.OpaqueId, Count: 1}}},
+						},
 						Matches: []*searchmsg.Match{
 							{
 								Score: 1,
@@ -469,6 +594,33 @@ var _ = Describe("Searchprovider", func() {
 					Expect(ids).To(ConsistOf("foo-id", "grant-shared-id", "grant-irrelevant-id"))
 				})
 
+				It("merges the facets of all spaces", func() {
+					res, err := s.Search(ctx, &searchsvc.SearchRequest{
+						Query:  "foo",
+						Facets: true,
+					})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(res.Facets)).To(Equal(2))
+					Expect(res.Facets[0].Name).To(Equal("mediatype"))
+					Expect(res.Facets[0].Values[0].Value).To(Equal("application/pdf"))
+					Expect(res.Facets[0].Values[0].Count).To(Equal(uint64(3)))
+					Expect(res.Facets[0].Values[1].Value).To(Equal("text/plain"))
+					Expect(res.Facets[0].Values[1].Count).To(Equal(uint64(1)))
+					Expect(res.Facets[1].Name).To(Equal("space"))
+					Expect(len(res.Facets[1].Values)).To(Equal(2))
+					Expect(res.Facets[1].Values[0].Count).To(Equal(uint64(2)))
+					Expect(res.Facets[1].Values[1].Count).To(Equal(uint64(1)))
+					Expect(res.Facets[1].Values[1].Value).To(Equal("storageid$personalspace!personalspace"))
+				})
+
+				It("does not return facets unless requested", func() {
+					res, err := s.Search(ctx, &searchsvc.SearchRequest{
+						Query: "foo",
+					})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(res.Facets).To(BeEmpty())
+				})
+
 				It("sorts and limits the combined results from all spaces", func() {
 					res, err := s.Search(ctx, &searchsvc.SearchRequest{
 						Query:    "foo",
@@ -24,8 +24,10 @@ import (
 	v0 "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/search/v0"
 	searchsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/search/v0"
 	"github.com/owncloud/ocis/v2/services/search/pkg/content"
+	"github.com/owncloud/ocis/v2/services/search/pkg/embedding"
 	"github.com/owncloud/ocis/v2/services/search/pkg/engine"
 	"github.com/owncloud/ocis/v2/services/search/pkg/query/bleve"
+	"github.com/owncloud/ocis/v2/services/search/pkg/query/opensearch"
 	"github.com/owncloud/ocis/v2/services/search/pkg/search"
 )
 
@@ -50,10 +52,30 @@ func NewHandler(opts ...Option) (searchsvc.SearchProviderHandler, func(), error)
 		}
 
 		eng = engine.NewBleveEngine(idx, bleve.DefaultCreator)
+	case "opensearch":
+		var err error
+		if eng, err = engine.NewOpenSearchEngine(cfg.Engine.OpenSearch, opensearch.DefaultCreator); err != nil {
+			return nil, teardown, err
+		}
 	default:
 		return nil, teardown, fmt.Errorf("unknown search engine: %s", cfg.Engine.Type)
 	}
 
+	if cfg.Engine.Embedding.Enabled {
+		vectors, err := engine.NewVectorIndex(cfg.Engine.Bleve.Datapath)
+		if err != nil {
+			return nil, teardown, err
+		}
+
+		closeEngine := teardown
+		teardown = func() {
+			closeEngine()
+			_ = vectors.Close()
+		}
+
+		eng = engine.NewHybridEngine(eng, vectors, embedding.NewHTTPProvider(cfg.Engine.Embedding), cfg.Engine.Embedding)
+	}
+
 	// initialize gateway
 	selector, err := pool.GatewaySelector(cfg.Reva.Address, pool.WithRegistry(registry.GetRegistry()), pool.WithTracerProvider(options.TracerProvider))
 	if err != nil {
@@ -74,6 +96,11 @@ func NewHandler(opts ...Option) (searchsvc.SearchProviderHandler, func(), error)
 	default:
 		return nil, teardown, fmt.Errorf("unknown search extractor: %s", cfg.Extractor.Type)
 	}
+	if cfg.Extractor.OCR.Enabled {
+		if extractor, err = content.NewOCRExtractor(extractor, selector, logger, cfg); err != nil {
+			return nil, teardown, err
+		}
+	}
 
 	bus, err := stream.NatsFromConfig(cfg.Service.Name, false, stream.NatsConfig{
 		Endpoint:             cfg.Events.Endpoint,
@@ -89,6 +116,7 @@ func NewHandler(opts ...Option) (searchsvc.SearchProviderHandler, func(), error)
 	}
 
 	ss := search.NewService(selector, eng, extractor, logger, cfg)
+	ss.ResumeIndexJobs()
 
 	// setup event handling
 	if err := search.HandleEvents(ss, bus, logger, cfg); err != nil {
@@ -170,9 +198,33 @@ func (s Service) Search(ctx context.Context, in *searchsvc.SearchRequest, out *s
 	return nil
 }
 
-// IndexSpace (re)indexes all resources of a given space.
-func (s Service) IndexSpace(ctx context.Context, in *searchsvc.IndexSpaceRequest, _ *searchsvc.IndexSpaceResponse) error {
-	return s.searcher.IndexSpace(&provider.StorageSpaceId{OpaqueId: in.SpaceId}, &user.UserId{OpaqueId: in.UserId})
+// IndexSpace (re)indexes all resources of a given space. In the background mode it returns as soon as the job is started.
+func (s Service) IndexSpace(_ context.Context, in *searchsvc.IndexSpaceRequest, out *searchsvc.IndexSpaceResponse) error {
+	spaceID := &provider.StorageSpaceId{OpaqueId: in.SpaceId}
+	userID := &user.UserId{OpaqueId: in.UserId}
+
+	if in.Background {
+		job, err := s.searcher.StartIndexSpace(spaceID, userID)
+		if err != nil {
+			return merrors.BadRequest(s.id, err.Error())
+		}
+		out.Job = job
+		return nil
+	}
+
+	if err := s.searcher.IndexSpace(spaceID, userID); err != nil {
+		return err
+	}
+	if jobs := s.searcher.IndexStatus(in.SpaceId); len(jobs) > 0 {
+		out.Job = jobs[0]
+	}
+	return nil
+}
+
+// GetIndexStatus returns the progress of the index job of a space or of all spaces.
+func (s Service) GetIndexStatus(_ context.Context, in *searchsvc.GetIndexStatusRequest, out *searchsvc.GetIndexStatusResponse) error {
+	out.Jobs = s.searcher.IndexStatus(in.SpaceId)
+	return nil
 }
 
 // FromCache pulls a search result from cache
@@ -37,6 +37,8 @@ type Config struct {
 
 	ServiceAccountIDs []string `yaml:"service_account_ids" env:"SETTINGS_SERVICE_ACCOUNT_IDS;OCIS_SERVICE_ACCOUNT_ID" desc:"The list of all service account IDs. These will be assigned the hidden 'service-account' role. Note: When using 'OCIS_SERVICE_ACCOUNT_ID' this will contain only one value while 'SETTINGS_SERVICE_ACCOUNT_IDS' can have multiple. See the 'auth-service' service description for more details about service accounts." introductionVersion:"5.0"`
 
+	QuotaPolicies QuotaPolicies `yaml:"quota_policies"`
+
 	DefaultLanguage string `yaml:"default_language" env:"OCIS_DEFAULT_LANGUAGE" desc:"The default language used by services and the WebUI. If not defined, English will be used as default. See the documentation for more details." introductionVersion:"5.0"`
 
 	Context context.Context `yaml:"-"`
@@ -53,6 +55,14 @@ type Metadata struct {
 	Cache            *Cache `yaml:"cache"`
 }
 
+// QuotaPolicies configures the store of the quota policies
+type QuotaPolicies struct {
+	Store        string   `yaml:"store" env:"OCIS_PERSISTENT_STORE;SETTINGS_QUOTA_POLICIES_STORE" desc:"The type of the store for the quota policies. Supported values are: 'memory', 'redis-sentinel' and 'nats-js-kv'. The store is shared with the graph, proxy and userlog services. See the text description for details." introductionVersion:"6.0.0"`
+	Nodes        []string `yaml:"nodes" env:"OCIS_PERSISTENT_STORE_NODES;SETTINGS_QUOTA_POLICIES_STORE_NODES" desc:"A list of nodes to access the configured store. This has no effect when 'memory' store is configured. Note that the behaviour how nodes are used is dependent on the library of the configured store. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+	AuthUsername string   `yaml:"username" env:"OCIS_PERSISTENT_STORE_AUTH_USERNAME;SETTINGS_QUOTA_POLICIES_STORE_AUTH_USERNAME" desc:"The username to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"6.0.0"`
+	AuthPassword string   `yaml:"password" env:"OCIS_PERSISTENT_STORE_AUTH_PASSWORD;SETTINGS_QUOTA_POLICIES_STORE_AUTH_PASSWORD" desc:"The password to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"6.0.0"`
+}
+
 // Cache configures the cache of the Metadata store
 type Cache struct {
 	Store              string        `yaml:"store" env:"OCIS_CACHE_STORE;SETTINGS_CACHE_STORE" desc:"The type of the cache store. Supported values are: 'memory', 'redis-sentinel', 'nats-js-kv', 'noop'. See the text description for details." introductionVersion:"pre5.0"`
@@ -61,6 +61,10 @@ func DefaultConfig() *config.Config {
 				TTL:            time.Minute * 10,
 			},
 		},
+		QuotaPolicies: config.QuotaPolicies{
+			Store: "nats-js-kv", // the policies are applied by other services, so we cannot use memory
+			Nodes: []string{"127.0.0.1:9233"},
+		},
 		BundlesPath:       "",
 		Bundles:           nil,
 		ServiceAccountIDs: []string{"service-user-id"},
@@ -80,6 +80,12 @@ func Server(opts ...Option) (ohttp.Service, error) {
 		settingssvc.RegisterValueServiceWeb(r, handle)
 		settingssvc.RegisterRoleServiceWeb(r, handle)
 		settingssvc.RegisterPermissionServiceWeb(r, handle)
+		r.Route("/api/v0/settings/quota-policies", func(r chi.Router) {
+			r.Get("/", handle.ListQuotaPolicies)
+			r.Post("/", handle.CreateQuotaPolicy)
+			r.Put("/{id}", handle.UpdateQuotaPolicy)
+			r.Delete("/{id}", handle.DeleteQuotaPolicy)
+		})
 	})
 
 	_ = chi.Walk(mux, func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
@@ -0,0 +1,110 @@
+package svc
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quotapolicy"
+)
+
+// ListQuotaPolicies lists all quota policies
+func (g Service) ListQuotaPolicies(w http.ResponseWriter, r *http.Request) {
+	if !g.canManageQuotaPolicies(w, r) {
+		return
+	}
+
+	policies, err := g.quotaPolicies.List()
+	if err != nil {
+		g.logger.Error().Err(err).Msg("could not list quota policies")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	g.writeQuotaPolicyJSON(w, http.StatusOK, policies)
+}
+
+// CreateQuotaPolicy creates a quota policy
+func (g Service) CreateQuotaPolicy(w http.ResponseWriter, r *http.Request) {
+	g.writeQuotaPolicy(w, r, "")
+}
+
+// UpdateQuotaPolicy replaces a quota policy
+func (g Service) UpdateQuotaPolicy(w http.ResponseWriter, r *http.Request) {
+	g.writeQuotaPolicy(w, r, chi.URLParam(r, "id"))
+}
+
+// DeleteQuotaPolicy deletes a quota policy. The quotas already applied to spaces are kept.
+func (g Service) DeleteQuotaPolicy(w http.ResponseWriter, r *http.Request) {
+	if !g.canManageQuotaPolicies(w, r) {
+		return
+	}
+
+	err := g.quotaPolicies.Delete(chi.URLParam(r, "id"))
+	switch {
+	case errors.Is(err, quotapolicy.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case err != nil:
+		g.logger.Error().Err(err).Msg("could not delete quota policy")
+		w.WriteHeader(http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (g Service) writeQuotaPolicy(w http.ResponseWriter, r *http.Request, id string) {
+	if !g.canManageQuotaPolicies(w, r) {
+		return
+	}
+
+	var p quotapolicy.Policy
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		g.writeQuotaPolicyJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid body: " + err.Error()})
+		return
+	}
+
+	status := http.StatusCreated
+	if id != "" {
+		if _, err := g.quotaPolicies.Get(id); err != nil {
+			if errors.Is(err, quotapolicy.ErrNotFound) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			g.logger.Error().Err(err).Str("id", id).Msg("could not read quota policy")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		status = http.StatusOK
+	}
+	p.ID = id
+
+	p, err := g.quotaPolicies.Write(p)
+	switch {
+	case errors.Is(err, quotapolicy.ErrConflict):
+		g.writeQuotaPolicyJSON(w, http.StatusConflict, map[string]string{"error": err.Error()})
+	case errors.Is(err, quotapolicy.ErrInvalid):
+		g.writeQuotaPolicyJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case err != nil:
+		g.logger.Error().Err(err).Msg("could not write quota policy")
+		w.WriteHeader(http.StatusInternalServerError)
+	default:
+		g.writeQuotaPolicyJSON(w, status, p)
+	}
+}
+
+// canManageQuotaPolicies writes the error response if the user isn't allowed to manage quota policies
+func (g Service) canManageQuotaPolicies(w http.ResponseWriter, r *http.Request) bool {
+	if !g.hasStaticPermission(r.Context(), SettingsManagementPermissionID) {
+		w.WriteHeader(http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+func (g Service) writeQuotaPolicyJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		g.logger.Error().Err(err).Msg("could not write response")
+	}
+}
@@ -9,8 +9,10 @@ import (
 	cs3permissions "github.com/cs3org/go-cs3apis/cs3/permissions/v1beta1"
 	rpcv1beta1 "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
 	"github.com/cs3org/reva/v2/pkg/rgrpc/status"
+	"github.com/cs3org/reva/v2/pkg/store"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 	"github.com/owncloud/ocis/v2/ocis-pkg/middleware"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quotapolicy"
 	"github.com/owncloud/ocis/v2/ocis-pkg/roles"
 	settingsmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/settings/v0"
 	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
@@ -20,6 +22,7 @@ import (
 	metastore "github.com/owncloud/ocis/v2/services/settings/pkg/store/metadata"
 	merrors "go-micro.dev/v4/errors"
 	"go-micro.dev/v4/metadata"
+	microstore "go-micro.dev/v4/store"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -29,6 +32,8 @@ type Service struct {
 	config  *config.Config
 	logger  log.Logger
 	manager settings.Manager
+
+	quotaPolicies *quotapolicy.Manager
 }
 
 // NewService returns a service implementation for Service.
@@ -40,6 +45,13 @@ func NewService(cfg *config.Config, logger log.Logger) settings.ServiceHandler {
 	}
 
 	service.manager = metastore.New(cfg)
+	service.quotaPolicies = quotapolicy.NewManager(store.Create(
+		store.Store(cfg.QuotaPolicies.Store),
+		microstore.Nodes(cfg.QuotaPolicies.Nodes...),
+		microstore.Database(quotapolicy.Database),
+		microstore.Table(quotapolicy.Table),
+		store.Authentication(cfg.QuotaPolicies.AuthUsername, cfg.QuotaPolicies.AuthPassword),
+	))
 	return service
 }
 
@@ -3,9 +3,13 @@ package svc
 import (
 	"context"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/owncloud/ocis/v2/ocis-pkg/middleware"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quotapolicy"
 	settingsmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/settings/v0"
 	v0 "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
 	"github.com/owncloud/ocis/v2/services/settings/pkg/settings/mocks"
@@ -14,6 +18,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	merrors "go-micro.dev/v4/errors"
 	"go-micro.dev/v4/metadata"
+	microstore "go-micro.dev/v4/store"
 )
 
 var (
@@ -450,3 +455,52 @@ func TestListRoleAssignmentsFilteredByRole(t *testing.T) {
 		})
 	}
 }
+
+func TestQuotaPolicies(t *testing.T) {
+	manager := &mocks.Manager{}
+	svc := Service{
+		manager:       manager,
+		quotaPolicies: quotapolicy.NewManager(microstore.NewMemoryStore()),
+	}
+	manager.On("ListRoleAssignments", mock.Anything).Return([]*settingsmsg.UserRoleAssignment{{RoleId: defaults.BundleUUIDRoleAdmin}}, nil)
+	manager.On("ReadPermissionByID", SettingsManagementPermissionID, []string{defaults.BundleUUIDRoleAdmin}).Return(&settingsmsg.Permission{}, nil)
+
+	mux := chi.NewMux()
+	mux.Get("/", svc.ListQuotaPolicies)
+	mux.Post("/", svc.CreateQuotaPolicy)
+	mux.Put("/{id}", svc.UpdateQuotaPolicy)
+	mux.Delete("/{id}", svc.DeleteQuotaPolicy)
+	request := func(ctx context.Context, method, target, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, strings.NewReader(body)).WithContext(ctx)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	w := request(ctxWithUUID, http.MethodPost, "/", `{"spaceType":"project","spaceTemplate":"default","quota":1000,"warningThresholds":[80]}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	policies, err := svc.quotaPolicies.List()
+	assert.NoError(t, err)
+	assert.Len(t, policies, 1)
+
+	w = request(ctxWithUUID, http.MethodPost, "/", `{"spaceType":"personal","quota":1000}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = request(ctxWithUUID, http.MethodPut, "/"+policies[0].ID, `{"spaceType":"project","spaceTemplate":"default","quota":2000}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	p, err := svc.quotaPolicies.Get(policies[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2000), p.Quota)
+
+	w = request(ctxWithUUID, http.MethodPut, "/unknown", `{"spaceType":"project","quota":2000}`)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = request(emptyCtx, http.MethodGet, "/", "")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = request(ctxWithUUID, http.MethodDelete, "/"+policies[0].ID, "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = request(ctxWithUUID, http.MethodGet, "/", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, "[]", w.Body.String())
+}
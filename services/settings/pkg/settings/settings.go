@@ -2,6 +2,7 @@ package settings
 
 import (
 	"errors"
+	"net/http"
 
 	cs3permissions "github.com/cs3org/go-cs3apis/cs3/permissions/v1beta1"
 	settingsmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/settings/v0"
@@ -32,6 +33,15 @@ type ServiceHandler interface {
 	settingssvc.RoleServiceHandler
 	settingssvc.PermissionServiceHandler
 	cs3permissions.PermissionsAPIServer
+	QuotaPolicyHandler
+}
+
+// QuotaPolicyHandler serves the http api to manage quota policies
+type QuotaPolicyHandler interface {
+	ListQuotaPolicies(w http.ResponseWriter, r *http.Request)
+	CreateQuotaPolicy(w http.ResponseWriter, r *http.Request)
+	UpdateQuotaPolicy(w http.ResponseWriter, r *http.Request)
+	DeleteQuotaPolicy(w http.ResponseWriter, r *http.Request)
 }
 
 // Manager combines service interfaces for abstraction of storage implementations
@@ -24,6 +24,12 @@ const (
 	SettingUUIDProfileDisableNotifications = "33ffb5d6-cd07-4dc0-afb0-84f7559ae438"
 	// SettingUUIDProfileAutoAcceptShares is the hardcoded setting UUID for the disable notifications setting
 	SettingUUIDProfileAutoAcceptShares = "ec3ed4a3-3946-4efc-8f9f-76d38b12d3a9"
+	// SettingUUIDProfileNotificationChannels is the hardcoded setting UUID for the notification channels setting
+	SettingUUIDProfileNotificationChannels = "5c1ea8d7-b2f4-4b3e-9d62-1f0e3a7c4d21"
+	// SettingUUIDProfileActivityDigestInterval is the hardcoded setting UUID for the activity digest interval setting
+	SettingUUIDProfileActivityDigestInterval = "8e4d2a61-3f7b-4c09-b5a8-6d1e9c2f7b43"
+	// SettingUUIDProfileWatchedResources is the hardcoded setting UUID for the folders and spaces watched by the user
+	SettingUUIDProfileWatchedResources = "c7a93f15-2e6d-4b81-9f0c-4a5d8e3b6c12"
 )
 
 // GenerateBundlesDefaultRoles bootstraps the default roles.
@@ -94,6 +100,8 @@ func generateBundleAdminRole() *settingsmsg.Bundle {
 			DeleteProjectSpacesPermission(All),
 			DeleteReadOnlyPublicLinkPasswordPermission(All),
 			DisableEmailNotificationsPermission(Own),
+			NotificationChannelsPermission(Own),
+			ActivityDigestPermission(Own),
 			GroupManagementPermission(All),
 			LanguageManagementPermission(All),
 			ListFavoritesPermission(Own),
@@ -127,6 +135,8 @@ func generateBundleSpaceAdminRole() *settingsmsg.Bundle {
 			DeleteProjectSpacesPermission(All),
 			DeleteReadOnlyPublicLinkPasswordPermission(All),
 			DisableEmailNotificationsPermission(Own),
+			NotificationChannelsPermission(Own),
+			ActivityDigestPermission(Own),
 			LanguageManagementPermission(Own),
 			ListFavoritesPermission(Own),
 			ListSpacesPermission(All),
@@ -155,6 +165,8 @@ func generateBundleUserRole() *settingsmsg.Bundle {
 			CreateSharePermission(All),
 			CreateSpacesPermission(Own),
 			DisableEmailNotificationsPermission(Own),
+			NotificationChannelsPermission(Own),
+			ActivityDigestPermission(Own),
 			LanguageManagementPermission(Own),
 			ListFavoritesPermission(Own),
 			SelfManagementPermission(Own),
@@ -176,6 +188,8 @@ func generateBundleUserLightRole() *settingsmsg.Bundle {
 		Settings: []*settingsmsg.Setting{
 			AutoAcceptSharesPermission(Own),
 			DisableEmailNotificationsPermission(Own),
+			NotificationChannelsPermission(Own),
+			ActivityDigestPermission(Own),
 			LanguageManagementPermission(Own),
 		},
 	}
@@ -222,10 +236,104 @@ func generateBundleProfileRequest() *settingsmsg.Bundle {
 				},
 				Value: &settingsmsg.Setting_BoolValue{BoolValue: &settingsmsg.Bool{Default: true, Label: "auto accept shares"}},
 			},
+			{
+				Id:          SettingUUIDProfileNotificationChannels,
+				Name:        "notification-channels",
+				DisplayName: "Notification Channels",
+				Description: "Channels used to deliver notifications",
+				Resource: &settingsmsg.Resource{
+					Type: settingsmsg.Resource_TYPE_USER,
+				},
+				Value: &notificationChannelsSetting,
+			},
+			{
+				Id:          SettingUUIDProfileActivityDigestInterval,
+				Name:        "activity-digest-interval",
+				DisplayName: "Activity Digest",
+				Description: "Interval of the digest of the activities in the watched folders and spaces",
+				Resource: &settingsmsg.Resource{
+					Type: settingsmsg.Resource_TYPE_USER,
+				},
+				Value: &activityDigestIntervalSetting,
+			},
+			{
+				Id:          SettingUUIDProfileWatchedResources,
+				Name:        "watched-resources",
+				DisplayName: "Watched Folders and Spaces",
+				Description: "Folders and spaces included in the activity digest",
+				Resource: &settingsmsg.Resource{
+					Type: settingsmsg.Resource_TYPE_USER,
+				},
+				Value: &settingsmsg.Setting_MultiChoiceValue{MultiChoiceValue: &settingsmsg.MultiChoiceList{}},
+			},
 		},
 	}
 }
 
+var notificationChannelsSetting = settingsmsg.Setting_MultiChoiceValue{
+	MultiChoiceValue: &settingsmsg.MultiChoiceList{
+		Options: []*settingsmsg.ListOption{
+			{
+				Value: &settingsmsg.ListOptionValue{
+					Option: &settingsmsg.ListOptionValue_StringValue{
+						StringValue: "mail",
+					},
+				},
+				DisplayValue: "Mail",
+				Default:      true,
+			},
+			{
+				Value: &settingsmsg.ListOptionValue{
+					Option: &settingsmsg.ListOptionValue_StringValue{
+						StringValue: "webhook",
+					},
+				},
+				DisplayValue: "Webhook",
+			},
+			{
+				Value: &settingsmsg.ListOptionValue{
+					Option: &settingsmsg.ListOptionValue_StringValue{
+						StringValue: "matrix",
+					},
+				},
+				DisplayValue: "Matrix",
+			},
+		},
+	},
+}
+
+var activityDigestIntervalSetting = settingsmsg.Setting_SingleChoiceValue{
+	SingleChoiceValue: &settingsmsg.SingleChoiceList{
+		Options: []*settingsmsg.ListOption{
+			{
+				Value: &settingsmsg.ListOptionValue{
+					Option: &settingsmsg.ListOptionValue_StringValue{
+						StringValue: "never",
+					},
+				},
+				DisplayValue: "Never",
+				Default:      true,
+			},
+			{
+				Value: &settingsmsg.ListOptionValue{
+					Option: &settingsmsg.ListOptionValue_StringValue{
+						StringValue: "daily",
+					},
+				},
+				DisplayValue: "Daily",
+			},
+			{
+				Value: &settingsmsg.ListOptionValue{
+					Option: &settingsmsg.ListOptionValue_StringValue{
+						StringValue: "weekly",
+					},
+				},
+				DisplayValue: "Weekly",
+			},
+		},
+	},
+}
+
 // TODO: languageSetting needed?
 var languageSetting = settingsmsg.Setting_SingleChoiceValue{
 	SingleChoiceValue: &settingsmsg.SingleChoiceList{
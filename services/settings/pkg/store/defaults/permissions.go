@@ -296,6 +296,44 @@ func ManageSpacePropertiesPermission(c settingsmsg.Permission_Constraint) *setti
 	}
 }
 
+// NotificationChannelsPermission is the permission to choose the notification channels
+func NotificationChannelsPermission(c settingsmsg.Permission_Constraint) *settingsmsg.Setting {
+	return &settingsmsg.Setting{
+		Id:          "b3f7c1a2-6d4e-4f85-9a0b-2c8e5d7f1a36",
+		Name:        "NotificationChannels.ReadWrite",
+		DisplayName: "Choose Notification Channels",
+		Resource: &settingsmsg.Resource{
+			Type: settingsmsg.Resource_TYPE_SETTING,
+			Id:   SettingUUIDProfileNotificationChannels,
+		},
+		Value: &settingsmsg.Setting_PermissionValue{
+			PermissionValue: &settingsmsg.Permission{
+				Operation:  settingsmsg.Permission_OPERATION_READWRITE,
+				Constraint: c,
+			},
+		},
+	}
+}
+
+// ActivityDigestPermission is the permission to choose the interval of the activity digest
+func ActivityDigestPermission(c settingsmsg.Permission_Constraint) *settingsmsg.Setting {
+	return &settingsmsg.Setting{
+		Id:          "f2b6d8a4-1c3e-4a7f-8d95-3e0b7c6a2f58",
+		Name:        "ActivityDigest.ReadWrite",
+		DisplayName: "Choose Activity Digest Interval",
+		Resource: &settingsmsg.Resource{
+			Type: settingsmsg.Resource_TYPE_SETTING,
+			Id:   SettingUUIDProfileActivityDigestInterval,
+		},
+		Value: &settingsmsg.Setting_PermissionValue{
+			PermissionValue: &settingsmsg.Permission{
+				Operation:  settingsmsg.Permission_OPERATION_READWRITE,
+				Constraint: c,
+			},
+		},
+	}
+}
+
 // RoleManagementPermission is the permission to manage roles
 func RoleManagementPermission(c settingsmsg.Permission_Constraint) *settingsmsg.Setting {
 	return &settingsmsg.Setting{
@@ -0,0 +1,133 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
+	"github.com/cs3org/reva/v2/pkg/storage/utils/walker"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
+	"github.com/owncloud/ocis/v2/ocis-pkg/registry"
+	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/config"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/config/parser"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/logging"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/thumbnail/storage"
+	"github.com/urfave/cli/v2"
+)
+
+// Cleanup is the entrypoint for the cleanup command.
+func Cleanup(cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "cleanup",
+		Usage: "remove the thumbnails of deleted or changed files",
+		Before: func(c *cli.Context) error {
+			if err := parser.ParseConfig(cfg); err != nil {
+				return configlog.ReturnError(err)
+			}
+			if cfg.Thumbnail.Storage != "filesystem" {
+				return fmt.Errorf("the cleanup is not supported for the '%s' thumbnail storage", cfg.Thumbnail.Storage)
+			}
+			if cfg.ServiceAccount.ServiceAccountID == "" {
+				return shared.MissingServiceAccountID(cfg.Service.Name)
+			}
+			if cfg.ServiceAccount.ServiceAccountSecret == "" {
+				return shared.MissingServiceAccountSecret(cfg.Service.Name)
+			}
+			return nil
+		},
+		Action: func(c *cli.Context) error {
+			logger := logging.Configure(cfg.Service.Name, cfg.Log)
+
+			tm, err := pool.StringToTLSMode(cfg.GRPCClientTLS.Mode)
+			if err != nil {
+				return err
+			}
+			gatewaySelector, err := pool.GatewaySelector(cfg.Thumbnail.RevaGateway,
+				pool.WithTLSCACert(cfg.GRPCClientTLS.CACert),
+				pool.WithTLSMode(tm),
+				pool.WithRegistry(registry.GetRegistry()),
+			)
+			if err != nil {
+				return fmt.Errorf("could not get gateway selector: %w", err)
+			}
+
+			checksums, err := collectChecksums(c.Context, gatewaySelector, cfg.ServiceAccount)
+			if err != nil {
+				return err
+			}
+
+			fs := storage.NewFileSystemStorage(cfg.Thumbnail.FileSystemStorage, logger)
+			removed, err := fs.Prune(func(checksum string) bool {
+				_, ok := checksums[checksum]
+				return ok
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Removed the thumbnails of %d source files.\n", removed)
+			return nil
+		},
+	}
+}
+
+// collectChecksums returns the checksums of all files in personal and project spaces.
+func collectChecksums(ctx context.Context, gatewaySelector pool.Selectable[gateway.GatewayAPIClient], sa config.ServiceAccount) (map[string]struct{}, error) {
+	client, err := gatewaySelector.Next()
+	if err != nil {
+		return nil, fmt.Errorf("could not select gateway client: %w", err)
+	}
+	ctx, err = utils.GetServiceUserContextWithContext(ctx, client, sa.ServiceAccountID, sa.ServiceAccountSecret)
+	if err != nil {
+		return nil, fmt.Errorf("could not get service user context: %w", err)
+	}
+
+	var spaces []*provider.StorageSpace
+	for _, spaceType := range []string{"personal", "project"} {
+		res, err := client.ListStorageSpaces(ctx, &provider.ListStorageSpacesRequest{
+			Filters: []*provider.ListStorageSpacesRequest_Filter{
+				{
+					Type: provider.ListStorageSpacesRequest_Filter_TYPE_SPACE_TYPE,
+					Term: &provider.ListStorageSpacesRequest_Filter_SpaceType{
+						SpaceType: spaceType,
+					},
+				},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if res.GetStatus().GetCode() != rpc.Code_CODE_OK {
+			return nil, fmt.Errorf("could not list %s spaces: %s", spaceType, res.GetStatus().GetMessage())
+		}
+		spaces = append(spaces, res.GetStorageSpaces()...)
+	}
+	if len(spaces) == 0 {
+		// refuse to remove all thumbnails if the service account can't see any space
+		return nil, errors.New("no spaces found")
+	}
+
+	checksums := make(map[string]struct{})
+	w := walker.NewWalker(gatewaySelector)
+	for _, space := range spaces {
+		err := w.Walk(ctx, space.GetRoot(), func(_ string, info *provider.ResourceInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if sum := info.GetChecksum().GetSum(); sum != "" {
+				checksums[sum] = struct{}{}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not walk space %s: %w", space.GetId().GetOpaqueId(), err)
+		}
+	}
+	return checksums, nil
+}
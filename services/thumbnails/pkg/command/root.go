@@ -15,6 +15,7 @@ func GetCommands(cfg *config.Config) cli.Commands {
 		Server(cfg),
 
 		// interaction with this service
+		Cleanup(cfg),
 
 		// infos about this service
 		Health(cfg),
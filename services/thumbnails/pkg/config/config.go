@@ -3,6 +3,7 @@ package config
 
 import (
 	"context"
+	"time"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
 	"go-micro.dev/v4/client"
@@ -26,21 +27,49 @@ type Config struct {
 
 	Thumbnail Thumbnail `yaml:"thumbnail"`
 
+	ServiceAccount ServiceAccount `yaml:"service_account"`
+
 	Context context.Context `yaml:"-"`
 }
 
+// ServiceAccount is the configuration for the used service account
+type ServiceAccount struct {
+	ServiceAccountID     string `yaml:"service_account_id" env:"OCIS_SERVICE_ACCOUNT_ID;THUMBNAILS_SERVICE_ACCOUNT_ID" desc:"The ID of the service account the service should use. It is only required by the 'cleanup' command. See the 'auth-service' service description for more details." introductionVersion:"6.0.0"`
+	ServiceAccountSecret string `yaml:"service_account_secret" env:"OCIS_SERVICE_ACCOUNT_SECRET;THUMBNAILS_SERVICE_ACCOUNT_SECRET" desc:"The service account secret." introductionVersion:"6.0.0"`
+}
+
 // FileSystemStorage defines the available filesystem storage configuration.
 type FileSystemStorage struct {
-	RootDirectory string `yaml:"root_directory" env:"THUMBNAILS_FILESYSTEMSTORAGE_ROOT" desc:"The directory where the filesystem storage will store the thumbnails. If not defined, the root directory derives from $OCIS_BASE_DATA_PATH:/thumbnails." introductionVersion:"pre5.0"`
+	RootDirectory    string        `yaml:"root_directory" env:"THUMBNAILS_FILESYSTEMSTORAGE_ROOT" desc:"The directory where the filesystem storage will store the thumbnails. If not defined, the root directory derives from $OCIS_BASE_DATA_PATH:/thumbnails." introductionVersion:"pre5.0"`
+	TTL              time.Duration `yaml:"ttl" env:"THUMBNAILS_FILESYSTEMSTORAGE_TTL" desc:"Time after which thumbnails which have not been requested are removed by the eviction. Set to '0' to keep unused thumbnails forever. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+	MaxSize          string        `yaml:"max_size" env:"THUMBNAILS_FILESYSTEMSTORAGE_MAX_SIZE" desc:"The maximum total size of all thumbnails on the filesystem. If exceeded, the least recently used thumbnails are removed by the eviction. Usable common abbreviations: [KB, KiB, MB, MiB, GB, GiB, TB, TiB, PB, PiB, EB, EiB], example: 2GB. Leave empty for no limit." introductionVersion:"6.0.0"`
+	EvictionInterval time.Duration `yaml:"eviction_interval" env:"THUMBNAILS_FILESYSTEMSTORAGE_EVICTION_INTERVAL" desc:"The interval in which the eviction removes unused thumbnails. Defaults to '1h'. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+}
+
+// S3Storage defines the available S3 storage configuration.
+type S3Storage struct {
+	Endpoint         string        `yaml:"endpoint" env:"THUMBNAILS_S3STORAGE_ENDPOINT" desc:"Endpoint of the S3 compatible object storage, for example 'https://s3.example.com'. An endpoint without scheme is accessed via https." introductionVersion:"6.0.0"`
+	Region           string        `yaml:"region" env:"THUMBNAILS_S3STORAGE_REGION" desc:"Region of the S3 bucket." introductionVersion:"6.0.0"`
+	Bucket           string        `yaml:"bucket" env:"THUMBNAILS_S3STORAGE_BUCKET" desc:"Name of the S3 bucket." introductionVersion:"6.0.0"`
+	AccessKey        string        `yaml:"access_key" env:"THUMBNAILS_S3STORAGE_ACCESS_KEY" desc:"Access key for the S3 bucket." introductionVersion:"6.0.0"`
+	SecretKey        string        `yaml:"secret_key" env:"THUMBNAILS_S3STORAGE_SECRET_KEY" desc:"Secret key for the S3 bucket." introductionVersion:"6.0.0"`
+	Insecure         bool          `yaml:"insecure" env:"OCIS_INSECURE;THUMBNAILS_S3STORAGE_INSECURE" desc:"Ignore untrusted SSL certificates when connecting to the S3 endpoint." introductionVersion:"6.0.0"`
+	TTL              time.Duration `yaml:"ttl" env:"THUMBNAILS_S3STORAGE_TTL" desc:"Time to live for thumbnails in the bucket. Older thumbnails are removed by the eviction. Set to '0' to keep thumbnails forever. Defaults to '720h' (30 days). See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+	MaxSize          string        `yaml:"max_size" env:"THUMBNAILS_S3STORAGE_MAX_SIZE" desc:"The maximum total size of all thumbnails in the bucket. If exceeded, the oldest thumbnails are removed by the eviction. Usable common abbreviations: [KB, KiB, MB, MiB, GB, GiB, TB, TiB, PB, PiB, EB, EiB], example: 2GB. Leave empty for no limit." introductionVersion:"6.0.0"`
+	EvictionInterval time.Duration `yaml:"eviction_interval" env:"THUMBNAILS_S3STORAGE_EVICTION_INTERVAL" desc:"The interval in which the eviction removes expired thumbnails. Defaults to '1h'. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
 }
 
 // Thumbnail defines the available thumbnail related configuration.
 type Thumbnail struct {
 	Resolutions           []string          `yaml:"resolutions" env:"THUMBNAILS_RESOLUTIONS" desc:"The supported list of target resolutions in the format WidthxHeight like 32x32. You can define any resolution as required. See the Environment Variable Types description for more details." introductionVersion:"pre5.0"`
+	Storage               string            `yaml:"storage" env:"THUMBNAILS_STORAGE" desc:"The storage for generated thumbnails. Supported values are 'filesystem' and 's3'. Defaults to 'filesystem'." introductionVersion:"6.0.0"`
 	FileSystemStorage     FileSystemStorage `yaml:"filesystem_storage"`
+	S3Storage             S3Storage         `yaml:"s3_storage"`
 	WebdavAllowInsecure   bool              `yaml:"webdav_allow_insecure" env:"OCIS_INSECURE;THUMBNAILS_WEBDAVSOURCE_INSECURE" desc:"Ignore untrusted SSL certificates when connecting to the webdav source." introductionVersion:"pre5.0"`
 	CS3AllowInsecure      bool              `yaml:"cs3_allow_insecure" env:"OCIS_INSECURE;THUMBNAILS_CS3SOURCE_INSECURE" desc:"Ignore untrusted SSL certificates when connecting to the CS3 source." introductionVersion:"pre5.0"`
 	RevaGateway           string            `yaml:"reva_gateway" env:"OCIS_REVA_GATEWAY" desc:"CS3 gateway used to look up user metadata" introductionVersion:"pre5.0"`
+	PdfCommand            string            `yaml:"pdf_command" env:"THUMBNAILS_PDF_COMMAND" desc:"The path to the 'pdftoppm' binary of poppler which renders the first page of PDF files. Leave empty to disable thumbnails for PDF files." introductionVersion:"6.0.0"`
+	VideoCommand          string            `yaml:"video_command" env:"THUMBNAILS_VIDEO_COMMAND" desc:"The path to the 'ffmpeg' binary which extracts a key frame of video files. Leave empty to disable thumbnails for video files." introductionVersion:"6.0.0"`
 	FontMapFile           string            `yaml:"font_map_file" env:"THUMBNAILS_TXT_FONTMAP_FILE" desc:"The path to a font file for txt thumbnails." introductionVersion:"pre5.0"`
 	TransferSecret        string            `yaml:"transfer_secret" env:"THUMBNAILS_TRANSFER_TOKEN" desc:"The secret to sign JWT to download the actual thumbnail file." introductionVersion:"pre5.0"`
 	DataEndpoint          string            `yaml:"data_endpoint" env:"THUMBNAILS_DATA_ENDPOINT" desc:"The HTTP endpoint where the actual thumbnail file can be downloaded." introductionVersion:"pre5.0"`
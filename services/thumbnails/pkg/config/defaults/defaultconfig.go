@@ -3,6 +3,7 @@ package defaults
 import (
 	"path"
 	"strings"
+	"time"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/defaults"
 	"github.com/owncloud/ocis/v2/ocis-pkg/shared"
@@ -42,8 +43,14 @@ func DefaultConfig() *config.Config {
 		},
 		Thumbnail: config.Thumbnail{
 			Resolutions: []string{"16x16", "32x32", "64x64", "128x128", "1080x1920", "1920x1080", "2160x3840", "3840x2160", "4320x7680", "7680x4320"},
+			Storage:     "filesystem",
 			FileSystemStorage: config.FileSystemStorage{
-				RootDirectory: path.Join(defaults.BaseDataPath(), "thumbnails"),
+				RootDirectory:    path.Join(defaults.BaseDataPath(), "thumbnails"),
+				EvictionInterval: time.Hour,
+			},
+			S3Storage: config.S3Storage{
+				TTL:              30 * 24 * time.Hour,
+				EvictionInterval: time.Hour,
 			},
 			WebdavAllowInsecure:   false,
 			RevaGateway:           shared.DefaultRevaConfig().Address,
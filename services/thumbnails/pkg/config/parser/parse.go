@@ -2,7 +2,9 @@ package parser
 
 import (
 	"errors"
+	"fmt"
 
+	"github.com/cs3org/reva/v2/pkg/bytesize"
 	ociscfg "github.com/owncloud/ocis/v2/ocis-pkg/config"
 	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/config"
 	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/config/defaults"
@@ -34,6 +36,27 @@ func ParseConfig(cfg *config.Config) error {
 }
 
 // Validate can validate the configuration
-func Validate(_ *config.Config) error {
+func Validate(cfg *config.Config) error {
+	switch cfg.Thumbnail.Storage {
+	case "filesystem":
+		fs := cfg.Thumbnail.FileSystemStorage
+		if fs.MaxSize != "" {
+			if _, err := bytesize.Parse(fs.MaxSize); err != nil {
+				return fmt.Errorf("invalid filesystem thumbnail storage max size '%s': %w", fs.MaxSize, err)
+			}
+		}
+	case "s3":
+		s3 := cfg.Thumbnail.S3Storage
+		if s3.Endpoint == "" || s3.Bucket == "" {
+			return errors.New("the s3 thumbnail storage requires an endpoint and a bucket")
+		}
+		if s3.MaxSize != "" {
+			if _, err := bytesize.Parse(s3.MaxSize); err != nil {
+				return fmt.Errorf("invalid s3 thumbnail storage max size '%s': %w", s3.MaxSize, err)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown thumbnail storage '%s'", cfg.Thumbnail.Storage)
+	}
 	return nil
 }
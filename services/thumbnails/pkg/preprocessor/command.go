@@ -0,0 +1,110 @@
+package preprocessor
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/kovidgoyal/imaging"
+	"github.com/pkg/errors"
+)
+
+// commandTimeout limits the time an external command may take to render a single image
+const commandTimeout = time.Minute
+
+// renderSize is the maximum width and height of the images rendered by external commands
+const renderSize = "1920"
+
+var (
+	// PdfMimeTypes contains the mimetypes handled by the PdfDecoder
+	PdfMimeTypes = map[string]struct{}{
+		"application/pdf": {},
+	}
+
+	// VideoMimeTypes contains the mimetypes handled by the VideoDecoder
+	VideoMimeTypes = map[string]struct{}{
+		"video/mp4":        {},
+		"video/mpeg":       {},
+		"video/ogg":        {},
+		"video/quicktime":  {},
+		"video/webm":       {},
+		"video/x-matroska": {},
+		"video/x-msvideo":  {},
+	}
+)
+
+// PdfDecoder is a converter for pdf files. It renders the first page using the
+// pdftoppm command of poppler.
+type PdfDecoder struct {
+	Command string
+}
+
+// Convert renders the first page of the pdf file into the thumbnail image
+func (p PdfDecoder) Convert(r io.Reader) (interface{}, error) {
+	return runCommand(r, p.Command, func(file string) []string {
+		return []string{"-png", "-f", "1", "-l", "1", "-singlefile", "-scale-to", renderSize, file}
+	})
+}
+
+// VideoDecoder is a converter for video files. It extracts a representative
+// key frame using the ffmpeg command.
+type VideoDecoder struct {
+	Command string
+}
+
+// Convert extracts a key frame of the video file into the thumbnail image
+func (v VideoDecoder) Convert(r io.Reader) (interface{}, error) {
+	return runCommand(r, v.Command, func(file string) []string {
+		return []string{
+			"-hide_banner", "-loglevel", "error",
+			// only decode key frames, the thumbnail filter picks the most representative of the first ten
+			"-skip_frame", "nokey",
+			"-i", file,
+			"-vf", "scale='min(" + renderSize + ",iw)':'min(" + renderSize + ",ih)':force_original_aspect_ratio=decrease,thumbnail=10",
+			"-frames:v", "1",
+			"-f", "image2pipe", "-c:v", "png", "-",
+		}
+	})
+}
+
+// runCommand writes the content of r to a temporary file, because not all formats
+// can be read from a stream, and decodes the png image the command writes to stdout.
+func runCommand(r io.Reader, command string, args func(file string) []string) (interface{}, error) {
+	if command == "" {
+		return nil, errors.New("no command configured")
+	}
+
+	f, err := os.CreateTemp("", "thumbnail-source-")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create temporary file")
+	}
+	defer os.Remove(f.Name())
+
+	_, err = io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not write temporary file")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command, args(f.Name())...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "%s failed: %s", command, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	img, err := imaging.Decode(&stdout)
+	if err != nil {
+		return nil, errors.Wrap(err, `could not decode the image`)
+	}
+	return img, nil
+}
@@ -269,6 +269,8 @@ func ForType(mimeType string, opts map[string]interface{}) FileConverter {
 		}
 	case "application/vnd.geogebra.slides":
 		return GgsDecoder{}
+	case "application/pdf":
+		return PdfDecoder{Command: stringOpt(opts, "pdfCommand")}
 	case "image/gif":
 		return GifDecoder{}
 	case "audio/flac":
@@ -278,6 +280,14 @@ func ForType(mimeType string, opts map[string]interface{}) FileConverter {
 	case "audio/ogg":
 		return AudioDecoder{}
 	default:
+		if _, ok := VideoMimeTypes[mimeType]; ok {
+			return VideoDecoder{Command: stringOpt(opts, "videoCommand")}
+		}
 		return ImageDecoder{}
 	}
 }
+
+func stringOpt(opts map[string]interface{}, key string) string {
+	s, _ := opts[key].(string)
+	return s
+}
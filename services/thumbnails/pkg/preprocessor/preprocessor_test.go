@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/opentype"
+	"image"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/ginkgo"
@@ -177,9 +180,60 @@ var _ = Describe("ImageDecoder", func() {
 			Expect(decoder).To(BeAssignableToTypeOf(TxtToImageConverter{}))
 		})
 
+		It("should return a PdfDecoder for pdf types", func() {
+			decoder := ForType("application/pdf", map[string]interface{}{"pdfCommand": "pdftoppm"})
+			Expect(decoder).To(Equal(PdfDecoder{Command: "pdftoppm"}))
+		})
+
+		It("should return a VideoDecoder for video types", func() {
+			decoder := ForType("video/mp4", map[string]interface{}{"videoCommand": "ffmpeg"})
+			Expect(decoder).To(Equal(VideoDecoder{Command: "ffmpeg"}))
+		})
+
 		It("should return an ImageDecoder for unknown types", func() {
 			decoder := ForType("unknown", nil)
 			Expect(decoder).To(BeAssignableToTypeOf(ImageDecoder{}))
 		})
 	})
+
+	Describe("should decode via external commands", func() {
+		var dir, command string
+		BeforeEach(func() {
+			asset, err := filepath.Abs("test_assets/noise.png")
+			Expect(err).ToNot(HaveOccurred())
+			dir, err = os.MkdirTemp("", "preprocessor")
+			Expect(err).ToNot(HaveOccurred())
+
+			// the fake command prints a png if one of its arguments is an existing file
+			command = filepath.Join(dir, "render")
+			script := "#!/bin/sh\nfor arg; do [ -f \"$arg\" ] && cat " + asset + " && exit 0; done\nexit 1\n"
+			Expect(os.WriteFile(command, []byte(script), 0700)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should render a pdf", func() {
+			img, err := PdfDecoder{Command: command}.Convert(strings.NewReader("%PDF-1.4"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(img).To(BeAssignableToTypeOf(&image.NRGBA{}))
+		})
+
+		It("should extract a video frame", func() {
+			img, err := VideoDecoder{Command: command}.Convert(strings.NewReader("video"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(img).ToNot(BeNil())
+		})
+
+		It("should return an error if the command fails", func() {
+			_, err := PdfDecoder{Command: "false"}.Convert(strings.NewReader("%PDF-1.4"))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error if no command is configured", func() {
+			_, err := VideoDecoder{}.Convert(strings.NewReader("video"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
@@ -61,18 +61,32 @@ func NewService(opts ...Option) grpc.Service {
 		return grpc.Service{}
 	}
 
+	var thumbnailStorage storage.Storage
+	switch tconf.Storage {
+	case "s3":
+		s3, err := storage.NewS3Storage(tconf.S3Storage, options.Logger)
+		if err != nil {
+			options.Logger.Error().Err(err).Msg("could not create s3 thumbnail storage")
+			return grpc.Service{}
+		}
+		go storage.RunEviction(options.Context, s3, tconf.S3Storage.EvictionInterval, options.Logger)
+		thumbnailStorage = s3
+	default:
+		fs := storage.NewFileSystemStorage(
+			tconf.FileSystemStorage,
+			options.Logger,
+		)
+		go storage.RunEviction(options.Context, fs, tconf.FileSystemStorage.EvictionInterval, options.Logger)
+		thumbnailStorage = fs
+	}
+
 	var thumbnail decorators.DecoratedService
 	{
 		thumbnail = svc.NewService(
 			svc.Config(options.Config),
 			svc.Logger(options.Logger),
 			svc.ThumbnailSource(imgsource.NewWebDavSource(tconf, b)),
-			svc.ThumbnailStorage(
-				storage.NewFileSystemStorage(
-					tconf.FileSystemStorage,
-					options.Logger,
-				),
-			),
+			svc.ThumbnailStorage(thumbnailStorage),
 			svc.CS3Source(imgsource.NewCS3Source(tconf, gatewaySelector, b)),
 			svc.GatewaySelector(gatewaySelector),
 		)
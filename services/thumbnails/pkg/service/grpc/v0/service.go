@@ -2,6 +2,7 @@ package svc
 
 import (
 	"context"
+	"mime"
 	"net/http"
 	"net/url"
 	"path"
@@ -52,6 +53,8 @@ func NewService(opts ...Option) decorators.DecoratedService {
 		selector:     options.GatewaySelector,
 		preprocessorOpts: PreprocessorOpts{
 			TxtFontFileMap: options.Config.Thumbnail.FontMapFile,
+			PdfCommand:     options.Config.Thumbnail.PdfCommand,
+			VideoCommand:   options.Config.Thumbnail.VideoCommand,
 		},
 		dataEndpoint:   options.Config.Thumbnail.DataEndpoint,
 		transferSecret: options.Config.Thumbnail.TransferSecret,
@@ -76,6 +79,28 @@ type Thumbnail struct {
 // PreprocessorOpts holds the options for the preprocessor
 type PreprocessorOpts struct {
 	TxtFontFileMap string
+	PdfCommand     string
+	VideoCommand   string
+}
+
+func (o PreprocessorOpts) toMap() map[string]interface{} {
+	return map[string]interface{}{
+		"fontFileMap":  o.TxtFontFileMap,
+		"pdfCommand":   o.PdfCommand,
+		"videoCommand": o.VideoCommand,
+	}
+}
+
+// supports returns false for mimetypes which need an external command that is not configured
+func (o PreprocessorOpts) supports(mimeType string) bool {
+	mimeType, _, _ = mime.ParseMediaType(mimeType)
+	if _, ok := preprocessor.PdfMimeTypes[mimeType]; ok {
+		return o.PdfCommand != ""
+	}
+	if _, ok := preprocessor.VideoMimeTypes[mimeType]; ok {
+		return o.VideoCommand != ""
+	}
+	return true
 }
 
 // GetThumbnail retrieves a thumbnail for an image
@@ -155,10 +180,7 @@ func (g Thumbnail) handleCS3Source(ctx context.Context, req *thumbnailssvc.GetTh
 		return "", merrors.InternalServerError(g.serviceID, "could not get image from source: %s", err.Error())
 	}
 	defer r.Close()
-	ppOpts := map[string]interface{}{
-		"fontFileMap": g.preprocessorOpts.TxtFontFileMap,
-	}
-	pp := preprocessor.ForType(sRes.GetInfo().GetMimeType(), ppOpts)
+	pp := preprocessor.ForType(sRes.GetInfo().GetMimeType(), g.preprocessorOpts.toMap())
 	img, err := pp.Convert(r)
 	if img == nil || err != nil {
 		return "", merrors.InternalServerError(g.serviceID, "could not get image")
@@ -239,10 +261,7 @@ func (g Thumbnail) handleWebdavSource(ctx context.Context, req *thumbnailssvc.Ge
 		return "", merrors.InternalServerError(g.serviceID, "could not get image from source: %s", err.Error())
 	}
 	defer r.Close()
-	ppOpts := map[string]interface{}{
-		"fontFileMap": g.preprocessorOpts.TxtFontFileMap,
-	}
-	pp := preprocessor.ForType(sRes.GetInfo().GetMimeType(), ppOpts)
+	pp := preprocessor.ForType(sRes.GetInfo().GetMimeType(), g.preprocessorOpts.toMap())
 	img, err := pp.Convert(r)
 	if img == nil || err != nil {
 		return "", merrors.InternalServerError(g.serviceID, "could not get image")
@@ -302,7 +321,7 @@ func (g Thumbnail) stat(path, auth string) (*provider.StatResponse, error) {
 		g.logger.Error().Msg("resource info is missing checksum")
 		return nil, merrors.NotFound(g.serviceID, "resource info is missing a checksum")
 	}
-	if !thumbnail.IsMimeTypeSupported(rsp.GetInfo().GetMimeType()) {
+	if !thumbnail.IsMimeTypeSupported(rsp.GetInfo().GetMimeType()) || !g.preprocessorOpts.supports(rsp.GetInfo().GetMimeType()) {
 		return nil, merrors.NotFound(g.serviceID, "Unsupported file type")
 	}
 	return rsp, nil
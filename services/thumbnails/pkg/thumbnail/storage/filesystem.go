@@ -1,12 +1,15 @@
 package storage
 
 import (
+	"context"
 	"io/fs"
 	"os"
 	"path/filepath"
-	"strconv"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/cs3org/reva/v2/pkg/bytesize"
 	"github.com/pkg/errors"
 
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
@@ -19,16 +22,26 @@ const (
 
 // NewFileSystemStorage creates a new instance of FileSystem
 func NewFileSystemStorage(cfg config.FileSystemStorage, logger log.Logger) FileSystem {
+	var maxSize bytesize.ByteSize
+	if cfg.MaxSize != "" {
+		// the max size is validated when parsing the config
+		maxSize, _ = bytesize.Parse(cfg.MaxSize)
+	}
+
 	return FileSystem{
-		root:   cfg.RootDirectory,
-		logger: logger,
+		root:    cfg.RootDirectory,
+		ttl:     cfg.TTL,
+		maxSize: maxSize.Bytes(),
+		logger:  logger,
 	}
 }
 
 // FileSystem represents a storage for the thumbnails using the local file system.
 type FileSystem struct {
-	root   string
-	logger log.Logger
+	root    string
+	ttl     time.Duration
+	maxSize uint64
+	logger  log.Logger
 }
 
 // Stat returns if a file for the given key exists on the filesystem
@@ -50,6 +63,12 @@ func (s FileSystem) Get(key string) ([]byte, error) {
 		}
 		return nil, err
 	}
+
+	if s.evicts() {
+		// the modification time tracks the last usage of a thumbnail for the eviction
+		now := time.Now()
+		_ = os.Chtimes(img, now, now)
+	}
 	return content, nil
 }
 
@@ -85,16 +104,124 @@ func (s FileSystem) Put(key string, img []byte) error {
 //
 // The key also represents the path to the thumbnail in the filesystem under the configured root directory.
 func (s FileSystem) BuildKey(r Request) string {
-	checksum := r.Checksum
-	filetype := r.Types[0]
+	return filepath.FromSlash(buildKey(r))
+}
 
-	parts := []string{strconv.Itoa(r.Resolution.Dx()), "x", strconv.Itoa(r.Resolution.Dy())}
+// Evict removes all thumbnails which have not been used within the configured TTL. If the remaining
+// thumbnails exceed the configured maximum size the least recently used ones are removed as well.
+func (s FileSystem) Evict(ctx context.Context) error {
+	if !s.evicts() {
+		return nil
+	}
 
-	if r.Characteristic != "" {
-		parts = append(parts, "-", r.Characteristic)
+	type file struct {
+		path    string
+		size    uint64
+		modTime time.Time
 	}
 
-	parts = append(parts, ".", filetype)
+	var (
+		files   []file
+		total   uint64
+		removed int
+		expired = time.Now().Add(-s.ttl)
+	)
+	err := filepath.WalkDir(filepath.Join(s.root, filesDir), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.IsDir() {
+			return nil
+		}
 
-	return filepath.Join(checksum[:2], checksum[2:4], checksum[4:], strings.Join(parts, ""))
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if s.ttl > 0 && info.ModTime().Before(expired) {
+			if s.remove(path) {
+				removed++
+			}
+			return nil
+		}
+		files = append(files, file{path: path, size: uint64(info.Size()), modTime: info.ModTime()})
+		total += uint64(info.Size())
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not walk thumbnails")
+	}
+
+	if s.maxSize > 0 && total > s.maxSize {
+		sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+		for _, f := range files {
+			if total <= s.maxSize {
+				break
+			}
+			if s.remove(f.path) {
+				removed++
+				total -= f.size
+			}
+		}
+	}
+
+	s.logger.Debug().Int("removed", removed).Uint64("size", total).Msg("evicted thumbnails")
+	return nil
+}
+
+// Prune removes the thumbnails of all source files whose checksum should not be kept.
+// It returns the number of removed checksums.
+func (s FileSystem) Prune(keep func(checksum string) bool) (int, error) {
+	// the thumbnails of a source file are stored in <checksum[:2]>/<checksum[2:4]>/<checksum[4:]>
+	dirs, err := filepath.Glob(filepath.Join(s.root, filesDir, "*", "*", "*"))
+	if err != nil {
+		return 0, err
+	}
+
+	var removed int
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(filepath.Join(s.root, filesDir), dir)
+		if err != nil {
+			continue
+		}
+		if keep(strings.Join(strings.Split(rel, string(filepath.Separator)), "")) {
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return removed, errors.Wrapf(err, "could not remove thumbnails in %s", dir)
+		}
+		s.removeEmptyParents(dir)
+		removed++
+	}
+	return removed, nil
+}
+
+func (s FileSystem) evicts() bool {
+	return s.ttl > 0 || s.maxSize > 0
+}
+
+func (s FileSystem) remove(path string) bool {
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		s.logger.Error().Err(err).Str("path", path).Msg("could not remove thumbnail")
+		return false
+	}
+	s.removeEmptyParents(path)
+	return true
+}
+
+// removeEmptyParents removes the empty directories above path up to the files directory
+func (s FileSystem) removeEmptyParents(path string) {
+	files := filepath.Join(s.root, filesDir)
+	for dir := filepath.Dir(path); strings.HasPrefix(dir, files+string(filepath.Separator)); dir = filepath.Dir(dir) {
+		// os.Remove fails for directories which are not empty
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+	}
 }
@@ -1,11 +1,18 @@
 package storage_test
 
 import (
+	"context"
 	"image"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	tAssert "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/config"
 	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/thumbnail/storage"
 )
 
@@ -63,3 +70,48 @@ func TestFileSystem_BuildKey(t *testing.T) {
 	}
 
 }
+
+func TestFileSystem_Evict(t *testing.T) {
+	root := t.TempDir()
+	s := storage.NewFileSystemStorage(config.FileSystemStorage{RootDirectory: root, TTL: time.Hour, MaxSize: "8"}, log.NopLogger())
+
+	keys := []string{"aa/bb/expired/1x1.png", "aa/bb/unused/1x1.png", "aa/cc/used/1x1.png"}
+	for _, k := range keys {
+		require.NoError(t, s.Put(k, []byte("12345")))
+	}
+	setModTime(t, root, keys[0], time.Now().Add(-2*time.Hour))
+	setModTime(t, root, keys[1], time.Now().Add(-time.Minute))
+	setModTime(t, root, keys[2], time.Now().Add(-2*time.Minute))
+
+	// getting a thumbnail marks it as recently used
+	_, err := s.Get(keys[2])
+	require.NoError(t, err)
+
+	require.NoError(t, s.Evict(context.Background()))
+	tAssert.False(t, s.Stat(keys[0]))
+	tAssert.False(t, s.Stat(keys[1]))
+	tAssert.True(t, s.Stat(keys[2]))
+
+	// empty directories are removed as well
+	_, err = os.Stat(filepath.Join(root, "files", "aa", "bb"))
+	tAssert.True(t, os.IsNotExist(err))
+}
+
+func TestFileSystem_Prune(t *testing.T) {
+	root := t.TempDir()
+	s := storage.NewFileSystemStorage(config.FileSystemStorage{RootDirectory: root}, log.NopLogger())
+
+	require.NoError(t, s.Put("12/0e/a8a2/1x1.png", []byte("1")))
+	require.NoError(t, s.Put("12/0e/a8a2/2x2.png", []byte("2")))
+	require.NoError(t, s.Put("97/9f/4c8d/1x1.png", []byte("3")))
+
+	removed, err := s.Prune(func(checksum string) bool { return checksum == "120ea8a2" })
+	require.NoError(t, err)
+	tAssert.Equal(t, 1, removed)
+	tAssert.True(t, s.Stat("12/0e/a8a2/2x2.png"))
+	tAssert.False(t, s.Stat("97/9f/4c8d/1x1.png"))
+}
+
+func setModTime(t *testing.T, root, key string, mtime time.Time) {
+	require.NoError(t, os.Chtimes(filepath.Join(root, "files", key), mtime, mtime))
+}
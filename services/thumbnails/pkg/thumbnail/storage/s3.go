@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"io"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/cs3org/reva/v2/pkg/bytesize"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/pkg/errors"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/config"
+)
+
+// NewS3Storage creates a new instance of S3
+func NewS3Storage(cfg config.S3Storage, logger log.Logger) (S3, error) {
+	endpoint, secure := cfg.Endpoint, true
+	if u, err := url.Parse(cfg.Endpoint); err == nil && u.Host != "" {
+		endpoint, secure = u.Host, u.Scheme != "http"
+	}
+
+	transport, err := minio.DefaultTransport(secure)
+	if err != nil {
+		return S3{}, errors.Wrap(err, "could not create s3 transport")
+	}
+	if cfg.Insecure {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} //nolint:gosec
+	}
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:     credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure:    secure,
+		Region:    cfg.Region,
+		Transport: transport,
+	})
+	if err != nil {
+		return S3{}, errors.Wrap(err, "could not create s3 client")
+	}
+
+	var maxSize bytesize.ByteSize
+	if cfg.MaxSize != "" {
+		if maxSize, err = bytesize.Parse(cfg.MaxSize); err != nil {
+			return S3{}, errors.Wrapf(err, "could not parse max size \"%s\"", cfg.MaxSize)
+		}
+	}
+
+	return S3{
+		client:  client,
+		bucket:  cfg.Bucket,
+		ttl:     cfg.TTL,
+		maxSize: maxSize.Bytes(),
+		logger:  logger,
+	}, nil
+}
+
+// S3 represents a storage for the thumbnails using an S3 compatible object storage.
+type S3 struct {
+	client  *minio.Client
+	bucket  string
+	ttl     time.Duration
+	maxSize uint64
+	logger  log.Logger
+}
+
+// Stat returns if an object for the given key exists in the bucket
+func (s S3) Stat(key string) bool {
+	if _, err := s.client.StatObject(context.Background(), s.bucket, key, minio.StatObjectOptions{}); err != nil {
+		return false
+	}
+	return true
+}
+
+// Get returns the object content for the given key
+func (s S3) Get(key string) ([]byte, error) {
+	obj, err := s.client.GetObject(context.Background(), s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+
+	content, err := io.ReadAll(obj)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code != "NoSuchKey" {
+			s.logger.Debug().Str("err", err.Error()).Str("key", key).Msg("could not load thumbnail from store")
+		}
+		return nil, err
+	}
+	return content, nil
+}
+
+// Put stores image data in the bucket for the given key
+func (s S3) Put(key string, img []byte) error {
+	_, err := s.client.PutObject(context.Background(), s.bucket, key, bytes.NewReader(img), int64(len(img)), minio.PutObjectOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "could not upload object \"%s\"", key)
+	}
+	return nil
+}
+
+// BuildKey generate the unique key for a thumbnail.
+// The key uses the same layout as the filesystem storage, e.g. 97/9f/4c8db98f7b82e768ef478d3c8612/500x300.png
+func (s S3) BuildKey(r Request) string {
+	return buildKey(r)
+}
+
+// Evict removes all thumbnails older than the configured TTL. If the remaining
+// thumbnails exceed the configured maximum size the oldest ones are removed as well.
+func (s S3) Evict(ctx context.Context) error {
+	if s.ttl <= 0 && s.maxSize == 0 {
+		return nil
+	}
+
+	var (
+		objects []minio.ObjectInfo
+		total   uint64
+		removed int
+		expired = time.Now().Add(-s.ttl)
+	)
+	for obj := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Recursive: true}) {
+		if obj.Err != nil {
+			return errors.Wrap(obj.Err, "could not list thumbnails")
+		}
+		if s.ttl > 0 && obj.LastModified.Before(expired) {
+			if s.remove(ctx, obj.Key) {
+				removed++
+			}
+			continue
+		}
+		objects = append(objects, obj)
+		total += uint64(obj.Size)
+	}
+
+	if s.maxSize > 0 && total > s.maxSize {
+		sort.Slice(objects, func(i, j int) bool { return objects[i].LastModified.Before(objects[j].LastModified) })
+		for _, obj := range objects {
+			if total <= s.maxSize {
+				break
+			}
+			if s.remove(ctx, obj.Key) {
+				removed++
+				total -= uint64(obj.Size)
+			}
+		}
+	}
+
+	s.logger.Debug().Int("removed", removed).Uint64("size", total).Msg("evicted thumbnails")
+	return nil
+}
+
+func (s S3) remove(ctx context.Context, key string) bool {
+	if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		s.logger.Error().Err(err).Str("key", key).Msg("could not remove thumbnail")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,169 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/config"
+	"github.com/owncloud/ocis/v2/services/thumbnails/pkg/thumbnail/storage"
+)
+
+type object struct {
+	data     []byte
+	modified time.Time
+}
+
+// fakeS3 is a minimal stand-in for an S3 compatible object storage serving a single bucket with path style requests.
+type fakeS3 struct {
+	sync.Mutex
+	bucket  string
+	objects map[string]*object
+}
+
+type listResult struct {
+	XMLName     xml.Name       `xml:"ListBucketResult"`
+	Name        string         `xml:"Name"`
+	KeyCount    int            `xml:"KeyCount"`
+	IsTruncated bool           `xml:"IsTruncated"`
+	Contents    []listContents `xml:"Contents"`
+}
+
+type listContents struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	Size         int    `xml:"Size"`
+	ETag         string `xml:"ETag"`
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/"+f.bucket), "/")
+	if key == "" && r.Method == http.MethodGet {
+		res := listResult{Name: f.bucket}
+		for k, o := range f.objects {
+			if strings.HasPrefix(k, r.URL.Query().Get("prefix")) {
+				res.Contents = append(res.Contents, listContents{
+					Key:          k,
+					LastModified: o.modified.UTC().Format(time.RFC3339),
+					Size:         len(o.data),
+					ETag:         `"etag"`,
+				})
+			}
+		}
+		sort.Slice(res.Contents, func(i, j int) bool { return res.Contents[i].Key < res.Contents[j].Key })
+		res.KeyCount = len(res.Contents)
+		w.Header().Set("Content-Type", "application/xml")
+		_ = xml.NewEncoder(w).Encode(res)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		if strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+			data = decodeChunked(data)
+		}
+		f.objects[key] = &object{data: data, modified: time.Now()}
+		w.Header().Set("ETag", `"etag"`)
+	case http.MethodGet, http.MethodHead:
+		o, ok := f.objects[key]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method == http.MethodGet {
+				_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`))
+			}
+			return
+		}
+		w.Header().Set("ETag", `"etag"`)
+		w.Header().Set("Last-Modified", o.modified.UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Length", strconv.Itoa(len(o.data)))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(o.data)
+		}
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+// decodeChunked strips the chunk signatures of a streaming upload
+func decodeChunked(body []byte) []byte {
+	var data []byte
+	for len(body) > 0 {
+		header, rest, _ := bytes.Cut(body, []byte("\r\n"))
+		size, err := strconv.ParseInt(string(bytes.SplitN(header, []byte(";"), 2)[0]), 16, 64)
+		if err != nil || size == 0 || int64(len(rest)) < size {
+			break
+		}
+		data = append(data, rest[:size]...)
+		body = bytes.TrimPrefix(rest[size:], []byte("\r\n"))
+	}
+	return data
+}
+
+func newS3(t *testing.T, cfg config.S3Storage) (storage.S3, *fakeS3) {
+	fake := &fakeS3{bucket: "thumbnails", objects: map[string]*object{}}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	cfg.Endpoint = srv.URL
+	cfg.Bucket = fake.bucket
+	cfg.Region = "default"
+	cfg.AccessKey = "access"
+	cfg.SecretKey = "secret"
+	s, err := storage.NewS3Storage(cfg, log.NopLogger())
+	require.NoError(t, err)
+	return s, fake
+}
+
+func TestS3_PutGet(t *testing.T) {
+	s, _ := newS3(t, config.S3Storage{})
+
+	key := s.BuildKey(storage.Request{Checksum: "120EA8A25E5D487BF68B5F7096440019", Types: []string{"png"}})
+	assert.Equal(t, "12/0E/A8A25E5D487BF68B5F7096440019/0x0.png", key)
+
+	assert.False(t, s.Stat(key))
+	_, err := s.Get(key)
+	assert.Error(t, err)
+
+	require.NoError(t, s.Put(key, []byte("image")))
+	assert.True(t, s.Stat(key))
+	img, err := s.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("image"), img)
+}
+
+func TestS3_Evict(t *testing.T) {
+	s, fake := newS3(t, config.S3Storage{TTL: time.Hour, MaxSize: "8"})
+
+	for _, k := range []string{"expired", "old", "new"} {
+		require.NoError(t, s.Put(k, []byte("12345")))
+	}
+	fake.objects["expired"].modified = time.Now().Add(-2 * time.Hour)
+	fake.objects["old"].modified = time.Now().Add(-time.Minute)
+
+	// the expired thumbnail exceeds the ttl, the old one is removed to stay within the max size
+	require.NoError(t, s.Evict(context.Background()))
+	assert.False(t, s.Stat("expired"))
+	assert.False(t, s.Stat("old"))
+	assert.True(t, s.Stat("new"))
+}
@@ -1,7 +1,14 @@
 package storage
 
 import (
+	"context"
 	"image"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/log"
 )
 
 // Request combines different attributes needed for storage operations.
@@ -30,3 +37,44 @@ type Storage interface {
 	Put(key string, img []byte) error
 	BuildKey(r Request) string
 }
+
+// Evictor is implemented by storages which are able to remove outdated thumbnails.
+type Evictor interface {
+	Evict(ctx context.Context) error
+}
+
+// RunEviction periodically calls Evict until the context is done.
+func RunEviction(ctx context.Context, e Evictor, interval time.Duration, logger log.Logger) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.Evict(ctx); err != nil {
+				logger.Error().Err(err).Msg("could not evict thumbnails")
+			}
+		}
+	}
+}
+
+// buildKey generates the slash separated key layout shared by all storages.
+func buildKey(r Request) string {
+	checksum := r.Checksum
+	filetype := r.Types[0]
+
+	parts := []string{strconv.Itoa(r.Resolution.Dx()), "x", strconv.Itoa(r.Resolution.Dy())}
+
+	if r.Characteristic != "" {
+		parts = append(parts, "-", r.Characteristic)
+	}
+
+	parts = append(parts, ".", filetype)
+
+	return path.Join(checksum[:2], checksum[2:4], checksum[4:], strings.Join(parts, ""))
+}
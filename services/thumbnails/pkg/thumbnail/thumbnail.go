@@ -26,6 +26,14 @@ var (
 		"audio/mpeg":                      {},
 		"audio/ogg":                       {},
 		"application/vnd.geogebra.slides": {},
+		"application/pdf":                 {},
+		"video/mp4":                       {},
+		"video/mpeg":                      {},
+		"video/ogg":                       {},
+		"video/quicktime":                 {},
+		"video/webm":                      {},
+		"video/x-matroska":                {},
+		"video/x-msvideo":                 {},
 	}
 )
 
@@ -12,6 +12,7 @@ import (
 	"github.com/oklog/run"
 	"github.com/owncloud/ocis/v2/ocis-pkg/config/configlog"
 	"github.com/owncloud/ocis/v2/ocis-pkg/handlers"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quotapolicy"
 	"github.com/owncloud/ocis/v2/ocis-pkg/registry"
 	"github.com/owncloud/ocis/v2/ocis-pkg/service/debug"
 	ogrpc "github.com/owncloud/ocis/v2/ocis-pkg/service/grpc"
@@ -21,6 +22,7 @@ import (
 	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
 	"github.com/owncloud/ocis/v2/services/userlog/pkg/config"
 	"github.com/owncloud/ocis/v2/services/userlog/pkg/config/parser"
+	"github.com/owncloud/ocis/v2/services/userlog/pkg/event"
 	"github.com/owncloud/ocis/v2/services/userlog/pkg/logging"
 	"github.com/owncloud/ocis/v2/services/userlog/pkg/metrics"
 	"github.com/owncloud/ocis/v2/services/userlog/pkg/server/http"
@@ -32,6 +34,7 @@ import (
 var _registeredEvents = []events.Unmarshaller{
 	// file related
 	events.PostprocessingStepFinished{},
+	events.UploadReady{},
 
 	// space related
 	events.SpaceDisabled{},
@@ -44,6 +47,9 @@ var _registeredEvents = []events.Unmarshaller{
 	events.ShareCreated{},
 	events.ShareRemoved{},
 	events.ShareExpired{},
+
+	// quota related
+	event.QuotaThresholdReached{},
 }
 
 // Server is the entrypoint for the server command.
@@ -97,6 +103,14 @@ func Server(cfg *config.Config) *cli.Command {
 				store.Authentication(cfg.Persistence.AuthUsername, cfg.Persistence.AuthPassword),
 			)
 
+			quotaPolicies := quotapolicy.NewManager(store.Create(
+				store.Store(cfg.QuotaPolicies.Store),
+				microstore.Nodes(cfg.QuotaPolicies.Nodes...),
+				microstore.Database(quotapolicy.Database),
+				microstore.Table(quotapolicy.Table),
+				store.Authentication(cfg.QuotaPolicies.AuthUsername, cfg.QuotaPolicies.AuthPassword),
+			))
+
 			tm, err := pool.StringToTLSMode(cfg.GRPCClientTLS.Mode)
 			if err != nil {
 				return err
@@ -130,6 +144,7 @@ func Server(cfg *config.Config) *cli.Command {
 					http.Role(rClient),
 					http.RegisteredEvents(_registeredEvents),
 					http.TracerProvider(tracerProvider),
+					http.QuotaPolicies(quotaPolicies),
 				)
 
 				if err != nil {
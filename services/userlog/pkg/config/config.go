@@ -34,6 +34,8 @@ type Config struct {
 
 	ServiceAccount ServiceAccount `yaml:"service_account"`
 
+	QuotaPolicies QuotaPolicies `yaml:"quota_policies"`
+
 	Context context.Context `yaml:"-"`
 }
 
@@ -49,6 +51,14 @@ type Persistence struct {
 	AuthPassword string        `yaml:"password" env:"OCIS_PERSISTENT_STORE_AUTH_PASSWORD;USERLOG_STORE_AUTH_PASSWORD" desc:"The password to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"5.0"`
 }
 
+// QuotaPolicies configures the store of the quota policies managed by the settings service
+type QuotaPolicies struct {
+	Store        string   `yaml:"store" env:"OCIS_PERSISTENT_STORE;USERLOG_QUOTA_POLICIES_STORE" desc:"The type of the store for the quota policies. Supported values are: 'memory', 'redis-sentinel' and 'nats-js-kv'. The store must be shared with the settings service. See the text description for details." introductionVersion:"6.0.0"`
+	Nodes        []string `yaml:"nodes" env:"OCIS_PERSISTENT_STORE_NODES;USERLOG_QUOTA_POLICIES_STORE_NODES" desc:"A list of nodes to access the configured store. This has no effect when 'memory' store is configured. Note that the behaviour how nodes are used is dependent on the library of the configured store. See the Environment Variable Types description for more details." introductionVersion:"6.0.0"`
+	AuthUsername string   `yaml:"username" env:"OCIS_PERSISTENT_STORE_AUTH_USERNAME;USERLOG_QUOTA_POLICIES_STORE_AUTH_USERNAME" desc:"The username to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"6.0.0"`
+	AuthPassword string   `yaml:"password" env:"OCIS_PERSISTENT_STORE_AUTH_PASSWORD;USERLOG_QUOTA_POLICIES_STORE_AUTH_PASSWORD" desc:"The password to authenticate with the store. Only applies when store type 'nats-js-kv' is configured." introductionVersion:"6.0.0"`
+}
+
 // Events combines the configuration options for the event bus.
 type Events struct {
 	Endpoint             string `yaml:"endpoint" env:"OCIS_EVENTS_ENDPOINT;USERLOG_EVENTS_ENDPOINT" desc:"The address of the event system. The event system is the message queuing service. It is used as message broker for the microservice architecture." introductionVersion:"pre5.0"`
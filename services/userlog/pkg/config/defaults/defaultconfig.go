@@ -40,6 +40,10 @@ func DefaultConfig() *config.Config {
 			Table:    "events",
 			TTL:      time.Hour * 336,
 		},
+		QuotaPolicies: config.QuotaPolicies{
+			Store: "nats-js-kv",
+			Nodes: []string{"127.0.0.1:9233"},
+		},
 		RevaGateway: shared.DefaultRevaConfig().Address,
 		HTTP: config.HTTP{
 			Addr:      "127.0.0.1:0",
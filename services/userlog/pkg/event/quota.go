@@ -0,0 +1,29 @@
+package event
+
+import (
+	"encoding/json"
+	"time"
+
+	user "github.com/cs3org/go-cs3apis/cs3/identity/user/v1beta1"
+	provider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+)
+
+// QuotaThresholdReached is emitted when the usage of a space or folder reaches a warning threshold of its quota policy
+type QuotaThresholdReached struct {
+	SpaceID    *provider.StorageSpaceId
+	SpaceType  string
+	SpaceOwner *user.UserId
+	// Path is the folder of the quota policy, empty if the quota of the whole space is meant
+	Path      string
+	Threshold int
+	Used      uint64
+	Quota     uint64
+	Timestamp time.Time
+}
+
+// Unmarshal to fulfill umarshaller interface
+func (QuotaThresholdReached) Unmarshal(v []byte) (interface{}, error) {
+	e := QuotaThresholdReached{}
+	err := json.Unmarshal(v, &e)
+	return e, err
+}
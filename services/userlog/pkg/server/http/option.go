@@ -7,6 +7,7 @@ import (
 	"github.com/cs3org/reva/v2/pkg/events"
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quotapolicy"
 	ehsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/eventhistory/v0"
 	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
 	"github.com/owncloud/ocis/v2/services/userlog/pkg/config"
@@ -35,6 +36,7 @@ type Options struct {
 	RoleClient       settingssvc.RoleService
 	RegisteredEvents []events.Unmarshaller
 	TracerProvider   trace.TracerProvider
+	QuotaPolicies    *quotapolicy.Manager
 }
 
 // newOptions initializes the available default options.
@@ -145,3 +147,10 @@ func TracerProvider(val trace.TracerProvider) Option {
 		o.TracerProvider = val
 	}
 }
+
+// QuotaPolicies provides a function to set the quota policies option
+func QuotaPolicies(val *quotapolicy.Manager) Option {
+	return func(o *Options) {
+		o.QuotaPolicies = val
+	}
+}
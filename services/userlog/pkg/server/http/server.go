@@ -89,6 +89,7 @@ func Server(opts ...Option) (http.Service, error) {
 		svc.RoleClient(options.RoleClient),
 		svc.RegisteredEvents(options.RegisteredEvents),
 		svc.TraceProvider(options.TracerProvider),
+		svc.QuotaPolicies(options.QuotaPolicies),
 	)
 	if err != nil {
 		return http.Service{}, err
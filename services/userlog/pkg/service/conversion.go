@@ -6,6 +6,7 @@ import (
 	"embed"
 	"encoding/json"
 	"fmt"
+	"path"
 	"strings"
 	"text/template"
 	"time"
@@ -19,6 +20,7 @@ import (
 	"github.com/cs3org/reva/v2/pkg/storagespace"
 	"github.com/cs3org/reva/v2/pkg/utils"
 	"github.com/owncloud/ocis/v2/ocis-pkg/l10n"
+	ulevent "github.com/owncloud/ocis/v2/services/userlog/pkg/event"
 )
 
 //go:embed l10n/locale
@@ -115,6 +117,10 @@ func (c *Converter) ConvertEvent(eventid string, event interface{}) (OC10Notific
 		return c.shareMessage(eventid, ShareExpired, ev.ShareOwner, ev.ItemID, ev.ShareID, ev.ExpiredAt)
 	case events.ShareRemoved:
 		return c.shareMessage(eventid, ShareRemoved, ev.Executant, ev.ItemID, ev.ShareID, ev.Timestamp)
+
+	// quota related
+	case ulevent.QuotaThresholdReached:
+		return c.quotaMessage(eventid, ev)
 	}
 }
 
@@ -295,6 +301,47 @@ func (c *Converter) policiesMessage(eventid string, nt NotificationTemplate, exe
 	}, nil
 }
 
+func (c *Converter) quotaMessage(eventid string, ev ulevent.QuotaThresholdReached) (OC10Notification, error) {
+	space, err := c.getSpace(c.serviceAccountContext, ev.SpaceID.GetOpaqueId())
+	if err != nil {
+		return OC10Notification{}, err
+	}
+
+	nt := SpaceQuotaThresholdReached
+	if ev.Path != "" {
+		nt = FolderQuotaThresholdReached
+	}
+	subj, subjraw, msg, msgraw, err := composeMessage(nt, c.locale, c.defaultLanguage, c.translationPath, map[string]interface{}{
+		"spacename":    space.GetName(),
+		"resourcename": path.Base(ev.Path),
+		"percent":      ev.Threshold,
+	})
+	if err != nil {
+		return OC10Notification{}, err
+	}
+
+	dets := generateDetails(nil, space, nil, nil)
+	dets["quota"] = map[string]interface{}{
+		"path":      ev.Path,
+		"threshold": ev.Threshold,
+		"used":      ev.Used,
+		"total":     ev.Quota,
+	}
+
+	return OC10Notification{
+		EventID:        eventid,
+		Service:        c.serviceName,
+		Timestamp:      ev.Timestamp.Format(time.RFC3339Nano),
+		ResourceID:     ev.SpaceID.GetOpaqueId(),
+		ResourceType:   _resourceTypeSpace,
+		Subject:        subj,
+		SubjectRaw:     subjraw,
+		Message:        msg,
+		MessageRaw:     msgraw,
+		MessageDetails: dets,
+	}, nil
+}
+
 func (c *Converter) deprovisionMessage(nt NotificationTemplate, deproDate string) (OC10Notification, error) {
 	subj, subjraw, msg, msgraw, err := composeMessage(nt, c.locale, c.defaultLanguage, c.translationPath, map[string]interface{}{
 		"date": deproDate,
@@ -6,6 +6,7 @@ import (
 	"github.com/cs3org/reva/v2/pkg/rgrpc/todo/pool"
 	"github.com/go-chi/chi/v5"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quotapolicy"
 	ehsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/eventhistory/v0"
 	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
 	"github.com/owncloud/ocis/v2/services/userlog/pkg/config"
@@ -29,6 +30,7 @@ type Options struct {
 	RoleClient       settingssvc.RoleService
 	RegisteredEvents []events.Unmarshaller
 	TraceProvider    trace.TracerProvider
+	QuotaPolicies    *quotapolicy.Manager
 }
 
 // Logger configures a logger for the userlog service
@@ -107,3 +109,10 @@ func TraceProvider(tp trace.TracerProvider) Option {
 		o.TraceProvider = tp
 	}
 }
+
+// QuotaPolicies adds the quota policies users are warned about
+func QuotaPolicies(m *quotapolicy.Manager) Option {
+	return func(o *Options) {
+		o.QuotaPolicies = m
+	}
+}
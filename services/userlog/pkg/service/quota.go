@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	gateway "github.com/cs3org/go-cs3apis/cs3/gateway/v1beta1"
+	rpc "github.com/cs3org/go-cs3apis/cs3/rpc/v1beta1"
+	storageprovider "github.com/cs3org/go-cs3apis/cs3/storage/provider/v1beta1"
+	"github.com/cs3org/reva/v2/pkg/events"
+	"github.com/cs3org/reva/v2/pkg/storagespace"
+	"github.com/cs3org/reva/v2/pkg/utils"
+	"go-micro.dev/v4/store"
+
+	"github.com/owncloud/ocis/v2/ocis-pkg/quotapolicy"
+	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
+	"github.com/owncloud/ocis/v2/services/userlog/pkg/event"
+)
+
+// prefix of the store keys holding the last threshold the users were warned about
+const _quotaThresholdPrefix = "quota-threshold:"
+
+// checkQuotaPolicies warns the users when an upload made a space or folder reach a warning threshold of its
+// quota policy. Every threshold is only reported once, until the usage drops below it again. Folder quotas are not
+// enforced by the storage, the warnings are all they do.
+func (ul *UserlogService) checkQuotaPolicies(ctx context.Context, gwc gateway.GatewayAPIClient, e events.UploadReady) error {
+	if ul.quotaPolicies == nil || e.Failed || e.FileRef.GetResourceId() == nil {
+		return nil
+	}
+
+	rid := e.FileRef.GetResourceId()
+	space, err := utils.GetSpace(ctx, storagespace.FormatStorageID(rid.GetStorageId(), rid.GetSpaceId()), gwc)
+	if err != nil {
+		return err
+	}
+
+	var roleID, template string
+	switch space.GetSpaceType() {
+	case quotapolicy.SpaceTypePersonal:
+		roleID, err = ul.roleOf(ctx, space.GetOwner().GetId().GetOpaqueId())
+	case quotapolicy.SpaceTypeProject:
+		template, err = spaceTemplate(ctx, gwc, space.GetRoot())
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	p, err := ul.quotaPolicies.SpacePolicy(space.GetSpaceType(), roleID, template)
+	if err != nil {
+		return err
+	}
+	if p != nil && len(p.WarningThresholds) > 0 {
+		used, total, err := spaceUsage(ctx, gwc, space)
+		if err != nil {
+			return err
+		}
+		if total == 0 {
+			total = p.Quota
+		}
+		if err := ul.reportThreshold(ctx, space, *p, used, total); err != nil {
+			return err
+		}
+	}
+
+	folders, err := ul.quotaPolicies.FolderPolicies(space.GetSpaceType(), roleID, template)
+	if err != nil {
+		return err
+	}
+	for _, p := range folders {
+		if len(p.WarningThresholds) == 0 || !p.Contains(e.FileRef.GetPath()) {
+			continue
+		}
+		used, err := folderSize(ctx, gwc, space.GetRoot(), p.Path)
+		if err != nil {
+			return err
+		}
+		if err := ul.reportThreshold(ctx, space, p, used, p.Quota); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reportThreshold publishes a QuotaThresholdReached event if the usage reached a higher threshold than the one
+// the users were warned about last
+func (ul *UserlogService) reportThreshold(ctx context.Context, space *storageprovider.StorageSpace, p quotapolicy.Policy, used, total uint64) error {
+	key := _quotaThresholdPrefix + space.GetId().GetOpaqueId() + ":" + p.ID
+	reached := quotapolicy.ReachedThreshold(p.WarningThresholds, used, total)
+
+	warned := 0
+	recs, err := ul.store.Read(key)
+	switch {
+	case err != nil && !errors.Is(err, store.ErrNotFound):
+		return err
+	case len(recs) > 0:
+		warned, _ = strconv.Atoi(string(recs[0].Value))
+	}
+
+	switch {
+	case reached == warned:
+		return nil
+	case reached == 0:
+		return ul.store.Delete(key)
+	}
+	if err := ul.store.Write(&store.Record{Key: key, Value: []byte(strconv.Itoa(reached))}); err != nil {
+		return err
+	}
+	if reached < warned {
+		// the usage dropped, the users are warned again when it rises
+		return nil
+	}
+
+	return events.Publish(ctx, ul.publisher, event.QuotaThresholdReached{
+		SpaceID:    space.GetId(),
+		SpaceType:  space.GetSpaceType(),
+		SpaceOwner: space.GetOwner().GetId(),
+		Path:       p.Path,
+		Threshold:  reached,
+		Used:       used,
+		Quota:      total,
+		Timestamp:  time.Now(),
+	})
+}
+
+// roleOf returns the role of the user
+func (ul *UserlogService) roleOf(ctx context.Context, userID string) (string, error) {
+	res, err := ul.roleClient.ListRoleAssignments(ctx, &settingssvc.ListRoleAssignmentsRequest{AccountUuid: userID})
+	if err != nil {
+		return "", err
+	}
+	if len(res.GetAssignments()) == 0 {
+		return "", nil
+	}
+	return res.GetAssignments()[0].GetRoleId(), nil
+}
+
+func spaceTemplate(ctx context.Context, gwc gateway.GatewayAPIClient, root *storageprovider.ResourceId) (string, error) {
+	res, err := gwc.Stat(ctx, &storageprovider.StatRequest{
+		Ref:                   &storageprovider.Reference{ResourceId: root, Path: "."},
+		ArbitraryMetadataKeys: []string{quotapolicy.TemplateKey},
+	})
+	switch {
+	case err != nil:
+		return "", err
+	case res.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return "", fmt.Errorf("could not stat space root: %s", res.GetStatus().GetMessage())
+	}
+	return res.GetInfo().GetArbitraryMetadata().GetMetadata()[quotapolicy.TemplateKey], nil
+}
+
+// folderSize returns the size of the folder, 0 if it does not exist
+func folderSize(ctx context.Context, gwc gateway.GatewayAPIClient, root *storageprovider.ResourceId, folder string) (uint64, error) {
+	res, err := gwc.Stat(ctx, &storageprovider.StatRequest{
+		Ref: &storageprovider.Reference{ResourceId: root, Path: utils.MakeRelativePath(folder)},
+	})
+	switch {
+	case err != nil:
+		return 0, err
+	case res.GetStatus().GetCode() == rpc.Code_CODE_NOT_FOUND:
+		return 0, nil
+	case res.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return 0, fmt.Errorf("could not stat folder: %s", res.GetStatus().GetMessage())
+	}
+	return res.GetInfo().GetSize(), nil
+}
+
+// spaceUsage returns the used bytes and the quota of the space, the quota is 0 if it is unlimited
+func spaceUsage(ctx context.Context, gwc gateway.GatewayAPIClient, space *storageprovider.StorageSpace) (uint64, uint64, error) {
+	res, err := gwc.GetQuota(ctx, &gateway.GetQuotaRequest{
+		Ref: &storageprovider.Reference{ResourceId: space.GetRoot(), Path: "."},
+	})
+	switch {
+	case err != nil:
+		return 0, 0, err
+	case res.GetStatus().GetCode() != rpc.Code_CODE_OK:
+		return 0, 0, fmt.Errorf("could not get quota: %s", res.GetStatus().GetMessage())
+	}
+	return res.GetUsedBytes(), space.GetQuota().GetQuotaMaxBytes(), nil
+}
@@ -19,11 +19,13 @@ import (
 	"github.com/cs3org/reva/v2/pkg/utils"
 	"github.com/owncloud/ocis/v2/ocis-pkg/l10n"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quotapolicy"
 	"github.com/owncloud/ocis/v2/ocis-pkg/roles"
 	ehmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/eventhistory/v0"
 	ehsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/eventhistory/v0"
 	settingssvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/settings/v0"
 	"github.com/owncloud/ocis/v2/services/userlog/pkg/config"
+	ulevent "github.com/owncloud/ocis/v2/services/userlog/pkg/event"
 )
 
 // UserlogService is the service responsible for user activities
@@ -35,6 +37,8 @@ type UserlogService struct {
 	historyClient    ehsvc.EventHistoryService
 	gatewaySelector  pool.Selectable[gateway.GatewayAPIClient]
 	valueClient      settingssvc.ValueService
+	roleClient       settingssvc.RoleService
+	quotaPolicies    *quotapolicy.Manager
 	registeredEvents map[string]events.Unmarshaller
 	tp               trace.TracerProvider
 	tracer           trace.Tracer
@@ -65,6 +69,8 @@ func NewUserlogService(opts ...Option) (*UserlogService, error) {
 		historyClient:    o.HistoryClient,
 		gatewaySelector:  o.GatewaySelector,
 		valueClient:      o.ValueClient,
+		roleClient:       o.RoleClient,
+		quotaPolicies:    o.QuotaPolicies,
 		registeredEvents: make(map[string]events.Unmarshaller),
 		tp:               o.TraceProvider,
 		tracer:           o.TraceProvider.Tracer("github.com/owncloud/ocis/services/userlog/pkg/service"),
@@ -149,6 +155,12 @@ func (ul *UserlogService) processEvent(event events.Event) {
 		default:
 			return
 		}
+	case events.UploadReady:
+		// nobody is notified about the upload itself, but it might exceed a warning threshold of a quota policy
+		if err := ul.checkQuotaPolicies(ctx, gwc, e); err != nil {
+			ul.log.Error().Err(err).Str("uploadid", e.UploadID).Msg("could not check the quota policies")
+		}
+		return
 
 	// space related // TODO: how to find spaceadmins?
 	case events.SpaceDisabled:
@@ -177,6 +189,14 @@ func (ul *UserlogService) processEvent(event events.Event) {
 		users, err = utils.ResolveID(ctx, e.GranteeUserID, e.GranteeGroupID, gwc)
 	case events.ShareExpired:
 		users, err = utils.ResolveID(ctx, e.GranteeUserID, e.GranteeGroupID, gwc)
+
+	// quota related
+	case ulevent.QuotaThresholdReached:
+		if e.SpaceType == quotapolicy.SpaceTypePersonal {
+			users = append(users, e.SpaceOwner.GetOpaqueId())
+		} else {
+			users, err = utils.GetSpaceMembers(ctx, e.SpaceID.GetOpaqueId(), gwc, utils.ManagerRole)
+		}
 	}
 
 	if err != nil {
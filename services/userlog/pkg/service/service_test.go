@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/owncloud/ocis/v2/ocis-pkg/log"
+	"github.com/owncloud/ocis/v2/ocis-pkg/quotapolicy"
 	ehmsg "github.com/owncloud/ocis/v2/protogen/gen/ocis/messages/eventhistory/v0"
 	ehsvc "github.com/owncloud/ocis/v2/protogen/gen/ocis/services/eventhistory/v0"
 	"github.com/owncloud/ocis/v2/protogen/gen/ocis/services/eventhistory/v0/mocks"
@@ -47,11 +48,14 @@ var _ = Describe("UserlogService", func() {
 
 		ehc mocks.EventHistoryService
 		vc  settingssvc.MockValueService
+
+		quotaPolicies *quotapolicy.Manager
 	)
 
 	BeforeEach(func() {
 		var err error
 		sto = store.Create()
+		quotaPolicies = quotapolicy.NewManager(microstore.NewMemoryStore())
 		bus = testBus(make(chan events.Event))
 
 		pool.RemoveSelector("GatewaySelector" + "com.owncloud.api.gateway")
@@ -88,8 +92,10 @@ var _ = Describe("UserlogService", func() {
 			service.ValueClient(&vc),
 			service.RegisteredEvents([]events.Unmarshaller{
 				events.SpaceDisabled{},
+				events.UploadReady{},
 			}),
 			service.TraceProvider(trace.NewNoopTracerProvider()),
+			service.QuotaPolicies(quotaPolicies),
 		)
 		Expect(err).ToNot(HaveOccurred())
 	})
@@ -132,6 +138,49 @@ var _ = Describe("UserlogService", func() {
 		Expect(len(evs)).To(Equal(0))
 	})
 
+	It("remembers the reached quota warning thresholds", func() {
+		p, err := quotaPolicies.Write(quotapolicy.Policy{SpaceType: quotapolicy.SpaceTypeProject, Quota: 100, WarningThresholds: []int{50, 80}})
+		Expect(err).ToNot(HaveOccurred())
+
+		gatewayClient.On("Stat", mock.Anything, mock.Anything).Return(&provider.StatResponse{Status: &rpc.Status{Code: rpc.Code_CODE_OK}, Info: &provider.ResourceInfo{}}, nil)
+		gatewayClient.On("GetQuota", mock.Anything, mock.Anything).Return(&provider.GetQuotaResponse{Status: &rpc.Status{Code: rpc.Code_CODE_OK}, UsedBytes: 90}, nil)
+
+		bus.publish(events.UploadReady{FileRef: &provider.Reference{ResourceId: &provider.ResourceId{StorageId: "storageid", SpaceId: "spaceid"}, Path: "./file.txt"}})
+
+		Eventually(func() string {
+			recs, err := sto.Read("quota-threshold::" + p.ID)
+			if err != nil || len(recs) == 0 {
+				return ""
+			}
+			return string(recs[0].Value)
+		}).Should(Equal("80"))
+	})
+
+	It("warns about folders reaching the thresholds of their quota policy", func() {
+		p, err := quotaPolicies.Write(quotapolicy.Policy{SpaceType: quotapolicy.SpaceTypeProject, Path: "Documents", Quota: 100, WarningThresholds: []int{50, 80}})
+		Expect(err).ToNot(HaveOccurred())
+		other, err := quotaPolicies.Write(quotapolicy.Policy{SpaceType: quotapolicy.SpaceTypeProject, Path: "Photos", Quota: 100, WarningThresholds: []int{50}})
+		Expect(err).ToNot(HaveOccurred())
+
+		gatewayClient.On("Stat", mock.Anything, mock.MatchedBy(func(req *provider.StatRequest) bool {
+			return req.GetRef().GetPath() == "./Documents"
+		})).Return(&provider.StatResponse{Status: &rpc.Status{Code: rpc.Code_CODE_OK}, Info: &provider.ResourceInfo{Size: 60}}, nil)
+		gatewayClient.On("Stat", mock.Anything, mock.Anything).Return(&provider.StatResponse{Status: &rpc.Status{Code: rpc.Code_CODE_OK}, Info: &provider.ResourceInfo{Size: 90}}, nil)
+
+		bus.publish(events.UploadReady{FileRef: &provider.Reference{ResourceId: &provider.ResourceId{StorageId: "storageid", SpaceId: "spaceid"}, Path: "./Documents/file.txt"}})
+
+		Eventually(func() string {
+			recs, err := sto.Read("quota-threshold::" + p.ID)
+			if err != nil || len(recs) == 0 {
+				return ""
+			}
+			return string(recs[0].Value)
+		}).Should(Equal("50"))
+
+		_, err = sto.Read("quota-threshold::" + other.ID)
+		Expect(err).To(MatchError(microstore.ErrNotFound))
+	})
+
 	AfterEach(func() {
 		close(bus)
 	})
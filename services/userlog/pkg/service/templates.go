@@ -54,6 +54,16 @@ var (
 		Message: l10n.Template("Access to {resource} expired"),
 	}
 
+	SpaceQuotaThresholdReached = NotificationTemplate{
+		Subject: l10n.Template("Quota almost used up"),
+		Message: l10n.Template("Space {space} uses {percent}% of its quota"),
+	}
+
+	FolderQuotaThresholdReached = NotificationTemplate{
+		Subject: l10n.Template("Quota almost used up"),
+		Message: l10n.Template("Folder {resource} in Space {space} uses {percent}% of its quota"),
+	}
+
 	PlatformDeprovision = NotificationTemplate{
 		Subject: l10n.Template("Instance will be shut down and deprovisioned"),
 		Message: l10n.Template("Attention! The instance will be shut down and deprovisioned on {date}. Download all your data before that date as no access past that date is possible."),
@@ -67,6 +77,7 @@ var _placeholders = map[string]string{
 	"{resource}": "{{ .resourcename }}",
 	"{virus}":    "{{ .virusdescription }}",
 	"{date}":     "{{ .date }}",
+	"{percent}":  "{{ .percent }}",
 }
 
 // NotificationTemplate is the data structure for the notifications
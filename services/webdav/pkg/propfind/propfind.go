@@ -67,6 +67,20 @@ type MultiStatusResponseXML struct {
 	XmlnsOC string   `xml:"xmlns:oc,attr,omitempty"`
 
 	Responses []*ResponseXML `xml:"d:response"`
+	Facets    []*FacetXML    `xml:"oc:facets>oc:facet,omitempty"`
+}
+
+// FacetXML holds the xml representation of the counts of a search facet
+type FacetXML struct {
+	Name   string           `xml:"name,attr"`
+	Values []*FacetValueXML `xml:"oc:value"`
+}
+
+// FacetValueXML holds the xml representation of a value of a search facet
+type FacetValueXML struct {
+	Name  string `xml:"name,attr,omitempty"`
+	Count uint64 `xml:"count,attr"`
+	Value string `xml:",chardata"`
 }
 
 // ResponseUnmarshalXML is a workaround for https://github.com/golang/go/issues/13400
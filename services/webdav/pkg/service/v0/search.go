@@ -54,6 +54,7 @@ func (g Webdav) Search(w http.ResponseWriter, r *http.Request) {
 	req := &searchsvc.SearchRequest{
 		Query:    rep.SearchFiles.Search.Pattern,
 		PageSize: int32(rep.SearchFiles.Search.Limit),
+		Facets:   rep.SearchFiles.Search.Facets,
 	}
 
 	// Limit search to the according space when searching /dav/spaces/
@@ -91,7 +92,7 @@ func (g Webdav) Search(w http.ResponseWriter, r *http.Request) {
 
 func (g Webdav) sendSearchResponse(rsp *searchsvc.SearchResponse, w http.ResponseWriter, r *http.Request) {
 	logger := g.log.SubloggerWithRequestID(r.Context())
-	responsesXML, err := multistatusResponse(r.Context(), rsp.Matches)
+	responsesXML, err := multistatusResponse(r.Context(), rsp.Matches, rsp.Facets)
 	if err != nil {
 		logger.Error().Err(err).Msg("error formatting propfind")
 		w.WriteHeader(http.StatusInternalServerError)
@@ -109,7 +110,7 @@ func (g Webdav) sendSearchResponse(rsp *searchsvc.SearchResponse, w http.Respons
 }
 
 // multistatusResponse converts a list of matches into a multistatus response string
-func multistatusResponse(ctx context.Context, matches []*searchmsg.Match) ([]byte, error) {
+func multistatusResponse(ctx context.Context, matches []*searchmsg.Match, facets []*searchmsg.Facet) ([]byte, error) {
 	responses := make([]*propfind.ResponseXML, 0, len(matches))
 	for i := range matches {
 		res, err := matchToPropResponse(ctx, matches[i])
@@ -121,6 +122,7 @@ func multistatusResponse(ctx context.Context, matches []*searchmsg.Match) ([]byt
 
 	msr := propfind.NewMultiStatusResponseXML()
 	msr.Responses = responses
+	msr.Facets = facetsToXML(facets)
 	msg, err := xml.Marshal(msr)
 	if err != nil {
 		return nil, err
@@ -128,6 +130,25 @@ func multistatusResponse(ctx context.Context, matches []*searchmsg.Match) ([]byt
 	return msg, nil
 }
 
+func facetsToXML(facets []*searchmsg.Facet) []*propfind.FacetXML {
+	if len(facets) == 0 {
+		return nil
+	}
+	facetsXML := make([]*propfind.FacetXML, 0, len(facets))
+	for _, f := range facets {
+		facetXML := &propfind.FacetXML{Name: f.GetName()}
+		for _, v := range f.GetValues() {
+			facetXML.Values = append(facetXML.Values, &propfind.FacetValueXML{
+				Name:  v.GetName(),
+				Count: v.GetCount(),
+				Value: v.GetValue(),
+			})
+		}
+		facetsXML = append(facetsXML, facetXML)
+	}
+	return facetsXML
+}
+
 func matchToPropResponse(ctx context.Context, match *searchmsg.Match) (*propfind.ResponseXML, error) {
 	// unfortunately search uses own versions of ResourceId and Ref. So we need to assert them here
 	var (
@@ -238,6 +259,7 @@ type reportSearchFilesSearch struct {
 	Pattern string `xml:"pattern"`
 	Limit   int    `xml:"limit"`
 	Offset  int    `xml:"offset"`
+	Facets  bool   `xml:"facets"`
 }
 
 type reportFilterFiles struct {